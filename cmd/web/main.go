@@ -6,8 +6,13 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
+	// league tee times are converted from their time zone even where the
+	// server has no time zone database
+	_ "time/tzdata"
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/jdonahue135/golf-league-app/internal/config"
@@ -16,13 +21,48 @@ import (
 	"github.com/jdonahue135/golf-league-app/internal/helpers"
 	"github.com/jdonahue135/golf-league-app/internal/models"
 	"github.com/jdonahue135/golf-league-app/internal/render"
+	"github.com/jdonahue135/golf-league-app/internal/repository/apitokenrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/calendarfeedrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/commissionertransferrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/courserepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/emailverificationrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/invitationrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/leagueadminrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/leaguerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/loginattemptrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/passwordresetrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/playerrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/recoverycoderepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/schedulerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/scorerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/seasonrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/settingrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/skinsrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/teamrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/webhookrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services/apitokenservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/calendarfeedservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/commissionertransferservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/courseservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/handicapservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/invitationservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/leagueroleservice"
 	"github.com/jdonahue135/golf-league-app/internal/services/leagueservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/loginthrottleservice"
 	"github.com/jdonahue135/golf-league-app/internal/services/playerservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/scheduleservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/scoreservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/seasonservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/skinsservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/standingsservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/teamservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/twofactorservice"
 	"github.com/jdonahue135/golf-league-app/internal/services/userservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/webhookservice"
+	"github.com/jdonahue135/golf-league-app/internal/sessionstore"
+	"github.com/jdonahue135/golf-league-app/internal/webhooks"
 )
 
 const portNumber = ":8080"
@@ -57,6 +97,7 @@ func run() (*driver.DB, error) {
 	gob.Register(models.User{})
 	gob.Register(models.League{})
 	gob.Register(map[string]int{})
+	gob.Register(time.Time{})
 
 	// read flags
 	inProduction := flag.Bool("production", true, "Application is in production")
@@ -67,6 +108,10 @@ func run() (*driver.DB, error) {
 	dbPass := flag.String("dbpass", "", "Database password")
 	dbPort := flag.String("dbport", "5432", "Database port")
 	dbSSL := flag.String("dbssl", "disable", "Database ssl settings (disable, prefer, require)")
+	sessionStore := flag.String("sessionstore", "memory", "Where sessions are kept (memory, postgres, file)")
+	sessionDir := flag.String("sessiondir", "./tmp/sessions", "Directory sessions are kept in when sessionstore is file")
+	throttleStore := flag.String("throttlestore", "memory", "Where failed logins are counted (memory, postgres)")
+	baseURL := flag.String("baseurl", "http://localhost"+portNumber, "Scheme and host emailed links and calendar feeds point to, like https://golfleague.app")
 
 	flag.Parse()
 
@@ -75,8 +120,25 @@ func run() (*driver.DB, error) {
 		os.Exit(1)
 	}
 
+	if *throttleStore != "memory" && *throttleStore != "postgres" {
+		fmt.Println("throttlestore must be memory or postgres")
+		os.Exit(1)
+	}
+
+	if u, err := url.Parse(*baseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		fmt.Println("baseurl must be an http or https URL")
+		os.Exit(1)
+	}
+	app.BaseURL = strings.TrimRight(*baseURL, "/")
+
 	mailChan := make(chan models.MailData)
 	app.MailChan = mailChan
+
+	listenForMail()
+
+	// buffered so a handler never waits for the webhook sender to be free
+	app.WebhookChan = make(chan bool, 1)
+
 	// change this to true when in production
 	app.InProduction = *inProduction
 
@@ -103,6 +165,12 @@ func run() (*driver.DB, error) {
 	}
 	log.Println("Connected to database.")
 
+	store, err := sessionstore.New(*sessionStore, db.SQL, *sessionDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	session.Store = store
+
 	tc, err := render.CreateTemplateCache()
 	if err != nil {
 		log.Fatal("cannot create template cache")
@@ -112,14 +180,52 @@ func run() (*driver.DB, error) {
 	app.TemplateCache = tc
 	app.UseCache = *useCache
 
+	dbManager := dbmanager.NewPostgresDBManager(db.SQL)
 	userRepo := userrepo.NewPostgresUserRepo(db.SQL)
-	userService := userservice.NewUserService(userRepo)
+	passwordResetRepo := passwordresetrepo.NewPostgresPasswordResetRepo(db.SQL)
+	emailVerificationRepo := emailverificationrepo.NewPostgresEmailVerificationRepo(db.SQL)
+	userService := userservice.NewUserService(userRepo, passwordResetRepo, emailVerificationRepo, dbManager)
 	playerRepo := playerrepo.NewPostgresPlayerRepo(db.SQL)
 	playerService := playerservice.NewPlayerService(playerRepo)
 	leagueRepo := leaguerepo.NewPostgresLeagueRepo(db.SQL)
-	dbManager := dbmanager.NewPostgresDBManager(db.SQL)
-	leagueService := leagueservice.NewLeagueService(leagueRepo, playerRepo, userRepo, dbManager)
-	handlers.NewHandlers(&app, userService, leagueService, playerService)
+	invitationRepo := invitationrepo.NewPostgresInvitationRepo(db.SQL)
+	leagueService := leagueservice.NewLeagueService(leagueRepo, playerRepo, userRepo, invitationRepo, dbManager)
+	invitationService := invitationservice.NewInvitationService(invitationRepo, userRepo, dbManager)
+	courseRepo := courserepo.NewPostgresCourseRepo(db.SQL)
+	courseService := courseservice.NewCourseService(courseRepo, dbManager)
+	scoreRepo := scorerepo.NewPostgresScoreRepo(db.SQL)
+	scoreService := scoreservice.NewScoreService(scoreRepo, courseRepo, dbManager)
+	handicapService := handicapservice.NewHandicapService(scoreRepo, courseRepo)
+	seasonRepo := seasonrepo.NewPostgresSeasonRepo(db.SQL)
+	seasonService := seasonservice.NewSeasonService(seasonRepo)
+	scheduleRepo := schedulerepo.NewPostgresScheduleRepo(db.SQL)
+	scheduleService := scheduleservice.NewScheduleService(scheduleRepo, dbManager)
+	standingsService := standingsservice.NewStandingsService(scoreRepo, scheduleRepo, courseRepo)
+	teamRepo := teamrepo.NewPostgresTeamRepo(db.SQL)
+	teamService := teamservice.NewTeamService(teamRepo, dbManager)
+	skinsRepo := skinsrepo.NewPostgresSkinsRepo(db.SQL)
+	skinsService := skinsservice.NewSkinsService(skinsRepo, scoreRepo, courseRepo, dbManager)
+	loginAttemptRepo := loginattemptrepo.NewMemoryLoginAttemptRepo()
+	if *throttleStore == "postgres" {
+		loginAttemptRepo = loginattemptrepo.NewPostgresLoginAttemptRepo(db.SQL)
+	}
+	loginThrottleService := loginthrottleservice.NewLoginThrottleService(loginAttemptRepo)
+	recoveryCodeRepo := recoverycoderepo.NewPostgresRecoveryCodeRepo(db.SQL)
+	settingRepo := settingrepo.NewPostgresSettingRepo(db.SQL)
+	twoFactorService := twofactorservice.NewTwoFactorService(userRepo, recoveryCodeRepo, settingRepo, playerRepo, dbManager)
+	leagueAdminRepo := leagueadminrepo.NewPostgresLeagueAdminRepo(db.SQL)
+	leagueRoleService := leagueroleservice.NewLeagueRoleService(playerRepo, leagueAdminRepo, userRepo)
+	commissionerTransferRepo := commissionertransferrepo.NewPostgresCommissionerTransferRepo(db.SQL)
+	commissionerTransferService := commissionertransferservice.NewCommissionerTransferService(commissionerTransferRepo, playerRepo, dbManager)
+	apiTokenRepo := apitokenrepo.NewPostgresAPITokenRepo(db.SQL)
+	apiTokenService := apitokenservice.NewAPITokenService(apiTokenRepo, userRepo)
+	webhookRepo := webhookrepo.NewPostgresWebhookRepo(db.SQL)
+	webhookService := webhookservice.NewWebhookService(webhookRepo, webhooks.NewClient())
+	calendarFeedRepo := calendarfeedrepo.NewPostgresCalendarFeedRepo(db.SQL)
+	calendarFeedService := calendarfeedservice.NewCalendarFeedService(calendarFeedRepo, userRepo)
+	handlers.NewHandlers(&app, userService, leagueService, playerService, courseService, scoreService, handicapService, seasonService, scheduleService, standingsService, teamService, skinsService, invitationService, loginThrottleService, twoFactorService, leagueRoleService, commissionerTransferService, apiTokenService, webhookService, calendarFeedService)
+
+	listenForWebhooks(webhookService)
 
 	render.NewRenderer(&app)
 	helpers.NewHelpers(&app)
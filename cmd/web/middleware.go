@@ -2,7 +2,9 @@ package main
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/jdonahue135/golf-league-app/internal/handlers"
 	"github.com/jdonahue135/golf-league-app/internal/helpers"
 	"github.com/justinas/nosurf"
 )
@@ -17,9 +19,42 @@ func NoSurf(next http.Handler) http.Handler {
 		Secure:   app.InProduction,
 		SameSite: http.SameSiteLaxMode,
 	})
+	// an API request that APIToken authenticated with a bearer token can't be
+	// forged, since browsers never send one on their own. Anything else, even
+	// with an Authorization header, still needs the CSRF token
+	csrfHandler.ExemptFunc(func(r *http.Request) bool {
+		_, ok := handlers.RequestAPIToken(r)
+		return ok && isAPIPath(r.URL.Path)
+	})
 	return csrfHandler
 }
 
+// APIToken authenticates the bearer token on a request to the JSON API, and
+// refuses the request if the token isn't valid. It runs before NoSurf, which
+// only lets through POST requests without a CSRF token once their API token
+// has been checked. Bearer tokens sent anywhere else are ignored
+func APIToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := handlers.BearerToken(r)
+		if !ok || !isAPIPath(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		r, _, err := handlers.Handler.AuthenticateAPIToken(r, token)
+		if err != nil {
+			handlers.WriteAPIError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isAPIPath reports whether path is part of the JSON API
+func isAPIPath(path string) bool {
+	return path == "/api/v1" || strings.HasPrefix(path, "/api/v1/")
+}
+
 // SessionLoad loads and saves the session on every request
 func SessionLoad(next http.Handler) http.Handler {
 	return session.LoadAndSave(next)
@@ -32,6 +67,13 @@ func Auth(next http.Handler) http.Handler {
 			http.Redirect(w, r, "/user/login", http.StatusSeeOther)
 			return
 		}
+		if !handlers.Handler.SessionIsCurrent(r) {
+			endSession(w, r)
+			return
+		}
+		if needsTwoFactor(w, r) {
+			return
+		}
 		next.ServeHTTP(w, r)
 	})
 }
@@ -43,6 +85,78 @@ func AuthAdmin(next http.Handler) http.Handler {
 			http.Redirect(w, r, "/user/login", http.StatusSeeOther)
 			return
 		}
+		if !handlers.Handler.SessionIsCurrent(r) {
+			endSession(w, r)
+			return
+		}
+		if needsTwoFactor(w, r) {
+			return
+		}
 		next.ServeHTTP(w, r)
 	})
 }
+
+// APIAuth is Auth for the JSON API, which responds with an error instead of
+// redirecting to the login page. Scripts can send an API token as a bearer
+// token instead of logging in, which APIToken has already checked
+func APIAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if apiToken, ok := handlers.RequestAPIToken(r); ok {
+			if !apiToken.CanWrite() && !isSafeMethod(r.Method) {
+				handlers.WriteAPIError(w, http.StatusForbidden, "this API token is read only")
+				return
+			}
+			if handlers.Handler.NeedsTwoFactor(apiToken.UserID) {
+				handlers.WriteAPIError(w, http.StatusForbidden, "commissioners must turn on two-factor authentication")
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+		if !helpers.IsAuthenticated(r) {
+			handlers.WriteAPIError(w, http.StatusUnauthorized, "log in first")
+			return
+		}
+		if !handlers.Handler.SessionIsCurrent(r) {
+			_ = session.Destroy(r.Context())
+			handlers.WriteAPIError(w, http.StatusUnauthorized, "your session has ended, log in again")
+			return
+		}
+		userID, _ := session.Get(r.Context(), "user_id").(int)
+		if handlers.Handler.NeedsTwoFactor(userID) {
+			handlers.WriteAPIError(w, http.StatusForbidden, "commissioners must turn on two-factor authentication")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isSafeMethod reports whether requests with method only read, so read only
+// API tokens can make them
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// needsTwoFactor sends a commissioner who has to turn on two-factor
+// authentication to set it up, and reports whether it did
+func needsTwoFactor(w http.ResponseWriter, r *http.Request) bool {
+	if strings.HasPrefix(r.URL.Path, "/user/profile/two-factor") {
+		return false
+	}
+	userID, _ := session.Get(r.Context(), "user_id").(int)
+	if !handlers.Handler.NeedsTwoFactor(userID) {
+		return false
+	}
+	session.Put(r.Context(), "warning", "Commissioners must turn on two-factor authentication")
+	http.Redirect(w, r, "/user/profile/two-factor", http.StatusSeeOther)
+	return true
+}
+
+// endSession logs out a user whose session was ended from elsewhere, like by
+// resetting their password
+func endSession(w http.ResponseWriter, r *http.Request) {
+	_ = session.Destroy(r.Context())
+	_ = session.RenewToken(r.Context())
+	session.Put(r.Context(), "error", "Your session has ended, log in again")
+	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+}
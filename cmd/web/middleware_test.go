@@ -31,3 +31,22 @@ func TestSessionLoad(t *testing.T) {
 		t.Error(fmt.Sprintf("type is not http.Handler, but is %T", v))
 	}
 }
+
+var isAPIPathTests = []struct {
+	path     string
+	expected bool
+}{
+	{"/api/v1", true},
+	{"/api/v1/leagues/1/rounds", true},
+	{"/api/v10/leagues", false},
+	{"/leagues/1/rounds", false},
+	{"/user/profile/api-tokens", false},
+}
+
+func TestIsAPIPath(t *testing.T) {
+	for _, e := range isAPIPathTests {
+		if isAPIPath(e.path) != e.expected {
+			t.Errorf("failed %s: expected %t, but got %t", e.path, e.expected, !e.expected)
+		}
+	}
+}
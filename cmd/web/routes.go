@@ -13,11 +13,13 @@ func routes(app *config.AppConfig) http.Handler {
 	mux := chi.NewRouter()
 
 	mux.Use(middleware.Recoverer)
+	mux.Use(APIToken)
 	mux.Use(NoSurf)
 	mux.Use(SessionLoad)
 
 	mux.Get("/", handlers.Handler.Home)
 	mux.Get("/about", handlers.Handler.About)
+	mux.Get("/calendar/{token}", handlers.Handler.CalendarFeed)
 
 	mux.Route("/leagues", func(mux chi.Router) {
 		mux.Use(Auth)
@@ -27,22 +29,125 @@ func routes(app *config.AppConfig) http.Handler {
 		mux.Get("/new", handlers.Handler.ShowLeagueForm)
 		mux.Get("/{id}", handlers.Handler.ShowLeague)
 		mux.Get("/{id}/add-player", handlers.Handler.ShowAddPlayerForm)
+		mux.Get("/{id}/import-players", handlers.Handler.ShowImportPlayersForm)
+		mux.Post("/{id}/import-players/preview", handlers.Handler.PreviewImportPlayers)
+		mux.Post("/{id}/import-players", handlers.Handler.ImportPlayers)
 		mux.Post("/{id}/players", handlers.Handler.AddPlayer)
 		mux.Get("/{league_id}/players/{id}/remove-player", handlers.Handler.RemovePlayer)
+		mux.Get("/{id}/players/{player_id}", handlers.Handler.ShowPlayer)
+		mux.Get("/{id}/seasons", handlers.Handler.Seasons)
+		mux.Post("/{id}/seasons", handlers.Handler.CreateSeason)
+		mux.Get("/{id}/seasons/new", handlers.Handler.ShowSeasonForm)
+		mux.Post("/{id}/seasons/{season_id}/open", handlers.Handler.OpenSeason)
+		mux.Post("/{id}/seasons/{season_id}/close", handlers.Handler.CloseSeason)
+		mux.Get("/{id}/settings", handlers.Handler.ShowLeagueSettings)
+		mux.Post("/{id}/settings", handlers.Handler.UpdateLeagueSettings)
+		mux.Get("/{id}/email", handlers.Handler.ShowLeagueEmail)
+		mux.Post("/{id}/email", handlers.Handler.PostLeagueEmail)
+		mux.Get("/{id}/schedule", handlers.Handler.Schedule)
+		mux.Get("/{id}/standings", handlers.Handler.Standings)
+		mux.Get("/{id}/teams", handlers.Handler.Teams)
+		mux.Post("/{id}/teams", handlers.Handler.CreateTeam)
+		mux.Get("/{id}/teams/new", handlers.Handler.ShowTeamForm)
+		mux.Post("/{id}/teams/{team_id}/delete", handlers.Handler.DeleteTeam)
+		mux.Get("/{id}/schedule/generate", handlers.Handler.ShowGenerateScheduleForm)
+		mux.Post("/{id}/schedule/generate", handlers.Handler.GenerateSchedule)
+		mux.Post("/{id}/schedule/publish", handlers.Handler.PublishSchedule)
+		mux.Get("/{id}/schedule/weeks/{week_id}/edit", handlers.Handler.ShowEditScheduleWeekForm)
+		mux.Post("/{id}/schedule/weeks/{week_id}", handlers.Handler.UpdateScheduleWeek)
+		mux.Post("/{id}/schedule/weeks/{week_id}/cancel", handlers.Handler.CancelScheduleWeek)
+		mux.Post("/{id}/schedule/weeks/{week_id}/reinstate", handlers.Handler.ReinstateScheduleWeek)
+		mux.Get("/{id}/schedule/weeks/{week_id}/results", handlers.Handler.WeekResults)
+		mux.Post("/{id}/schedule/weeks/{week_id}/skins", handlers.Handler.UpdateSkinsGame)
+		mux.Post("/{id}/invitations/{invitation_id}/resend", handlers.Handler.ResendInvitation)
+		mux.Post("/{id}/invitations/{invitation_id}/revoke", handlers.Handler.RevokeInvitation)
+		mux.Get("/{id}/roles", handlers.Handler.LeagueRoles)
+		mux.Post("/{id}/roles", handlers.Handler.GrantLeagueRole)
+		mux.Post("/{id}/roles/{role_id}/revoke", handlers.Handler.RevokeLeagueRole)
+		mux.Get("/{id}/commissioner-transfer", handlers.Handler.ShowCommissionerTransfer)
+		mux.Post("/{id}/commissioner-transfer", handlers.Handler.NominateCommissioner)
+		mux.Post("/{id}/commissioner-transfer/cancel", handlers.Handler.CancelCommissionerTransfer)
+		mux.Get("/{id}/webhooks", handlers.Handler.Webhooks)
+		mux.Post("/{id}/webhooks", handlers.Handler.CreateWebhook)
+		mux.Get("/{id}/webhooks/{webhook_id}", handlers.Handler.ShowWebhook)
+		mux.Post("/{id}/webhooks/{webhook_id}/test", handlers.Handler.SendTestWebhook)
+		mux.Post("/{id}/webhooks/{webhook_id}/delete", handlers.Handler.DeleteWebhook)
+		mux.Get("/{id}/rounds/new", handlers.Handler.ShowRoundForm)
+		mux.Post("/{id}/rounds", handlers.Handler.PostRound)
+	})
+
+	mux.Route("/courses", func(mux chi.Router) {
+		mux.Use(Auth)
+
+		mux.Get("/", handlers.Handler.Courses)
+		mux.Post("/", handlers.Handler.CreateCourse)
+		mux.Get("/new", handlers.Handler.ShowCourseForm)
+		mux.Get("/{id}", handlers.Handler.ShowCourse)
+		mux.Get("/{id}/tee-sets/new", handlers.Handler.ShowTeeSetForm)
+		mux.Post("/{id}/tee-sets", handlers.Handler.CreateTeeSet)
 	})
 
 	mux.Route("/user", func(mux chi.Router) {
 		mux.Get("/login", handlers.Handler.ShowLogin)
 		mux.Post("/login", handlers.Handler.PostShowLogin)
+		mux.Get("/login/two-factor", handlers.Handler.ShowTwoFactorLogin)
+		mux.Post("/login/two-factor", handlers.Handler.PostTwoFactorLogin)
 		mux.Get("/logout", handlers.Handler.Logout)
 		mux.Get("/sign-up", handlers.Handler.ShowSignUp)
 		mux.Post("/sign-up", handlers.Handler.PostShowSignUp)
+		mux.Get("/claim/{token}", handlers.Handler.ShowClaimAccount)
+		mux.Post("/claim/{token}", handlers.Handler.PostClaimAccount)
+		mux.Get("/forgot-password", handlers.Handler.ShowForgotPassword)
+		mux.Post("/forgot-password", handlers.Handler.PostForgotPassword)
+		mux.Get("/reset-password/{token}", handlers.Handler.ShowResetPassword)
+		mux.Post("/reset-password/{token}", handlers.Handler.PostResetPassword)
+		mux.Get("/verify-email/{token}", handlers.Handler.VerifyEmail)
+		mux.Post("/verify-email", handlers.Handler.ResendVerificationEmail)
+		mux.With(Auth).Get("/profile", handlers.Handler.ShowProfile)
+		mux.With(Auth).Post("/profile", handlers.Handler.UpdateProfile)
+		mux.With(Auth).Post("/profile/password", handlers.Handler.UpdatePassword)
+		mux.With(Auth).Post("/profile/logout-everywhere", handlers.Handler.LogOutEverywhere)
+		mux.With(Auth).Post("/profile/api-tokens", handlers.Handler.CreateAPIToken)
+		mux.With(Auth).Post("/profile/api-tokens/{id}/revoke", handlers.Handler.RevokeAPIToken)
+		mux.With(Auth).Post("/profile/calendar-feeds", handlers.Handler.CreateCalendarFeed)
+		mux.With(Auth).Post("/profile/calendar-feeds/{id}/revoke", handlers.Handler.RevokeCalendarFeed)
+		mux.With(Auth).Get("/profile/two-factor", handlers.Handler.ShowTwoFactorSetup)
+		mux.With(Auth).Post("/profile/two-factor", handlers.Handler.EnableTwoFactor)
+		mux.With(Auth).Post("/profile/two-factor/disable", handlers.Handler.DisableTwoFactor)
+		mux.With(Auth).Get("/commissioner-transfer/{token}", handlers.Handler.ShowAcceptCommissionerTransfer)
+		mux.With(Auth).Post("/commissioner-transfer/{token}", handlers.Handler.AcceptCommissionerTransfer)
 	})
 
 	mux.Route("/admin", func(mux chi.Router) {
 		mux.Use(AuthAdmin)
 
 		mux.Get("/dashboard", handlers.Handler.AdminDashboard)
+		mux.Get("/users", handlers.Handler.AdminUsers)
+		mux.Get("/users/{id}", handlers.Handler.AdminShowUser)
+		mux.Post("/users/{id}/access-level", handlers.Handler.AdminUpdateAccessLevel)
+		mux.Post("/users/{id}/deactivate", handlers.Handler.AdminDeactivateUser)
+		mux.Post("/users/{id}/reactivate", handlers.Handler.AdminReactivateUser)
+		mux.Post("/users/{id}/email", handlers.Handler.AdminFixEmail)
+		mux.Get("/leagues", handlers.Handler.AdminLeagues)
+		mux.Get("/leagues/{id}", handlers.Handler.AdminShowLeague)
+		mux.Post("/lockouts/clear", handlers.Handler.ClearLockout)
+		mux.Post("/settings/two-factor", handlers.Handler.UpdateTwoFactorRequirement)
+		mux.Get("/commissioner", handlers.Handler.ShowForceCommissionerTransfer)
+		mux.Post("/commissioner", handlers.Handler.ForceCommissionerTransfer)
+	})
+
+	mux.Route("/api/v1", func(mux chi.Router) {
+		mux.Use(APIAuth)
+
+		mux.Get("/user", handlers.Handler.APIUser)
+		mux.Get("/leagues", handlers.Handler.APILeagues)
+		mux.Get("/leagues/{id}", handlers.Handler.APILeague)
+		mux.Get("/leagues/{id}/players", handlers.Handler.APIPlayers)
+		mux.Get("/leagues/{id}/players/{player_id}", handlers.Handler.APIPlayer)
+		mux.Get("/leagues/{id}/rounds", handlers.Handler.APIRounds)
+		mux.Post("/leagues/{id}/rounds", handlers.Handler.APIPostRound)
+		mux.Get("/leagues/{id}/rounds/{round_id}", handlers.Handler.APIRound)
+		mux.Get("/leagues/{id}/standings", handlers.Handler.APIStandings)
 	})
 
 	fileServer := http.FileServer(http.Dir("./static/"))
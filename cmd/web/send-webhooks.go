@@ -0,0 +1,44 @@
+package main
+
+import (
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+// webhookInterval is how often deliveries are checked for, so failed ones are
+// retried even when nothing new happens
+const webhookInterval = 30 * time.Second
+
+// listenForWebhooks sends webhook deliveries as they become due, straight away
+// when a handler says there are new ones
+func listenForWebhooks(webhookService services.WebhookService) {
+	go func() {
+		ticker := time.NewTicker(webhookInterval)
+		for {
+			select {
+			case <-app.WebhookChan:
+			case <-ticker.C:
+			}
+			sendWebhooks(webhookService)
+		}
+	}()
+}
+
+// sendWebhooks sends every delivery that's due, a batch at a time
+func sendWebhooks(webhookService services.WebhookService) {
+	for {
+		deliveries, err := webhookService.DeliverDue()
+		if err != nil {
+			errorLog.Println(err)
+		}
+		if err != nil || len(deliveries) == 0 {
+			return
+		}
+		for _, d := range deliveries {
+			if !d.IsDelivered() {
+				infoLog.Printf("webhook delivery %d failed on attempt %d: %s", d.ID, d.Attempts, d.LastError)
+			}
+		}
+	}
+}
@@ -4,11 +4,11 @@ go 1.15
 
 require (
 	github.com/alexedwards/scs/v2 v2.4.0
-	github.com/asaskevich/govalidator v0.0.0-20230301143203-a9d515a09cc2 // indirect
+	github.com/asaskevich/govalidator v0.0.0-20230301143203-a9d515a09cc2
 	github.com/go-chi/chi v1.5.1
-	github.com/jackc/pgconn v1.14.1 // indirect
-	github.com/jackc/pgx/v4 v4.18.1 // indirect
+	github.com/jackc/pgconn v1.14.1
+	github.com/jackc/pgx/v4 v4.18.1
 	github.com/justinas/nosurf v1.1.1
-	github.com/xhit/go-simple-mail/v2 v2.16.0 // indirect
-	golang.org/x/crypto v0.18.0 // indirect
+	github.com/xhit/go-simple-mail/v2 v2.16.0
+	golang.org/x/crypto v0.18.0
 )
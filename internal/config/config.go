@@ -15,6 +15,11 @@ type AppConfig struct {
 	InfoLog       *log.Logger
 	ErrorLog      *log.Logger
 	InProduction  bool
-	Session       *scs.SessionManager
-	MailChan      chan models.MailData
+	// BaseURL is the scheme and host links in emails and calendar feed URLs
+	// are built from. It's configured rather than taken from requests, whose
+	// Host header the client chooses
+	BaseURL     string
+	Session     *scs.SessionManager
+	MailChan    chan models.MailData
+	WebhookChan chan bool
 }
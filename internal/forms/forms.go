@@ -3,7 +3,9 @@ package forms
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 )
@@ -72,3 +74,43 @@ func (f *Form) IsEmail(field string) {
 		f.Errors.Add(field, "Invalid email address")
 	}
 }
+
+// IntBetween checks for a whole number between min and max inclusive
+func (f *Form) IntBetween(field string, min, max int) bool {
+	x, err := strconv.Atoi(strings.TrimSpace(f.Get(field)))
+	if err != nil || x < min || x > max {
+		f.Errors.Add(field, fmt.Sprintf("This field must be a whole number from %d to %d", min, max))
+		return false
+	}
+	return true
+}
+
+// FloatBetween checks for a number between min and max inclusive
+func (f *Form) FloatBetween(field string, min, max float64) bool {
+	x, err := strconv.ParseFloat(strings.TrimSpace(f.Get(field)), 64)
+	if err != nil || x < min || x > max {
+		f.Errors.Add(field, fmt.Sprintf("This field must be a number from %g to %g", min, max))
+		return false
+	}
+	return true
+}
+
+// IsDate checks for a date in YYYY-MM-DD format
+func (f *Form) IsDate(field string) bool {
+	_, err := time.Parse("2006-01-02", f.Get(field))
+	if err != nil {
+		f.Errors.Add(field, "Invalid date")
+		return false
+	}
+	return true
+}
+
+// IsTime checks for a time of day in HH:MM format
+func (f *Form) IsTime(field string) bool {
+	_, err := time.Parse("15:04", f.Get(field))
+	if err != nil {
+		f.Errors.Add(field, "Invalid time")
+		return false
+	}
+	return true
+}
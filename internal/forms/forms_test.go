@@ -148,3 +148,101 @@ func TestForm_IsEmail(t *testing.T) {
 		t.Error("got valid for invalid email address")
 	}
 }
+
+func TestForm_IntBetween(t *testing.T) {
+	postedValues := url.Values{}
+	postedValues.Add("strokes", "16")
+	form := New(postedValues)
+
+	form.IntBetween("strokes", 1, 15)
+	if form.Valid() {
+		t.Error("shows 16 is between 1 and 15")
+	}
+
+	postedValues = url.Values{}
+	postedValues.Add("strokes", "four")
+	form = New(postedValues)
+
+	form.IntBetween("strokes", 1, 15)
+	if form.Valid() {
+		t.Error("shows non-numeric value is between 1 and 15")
+	}
+
+	postedValues = url.Values{}
+	postedValues.Add("strokes", "4")
+	form = New(postedValues)
+
+	form.IntBetween("strokes", 1, 15)
+	if !form.Valid() {
+		t.Error("shows 4 is not between 1 and 15")
+	}
+}
+
+func TestForm_FloatBetween(t *testing.T) {
+	postedValues := url.Values{}
+	postedValues.Add("rating", "90.5")
+	form := New(postedValues)
+
+	form.FloatBetween("rating", 25, 85)
+	if form.Valid() {
+		t.Error("shows 90.5 is between 25 and 85")
+	}
+
+	postedValues = url.Values{}
+	postedValues.Add("rating", "x")
+	form = New(postedValues)
+
+	form.FloatBetween("rating", 25, 85)
+	if form.Valid() {
+		t.Error("shows non-numeric value is between 25 and 85")
+	}
+
+	postedValues = url.Values{}
+	postedValues.Add("rating", "71.2")
+	form = New(postedValues)
+
+	form.FloatBetween("rating", 25, 85)
+	if !form.Valid() {
+		t.Error("shows 71.2 is not between 25 and 85")
+	}
+}
+
+func TestForm_IsDate(t *testing.T) {
+	postedValues := url.Values{}
+	postedValues.Add("played_on", "04/08/2024")
+	form := New(postedValues)
+
+	form.IsDate("played_on")
+	if form.Valid() {
+		t.Error("got valid for date in the wrong format")
+	}
+
+	postedValues = url.Values{}
+	postedValues.Add("played_on", "2024-04-08")
+	form = New(postedValues)
+
+	form.IsDate("played_on")
+	if !form.Valid() {
+		t.Error("got invalid for a valid date")
+	}
+}
+
+func TestForm_IsTime(t *testing.T) {
+	postedValues := url.Values{}
+	postedValues.Add("tee_time", "5:30 PM")
+	form := New(postedValues)
+
+	form.IsTime("tee_time")
+	if form.Valid() {
+		t.Error("got valid for time in the wrong format")
+	}
+
+	postedValues = url.Values{}
+	postedValues.Add("tee_time", "17:30")
+	form = New(postedValues)
+
+	form.IsTime("tee_time")
+	if !form.Valid() {
+		t.Error("got invalid for a valid time")
+	}
+}
@@ -0,0 +1,363 @@
+// Package handicap implements the World Handicap System calculations used to
+// turn posted rounds into a Handicap Index and a Handicap Index into strokes
+package handicap
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// MaxIndex is the highest Handicap Index that can be issued
+const MaxIndex = 54.0
+
+// standardSlope is the slope rating of a course of standard difficulty
+const standardSlope = 113.0
+
+// scoringRecordSize is the number of most recent differentials an index is calculated from
+const scoringRecordSize = 20
+
+// softCap is how far an index may rise above the low index before increases are halved
+const softCap = 3.0
+
+// hardCap is the most an index may rise above the low index
+const hardCap = 5.0
+
+// lowIndexPeriod is how far back the low index is looked for
+const lowIndexPeriod = 365 * 24 * time.Hour
+
+// noIndexMaxOverPar caps each hole at par plus this for golfers without an index
+const noIndexMaxOverPar = 5
+
+// indexCalculation is how many of the lowest differentials are averaged and
+// the adjustment applied, by the number of differentials in the scoring record
+var indexCalculation = map[int]struct {
+	lowest     int
+	adjustment float64
+}{
+	3:  {1, -2.0},
+	4:  {1, -1.0},
+	5:  {1, 0},
+	6:  {2, -1.0},
+	7:  {2, 0},
+	8:  {2, 0},
+	9:  {3, 0},
+	10: {3, 0},
+	11: {3, 0},
+	12: {4, 0},
+	13: {4, 0},
+	14: {4, 0},
+	15: {5, 0},
+	16: {5, 0},
+	17: {6, 0},
+	18: {6, 0},
+	19: {7, 0},
+	20: {8, 0},
+}
+
+// round1 rounds to the nearest tenth
+func round1(x float64) float64 {
+	return math.Round(x*10) / 10
+}
+
+// StrokesReceived returns the handicap strokes received on a hole with the
+// given stroke index. Strokes are given on the lowest stroke indexes first; a
+// plus handicap gives strokes back starting from the highest stroke index
+func StrokesReceived(courseHandicap, strokeIndex, numberOfHoles int) int {
+	if numberOfHoles <= 0 {
+		return 0
+	}
+
+	if courseHandicap < 0 {
+		plus := -courseHandicap
+		strokes := plus / numberOfHoles
+		if strokeIndex > numberOfHoles-plus%numberOfHoles {
+			strokes++
+		}
+		return -strokes
+	}
+
+	strokes := courseHandicap / numberOfHoles
+	if strokeIndex <= courseHandicap%numberOfHoles {
+		strokes++
+	}
+	return strokes
+}
+
+// StrokesByHole returns the strokes received on each of the holes played,
+// keyed by hole number. Holes are ranked by stroke index among themselves, so
+// nine holes of an eighteen hole course get strokes in the same order as the
+// full course does
+func StrokesByHole(courseHandicap int, holes []models.Hole) map[int]int {
+	sorted := append([]models.Hole(nil), holes...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StrokeIndex < sorted[j].StrokeIndex
+	})
+
+	strokes := make(map[int]int)
+	for i, h := range sorted {
+		strokes[h.Number] = StrokesReceived(courseHandicap, i+1, len(sorted))
+	}
+	return strokes
+}
+
+// CourseHandicap converts a Handicap Index into the strokes received from a
+// tee set. Nine hole tee sets use half of the index
+func CourseHandicap(index float64, teeSet models.TeeSet) int {
+	if len(teeSet.Holes) == 9 {
+		index = index / 2
+	}
+	return int(math.Round(index*float64(teeSet.Slope)/standardSlope + teeSet.CourseRating - float64(teeSet.Par())))
+}
+
+// AdjustedGrossScore caps each hole score at net double bogey, or at par plus
+// five for a golfer who does not have an index yet, and totals the round
+func AdjustedGrossScore(teeSet models.TeeSet, holeScores []models.HoleScore, courseHandicap int, hasIndex bool) int {
+	holes := make(map[int]models.Hole)
+	for _, h := range teeSet.Holes {
+		holes[h.Number] = h
+	}
+
+	total := 0
+	for _, s := range holeScores {
+		hole := holes[s.HoleNumber]
+
+		max := hole.Par + noIndexMaxOverPar
+		if hasIndex {
+			max = hole.Par + 2 + StrokesReceived(courseHandicap, hole.StrokeIndex, len(teeSet.Holes))
+		}
+
+		if s.Strokes > max {
+			total += max
+		} else {
+			total += s.Strokes
+		}
+	}
+
+	return total
+}
+
+// ScoreDifferential returns the score differential of an adjusted gross score
+func ScoreDifferential(adjustedGrossScore int, courseRating float64, slope int) float64 {
+	if slope == 0 {
+		return 0
+	}
+	return round1(standardSlope / float64(slope) * (float64(adjustedGrossScore) - courseRating))
+}
+
+// ExceptionalScoreReduction returns the reduction applied to the scoring
+// record when a differential is 7.0 or more below the index it was played to
+func ExceptionalScoreReduction(index, differential float64) float64 {
+	diff := round1(index - differential)
+	switch {
+	case diff >= 10:
+		return -2.0
+	case diff >= 7:
+		return -1.0
+	default:
+		return 0
+	}
+}
+
+// IndexFromDifferentials averages the lowest differentials of a scoring record
+// of up to the 20 most recent values. An index needs at least 3 values
+func IndexFromDifferentials(values []float64) (float64, bool) {
+	if len(values) > scoringRecordSize {
+		values = values[len(values)-scoringRecordSize:]
+	}
+
+	calculation, ok := indexCalculation[len(values)]
+	if !ok {
+		return 0, false
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted[:calculation.lowest] {
+		sum += v
+	}
+
+	index := round1(sum/float64(calculation.lowest) + calculation.adjustment)
+	if index > MaxIndex {
+		index = MaxIndex
+	}
+
+	return index, true
+}
+
+// applyCaps limits how far an index can rise above the low index
+func applyCaps(index, lowIndex float64) (float64, bool, bool) {
+	softCapped, hardCapped := false, false
+
+	if index-lowIndex > softCap {
+		index = round1(lowIndex + softCap + (index-lowIndex-softCap)/2)
+		softCapped = true
+	}
+
+	if index-lowIndex > hardCap {
+		index = round1(lowIndex + hardCap)
+		hardCapped = true
+	}
+
+	return index, softCapped, hardCapped
+}
+
+type revision struct {
+	date  time.Time
+	index float64
+}
+
+// Calculate builds a golfer's handicap record from their rounds. Each round
+// needs its tee set (with holes, rating and slope) and hole scores. Rounds are
+// processed in the order played so that net double bogey and exceptional
+// scores use the index the golfer held at the time
+func Calculate(rounds []models.Round) models.HandicapRecord {
+	sorted := append([]models.Round(nil), rounds...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].PlayedOn.Equal(sorted[j].PlayedOn) {
+			return sorted[i].ID < sorted[j].ID
+		}
+		return sorted[i].PlayedOn.Before(sorted[j].PlayedOn)
+	})
+
+	var record models.HandicapRecord
+	var differentials []models.Differential
+	var history []revision
+	var pendingNine *models.Differential
+
+	for _, round := range sorted {
+		courseHandicap := 0
+		if record.HasIndex {
+			courseHandicap = CourseHandicap(record.Index, round.TeeSet)
+		}
+
+		d := models.Differential{
+			RoundID:            round.ID,
+			PlayedOn:           round.PlayedOn,
+			CourseName:         round.Course.Name,
+			TeeName:            round.TeeSet.Name,
+			AdjustedGrossScore: AdjustedGrossScore(round.TeeSet, round.HoleScores, courseHandicap, record.HasIndex),
+			CourseRating:       round.TeeSet.CourseRating,
+			Slope:              round.TeeSet.Slope,
+		}
+
+		if len(round.TeeSet.Holes) == 9 {
+			if pendingNine == nil {
+				pendingNine = &d
+				continue
+			}
+			// two nine hole scores make one 18 hole score, played on the later date
+			d.AdjustedGrossScore += pendingNine.AdjustedGrossScore
+			d.CourseRating = round1(d.CourseRating + pendingNine.CourseRating)
+			d.Slope = int(math.Round(float64(d.Slope+pendingNine.Slope) / 2))
+			d.CourseName = pendingNine.CourseName + " / " + d.CourseName
+			d.TeeName = pendingNine.TeeName + " / " + d.TeeName
+			d.IsCombined = true
+			pendingNine = nil
+		}
+
+		d.ScoreDifferential = ScoreDifferential(d.AdjustedGrossScore, d.CourseRating, d.Slope)
+		differentials = append(differentials, d)
+
+		if record.HasIndex {
+			reduction := ExceptionalScoreReduction(record.Index, d.ScoreDifferential)
+			if reduction != 0 {
+				start := len(differentials) - scoringRecordSize
+				if start < 0 {
+					start = 0
+				}
+				for i := start; i < len(differentials); i++ {
+					differentials[i].Adjustment = round1(differentials[i].Adjustment + reduction)
+				}
+			}
+		}
+
+		record = revise(record, differentials, history, d.PlayedOn)
+		if record.HasIndex {
+			history = append(history, revision{date: d.PlayedOn, index: record.Index})
+		}
+	}
+
+	record.Differentials = mostRecent(differentials)
+	markCounted(record.Differentials)
+
+	return record
+}
+
+// revise recalculates the index after a new differential is added
+func revise(record models.HandicapRecord, differentials []models.Differential, history []revision, playedOn time.Time) models.HandicapRecord {
+	var values []float64
+	for _, d := range differentials {
+		values = append(values, d.Value())
+	}
+
+	index, ok := IndexFromDifferentials(values)
+	if !ok {
+		return record
+	}
+
+	record.Index = index
+	record.HasIndex = true
+	record.SoftCapApplied = false
+	record.HardCapApplied = false
+	record.HasLowIndex = false
+
+	// the low index only applies once a full scoring record exists
+	if len(differentials) < scoringRecordSize {
+		return record
+	}
+
+	for _, h := range history {
+		if playedOn.Sub(h.date) > lowIndexPeriod {
+			continue
+		}
+		if !record.HasLowIndex || h.index < record.LowIndex {
+			record.LowIndex = h.index
+			record.HasLowIndex = true
+		}
+	}
+
+	if record.HasLowIndex {
+		record.Index, record.SoftCapApplied, record.HardCapApplied = applyCaps(record.Index, record.LowIndex)
+	}
+
+	return record
+}
+
+// mostRecent returns the scoring record, newest first
+func mostRecent(differentials []models.Differential) []models.Differential {
+	start := len(differentials) - scoringRecordSize
+	if start < 0 {
+		start = 0
+	}
+
+	var recent []models.Differential
+	for i := len(differentials) - 1; i >= start; i-- {
+		recent = append(recent, differentials[i])
+	}
+	return recent
+}
+
+// markCounted flags the lowest differentials that make up the current index
+func markCounted(differentials []models.Differential) {
+	calculation, ok := indexCalculation[len(differentials)]
+	if !ok {
+		return
+	}
+
+	order := make([]int, len(differentials))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return differentials[order[i]].Value() < differentials[order[j]].Value()
+	})
+
+	for _, i := range order[:calculation.lowest] {
+		differentials[i].Counted = true
+	}
+}
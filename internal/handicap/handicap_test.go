@@ -0,0 +1,373 @@
+package handicap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// testTeeSet returns a tee set of par 4 holes with stroke index equal to the hole number
+func testTeeSet(numberOfHoles int, courseRating float64, slope int) models.TeeSet {
+	teeSet := models.TeeSet{Name: "White", CourseRating: courseRating, Slope: slope}
+	for i := 1; i <= numberOfHoles; i++ {
+		teeSet.Holes = append(teeSet.Holes, models.Hole{Number: i, Par: 4, StrokeIndex: i})
+	}
+	return teeSet
+}
+
+// testRound returns a round on the tee set adding up to gross, spreading the
+// strokes over par one hole at a time
+func testRound(id int, day int, teeSet models.TeeSet, gross int) models.Round {
+	round := models.Round{
+		ID:       id,
+		PlayedOn: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, day),
+		TeeSet:   teeSet,
+	}
+
+	extra := gross - teeSet.Par()
+	for i := range teeSet.Holes {
+		strokes := 4 + extra/len(teeSet.Holes)
+		if i < extra%len(teeSet.Holes) {
+			strokes++
+		}
+		round.HoleScores = append(round.HoleScores, models.HoleScore{HoleNumber: i + 1, Strokes: strokes})
+	}
+
+	return round
+}
+
+// testRounds returns count 18 hole rounds of the same gross score, one per day
+func testRounds(start, count, gross int) []models.Round {
+	var rounds []models.Round
+	for i := start; i < start+count; i++ {
+		rounds = append(rounds, testRound(i+1, i, testTeeSet(18, 72, 113), gross))
+	}
+	return rounds
+}
+
+var strokesReceivedTests = []struct {
+	name           string
+	courseHandicap int
+	strokeIndex    int
+	numberOfHoles  int
+	expected       int
+}{
+	{"scratch", 0, 1, 18, 0},
+	{"hardest hole inside handicap", 10, 10, 18, 1},
+	{"easier hole outside handicap", 10, 11, 18, 0},
+	{"second stroke on hardest holes", 20, 2, 18, 2},
+	{"one stroke on remaining holes", 20, 3, 18, 1},
+	{"plus handicap gives back on easiest hole", -2, 18, 18, -1},
+	{"plus handicap gives back on second easiest hole", -2, 17, 18, -1},
+	{"plus handicap keeps harder holes", -2, 16, 18, 0},
+	{"nine holes", 5, 5, 9, 1},
+	{"no holes", 5, 1, 0, 0},
+}
+
+func TestStrokesReceived(t *testing.T) {
+	for _, e := range strokesReceivedTests {
+		actual := StrokesReceived(e.courseHandicap, e.strokeIndex, e.numberOfHoles)
+		if actual != e.expected {
+			t.Errorf("failed %s: expected %d, but got %d", e.name, e.expected, actual)
+		}
+	}
+}
+
+func TestStrokesByHole(t *testing.T) {
+	// the back nine of an eighteen hole course has even stroke indexes
+	var holes []models.Hole
+	for i, si := range []int{10, 2, 14, 6, 18, 4, 12, 16, 8} {
+		holes = append(holes, models.Hole{Number: i + 10, StrokeIndex: si})
+	}
+
+	expected := map[int]int{10: 1, 11: 2, 12: 1, 13: 1, 14: 1, 15: 2, 16: 1, 17: 1, 18: 1}
+	strokes := StrokesByHole(11, holes)
+	for number, e := range expected {
+		if strokes[number] != e {
+			t.Errorf("hole %d: expected %d strokes, but got %d", number, e, strokes[number])
+		}
+	}
+
+	plus := StrokesByHole(-1, holes)
+	if plus[14] != -1 || plus[11] != 0 {
+		t.Errorf("expected a plus handicap to give a stroke back on the easiest hole, but got %v", plus)
+	}
+}
+
+var courseHandicapTests = []struct {
+	name     string
+	index    float64
+	teeSet   models.TeeSet
+	expected int
+}{
+	{"standard course", 10.0, testTeeSet(18, 72, 113), 10},
+	{"harder slope", 10.0, testTeeSet(18, 71.5, 130), 11},
+	{"rating above par", 0, testTeeSet(18, 73.6, 113), 2},
+	{"nine holes use half the index", 10.0, testTeeSet(9, 36, 113), 5},
+	{"plus index", -2.0, testTeeSet(18, 72, 113), -2},
+}
+
+func TestCourseHandicap(t *testing.T) {
+	for _, e := range courseHandicapTests {
+		actual := CourseHandicap(e.index, e.teeSet)
+		if actual != e.expected {
+			t.Errorf("failed %s: expected %d, but got %d", e.name, e.expected, actual)
+		}
+	}
+}
+
+var adjustedGrossScoreTests = []struct {
+	name           string
+	strokes        int
+	courseHandicap int
+	hasIndex       bool
+	expected       int
+}{
+	{"no index under par plus five", 9, 0, false, 77},
+	{"no index capped at par plus five", 12, 0, false, 77},
+	{"scratch capped at double bogey", 8, 0, true, 74},
+	{"stroke raises the cap", 8, 1, true, 75},
+	{"no adjustment needed", 5, 0, true, 73},
+}
+
+func TestAdjustedGrossScore(t *testing.T) {
+	for _, e := range adjustedGrossScoreTests {
+		round := testRound(1, 0, testTeeSet(18, 72, 113), 72)
+		round.HoleScores[0].Strokes = e.strokes
+
+		actual := AdjustedGrossScore(round.TeeSet, round.HoleScores, e.courseHandicap, e.hasIndex)
+		if actual != e.expected {
+			t.Errorf("failed %s: expected %d, but got %d", e.name, e.expected, actual)
+		}
+	}
+}
+
+var scoreDifferentialTests = []struct {
+	name         string
+	ags          int
+	courseRating float64
+	slope        int
+	expected     float64
+}{
+	{"standard slope", 85, 72.0, 113, 13.0},
+	{"rounded to a tenth", 90, 70.1, 125, 18.0},
+	{"under the rating", 70, 72.0, 113, -2.0},
+	{"missing slope", 85, 72.0, 0, 0},
+}
+
+func TestScoreDifferential(t *testing.T) {
+	for _, e := range scoreDifferentialTests {
+		actual := ScoreDifferential(e.ags, e.courseRating, e.slope)
+		if actual != e.expected {
+			t.Errorf("failed %s: expected %.1f, but got %.1f", e.name, e.expected, actual)
+		}
+	}
+}
+
+var exceptionalScoreReductionTests = []struct {
+	name         string
+	index        float64
+	differential float64
+	expected     float64
+}{
+	{"not exceptional", 15.0, 8.1, 0},
+	{"seven below", 15.0, 8.0, -1.0},
+	{"ten below", 15.0, 5.0, -2.0},
+}
+
+func TestExceptionalScoreReduction(t *testing.T) {
+	for _, e := range exceptionalScoreReductionTests {
+		actual := ExceptionalScoreReduction(e.index, e.differential)
+		if actual != e.expected {
+			t.Errorf("failed %s: expected %.1f, but got %.1f", e.name, e.expected, actual)
+		}
+	}
+}
+
+func sequence(from, to float64) []float64 {
+	var values []float64
+	for v := from; v <= to; v++ {
+		values = append(values, v)
+	}
+	return values
+}
+
+var indexFromDifferentialsTests = []struct {
+	name        string
+	values      []float64
+	expected    float64
+	expectIndex bool
+}{
+	{"not enough scores", []float64{10, 12}, 0, false},
+	{"three scores", []float64{10, 12, 14}, 8.0, true},
+	{"six scores", []float64{10, 12, 14, 16, 18, 20}, 10.0, true},
+	{"best eight of twenty", sequence(1, 20), 4.5, true},
+	{"only the most recent twenty", append([]float64{0, 0}, sequence(1, 20)...), 4.5, true},
+	{"maximum index", []float64{60, 60, 60}, MaxIndex, true},
+}
+
+func TestIndexFromDifferentials(t *testing.T) {
+	for _, e := range indexFromDifferentialsTests {
+		actual, ok := IndexFromDifferentials(e.values)
+		if ok != e.expectIndex {
+			t.Errorf("failed %s: expected index %t, but got %t", e.name, e.expectIndex, ok)
+		}
+		if actual != e.expected {
+			t.Errorf("failed %s: expected %.1f, but got %.1f", e.name, e.expected, actual)
+		}
+	}
+}
+
+var applyCapsTests = []struct {
+	name       string
+	index      float64
+	lowIndex   float64
+	expected   float64
+	softCapped bool
+	hardCapped bool
+}{
+	{"within soft cap", 8.0, 5.0, 8.0, false, false},
+	{"soft cap halves the increase", 10.0, 5.0, 9.0, true, false},
+	{"hard cap", 20.0, 5.0, 10.0, true, true},
+}
+
+func TestApplyCaps(t *testing.T) {
+	for _, e := range applyCapsTests {
+		actual, softCapped, hardCapped := applyCaps(e.index, e.lowIndex)
+		if actual != e.expected || softCapped != e.softCapped || hardCapped != e.hardCapped {
+			t.Errorf("failed %s: expected %.1f %t %t, but got %.1f %t %t", e.name, e.expected, e.softCapped, e.hardCapped, actual, softCapped, hardCapped)
+		}
+	}
+}
+
+var calculateTests = []struct {
+	name                  string
+	rounds                func() []models.Round
+	expectIndex           bool
+	expectedIndex         float64
+	expectedDifferentials int
+	expectedCounted       int
+	softCapped            bool
+	hardCapped            bool
+}{
+	{
+		name:   "no rounds",
+		rounds: func() []models.Round { return nil },
+	},
+	{
+		name:                  "not enough rounds",
+		rounds:                func() []models.Round { return testRounds(0, 2, 85) },
+		expectedDifferentials: 2,
+	},
+	{
+		name: "first index",
+		rounds: func() []models.Round {
+			rounds := testRounds(0, 1, 85)
+			rounds = append(rounds, testRounds(1, 1, 90)...)
+			return append(rounds, testRounds(2, 1, 95)...)
+		},
+		expectIndex:           true,
+		expectedIndex:         11.0,
+		expectedDifferentials: 3,
+		expectedCounted:       1,
+	},
+	{
+		name: "nine hole rounds are combined",
+		rounds: func() []models.Round {
+			teeSet := testTeeSet(9, 36, 113)
+			return []models.Round{
+				testRound(1, 0, teeSet, 42),
+				testRound(2, 1, teeSet, 44),
+				testRound(3, 2, teeSet, 44),
+			}
+		},
+		expectedDifferentials: 1,
+	},
+	{
+		name: "exceptional score",
+		rounds: func() []models.Round {
+			return append(testRounds(0, 3, 100), testRounds(3, 1, 82)...)
+		},
+		expectIndex:           true,
+		expectedIndex:         7.0,
+		expectedDifferentials: 4,
+		expectedCounted:       1,
+	},
+	{
+		name:                  "only the most recent twenty",
+		rounds:                func() []models.Round { return append(testRounds(0, 5, 80), testRounds(5, 20, 77)...) },
+		expectIndex:           true,
+		expectedIndex:         5.0,
+		expectedDifferentials: 20,
+		expectedCounted:       8,
+	},
+	{
+		name:                  "soft cap",
+		rounds:                func() []models.Round { return append(testRounds(0, 20, 77), testRounds(20, 20, 80)...) },
+		expectIndex:           true,
+		expectedIndex:         7.0,
+		expectedDifferentials: 20,
+		expectedCounted:       8,
+		softCapped:            true,
+	},
+	{
+		name:                  "hard cap",
+		rounds:                func() []models.Round { return append(testRounds(0, 20, 77), testRounds(20, 20, 100)...) },
+		expectIndex:           true,
+		expectedIndex:         8.0,
+		expectedDifferentials: 20,
+		expectedCounted:       8,
+		softCapped:            true,
+		hardCapped:            true,
+	},
+}
+
+func TestCalculate(t *testing.T) {
+	for _, e := range calculateTests {
+		record := Calculate(e.rounds())
+
+		if record.HasIndex != e.expectIndex {
+			t.Errorf("failed %s: expected index %t, but got %t", e.name, e.expectIndex, record.HasIndex)
+		}
+		if record.Index != e.expectedIndex {
+			t.Errorf("failed %s: expected index %.1f, but got %.1f", e.name, e.expectedIndex, record.Index)
+		}
+		if len(record.Differentials) != e.expectedDifferentials {
+			t.Errorf("failed %s: expected %d differentials, but got %d", e.name, e.expectedDifferentials, len(record.Differentials))
+		}
+		if record.SoftCapApplied != e.softCapped || record.HardCapApplied != e.hardCapped {
+			t.Errorf("failed %s: expected caps %t %t, but got %t %t", e.name, e.softCapped, e.hardCapped, record.SoftCapApplied, record.HardCapApplied)
+		}
+
+		counted := 0
+		for _, d := range record.Differentials {
+			if d.Counted {
+				counted++
+			}
+		}
+		if counted != e.expectedCounted {
+			t.Errorf("failed %s: expected %d counted, but got %d", e.name, e.expectedCounted, counted)
+		}
+	}
+}
+
+func TestCalculateDifferentials(t *testing.T) {
+	teeSet := testTeeSet(9, 36, 113)
+	record := Calculate([]models.Round{testRound(1, 0, teeSet, 42), testRound(2, 1, teeSet, 44)})
+
+	d := record.Differentials[0]
+	if !d.IsCombined || d.AdjustedGrossScore != 86 || d.CourseRating != 72 || d.ScoreDifferential != 14.0 {
+		t.Errorf("nine hole rounds combined incorrectly: %+v", d)
+	}
+
+	record = Calculate(append(testRounds(0, 3, 100), testRounds(3, 1, 82)...))
+	for _, d := range record.Differentials {
+		if d.Adjustment != -2.0 {
+			t.Errorf("expected exceptional score reduction of -2.0, but got %.1f", d.Adjustment)
+		}
+	}
+	if !record.Differentials[0].Counted {
+		t.Error("expected exceptional score to count")
+	}
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// adminIDIndex is where the id is in /admin/users/{id} and /admin/leagues/{id}
+const adminIDIndex = 3
+
+// adminPageSize is how many users or leagues the admin lists show at once
+const adminPageSize = 25
+
+// recentSignUpCount is how many of the newest users the dashboard shows
+const recentSignUpCount = 5
+
+func getAdminIDFromURI(URI string) (int, error) {
+	return getIDFromURI(URI, adminIDIndex)
+}
+
+// adminPage returns the page of a list asked for in the query string
+func adminPage(r *http.Request) models.Page {
+	number, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	return models.NewPage(number, adminPageSize)
+}
+
+// AdminDashboard renders the super admin's overview of the site
+func (m *Handlers) AdminDashboard(w http.ResponseWriter, r *http.Request) {
+	recentUsers, userPage, err := m.UserService.SearchUsers("", models.NewPage(1, recentSignUpCount))
+	if err != nil {
+		log.Println(err)
+	}
+
+	_, leaguePage, err := m.LeagueService.SearchLeagues("", models.NewPage(1, 1))
+	if err != nil {
+		log.Println(err)
+	}
+
+	lockouts, err := m.LoginThrottleService.GetLockouts()
+	if err != nil {
+		log.Println(err)
+	}
+
+	twoFactorRequired, err := m.TwoFactorService.RequiredForCommissioners()
+	if err != nil {
+		log.Println(err)
+	}
+
+	data := make(map[string]interface{})
+	data["user_count"] = userPage.Total
+	data["league_count"] = leaguePage.Total
+	data["recent_users"] = recentUsers
+	data["lockouts"] = lockouts
+	data["two_factor_required"] = twoFactorRequired
+
+	render.Template(w, r, "admin-dashboard.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// AdminUsers renders a page of every user on the site, optionally only the
+// ones whose name or email contains a search
+func (m *Handlers) AdminUsers(w http.ResponseWriter, r *http.Request) {
+	search := r.URL.Query().Get("q")
+
+	users, page, err := m.UserService.SearchUsers(search, adminPage(r))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get users")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["users"] = users
+	data["page"] = page
+	data["search"] = search
+
+	render.Template(w, r, "admin-users.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// adminUserData returns what the admin page for a user shows
+func (m *Handlers) adminUserData(r *http.Request, userID int) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		return data, err
+	}
+
+	leagues, err := m.LeagueService.GetLeaguesByUser(user.ID)
+	if err != nil {
+		return data, err
+	}
+
+	adminID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+
+	data["user"] = user
+	data["leagues"] = leagues
+	data["access_levels"] = models.AccessLevelNames
+	data["is_self"] = user.ID == adminID
+
+	return data, nil
+}
+
+// AdminShowUser renders the page where a super admin manages one user's account
+func (m *Handlers) AdminShowUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := getAdminIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		return
+	}
+
+	data, err := m.adminUserData(r, userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find user")
+		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "admin-user.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// AdminUpdateAccessLevel handles a super admin changing what a user is allowed
+// to do on the site. Super admins can't change their own access level, so
+// there's always someone left who can
+func (m *Handlers) AdminUpdateAccessLevel(w http.ResponseWriter, r *http.Request) {
+	userID, err := getAdminIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		return
+	}
+
+	if adminID, _ := m.App.Session.Get(r.Context(), "user_id").(int); adminID == userID {
+		m.App.Session.Put(r.Context(), "error", "you can't change your own access level")
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	accessLevel, err := strconv.Atoi(r.Form.Get("access_level"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "choose one of the access levels")
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.UserService.SetAccessLevel(userID, accessLevel)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("access level changed to %s!", models.AccessLevelNames[accessLevel]))
+	http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+}
+
+// AdminDeactivateUser handles a super admin shutting a user's account off.
+// Super admins can't deactivate themselves
+func (m *Handlers) AdminDeactivateUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := getAdminIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		return
+	}
+
+	if adminID, _ := m.App.Session.Get(r.Context(), "user_id").(int); adminID == userID {
+		m.App.Session.Put(r.Context(), "error", "you can't deactivate your own account")
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.UserService.DeactivateUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "account deactivated!")
+	http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+}
+
+// AdminReactivateUser handles a super admin letting a deactivated user log in
+// again
+func (m *Handlers) AdminReactivateUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := getAdminIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		return
+	}
+
+	err = m.UserService.ReactivateUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "account reactivated!")
+	http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+}
+
+// AdminFixEmail handles a super admin correcting the email address of a user
+// who hasn't claimed their account, like when a commissioner made a typo
+// adding them to a league
+func (m *Handlers) AdminFixEmail(w http.ResponseWriter, r *http.Request) {
+	userID, err := getAdminIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("email")
+	form.IsEmail("email")
+
+	if !form.Valid() {
+		data, err := m.adminUserData(r, userID)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot find user")
+			http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+			return
+		}
+
+		render.Template(w, r, "admin-user.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	email := form.Get("email")
+
+	err = m.UserService.FixUnclaimedEmail(userID, email)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("email changed to %s! Resend their invitation from the league page", email))
+	http.Redirect(w, r, fmt.Sprintf("/admin/users/%d", userID), http.StatusSeeOther)
+}
+
+// AdminLeagues renders a page of every league on the site, optionally only
+// the ones whose name contains a search
+func (m *Handlers) AdminLeagues(w http.ResponseWriter, r *http.Request) {
+	search := r.URL.Query().Get("q")
+
+	leagues, page, err := m.LeagueService.SearchLeagues(search, adminPage(r))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get leagues")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["leagues"] = leagues
+	data["page"] = page
+	data["search"] = search
+
+	render.Template(w, r, "admin-leagues.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// AdminShowLeague renders any league's roster for a super admin, including
+// the players who haven't claimed their account yet
+func (m *Handlers) AdminShowLeague(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := getAdminIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/admin/leagues", http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/admin/leagues", http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, "/admin/leagues", http.StatusSeeOther)
+		return
+	}
+
+	invitations, err := m.InvitationService.GetOpenInvitationsInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get invitations for league")
+		http.Redirect(w, r, "/admin/leagues", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["players"] = players
+	data["invitations"] = invitations
+	data["now"] = time.Now()
+
+	render.Template(w, r, "admin-league.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var adminListTests = []struct {
+	name               string
+	url                string
+	handler            func(*Handlers, http.ResponseWriter, *http.Request)
+	expectedStatusCode int
+}{
+	{"users", "/admin/users?q=jack&page=1", (*Handlers).AdminUsers, http.StatusOK},
+	{"users error", "/admin/users?q=error", (*Handlers).AdminUsers, http.StatusSeeOther},
+	{"leagues", "/admin/leagues?q=league&page=3", (*Handlers).AdminLeagues, http.StatusOK},
+	{"leagues error", "/admin/leagues?q=error", (*Handlers).AdminLeagues, http.StatusSeeOther},
+}
+
+func TestAdminLists(t *testing.T) {
+	for _, e := range adminListTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+		e.handler(Handler, rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+	}
+}
+
+var adminShowTests = []struct {
+	name               string
+	url                string
+	handler            func(*Handlers, http.ResponseWriter, *http.Request)
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"user", "/admin/users/1", (*Handlers).AdminShowUser, http.StatusOK, ""},
+	{"deactivated user", "/admin/users/11", (*Handlers).AdminShowUser, http.StatusOK, ""},
+	{"user bad url parameter", "/admin/users/s", (*Handlers).AdminShowUser, http.StatusSeeOther, "/admin/users"},
+	{"user not found", "/admin/users/0", (*Handlers).AdminShowUser, http.StatusSeeOther, "/admin/users"},
+	{"user leagues error", "/admin/users/2", (*Handlers).AdminShowUser, http.StatusSeeOther, "/admin/users"},
+	{"league", "/admin/leagues/1", (*Handlers).AdminShowLeague, http.StatusOK, ""},
+	{"league bad url parameter", "/admin/leagues/s", (*Handlers).AdminShowLeague, http.StatusSeeOther, "/admin/leagues"},
+	{"league not found", "/admin/leagues/3", (*Handlers).AdminShowLeague, http.StatusSeeOther, "/admin/leagues"},
+	{"league players error", "/admin/leagues/2", (*Handlers).AdminShowLeague, http.StatusSeeOther, "/admin/leagues"},
+	{"league invitations error", "/admin/leagues/8", (*Handlers).AdminShowLeague, http.StatusSeeOther, "/admin/leagues"},
+}
+
+func TestAdminShow(t *testing.T) {
+	for _, e := range adminShowTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", 1)
+
+		rr := httptest.NewRecorder()
+		e.handler(Handler, rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var adminUserActionTests = []struct {
+	name               string
+	adminID            int
+	url                string
+	handler            func(*Handlers, http.ResponseWriter, *http.Request)
+	postedData         url.Values
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "access level",
+		adminID:            1,
+		url:                "/admin/users/2/access-level",
+		handler:            (*Handlers).AdminUpdateAccessLevel,
+		postedData:         url.Values{"access_level": {"2"}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/2",
+	},
+	{
+		name:               "access level bad url parameter",
+		adminID:            1,
+		url:                "/admin/users/s/access-level",
+		handler:            (*Handlers).AdminUpdateAccessLevel,
+		postedData:         url.Values{"access_level": {"2"}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users",
+	},
+	{
+		name:               "access level of self",
+		adminID:            2,
+		url:                "/admin/users/2/access-level",
+		handler:            (*Handlers).AdminUpdateAccessLevel,
+		postedData:         url.Values{"access_level": {"1"}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/2",
+	},
+	{
+		name:               "access level missing",
+		adminID:            1,
+		url:                "/admin/users/2/access-level",
+		handler:            (*Handlers).AdminUpdateAccessLevel,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/2",
+	},
+	{
+		name:               "access level service error",
+		adminID:            1,
+		url:                "/admin/users/3/access-level",
+		handler:            (*Handlers).AdminUpdateAccessLevel,
+		postedData:         url.Values{"access_level": {"2"}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/3",
+	},
+	{
+		name:               "deactivate",
+		adminID:            1,
+		url:                "/admin/users/2/deactivate",
+		handler:            (*Handlers).AdminDeactivateUser,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/2",
+	},
+	{
+		name:               "deactivate bad url parameter",
+		adminID:            1,
+		url:                "/admin/users/s/deactivate",
+		handler:            (*Handlers).AdminDeactivateUser,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users",
+	},
+	{
+		name:               "deactivate self",
+		adminID:            2,
+		url:                "/admin/users/2/deactivate",
+		handler:            (*Handlers).AdminDeactivateUser,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/2",
+	},
+	{
+		name:               "deactivate service error",
+		adminID:            1,
+		url:                "/admin/users/3/deactivate",
+		handler:            (*Handlers).AdminDeactivateUser,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/3",
+	},
+	{
+		name:               "reactivate",
+		adminID:            1,
+		url:                "/admin/users/11/reactivate",
+		handler:            (*Handlers).AdminReactivateUser,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/11",
+	},
+	{
+		name:               "reactivate bad url parameter",
+		adminID:            1,
+		url:                "/admin/users/s/reactivate",
+		handler:            (*Handlers).AdminReactivateUser,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users",
+	},
+	{
+		name:               "reactivate service error",
+		adminID:            1,
+		url:                "/admin/users/3/reactivate",
+		handler:            (*Handlers).AdminReactivateUser,
+		postedData:         url.Values{},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/3",
+	},
+	{
+		name:               "fix email",
+		adminID:            1,
+		url:                "/admin/users/2/email",
+		handler:            (*Handlers).AdminFixEmail,
+		postedData:         url.Values{"email": {"fixed@here.com"}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/2",
+	},
+	{
+		name:               "fix email bad url parameter",
+		adminID:            1,
+		url:                "/admin/users/s/email",
+		handler:            (*Handlers).AdminFixEmail,
+		postedData:         url.Values{"email": {"fixed@here.com"}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users",
+	},
+	{
+		name:               "fix email invalid form",
+		adminID:            1,
+		url:                "/admin/users/4/email",
+		handler:            (*Handlers).AdminFixEmail,
+		postedData:         url.Values{"email": {"not an email"}},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "fix email invalid form and user not found",
+		adminID:            1,
+		url:                "/admin/users/0/email",
+		handler:            (*Handlers).AdminFixEmail,
+		postedData:         url.Values{"email": {""}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users",
+	},
+	{
+		name:               "fix email service error",
+		adminID:            1,
+		url:                "/admin/users/2/email",
+		handler:            (*Handlers).AdminFixEmail,
+		postedData:         url.Values{"email": {"error@here.com"}},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/admin/users/2",
+	},
+}
+
+func TestAdminUserActions(t *testing.T) {
+	for _, e := range adminUserActionTests {
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(e.postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		session.Put(req.Context(), "user_id", e.adminID)
+
+		rr := httptest.NewRecorder()
+		e.handler(Handler, rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
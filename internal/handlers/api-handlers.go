@@ -0,0 +1,384 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// Where ids are in /api/v1/leagues/{id}/players/{player_id} and
+// /api/v1/leagues/{id}/rounds/{round_id}
+const (
+	apiLeagueIDIndex = 4
+	apiItemIDIndex   = 6
+)
+
+// lookupStatus returns the status code for a failed lookup, which is only
+// not found when there was nothing to find
+func lookupStatus(err error) int {
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// contextKey is the type of keys for values handlers put in a request's context
+type contextKey string
+
+// apiTokenKey is where the API token a request was authenticated with is kept
+// in its context
+const apiTokenKey contextKey = "api_token"
+
+// BearerToken returns the token in a request's Authorization header, if it
+// has one
+func BearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	if len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+		return "", false
+	}
+
+	token := strings.TrimSpace(header[len("Bearer "):])
+	return token, token != ""
+}
+
+// AuthenticateAPIToken checks a bearer token and returns the request with the
+// token in its context, so the API handlers act as the token's user instead of
+// whoever is logged in to the session
+func (m *Handlers) AuthenticateAPIToken(r *http.Request, token string) (*http.Request, models.APIToken, error) {
+	_, apiToken, err := m.APITokenService.Authenticate(token)
+	if err != nil {
+		return r, apiToken, err
+	}
+
+	return r.WithContext(context.WithValue(r.Context(), apiTokenKey, apiToken)), apiToken, nil
+}
+
+// RequestAPIToken returns the API token a request was authenticated with, if
+// it was
+func RequestAPIToken(r *http.Request) (models.APIToken, bool) {
+	apiToken, ok := r.Context().Value(apiTokenKey).(models.APIToken)
+	return apiToken, ok
+}
+
+// apiUserID returns the id of the user making an API request, either with a
+// bearer token or their session
+func (m *Handlers) apiUserID(r *http.Request) int {
+	if apiToken, ok := RequestAPIToken(r); ok {
+		return apiToken.UserID
+	}
+
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	return userID
+}
+
+// apiLeague returns the league in the URI of an API request after checking
+// the user is in it. On failure it returns the status code to respond with
+func (m *Handlers) apiLeague(r *http.Request) (models.League, int, error) {
+	userID := m.apiUserID(r)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		return models.League{}, http.StatusUnauthorized, errors.New("log in first")
+	}
+
+	leagueID, err := getIDFromURI(r.RequestURI, apiLeagueIDIndex)
+	if err != nil {
+		return models.League{}, http.StatusBadRequest, errors.New("missing url parameter")
+	}
+
+	if _, err := m.LeagueRoleService.GetMembership(userID, leagueID); err != nil {
+		return models.League{}, http.StatusForbidden, errors.New("user not in this league")
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		return league, lookupStatus(err), errors.New("cannot find league")
+	}
+
+	return league, http.StatusOK, nil
+}
+
+// APIUser responds with the user making the request
+func (m *Handlers) APIUser(w http.ResponseWriter, r *http.Request) {
+	user, err := m.UserService.GetUser(m.apiUserID(r))
+	if err != nil {
+		WriteAPIError(w, http.StatusUnauthorized, "log in first")
+		return
+	}
+
+	writeAPIData(w, newAPIUser(user))
+}
+
+// APILeagues responds with the leagues the user is in
+func (m *Handlers) APILeagues(w http.ResponseWriter, r *http.Request) {
+	user, err := m.UserService.GetUser(m.apiUserID(r))
+	if err != nil {
+		WriteAPIError(w, http.StatusUnauthorized, "log in first")
+		return
+	}
+
+	leagues, err := m.LeagueService.GetLeaguesByUser(user.ID)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot get leagues")
+		return
+	}
+
+	writeAPIData(w, newAPILeagues(leagues))
+}
+
+// APILeague responds with one of the user's leagues
+func (m *Handlers) APILeague(w http.ResponseWriter, r *http.Request) {
+	league, status, err := m.apiLeague(r)
+	if err != nil {
+		WriteAPIError(w, status, err.Error())
+		return
+	}
+
+	writeAPIData(w, newAPILeague(league))
+}
+
+// APIPlayers responds with a league's roster, including each player's
+// handicap index
+func (m *Handlers) APIPlayers(w http.ResponseWriter, r *http.Request) {
+	league, status, err := m.apiLeague(r)
+	if err != nil {
+		WriteAPIError(w, status, err.Error())
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot get players for league")
+		return
+	}
+
+	handicaps, err := m.HandicapService.GetHandicapRecords(players)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot get handicaps for league")
+		return
+	}
+
+	out := make([]apiPlayer, 0, len(players))
+	for _, p := range players {
+		out = append(out, newAPIPlayer(p, handicaps[p.ID]))
+	}
+
+	writeAPIData(w, out)
+}
+
+// APIPlayer responds with one player in a league
+func (m *Handlers) APIPlayer(w http.ResponseWriter, r *http.Request) {
+	league, status, err := m.apiLeague(r)
+	if err != nil {
+		WriteAPIError(w, status, err.Error())
+		return
+	}
+
+	playerID, err := getIDFromURI(r.RequestURI, apiItemIDIndex)
+	if err != nil {
+		WriteAPIError(w, http.StatusBadRequest, "missing url parameter")
+		return
+	}
+
+	player, err := m.PlayerService.GetPlayer(playerID)
+	if err != nil {
+		WriteAPIError(w, lookupStatus(err), "cannot find player")
+		return
+	}
+	if player.LeagueID != league.ID {
+		WriteAPIError(w, http.StatusNotFound, "cannot find player")
+		return
+	}
+
+	player.User, err = m.UserService.GetUser(player.UserID)
+	if err != nil {
+		WriteAPIError(w, lookupStatus(err), "cannot find player")
+		return
+	}
+
+	handicap, err := m.HandicapService.GetHandicapRecord(player.UserID)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot calculate handicap")
+		return
+	}
+
+	writeAPIData(w, newAPIPlayer(player, handicap))
+}
+
+// APIRounds responds with the rounds posted in a season, chosen with the
+// season_id query parameter or else the league's active season
+func (m *Handlers) APIRounds(w http.ResponseWriter, r *http.Request) {
+	league, status, err := m.apiLeague(r)
+	if err != nil {
+		WriteAPIError(w, status, err.Error())
+		return
+	}
+
+	season, err := m.scheduleSeason(r, league.ID)
+	if err != nil {
+		WriteAPIError(w, http.StatusNotFound, "cannot find season")
+		return
+	}
+
+	rounds, err := m.ScoreService.GetRoundsInSeason(season.ID)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot get rounds for season")
+		return
+	}
+
+	writeAPIData(w, newAPIRounds(rounds))
+}
+
+// APIRound responds with one round posted in a league, hole by hole
+func (m *Handlers) APIRound(w http.ResponseWriter, r *http.Request) {
+	league, status, err := m.apiLeague(r)
+	if err != nil {
+		WriteAPIError(w, status, err.Error())
+		return
+	}
+
+	roundID, err := getIDFromURI(r.RequestURI, apiItemIDIndex)
+	if err != nil {
+		WriteAPIError(w, http.StatusBadRequest, "missing url parameter")
+		return
+	}
+
+	round, err := m.ScoreService.GetRound(roundID)
+	if err != nil {
+		WriteAPIError(w, lookupStatus(err), "cannot find round")
+		return
+	}
+	if round.LeagueID != league.ID {
+		WriteAPIError(w, http.StatusNotFound, "cannot find round")
+		return
+	}
+
+	writeAPIData(w, newAPIRound(round))
+}
+
+// APIStandings responds with a season's standings, chosen with the season_id
+// query parameter or else the league's active season, ranked by the sort
+// query parameter
+func (m *Handlers) APIStandings(w http.ResponseWriter, r *http.Request) {
+	league, status, err := m.apiLeague(r)
+	if err != nil {
+		WriteAPIError(w, status, err.Error())
+		return
+	}
+
+	season, err := m.scheduleSeason(r, league.ID)
+	if err != nil {
+		WriteAPIError(w, http.StatusNotFound, "cannot find season")
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot get players for league")
+		return
+	}
+
+	standings, err := m.StandingsService.GetStandings(league, season, players, r.URL.Query().Get("sort"))
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot get standings for season")
+		return
+	}
+
+	writeAPIData(w, newAPIStandings(season, standings))
+}
+
+// APIPostRound posts a round for the user making the request, checked the same
+// way as the round form. It responds with the new round
+func (m *Handlers) APIPostRound(w http.ResponseWriter, r *http.Request) {
+	league, status, err := m.apiLeague(r)
+	if err != nil {
+		WriteAPIError(w, status, err.Error())
+		return
+	}
+
+	userID := m.apiUserID(r)
+	player, err := m.PlayerService.GetPlayerInLeague(userID, league.ID)
+	if err != nil || !player.IsActive {
+		WriteAPIError(w, http.StatusForbidden, "you must be an active player in this league to post a round")
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, league.ID)
+	if err != nil || !membership.CanPostRounds() {
+		WriteAPIError(w, http.StatusForbidden, "read-only members can't post rounds")
+		return
+	}
+
+	season, err := m.SeasonService.GetActiveSeason(league.ID)
+	if err != nil {
+		WriteAPIError(w, http.StatusUnprocessableEntity, "rounds can only be posted while the league has an active season")
+		return
+	}
+
+	var body apiNewRound
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		WriteAPIError(w, http.StatusBadRequest, "the request body must be a round in json")
+		return
+	}
+
+	course, err := m.CourseService.GetCourse(body.CourseID)
+	if err != nil {
+		WriteAPIError(w, http.StatusUnprocessableEntity, "cannot find course")
+		return
+	}
+	if len(body.Strokes) != course.NumberOfHoles {
+		WriteAPIError(w, http.StatusUnprocessableEntity, fmt.Sprintf("strokes must have a score for each of the course's %d holes", course.NumberOfHoles))
+		return
+	}
+
+	form, round, teeSet := m.validateRound(body.values(), course, season)
+	round.LeagueID = league.ID
+	round.SeasonID = season.ID
+	round.PlayerID = player.ID
+
+	if !form.Valid() {
+		fields := []string{"played_on", "tee_set_id"}
+		for number := 1; number <= course.NumberOfHoles; number++ {
+			fields = append(fields, fmt.Sprintf("strokes_%d", number))
+		}
+		for _, field := range fields {
+			if message := form.Errors.Get(field); message != "" {
+				WriteAPIError(w, http.StatusUnprocessableEntity, fmt.Sprintf("%s: %s", field, message))
+				return
+			}
+		}
+		WriteAPIError(w, http.StatusUnprocessableEntity, "invalid round")
+		return
+	}
+
+	courseHandicap, hasIndex, err := m.HandicapService.GetCourseHandicap(userID, teeSet)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot calculate handicap")
+		return
+	}
+	if !hasIndex {
+		courseHandicap = player.Handicap
+	}
+	round.CourseHandicap = courseHandicap
+
+	round.ID, err = m.ScoreService.PostRound(round)
+	if err != nil {
+		WriteAPIError(w, http.StatusInternalServerError, "cannot save round")
+		return
+	}
+
+	// send the round as it was saved, with its scores totalled
+	posted, err := m.ScoreService.GetRound(round.ID)
+	if err != nil {
+		log.Println(err)
+	} else {
+		m.publishWebhookEvent(league.ID, models.WebhookEventRoundPosted, newAPIRound(posted))
+	}
+
+	writeJSON(w, http.StatusCreated, apiEnvelope{Data: newAPIRound(round)})
+}
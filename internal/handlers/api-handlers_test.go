@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var apiTests = []struct {
+	name               string
+	userID             int
+	url                string
+	handler            func(*Handlers, http.ResponseWriter, *http.Request)
+	expectedStatusCode int
+}{
+	{"user", 1, "/api/v1/user", (*Handlers).APIUser, http.StatusOK},
+	{"user not found", 0, "/api/v1/user", (*Handlers).APIUser, http.StatusUnauthorized},
+
+	{"leagues", 1, "/api/v1/leagues", (*Handlers).APILeagues, http.StatusOK},
+	{"leagues user not found", 0, "/api/v1/leagues", (*Handlers).APILeagues, http.StatusUnauthorized},
+	{"leagues error", 2, "/api/v1/leagues", (*Handlers).APILeagues, http.StatusInternalServerError},
+
+	{"league", 1, "/api/v1/leagues/1", (*Handlers).APILeague, http.StatusOK},
+	{"league user not found", 0, "/api/v1/leagues/1", (*Handlers).APILeague, http.StatusUnauthorized},
+	{"league bad url parameter", 1, "/api/v1/leagues/s", (*Handlers).APILeague, http.StatusBadRequest},
+	{"league not a member", 4, "/api/v1/leagues/4", (*Handlers).APILeague, http.StatusForbidden},
+	{"league error", 1, "/api/v1/leagues/3", (*Handlers).APILeague, http.StatusInternalServerError},
+
+	{"players", 1, "/api/v1/leagues/1/players", (*Handlers).APIPlayers, http.StatusOK},
+	{"players not a member", 4, "/api/v1/leagues/4/players", (*Handlers).APIPlayers, http.StatusForbidden},
+	{"players error", 1, "/api/v1/leagues/2/players", (*Handlers).APIPlayers, http.StatusInternalServerError},
+
+	{"player", 1, "/api/v1/leagues/1/players/5", (*Handlers).APIPlayer, http.StatusOK},
+	{"player not a member", 4, "/api/v1/leagues/4/players/5", (*Handlers).APIPlayer, http.StatusForbidden},
+	{"player bad url parameter", 1, "/api/v1/leagues/1/players/s", (*Handlers).APIPlayer, http.StatusBadRequest},
+	{"player error", 1, "/api/v1/leagues/1/players/9", (*Handlers).APIPlayer, http.StatusInternalServerError},
+	{"player in another league", 1, "/api/v1/leagues/2/players/5", (*Handlers).APIPlayer, http.StatusNotFound},
+	{"player user error", 1, "/api/v1/leagues/1/players/0", (*Handlers).APIPlayer, http.StatusInternalServerError},
+
+	{"rounds", 1, "/api/v1/leagues/1/rounds", (*Handlers).APIRounds, http.StatusOK},
+	{"rounds not a member", 4, "/api/v1/leagues/4/rounds", (*Handlers).APIRounds, http.StatusForbidden},
+	{"rounds no active season", 1, "/api/v1/leagues/6/rounds", (*Handlers).APIRounds, http.StatusNotFound},
+	{"rounds season not found", 1, "/api/v1/leagues/1/rounds?season_id=3", (*Handlers).APIRounds, http.StatusNotFound},
+	{"rounds error", 1, "/api/v1/leagues/1/rounds?season_id=5", (*Handlers).APIRounds, http.StatusInternalServerError},
+
+	{"round", 1, "/api/v1/leagues/1/rounds/1", (*Handlers).APIRound, http.StatusOK},
+	{"round not a member", 4, "/api/v1/leagues/4/rounds/1", (*Handlers).APIRound, http.StatusForbidden},
+	{"round bad url parameter", 1, "/api/v1/leagues/1/rounds/s", (*Handlers).APIRound, http.StatusBadRequest},
+	{"round error", 1, "/api/v1/leagues/1/rounds/3", (*Handlers).APIRound, http.StatusInternalServerError},
+	{"round in another league", 1, "/api/v1/leagues/1/rounds/4", (*Handlers).APIRound, http.StatusNotFound},
+
+	{"standings", 1, "/api/v1/leagues/1/standings?sort=net", (*Handlers).APIStandings, http.StatusOK},
+	{"standings not a member", 4, "/api/v1/leagues/4/standings", (*Handlers).APIStandings, http.StatusForbidden},
+	{"standings no active season", 1, "/api/v1/leagues/6/standings", (*Handlers).APIStandings, http.StatusNotFound},
+	{"standings players error", 1, "/api/v1/leagues/2/standings", (*Handlers).APIStandings, http.StatusInternalServerError},
+	{"standings error", 1, "/api/v1/leagues/1/standings?season_id=5", (*Handlers).APIStandings, http.StatusInternalServerError},
+}
+
+func TestAPI(t *testing.T) {
+	for _, e := range apiTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		e.handler(Handler, rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if rr.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("failed %s: expected a json response, but got %s", e.name, rr.Header().Get("Content-Type"))
+		}
+
+		var body struct {
+			Data  json.RawMessage `json:"data"`
+			Error *apiError       `json:"error"`
+		}
+		err := json.Unmarshal(rr.Body.Bytes(), &body)
+		if err != nil {
+			t.Errorf("failed %s: cannot decode response: %s", e.name, err.Error())
+			continue
+		}
+
+		if e.expectedStatusCode == http.StatusOK {
+			if body.Error != nil || len(body.Data) == 0 {
+				t.Errorf("failed %s: expected data, but got %s", e.name, rr.Body.String())
+			}
+		} else if body.Error == nil || body.Error.Status != e.expectedStatusCode || body.Data != nil {
+			t.Errorf("failed %s: expected an error with status %d, but got %s", e.name, e.expectedStatusCode, rr.Body.String())
+		}
+	}
+}
+
+func TestAPIRoundBody(t *testing.T) {
+	url := "/api/v1/leagues/1/rounds/1"
+	req, _ := http.NewRequest("GET", url, nil)
+	ctx := getCtx(req)
+	req = req.WithContext(ctx)
+	req.RequestURI = url
+
+	session.Put(req.Context(), "user_id", 1)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(Handler.APIRound)
+	handler.ServeHTTP(rr, req)
+
+	var body struct {
+		Data apiRound `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if body.Data.ID != 1 || body.Data.LeagueID != 1 {
+		t.Errorf("expected round 1 in league 1, but got round %d in league %d", body.Data.ID, body.Data.LeagueID)
+	}
+	if len(body.Data.HoleScores) != 1 || body.Data.HoleScores[0].Strokes != 4 {
+		t.Errorf("expected the round's hole scores, but got %v", body.Data.HoleScores)
+	}
+}
+
+const apiRoundBody = `{"course_id": 1, "tee_set_id": 1, "played_on": "2024-04-08", "strokes": [5, 4, 5, 3, 6, 5, 4, 5, 4]}`
+
+var apiPostRoundTests = []struct {
+	name               string
+	userID             int
+	url                string
+	body               string
+	expectedStatusCode int
+}{
+	{"user not found", 0, "/api/v1/leagues/1/rounds", apiRoundBody, http.StatusUnauthorized},
+	{"not a member", 4, "/api/v1/leagues/4/rounds", apiRoundBody, http.StatusForbidden},
+	{"inactive player", 2, "/api/v1/leagues/1/rounds", apiRoundBody, http.StatusForbidden},
+	{"read-only member", 14, "/api/v1/leagues/1/rounds", apiRoundBody, http.StatusForbidden},
+	{"no active season", 1, "/api/v1/leagues/6/rounds", apiRoundBody, http.StatusUnprocessableEntity},
+	{"not json", 1, "/api/v1/leagues/1/rounds", "course_id=1", http.StatusBadRequest},
+	{"course not found", 1, "/api/v1/leagues/1/rounds", strings.Replace(apiRoundBody, `"course_id": 1`, `"course_id": 3`, 1), http.StatusUnprocessableEntity},
+	{"missing holes", 1, "/api/v1/leagues/1/rounds", strings.Replace(apiRoundBody, "5, 4, 5, 3, ", "", 1), http.StatusUnprocessableEntity},
+	{"future date", 1, "/api/v1/leagues/1/rounds", strings.Replace(apiRoundBody, "2024-04-08", "2999-01-01", 1), http.StatusUnprocessableEntity},
+	{"tees not at course", 1, "/api/v1/leagues/1/rounds", strings.Replace(apiRoundBody, `"course_id": 1`, `"course_id": 2`, 1), http.StatusUnprocessableEntity},
+	{"strokes out of range", 1, "/api/v1/leagues/1/rounds", strings.Replace(apiRoundBody, "[5,", "[16,", 1), http.StatusUnprocessableEntity},
+	{"error saving round", 1, "/api/v1/leagues/1/rounds", strings.Replace(apiRoundBody, `"tee_set_id": 1`, `"tee_set_id": 5`, 1), http.StatusInternalServerError},
+	{"created", 1, "/api/v1/leagues/1/rounds", apiRoundBody, http.StatusCreated},
+}
+
+func TestAPIPostRound(t *testing.T) {
+	for _, e := range apiPostRoundTests {
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(e.body))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+		req.Header.Set("Content-Type", "application/json")
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.APIPostRound)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d: %s", e.name, e.expectedStatusCode, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestAPIPostRoundWithToken(t *testing.T) {
+	url := "/api/v1/leagues/1/rounds"
+	req, _ := http.NewRequest("POST", url, strings.NewReader(apiRoundBody))
+	ctx := getCtx(req)
+	req = req.WithContext(ctx)
+	req.RequestURI = url
+
+	// the token's user posts the round, not whoever is logged in
+	session.Put(req.Context(), "user_id", 0)
+
+	req, _, err := Handler.AuthenticateAPIToken(req, "write")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(Handler.APIPostRound)
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected code %d, but got %d", http.StatusCreated, rr.Code)
+	}
+}
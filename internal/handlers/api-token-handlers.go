@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// apiTokenIDIndex is where the id is in /user/profile/api-tokens/{id}/revoke
+const apiTokenIDIndex = 4
+
+// CreateAPIToken handles request to make a new API token for the logged in
+// user. The token is shown this once
+func (m *Handlers) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("token_name", "scope")
+	form.MaxLength("token_name", 100)
+
+	expiresInDays, err := strconv.Atoi(r.Form.Get("expires_in"))
+	if err != nil && r.Form.Get("expires_in") != "" {
+		form.Errors.Add("expires_in", "Choose when the token expires")
+	}
+
+	if !form.Valid() {
+		render.Template(w, r, "profile.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: m.profileData(r, user),
+		})
+		return
+	}
+
+	_, token, err := m.APITokenService.CreateAPIToken(user.ID, r.Form.Get("token_name"), r.Form.Get("scope"), expiresInDays)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	data := m.profileData(r, user)
+	data["new_api_token"] = token
+
+	m.App.Session.Put(r.Context(), "flash", "API token created")
+	render.Template(w, r, "profile.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// RevokeAPIToken handles request to delete one of the logged in user's API
+// tokens, so scripts using it stop working
+func (m *Handlers) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	tokenID, err := getIDFromURI(r.RequestURI, apiTokenIDIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	err = m.APITokenService.RevokeAPIToken(user.ID, tokenID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't revoke API token!")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "API token revoked")
+	http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+}
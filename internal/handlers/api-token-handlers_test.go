@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var createAPITokenTests = []struct {
+	name               string
+	userID             int
+	tokenName          string
+	scope              string
+	expiresIn          string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"user not found", 0, "Script", "read", "30", http.StatusSeeOther, "/user/login"},
+	{"missing name", 1, "", "read", "30", http.StatusOK, ""},
+	{"missing scope", 1, "Script", "", "30", http.StatusOK, ""},
+	{"invalid expiry", 1, "Script", "read", "soon", http.StatusOK, ""},
+	{"service error", 1, "error", "read", "30", http.StatusSeeOther, "/user/profile"},
+	{"valid", 1, "Script", "read", "30", http.StatusOK, ""},
+	{"never expires", 1, "Script", "read_write", "", http.StatusOK, ""},
+}
+
+func TestCreateAPIToken(t *testing.T) {
+	for _, e := range createAPITokenTests {
+		postedData := url.Values{}
+		postedData.Add("token_name", e.tokenName)
+		postedData.Add("scope", e.scope)
+		postedData.Add("expires_in", e.expiresIn)
+
+		req, _ := http.NewRequest("POST", "/user/profile/api-tokens", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.CreateAPIToken)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var revokeAPITokenTests = []struct {
+	name             string
+	userID           int
+	url              string
+	expectedLocation string
+}{
+	{"user not found", 0, "/user/profile/api-tokens/1/revoke", "/user/login"},
+	{"bad url parameter", 1, "/user/profile/api-tokens/s/revoke", "/user/profile"},
+	{"service error", 1, "/user/profile/api-tokens/2/revoke", "/user/profile"},
+	{"valid", 1, "/user/profile/api-tokens/1/revoke", "/user/profile"},
+}
+
+func TestRevokeAPIToken(t *testing.T) {
+	for _, e := range revokeAPITokenTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.RevokeAPIToken)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+var bearerTokenTests = []struct {
+	name          string
+	header        string
+	expectedToken string
+	expectedOK    bool
+}{
+	{"no header", "", "", false},
+	{"bearer", "Bearer abc", "abc", true},
+	{"lower case scheme", "bearer abc", "abc", true},
+	{"other scheme", "Basic abc", "", false},
+	{"empty token", "Bearer  ", "", false},
+}
+
+func TestBearerToken(t *testing.T) {
+	for _, e := range bearerTokenTests {
+		req, _ := http.NewRequest("GET", "/api/v1/user", nil)
+		if e.header != "" {
+			req.Header.Set("Authorization", e.header)
+		}
+
+		token, ok := BearerToken(req)
+		if token != e.expectedToken || ok != e.expectedOK {
+			t.Errorf("failed %s: expected %q %t, but got %q %t", e.name, e.expectedToken, e.expectedOK, token, ok)
+		}
+	}
+}
+
+func TestAuthenticateAPIToken(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/api/v1/user", nil)
+	ctx := getCtx(req)
+	req = req.WithContext(ctx)
+
+	_, _, err := Handler.AuthenticateAPIToken(req, "invalid")
+	if err == nil {
+		t.Error("failed invalid token: expected error, but didn't get one")
+	}
+
+	// the token's user is who the API acts as, not whoever is logged in
+	session.Put(req.Context(), "user_id", 0)
+
+	req, apiToken, err := Handler.AuthenticateAPIToken(req, "valid")
+	if err != nil {
+		t.Fatalf("failed valid token: expected no error, but got %s", err.Error())
+	}
+	if apiToken.CanWrite() {
+		t.Error("failed valid token: expected a read only token")
+	}
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(Handler.APIUser)
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("failed valid token: expected code %d, but got %d", http.StatusOK, rr.Code)
+	}
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// apiDateLayout is how the API writes dates that have no time of day
+const apiDateLayout = "2006-01-02"
+
+// apiEnvelope is the body of every API response. Successful responses only
+// have data and failed ones only have an error
+type apiEnvelope struct {
+	Data  interface{} `json:"data,omitempty"`
+	Error *apiError   `json:"error,omitempty"`
+}
+
+// apiError says why an API request failed. Status repeats the HTTP status code
+// for clients that only look at the body
+type apiError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// writeJSON writes an API response with the given status code
+func writeJSON(w http.ResponseWriter, status int, envelope apiEnvelope) {
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		log.Println(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(out)
+}
+
+// writeAPIData writes a successful API response
+func writeAPIData(w http.ResponseWriter, data interface{}) {
+	writeJSON(w, http.StatusOK, apiEnvelope{Data: data})
+}
+
+// WriteAPIError writes a failed API response. It's exported for the
+// middleware that guards the API
+func WriteAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, apiEnvelope{Error: &apiError{Status: status, Message: message}})
+}
+
+type apiUser struct {
+	ID        int    `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Verified  bool   `json:"verified"`
+}
+
+func newAPIUser(u models.User) apiUser {
+	return apiUser{
+		ID:        u.ID,
+		FirstName: u.FirstName,
+		LastName:  u.LastName,
+		Email:     u.Email,
+		Verified:  u.IsVerified(),
+	}
+}
+
+type apiLeague struct {
+	ID            int       `json:"id"`
+	Name          string    `json:"name"`
+	ScoringFormat string    `json:"scoring_format"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func newAPILeague(l models.League) apiLeague {
+	return apiLeague{
+		ID:            l.ID,
+		Name:          l.Name,
+		ScoringFormat: l.ScoringFormat,
+		CreatedAt:     l.CreatedAt,
+	}
+}
+
+func newAPILeagues(leagues []models.League) []apiLeague {
+	out := make([]apiLeague, 0, len(leagues))
+	for _, l := range leagues {
+		out = append(out, newAPILeague(l))
+	}
+	return out
+}
+
+// apiPlayer is a player on a league's roster. HandicapIndex is null until the
+// player has posted enough rounds to have one
+type apiPlayer struct {
+	ID             int      `json:"id"`
+	LeagueID       int      `json:"league_id"`
+	UserID         int      `json:"user_id"`
+	FirstName      string   `json:"first_name"`
+	LastName       string   `json:"last_name"`
+	IsCommissioner bool     `json:"is_commissioner"`
+	IsActive       bool     `json:"is_active"`
+	HandicapIndex  *float64 `json:"handicap_index"`
+}
+
+func newAPIPlayer(p models.Player, handicap models.HandicapRecord) apiPlayer {
+	player := apiPlayer{
+		ID:             p.ID,
+		LeagueID:       p.LeagueID,
+		UserID:         p.UserID,
+		FirstName:      p.User.FirstName,
+		LastName:       p.User.LastName,
+		IsCommissioner: p.IsCommissioner,
+		IsActive:       p.IsActive,
+	}
+	if handicap.HasIndex {
+		index := handicap.Index
+		player.HandicapIndex = &index
+	}
+	return player
+}
+
+type apiSeason struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+func newAPISeason(s models.Season) apiSeason {
+	return apiSeason{
+		ID:        s.ID,
+		Name:      s.Name,
+		Status:    s.Status,
+		StartDate: s.StartDate.Format(apiDateLayout),
+		EndDate:   s.EndDate.Format(apiDateLayout),
+	}
+}
+
+type apiHoleScore struct {
+	Hole    int `json:"hole"`
+	Strokes int `json:"strokes"`
+}
+
+type apiRound struct {
+	ID             int            `json:"id"`
+	LeagueID       int            `json:"league_id"`
+	SeasonID       int            `json:"season_id"`
+	PlayerID       int            `json:"player_id"`
+	TeeSetID       int            `json:"tee_set_id"`
+	PlayedOn       string         `json:"played_on"`
+	GrossScore     int            `json:"gross_score"`
+	CourseHandicap int            `json:"course_handicap"`
+	NetScore       int            `json:"net_score"`
+	HoleScores     []apiHoleScore `json:"hole_scores"`
+}
+
+func newAPIRound(r models.Round) apiRound {
+	round := apiRound{
+		ID:             r.ID,
+		LeagueID:       r.LeagueID,
+		SeasonID:       r.SeasonID,
+		PlayerID:       r.PlayerID,
+		TeeSetID:       r.TeeSetID,
+		PlayedOn:       r.PlayedOn.Format(apiDateLayout),
+		GrossScore:     r.GrossScore,
+		CourseHandicap: r.CourseHandicap,
+		NetScore:       r.NetScore(),
+		HoleScores:     make([]apiHoleScore, 0, len(r.HoleScores)),
+	}
+	for _, h := range r.HoleScores {
+		round.HoleScores = append(round.HoleScores, apiHoleScore{Hole: h.HoleNumber, Strokes: h.Strokes})
+	}
+	return round
+}
+
+// apiNewRound is the body of a request to post a round. Strokes are for each
+// hole in order
+type apiNewRound struct {
+	CourseID int    `json:"course_id"`
+	TeeSetID int    `json:"tee_set_id"`
+	PlayedOn string `json:"played_on"`
+	Strokes  []int  `json:"strokes"`
+}
+
+// values puts the round in the fields the round form posts, so it's checked
+// the same way
+func (n apiNewRound) values() url.Values {
+	values := url.Values{}
+	values.Set("course_id", strconv.Itoa(n.CourseID))
+	values.Set("tee_set_id", strconv.Itoa(n.TeeSetID))
+	values.Set("played_on", n.PlayedOn)
+	for i, strokes := range n.Strokes {
+		values.Set(fmt.Sprintf("strokes_%d", i+1), strconv.Itoa(strokes))
+	}
+	return values
+}
+
+func newAPIRounds(rounds []models.Round) []apiRound {
+	out := make([]apiRound, 0, len(rounds))
+	for _, r := range rounds {
+		out = append(out, newAPIRound(r))
+	}
+	return out
+}
+
+type apiStanding struct {
+	PlayerID     int     `json:"player_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	Rank         int     `json:"rank"`
+	Points       float64 `json:"points"`
+	Wins         int     `json:"wins"`
+	Losses       int     `json:"losses"`
+	Ties         int     `json:"ties"`
+	Rounds       int     `json:"rounds"`
+	GrossAverage float64 `json:"gross_average"`
+	NetAverage   float64 `json:"net_average"`
+	LowGross     int     `json:"low_gross"`
+	LowNet       int     `json:"low_net"`
+}
+
+type apiStandings struct {
+	Season  apiSeason     `json:"season"`
+	SortBy  string        `json:"sort_by"`
+	AsOf    time.Time     `json:"as_of"`
+	Players []apiStanding `json:"players"`
+}
+
+func newAPIStandings(season models.Season, s models.Standings) apiStandings {
+	standings := apiStandings{
+		Season:  newAPISeason(season),
+		SortBy:  s.SortBy,
+		AsOf:    s.AsOf,
+		Players: make([]apiStanding, 0, len(s.Players)),
+	}
+	for _, p := range s.Players {
+		standings.Players = append(standings.Players, apiStanding{
+			PlayerID:     p.Player.ID,
+			FirstName:    p.Player.User.FirstName,
+			LastName:     p.Player.User.LastName,
+			Rank:         p.Rank,
+			Points:       p.Points,
+			Wins:         p.Wins,
+			Losses:       p.Losses,
+			Ties:         p.Ties,
+			Rounds:       p.Rounds,
+			GrossAverage: p.GrossAverage(),
+			NetAverage:   p.NetAverage(),
+			LowGross:     p.LowGross,
+			LowNet:       p.LowNet,
+		})
+	}
+	return standings
+}
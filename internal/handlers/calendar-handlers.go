@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/ical"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// Where ids are in /user/profile/calendar-feeds/{id}/revoke and the token is
+// in /calendar/{token}.ics
+const (
+	calendarFeedIDIndex = 4
+	calendarTokenIndex  = 2
+)
+
+// calendarUIDDomain makes the ids of the app's calendar events unique
+// everywhere
+const calendarUIDDomain = "golfleague.app"
+
+// calendarFeedURL returns the URL calendar apps subscribe to for a feed token
+func (m *Handlers) calendarFeedURL(token string) string {
+	return fmt.Sprintf("%s/calendar/%s.ics", m.App.BaseURL, token)
+}
+
+// CreateCalendarFeed handles request to make a calendar feed of the logged in
+// user's schedule, for one of their leagues or all of them. Its URL is shown
+// this once, and replaces the URL of the feed they had for the same leagues
+func (m *Handlers) CreateCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	leagueID := 0
+	if r.Form.Get("league_id") != "" {
+		leagueID, err = strconv.Atoi(r.Form.Get("league_id"))
+		if err == nil {
+			_, err = m.LeagueRoleService.GetMembership(user.ID, leagueID)
+		}
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "user not in this league!")
+			http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+			return
+		}
+	}
+
+	_, token, err := m.CalendarFeedService.CreateCalendarFeed(user.ID, leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't create calendar feed!")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	data := m.profileData(r, user)
+	data["new_calendar_feed_url"] = m.calendarFeedURL(token)
+
+	m.App.Session.Put(r.Context(), "flash", "calendar feed created")
+	render.Template(w, r, "profile.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// RevokeCalendarFeed handles request to delete one of the logged in user's
+// calendar feeds, so calendars subscribed to it stop updating
+func (m *Handlers) RevokeCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	feedID, err := getIDFromURI(r.RequestURI, calendarFeedIDIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	err = m.CalendarFeedService.RevokeCalendarFeed(user.ID, feedID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't revoke calendar feed!")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "calendar feed revoked")
+	http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+}
+
+// CalendarFeed responds with the published schedule of a calendar feed in
+// iCalendar format. Calendar apps fetch it without a session, so the token in
+// the URL is all that identifies the user
+func (m *Handlers) CalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token, err := getTokenFromURI(r.RequestURI, calendarTokenIndex)
+	if err != nil {
+		http.Error(w, "calendar not found", http.StatusNotFound)
+		return
+	}
+
+	user, feed, err := m.CalendarFeedService.Authenticate(strings.TrimSuffix(token, ".ics"))
+	if err != nil {
+		http.Error(w, "calendar not found", http.StatusNotFound)
+		return
+	}
+
+	var leagues []models.League
+	name := "Golf League Schedule"
+	if feed.IsLeagueFeed() {
+		// players who leave a league, or are removed from it, stop getting
+		// its schedule
+		if _, err := m.LeagueRoleService.GetMembership(user.ID, feed.LeagueID); err != nil {
+			http.Error(w, "calendar not found", http.StatusNotFound)
+			return
+		}
+
+		league, err := m.LeagueService.GetLeague(feed.LeagueID)
+		if err != nil {
+			http.Error(w, "cannot find league", lookupStatus(err))
+			return
+		}
+		leagues = append(leagues, league)
+		name = league.Name + " Schedule"
+	} else {
+		leagues, err = m.LeagueService.GetLeaguesByUser(user.ID)
+		if err != nil {
+			http.Error(w, "cannot get leagues", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	cal := ical.Calendar{Name: name}
+	for _, league := range leagues {
+		if !feed.IsLeagueFeed() {
+			if _, err := m.LeagueRoleService.GetMembership(user.ID, league.ID); err != nil {
+				continue
+			}
+		}
+
+		events, err := m.leagueCalendarEvents(league, user.ID, feed.IsLeagueFeed())
+		if err != nil {
+			http.Error(w, "cannot get schedule", http.StatusInternalServerError)
+			return
+		}
+		cal.Events = append(cal.Events, events...)
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if _, err := cal.WriteTo(w); err != nil {
+		log.Println(err)
+	}
+}
+
+// leagueCalendarEvents returns an event for every published week of a
+// league's schedule. A user's own feed only has the weeks they play in, while
+// a league's feed has every week and lists all of its matchups
+func (m *Handlers) leagueCalendarEvents(league models.League, userID int, wholeLeague bool) ([]ical.Event, error) {
+	seasons, err := m.SeasonService.GetSeasonsInLeague(league.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ical.Event
+	for _, season := range seasons {
+		weeks, err := m.ScheduleService.GetSchedule(season.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, week := range weeks {
+			if !week.IsPublished {
+				continue
+			}
+
+			matchup, plays := week.PlayerMatchup(userID)
+			if !wholeLeague && (!plays || matchup.IsBye()) {
+				continue
+			}
+
+			events = append(events, scheduleWeekEvent(league, week, userID, wholeLeague))
+		}
+	}
+
+	return events, nil
+}
+
+// scheduleWeekEvent returns the calendar event for a week. Weeks without a tee
+// time are all day events, and tee times in a league without a time zone are
+// at the same clock time wherever the calendar is
+func scheduleWeekEvent(league models.League, week models.ScheduleWeek, userID int, wholeLeague bool) ical.Event {
+	event := ical.Event{
+		UID:       fmt.Sprintf("week-%d-user-%d@%s", week.ID, userID, calendarUIDDomain),
+		Sequence:  week.Sequence,
+		Stamp:     week.UpdatedAt,
+		Summary:   fmt.Sprintf("%s: week %d", league.Name, week.WeekNumber),
+		Cancelled: week.IsCancelled(),
+	}
+	if wholeLeague {
+		event.UID = fmt.Sprintf("week-%d@%s", week.ID, calendarUIDDomain)
+	}
+
+	loc, hasZone := league.Location()
+	if !hasZone {
+		loc = time.UTC
+	}
+	if start, ok := week.TeesOffAt(loc); ok {
+		event.Start = start
+		event.End = start.Add(models.ScheduledRoundLength)
+		event.Floating = !hasZone
+	} else {
+		event.Start = week.PlayDate
+		event.End = week.PlayDate.AddDate(0, 0, 1)
+		event.AllDay = true
+	}
+
+	if matchup, ok := week.PlayerMatchup(userID); ok {
+		if opponent, ok := matchup.Opponent(userID); ok {
+			event.Summary += fmt.Sprintf(" vs. %s %s", opponent.User.FirstName, opponent.User.LastName)
+		}
+	}
+
+	if week.CourseID != 0 {
+		event.Location = week.Course.Name
+		if week.Course.Location != "" {
+			event.Location += ", " + week.Course.Location
+		}
+	}
+
+	description := []string{"Format: " + render.FormatName(week.ScoringFormat(league))}
+	if week.Nine != "" {
+		description = append(description, fmt.Sprintf("Playing the %s nine", week.Nine))
+	}
+	if wholeLeague {
+		for _, matchup := range week.Matchups {
+			home := matchup.HomePlayer.User.FirstName + " " + matchup.HomePlayer.User.LastName
+			if matchup.IsBye() {
+				description = append(description, home+" has a bye")
+				continue
+			}
+			away := matchup.AwayPlayer.User.FirstName + " " + matchup.AwayPlayer.User.LastName
+			description = append(description, home+" vs. "+away)
+		}
+	}
+	event.Description = strings.Join(description, "\n")
+
+	return event
+}
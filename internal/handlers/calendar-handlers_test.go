@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var createCalendarFeedTests = []struct {
+	name               string
+	userID             int
+	leagueID           string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"user not found", 0, "", http.StatusSeeOther, "/user/login"},
+	{"bad league id", 1, "s", http.StatusSeeOther, "/user/profile"},
+	{"not a member", 4, "4", http.StatusSeeOther, "/user/profile"},
+	{"service error", 1, "5", http.StatusSeeOther, "/user/profile"},
+	{"league", 1, "1", http.StatusOK, ""},
+	{"all leagues", 1, "", http.StatusOK, ""},
+}
+
+func TestCreateCalendarFeed(t *testing.T) {
+	for _, e := range createCalendarFeedTests {
+		postedData := url.Values{}
+		postedData.Add("league_id", e.leagueID)
+
+		req, _ := http.NewRequest("POST", "/user/profile/calendar-feeds", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.CreateCalendarFeed)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var revokeCalendarFeedTests = []struct {
+	name             string
+	userID           int
+	url              string
+	expectedLocation string
+}{
+	{"user not found", 0, "/user/profile/calendar-feeds/1/revoke", "/user/login"},
+	{"bad url parameter", 1, "/user/profile/calendar-feeds/s/revoke", "/user/profile"},
+	{"service error", 1, "/user/profile/calendar-feeds/2/revoke", "/user/profile"},
+	{"valid", 1, "/user/profile/calendar-feeds/1/revoke", "/user/profile"},
+}
+
+func TestRevokeCalendarFeed(t *testing.T) {
+	for _, e := range revokeCalendarFeedTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.RevokeCalendarFeed)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+var calendarFeedTests = []struct {
+	name               string
+	url                string
+	expectedStatusCode int
+	expectedBody       string
+}{
+	{"invalid token", "/calendar/invalid.ics", http.StatusNotFound, ""},
+	{"not a member", "/calendar/not-a-member.ics", http.StatusNotFound, ""},
+	{"league error", "/calendar/league-error.ics", http.StatusInternalServerError, ""},
+	{"leagues error", "/calendar/leagues-error.ics", http.StatusInternalServerError, ""},
+	{"seasons error", "/calendar/seasons-error.ics", http.StatusInternalServerError, ""},
+	{"schedule error", "/calendar/schedule-error.ics", http.StatusInternalServerError, ""},
+	{"league feed", "/calendar/league.ics", http.StatusOK, "SUMMARY:"},
+	{"all leagues", "/calendar/valid.ics", http.StatusOK, "BEGIN:VCALENDAR"},
+	{"all leagues skips left leagues", "/calendar/left-league.ics", http.StatusOK, "BEGIN:VCALENDAR"},
+}
+
+func TestCalendarFeed(t *testing.T) {
+	for _, e := range calendarFeedTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+
+		handler := http.HandlerFunc(Handler.CalendarFeed)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedBody != "" && !strings.Contains(rr.Body.String(), e.expectedBody) {
+			t.Errorf("failed %s: expected body to contain %q", e.name, e.expectedBody)
+		}
+	}
+}
+
+func TestScheduleWeekEvent(t *testing.T) {
+	league := models.League{ID: 1, Name: "Test League", TimeZone: "America/Chicago"}
+	week := models.ScheduleWeek{
+		ID:         1,
+		WeekNumber: 3,
+		PlayDate:   time.Date(2024, time.June, 4, 0, 0, 0, 0, time.UTC),
+		TeeTime:    "17:30",
+		Matchups: []models.Matchup{
+			{
+				HomePlayerID: 1,
+				AwayPlayerID: 2,
+				HomePlayer:   models.Player{UserID: 1, User: models.User{FirstName: "Home", LastName: "Player"}},
+				AwayPlayer:   models.Player{UserID: 2, User: models.User{FirstName: "Away", LastName: "Player"}},
+			},
+		},
+	}
+
+	event := scheduleWeekEvent(league, week, 1, false)
+	if !event.Start.Equal(time.Date(2024, time.June, 4, 22, 30, 0, 0, time.UTC)) {
+		t.Errorf("failed tee time: expected start in league time zone, but got %s", event.Start)
+	}
+	if !strings.Contains(event.Summary, "vs. Away Player") {
+		t.Errorf("failed opponent: expected opponent in summary, but got %q", event.Summary)
+	}
+
+	league.TimeZone = ""
+	week.TeeTime = ""
+	event = scheduleWeekEvent(league, week, 1, false)
+	if !event.AllDay {
+		t.Error("failed no tee time: expected all day event, but didn't get one")
+	}
+}
+
+func TestCalendarFeedURL(t *testing.T) {
+	// links are built from the configured base URL, never the request's Host
+	url := Handler.calendarFeedURL("token")
+	if url != "http://localhost:8080/calendar/token.ics" {
+		t.Errorf("failed calendar feed url: expected base url link, but got %s", url)
+	}
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const transferTokenIndex = 3
+
+// sendCommissionerNomination emails a nominee the link to accept running the league
+func (m *Handlers) sendCommissionerNomination(transfer models.CommissionerTransfer, token string) {
+	link := fmt.Sprintf("%s/user/commissioner-transfer/%s", m.App.BaseURL, token)
+
+	content := fmt.Sprintf(`
+		<p>Hi %s,</p>
+		<p>%s %s would like you to take over as commissioner of %s. Log in and accept here:</p>
+		<p><a href="%s">%s</a></p>
+		<p>This link expires in %d days.</p>`,
+		template.HTMLEscapeString(transfer.ToUser.FirstName),
+		template.HTMLEscapeString(transfer.FromUser.FirstName),
+		template.HTMLEscapeString(transfer.FromUser.LastName),
+		template.HTMLEscapeString(transfer.League.Name),
+		link,
+		link,
+		int(models.CommissionerTransferLifetime.Hours()/24),
+	)
+
+	m.sendEmail(transfer.ToUser.Email, fmt.Sprintf("Take over %s?", transfer.League.Name), content)
+}
+
+// sendCommissionerTransferAccepted lets the old commissioner know the nominee
+// has taken over
+func (m *Handlers) sendCommissionerTransferAccepted(transfer models.CommissionerTransfer) {
+	content := fmt.Sprintf(`
+		<p>Hi %s,</p>
+		<p>%s %s has accepted and is now the commissioner of %s. You're still in the league as a player.</p>`,
+		template.HTMLEscapeString(transfer.FromUser.FirstName),
+		template.HTMLEscapeString(transfer.ToUser.FirstName),
+		template.HTMLEscapeString(transfer.ToUser.LastName),
+		template.HTMLEscapeString(transfer.League.Name),
+	)
+
+	m.sendEmail(transfer.FromUser.Email, fmt.Sprintf("%s has a new commissioner", transfer.League.Name), content)
+}
+
+// commissionerCandidates returns the active players who could take over the league
+func commissionerCandidates(players []models.Player) []models.Player {
+	var candidates []models.Player
+	for _, p := range players {
+		if p.IsActive && !p.IsCommissioner {
+			candidates = append(candidates, p)
+		}
+	}
+	return candidates
+}
+
+// ShowCommissionerTransfer renders the page where the commissioner nominates
+// someone to take over the league
+func (m *Handlers) ShowCommissionerTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.IsCommissioner() {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to hand over the league!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	transfer, err := m.CommissionerTransferService.GetOpenTransfer(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get commissioner transfer for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["players"] = commissionerCandidates(players)
+	data["transfer"] = transfer
+	data["has_transfer"] = transfer.ID != 0
+	data["now"] = time.Now()
+
+	render.Template(w, r, "commissioner-transfer.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// NominateCommissioner handles request to nominate a player to take over the
+// league and emails them the link to accept. An earlier nomination stops working
+func (m *Handlers) NominateCommissioner(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.IsCommissioner() {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to hand over the league!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	playerID, err := strconv.Atoi(r.Form.Get("player_id"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "choose who should take over the league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/commissioner-transfer", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	transfer, token, err := m.CommissionerTransferService.NominateCommissioner(leagueID, userID, playerID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/commissioner-transfer", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.sendCommissionerNomination(transfer, token)
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("nomination sent to %s!", transfer.ToUser.Email))
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/commissioner-transfer", leagueID), http.StatusSeeOther)
+}
+
+// CancelCommissionerTransfer handles request to withdraw the league's open
+// nomination so the link in it stops working
+func (m *Handlers) CancelCommissionerTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.IsCommissioner() {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to hand over the league!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	transfer, err := m.CommissionerTransferService.GetOpenTransfer(leagueID)
+	if err != nil || transfer.ID == 0 {
+		m.App.Session.Put(r.Context(), "error", "cannot find nomination")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/commissioner-transfer", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.CommissionerTransferService.CancelTransfer(transfer)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/commissioner-transfer", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "nomination cancelled")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/commissioner-transfer", leagueID), http.StatusSeeOther)
+}
+
+// ShowAcceptCommissionerTransfer renders the page a nominee lands on from
+// their emailed link
+func (m *Handlers) ShowAcceptCommissionerTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	token, err := getTokenFromURI(r.RequestURI, transferTokenIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
+		return
+	}
+
+	transfer, err := m.CommissionerTransferService.GetTransferByToken(token)
+	if err != nil || transfer.ToUser.ID != userID {
+		m.App.Session.Put(r.Context(), "error", "this link is no longer valid, ask your commissioner to nominate you again")
+		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["transfer"] = transfer
+	data["token"] = token
+
+	render.Template(w, r, "accept-commissioner-transfer.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// AcceptCommissionerTransfer handles a nominee accepting, which makes them
+// the league's commissioner and the old commissioner a player
+func (m *Handlers) AcceptCommissionerTransfer(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	token, err := getTokenFromURI(r.RequestURI, transferTokenIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
+		return
+	}
+
+	transfer, err := m.CommissionerTransferService.GetTransferByToken(token)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this link is no longer valid, ask your commissioner to nominate you again")
+		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
+		return
+	}
+
+	err = m.CommissionerTransferService.AcceptTransfer(transfer, userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
+		return
+	}
+
+	m.sendCommissionerTransferAccepted(transfer)
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("you're now the commissioner of %s!", transfer.League.Name))
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", transfer.LeagueID), http.StatusSeeOther)
+}
+
+// ShowForceCommissionerTransfer renders the page where a super admin picks a
+// new commissioner for an abandoned league
+func (m *Handlers) ShowForceCommissionerTransfer(w http.ResponseWriter, r *http.Request) {
+	leagueID, err := strconv.Atoi(r.URL.Query().Get("league_id"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "enter a league id")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	var commissioner models.Player
+	for _, p := range players {
+		if p.IsCommissioner {
+			commissioner = p
+		}
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["commissioner"] = commissioner
+	data["players"] = commissionerCandidates(players)
+
+	render.Template(w, r, "admin-commissioner.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// ForceCommissionerTransfer handles a super admin making a player the
+// league's commissioner without anyone having to accept
+func (m *Handlers) ForceCommissionerTransfer(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	leagueID, err := strconv.Atoi(r.Form.Get("league_id"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing league")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	playerID, err := strconv.Atoi(r.Form.Get("player_id"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "choose who should take over the league")
+		http.Redirect(w, r, fmt.Sprintf("/admin/commissioner?league_id=%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.CommissionerTransferService.ForceTransfer(leagueID, playerID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/admin/commissioner?league_id=%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "commissioner changed!")
+	http.Redirect(w, r, fmt.Sprintf("/admin/commissioner?league_id=%d", leagueID), http.StatusSeeOther)
+}
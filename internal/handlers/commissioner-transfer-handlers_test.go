@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var showCommissionerTransferTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/commissioner-transfer",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/commissioner-transfer",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "co-commissioner",
+		userID:             12,
+		url:                "/leagues/1/commissioner-transfer",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/commissioner-transfer",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "players error",
+		userID:             1,
+		url:                "/leagues/2/commissioner-transfer",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2",
+	},
+	{
+		name:               "transfer error",
+		userID:             1,
+		url:                "/leagues/5/commissioner-transfer",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/5",
+	},
+	{
+		name:               "open nomination",
+		userID:             1,
+		url:                "/leagues/1/commissioner-transfer",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "no nomination",
+		userID:             1,
+		url:                "/leagues/4/commissioner-transfer",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowCommissionerTransfer(t *testing.T) {
+	for _, e := range showCommissionerTransferTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowCommissionerTransfer)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var nominateCommissionerTests = []struct {
+	name             string
+	userID           int
+	url              string
+	playerID         string
+	expectedLocation string
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		url:              "/leagues/1/commissioner-transfer",
+		playerID:         "20",
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "bad url parameter",
+		userID:           1,
+		url:              "/leagues/s/commissioner-transfer",
+		playerID:         "20",
+		expectedLocation: "/",
+	},
+	{
+		name:             "user not commissioner",
+		userID:           3,
+		url:              "/leagues/1/commissioner-transfer",
+		playerID:         "20",
+		expectedLocation: "/leagues/1",
+	},
+	{
+		name:             "missing player",
+		userID:           1,
+		url:              "/leagues/1/commissioner-transfer",
+		playerID:         "",
+		expectedLocation: "/leagues/1/commissioner-transfer",
+	},
+	{
+		name:             "service error",
+		userID:           1,
+		url:              "/leagues/1/commissioner-transfer",
+		playerID:         "9",
+		expectedLocation: "/leagues/1/commissioner-transfer",
+	},
+	{
+		name:             "happy path",
+		userID:           1,
+		url:              "/leagues/1/commissioner-transfer",
+		playerID:         "20",
+		expectedLocation: "/leagues/1/commissioner-transfer",
+	},
+}
+
+func TestNominateCommissioner(t *testing.T) {
+	for _, e := range nominateCommissionerTests {
+		postedData := url.Values{}
+		postedData.Add("player_id", e.playerID)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.NominateCommissioner)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+var cancelCommissionerTransferTests = []struct {
+	name             string
+	userID           int
+	url              string
+	expectedLocation string
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		url:              "/leagues/1/commissioner-transfer/cancel",
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "bad url parameter",
+		userID:           1,
+		url:              "/leagues/s/commissioner-transfer/cancel",
+		expectedLocation: "/",
+	},
+	{
+		name:             "user not commissioner",
+		userID:           3,
+		url:              "/leagues/1/commissioner-transfer/cancel",
+		expectedLocation: "/leagues/1",
+	},
+	{
+		name:             "no open nomination",
+		userID:           1,
+		url:              "/leagues/4/commissioner-transfer/cancel",
+		expectedLocation: "/leagues/4/commissioner-transfer",
+	},
+	{
+		name:             "transfer error",
+		userID:           1,
+		url:              "/leagues/5/commissioner-transfer/cancel",
+		expectedLocation: "/leagues/5/commissioner-transfer",
+	},
+	{
+		name:             "service error",
+		userID:           1,
+		url:              "/leagues/6/commissioner-transfer/cancel",
+		expectedLocation: "/leagues/6/commissioner-transfer",
+	},
+	{
+		name:             "happy path",
+		userID:           1,
+		url:              "/leagues/1/commissioner-transfer/cancel",
+		expectedLocation: "/leagues/1/commissioner-transfer",
+	},
+}
+
+func TestCancelCommissionerTransfer(t *testing.T) {
+	for _, e := range cancelCommissionerTransferTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.CancelCommissionerTransfer)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+var acceptCommissionerTransferTests = []struct {
+	name                   string
+	userID                 int
+	url                    string
+	expectedShowStatusCode int
+	expectedLocation       string
+}{
+	{
+		name:                   "user not found",
+		userID:                 0,
+		url:                    "/user/commissioner-transfer/token",
+		expectedShowStatusCode: http.StatusSeeOther,
+		expectedLocation:       "/user/login",
+	},
+	{
+		name:                   "missing token",
+		userID:                 20,
+		url:                    "/user/commissioner-transfer/",
+		expectedShowStatusCode: http.StatusSeeOther,
+		expectedLocation:       "/leagues",
+	},
+	{
+		name:                   "invalid token",
+		userID:                 20,
+		url:                    "/user/commissioner-transfer/expired",
+		expectedShowStatusCode: http.StatusSeeOther,
+		expectedLocation:       "/leagues",
+	},
+	{
+		name:                   "someone else",
+		userID:                 1,
+		url:                    "/user/commissioner-transfer/token",
+		expectedShowStatusCode: http.StatusSeeOther,
+		expectedLocation:       "/leagues",
+	},
+	{
+		name:                   "service error",
+		userID:                 20,
+		url:                    "/user/commissioner-transfer/accepterror",
+		expectedShowStatusCode: http.StatusOK,
+		expectedLocation:       "/leagues",
+	},
+	{
+		name:                   "happy path",
+		userID:                 20,
+		url:                    "/user/commissioner-transfer/token",
+		expectedShowStatusCode: http.StatusOK,
+		expectedLocation:       "/leagues/1",
+	},
+}
+
+func TestShowAcceptCommissionerTransfer(t *testing.T) {
+	for _, e := range acceptCommissionerTransferTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowAcceptCommissionerTransfer)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedShowStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedShowStatusCode, rr.Code)
+		}
+	}
+}
+
+func TestAcceptCommissionerTransfer(t *testing.T) {
+	for _, e := range acceptCommissionerTransferTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.AcceptCommissionerTransfer)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+var showForceCommissionerTransferTests = []struct {
+	name               string
+	url                string
+	expectedStatusCode int
+}{
+	{"missing league", "/admin/commissioner", http.StatusSeeOther},
+	{"non-existing league", "/admin/commissioner?league_id=3", http.StatusSeeOther},
+	{"players error", "/admin/commissioner?league_id=2", http.StatusSeeOther},
+	{"success", "/admin/commissioner?league_id=1", http.StatusOK},
+}
+
+func TestShowForceCommissionerTransfer(t *testing.T) {
+	for _, e := range showForceCommissionerTransferTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowForceCommissionerTransfer)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+	}
+}
+
+var forceCommissionerTransferTests = []struct {
+	name             string
+	leagueID         string
+	playerID         string
+	expectedLocation string
+}{
+	{"missing league", "", "20", "/admin/dashboard"},
+	{"missing player", "1", "", "/admin/commissioner?league_id=1"},
+	{"service error", "1", "9", "/admin/commissioner?league_id=1"},
+	{"happy path", "1", "20", "/admin/commissioner?league_id=1"},
+}
+
+func TestForceCommissionerTransfer(t *testing.T) {
+	for _, e := range forceCommissionerTransferTests {
+		postedData := url.Values{}
+		postedData.Add("league_id", e.leagueID)
+		postedData.Add("player_id", e.playerID)
+
+		req, _ := http.NewRequest("POST", "/admin/commissioner", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ForceCommissionerTransfer)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
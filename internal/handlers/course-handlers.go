@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/helpers"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const courseIDIndex = 2
+
+func getCourseIDFromURI(URI string) (int, error) {
+	return getIDFromURI(URI, courseIDIndex)
+}
+
+// canManageCourses reports whether the user may add courses and tee sets,
+// which is limited to super admins and league commissioners
+func (m *Handlers) canManageCourses(r *http.Request, userID int) bool {
+	if helpers.IsSuperAdmin(r) {
+		return true
+	}
+	isCommissioner, err := m.PlayerService.IsCommissioner(userID)
+	return err == nil && isCommissioner
+}
+
+// Courses is the course catalog page handler
+func (m *Handlers) Courses(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	courses, err := m.CourseService.GetCourses()
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get courses")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["courses"] = courses
+	data["can_manage"] = m.canManageCourses(r, userID)
+
+	render.Template(w, r, "courses.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// ShowCourseForm renders the add a course page and displays form
+func (m *Handlers) ShowCourseForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	if !m.canManageCourses(r, userID) {
+		m.App.Session.Put(r.Context(), "error", "must be a commissioner or admin to add courses!")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "create-course.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+	})
+}
+
+// CreateCourse handles request to add a course to the catalog
+func (m *Handlers) CreateCourse(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	if !m.canManageCourses(r, userID) {
+		m.App.Session.Put(r.Context(), "error", "must be a commissioner or admin to add courses!")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("name", "number_of_holes")
+	form.MinLength("name", 3)
+	form.MaxLength("name", 100)
+	form.MaxLength("location", 100)
+
+	numberOfHoles, _ := strconv.Atoi(r.Form.Get("number_of_holes"))
+	if numberOfHoles != 9 && numberOfHoles != 18 {
+		form.Errors.Add("number_of_holes", "A course must have 9 or 18 holes")
+	}
+
+	course := models.Course{
+		Name:          r.Form.Get("name"),
+		Location:      r.Form.Get("location"),
+		NumberOfHoles: numberOfHoles,
+	}
+
+	if form.Valid() {
+		if _, err = m.CourseService.GetCourseByName(course.Name); err == nil {
+			form.Errors.Add("name", "A course with this name already exists")
+		}
+	}
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["course"] = course
+
+		render.Template(w, r, "create-course.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	id, err := m.CourseService.CreateCourse(course)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't insert course into database!")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "course added! Now add its tees")
+	http.Redirect(w, r, fmt.Sprintf("/courses/%d/tee-sets/new", id), http.StatusSeeOther)
+}
+
+// ShowCourse shows a course with its tee sets and scorecard
+func (m *Handlers) ShowCourse(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	courseID, err := getCourseIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	course, err := m.CourseService.GetCourse(courseID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find course")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["course"] = course
+	data["can_manage"] = m.canManageCourses(r, userID)
+
+	render.Template(w, r, "course.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// ShowTeeSetForm renders the add tees to a course page and displays form
+func (m *Handlers) ShowTeeSetForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	if !m.canManageCourses(r, userID) {
+		m.App.Session.Put(r.Context(), "error", "must be a commissioner or admin to add tees!")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	courseID, err := getCourseIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	course, err := m.CourseService.GetCourse(courseID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find course")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["course"] = course
+
+	render.Template(w, r, "create-tee-set.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// CreateTeeSet handles request to add a tee set with its holes to a course
+func (m *Handlers) CreateTeeSet(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	if !m.canManageCourses(r, userID) {
+		m.App.Session.Put(r.Context(), "error", "must be a commissioner or admin to add tees!")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	courseID, err := getCourseIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	course, err := m.CourseService.GetCourse(courseID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find course")
+		http.Redirect(w, r, "/courses", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("name", "course_rating", "slope")
+	form.MinLength("name", 2)
+	form.MaxLength("name", 35)
+	if course.NumberOfHoles == 9 {
+		form.FloatBetween("course_rating", 25, 45)
+	} else {
+		form.FloatBetween("course_rating", 50, 85)
+	}
+	form.IntBetween("slope", 55, 155)
+
+	courseRating, _ := strconv.ParseFloat(r.Form.Get("course_rating"), 64)
+	slope, _ := strconv.Atoi(r.Form.Get("slope"))
+	teeSet := models.TeeSet{
+		CourseID:     course.ID,
+		Name:         r.Form.Get("name"),
+		CourseRating: courseRating,
+		Slope:        slope,
+	}
+
+	strokeIndexes := make(map[int]bool)
+	for number := 1; number <= course.NumberOfHoles; number++ {
+		parField := fmt.Sprintf("par_%d", number)
+		yardageField := fmt.Sprintf("yardage_%d", number)
+		strokeIndexField := fmt.Sprintf("stroke_index_%d", number)
+
+		form.IntBetween(parField, 3, 6)
+		form.IntBetween(yardageField, 50, 800)
+		if form.IntBetween(strokeIndexField, 1, course.NumberOfHoles) {
+			strokeIndex, _ := strconv.Atoi(r.Form.Get(strokeIndexField))
+			if strokeIndexes[strokeIndex] {
+				form.Errors.Add(strokeIndexField, "Each stroke index can only be used once")
+			}
+			strokeIndexes[strokeIndex] = true
+		}
+
+		par, _ := strconv.Atoi(r.Form.Get(parField))
+		yardage, _ := strconv.Atoi(r.Form.Get(yardageField))
+		strokeIndex, _ := strconv.Atoi(r.Form.Get(strokeIndexField))
+		teeSet.Holes = append(teeSet.Holes, models.Hole{
+			Number:      number,
+			Par:         par,
+			Yardage:     yardage,
+			StrokeIndex: strokeIndex,
+		})
+	}
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["course"] = course
+		data["tee_set"] = teeSet
+
+		render.Template(w, r, "create-tee-set.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	_, err = m.CourseService.CreateTeeSet(teeSet)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't insert tee set into database!")
+		http.Redirect(w, r, fmt.Sprintf("/courses/%d", course.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "tees added!")
+	http.Redirect(w, r, fmt.Sprintf("/courses/%d", course.ID), http.StatusSeeOther)
+}
@@ -0,0 +1,425 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var coursesTests = []struct {
+	name               string
+	userID             int
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestCourses(t *testing.T) {
+	for _, e := range coursesTests {
+		req, _ := http.NewRequest("GET", "/courses", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.Courses)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var showCourseFormTests = []struct {
+	name               string
+	userID             int
+	accessLevel        int
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		accessLevel:        models.AccessLevelPlayer,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		accessLevel:        models.AccessLevelPlayer,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "super admin",
+		userID:             3,
+		accessLevel:        models.AccessLevelSuperAdmin,
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "commissioner",
+		userID:             1,
+		accessLevel:        models.AccessLevelPlayer,
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowCourseForm(t *testing.T) {
+	for _, e := range showCourseFormTests {
+		req, _ := http.NewRequest("GET", "/courses/new", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		session.Put(req.Context(), "user_id", e.userID)
+		session.Put(req.Context(), "access_level", e.accessLevel)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowCourseForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var postCourseTests = []struct {
+	name               string
+	courseName         string
+	numberOfHoles      string
+	userID             int
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		courseName:         "course2",
+		numberOfHoles:      "18",
+		userID:             0,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		courseName:         "course2",
+		numberOfHoles:      "18",
+		userID:             3,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/courses",
+	},
+	{
+		name:               "name too short",
+		courseName:         "c",
+		numberOfHoles:      "18",
+		userID:             1,
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid number of holes",
+		courseName:         "course2",
+		numberOfHoles:      "12",
+		userID:             1,
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "name not unique in DB",
+		courseName:         "course0",
+		numberOfHoles:      "18",
+		userID:             1,
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "error inserting course",
+		courseName:         "course1",
+		numberOfHoles:      "18",
+		userID:             1,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/courses",
+	},
+	{
+		name:               "happy path",
+		courseName:         "course2",
+		numberOfHoles:      "9",
+		userID:             1,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/courses/1/tee-sets/new",
+	},
+}
+
+func TestCreateCourse(t *testing.T) {
+	for _, e := range postCourseTests {
+		postedData := url.Values{}
+		postedData.Add("name", e.courseName)
+		postedData.Add("location", "Springfield")
+		postedData.Add("number_of_holes", e.numberOfHoles)
+
+		req, _ := http.NewRequest("POST", "/courses", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.CreateCourse)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var showCourseTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/courses/1",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/courses/s",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing course",
+		userID:             1,
+		url:                "/courses/3",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "existing course",
+		userID:             1,
+		url:                "/courses/1",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowCourse(t *testing.T) {
+	for _, e := range showCourseTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowCourse)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var showTeeSetFormTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/courses/1/tee-sets/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/courses/1/tee-sets/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/courses/s/tee-sets/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing course",
+		userID:             1,
+		url:                "/courses/3/tee-sets/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "existing course",
+		userID:             1,
+		url:                "/courses/1/tee-sets/new",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowTeeSetForm(t *testing.T) {
+	for _, e := range showTeeSetFormTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+		session.Put(req.Context(), "access_level", models.AccessLevelPlayer)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowTeeSetForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+// teeSetPostData returns posted values for a valid set of nine holes
+func teeSetPostData(name string) url.Values {
+	postedData := url.Values{}
+	postedData.Add("name", name)
+	postedData.Add("course_rating", "35.4")
+	postedData.Add("slope", "121")
+	for i := 1; i <= 9; i++ {
+		postedData.Add(fmt.Sprintf("par_%d", i), "4")
+		postedData.Add(fmt.Sprintf("yardage_%d", i), "380")
+		postedData.Add(fmt.Sprintf("stroke_index_%d", i), fmt.Sprintf("%d", i))
+	}
+	return postedData
+}
+
+var postTeeSetTests = []struct {
+	name               string
+	userID             int
+	courseID           int
+	postedData         func() url.Values
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		courseID:           1,
+		postedData:         func() url.Values { return teeSetPostData("Blue") },
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		courseID:           1,
+		postedData:         func() url.Values { return teeSetPostData("Blue") },
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "invalid url param",
+		userID:             1,
+		courseID:           0,
+		postedData:         func() url.Values { return teeSetPostData("Blue") },
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "course doesn't exist",
+		userID:             1,
+		courseID:           3,
+		postedData:         func() url.Values { return teeSetPostData("Blue") },
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:     "course rating out of range",
+		userID:   1,
+		courseID: 1,
+		postedData: func() url.Values {
+			postedData := teeSetPostData("Blue")
+			postedData.Set("course_rating", "72.1")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:     "missing hole",
+		userID:   1,
+		courseID: 1,
+		postedData: func() url.Values {
+			postedData := teeSetPostData("Blue")
+			postedData.Del("par_9")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:     "duplicate stroke index",
+		userID:   1,
+		courseID: 1,
+		postedData: func() url.Values {
+			postedData := teeSetPostData("Blue")
+			postedData.Set("stroke_index_2", "1")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "error inserting tee set",
+		userID:             1,
+		courseID:           1,
+		postedData:         func() url.Values { return teeSetPostData("Tee Set Error") },
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		courseID:           1,
+		postedData:         func() url.Values { return teeSetPostData("Blue") },
+		expectedStatusCode: http.StatusSeeOther,
+	},
+}
+
+func TestCreateTeeSet(t *testing.T) {
+	for _, e := range postTeeSetTests {
+		URI := fmt.Sprintf("/courses/%d/tee-sets", e.courseID)
+		if e.courseID == 0 {
+			URI = "/courses/s/tee-sets"
+		}
+
+		req, _ := http.NewRequest("POST", URI, strings.NewReader(e.postedData().Encode()))
+		req.RequestURI = URI
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.CreateTeeSet)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// ShowPlayer shows a player's handicap index and the differentials it was calculated from
+func (m *Handlers) ShowPlayer(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	playerID, err := getPlayerIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	if _, err = m.LeagueRoleService.GetMembership(userID, leagueID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not in this league!")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	player, err := m.PlayerService.GetPlayer(playerID)
+	if err != nil || player.LeagueID != league.ID {
+		m.App.Session.Put(r.Context(), "error", "cannot find player")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	player.User, err = m.UserService.GetUser(player.UserID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find player")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	record, err := m.HandicapService.GetHandicapRecord(player.UserID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot calculate handicap")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["player"] = player
+	data["handicap"] = record
+
+	render.Template(w, r, "player.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
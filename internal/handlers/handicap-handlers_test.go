@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var showPlayerHandicapTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/players/1",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad league url parameter",
+		userID:             1,
+		url:                "/leagues/s/players/1",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "bad player url parameter",
+		userID:             1,
+		url:                "/leagues/1/players/s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "user not in league",
+		userID:             4,
+		url:                "/leagues/4/players/1",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/players/1",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "non-existing player",
+		userID:             1,
+		url:                "/leagues/1/players/9",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "player in another league",
+		userID:             1,
+		url:                "/leagues/2/players/1",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2",
+	},
+	{
+		name:               "existing player",
+		userID:             1,
+		url:                "/leagues/1/players/1",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowPlayer(t *testing.T) {
+	for _, e := range showPlayerHandicapTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowPlayer)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jdonahue135/golf-league-app/internal/config"
 	"github.com/jdonahue135/golf-league-app/internal/forms"
@@ -28,11 +29,59 @@ var LeagueService services.LeagueService
 
 var PlayerService services.PlayerService
 
+var CourseService services.CourseService
+
+var ScoreService services.ScoreService
+
+var HandicapService services.HandicapService
+
+var SeasonService services.SeasonService
+
+var ScheduleService services.ScheduleService
+
+var StandingsService services.StandingsService
+
+var TeamService services.TeamService
+
+var SkinsService services.SkinsService
+
+var InvitationService services.InvitationService
+
+var LoginThrottleService services.LoginThrottleService
+
+var TwoFactorService services.TwoFactorService
+
+var LeagueRoleService services.LeagueRoleService
+
+var CommissionerTransferService services.CommissionerTransferService
+
+var APITokenService services.APITokenService
+
+var WebhookService services.WebhookService
+
+var CalendarFeedService services.CalendarFeedService
+
 type Handlers struct {
-	App           *config.AppConfig
-	UserService   services.UserService
-	LeagueService services.LeagueService
-	PlayerService services.PlayerService
+	App                         *config.AppConfig
+	UserService                 services.UserService
+	LeagueService               services.LeagueService
+	PlayerService               services.PlayerService
+	CourseService               services.CourseService
+	ScoreService                services.ScoreService
+	HandicapService             services.HandicapService
+	SeasonService               services.SeasonService
+	ScheduleService             services.ScheduleService
+	StandingsService            services.StandingsService
+	TeamService                 services.TeamService
+	SkinsService                services.SkinsService
+	InvitationService           services.InvitationService
+	LoginThrottleService        services.LoginThrottleService
+	TwoFactorService            services.TwoFactorService
+	LeagueRoleService           services.LeagueRoleService
+	CommissionerTransferService services.CommissionerTransferService
+	APITokenService             services.APITokenService
+	WebhookService              services.WebhookService
+	CalendarFeedService         services.CalendarFeedService
 }
 
 // NewHandlers sets dependencies of handlers
@@ -41,12 +90,44 @@ func NewHandlers(
 	userService services.UserService,
 	leagueService services.LeagueService,
 	playerService services.PlayerService,
+	courseService services.CourseService,
+	scoreService services.ScoreService,
+	handicapService services.HandicapService,
+	seasonService services.SeasonService,
+	scheduleService services.ScheduleService,
+	standingsService services.StandingsService,
+	teamService services.TeamService,
+	skinsService services.SkinsService,
+	invitationService services.InvitationService,
+	loginThrottleService services.LoginThrottleService,
+	twoFactorService services.TwoFactorService,
+	leagueRoleService services.LeagueRoleService,
+	commissionerTransferService services.CommissionerTransferService,
+	apiTokenService services.APITokenService,
+	webhookService services.WebhookService,
+	calendarFeedService services.CalendarFeedService,
 ) {
 	h := Handlers{
-		App:           a,
-		UserService:   userService,
-		LeagueService: leagueService,
-		PlayerService: playerService,
+		App:                         a,
+		UserService:                 userService,
+		LeagueService:               leagueService,
+		PlayerService:               playerService,
+		CourseService:               courseService,
+		ScoreService:                scoreService,
+		HandicapService:             handicapService,
+		SeasonService:               seasonService,
+		ScheduleService:             scheduleService,
+		StandingsService:            standingsService,
+		TeamService:                 teamService,
+		SkinsService:                skinsService,
+		InvitationService:           invitationService,
+		LoginThrottleService:        loginThrottleService,
+		TwoFactorService:            twoFactorService,
+		LeagueRoleService:           leagueRoleService,
+		CommissionerTransferService: commissionerTransferService,
+		APITokenService:             apiTokenService,
+		WebhookService:              webhookService,
+		CalendarFeedService:         calendarFeedService,
 	}
 	Handler = &h
 }
@@ -90,6 +171,13 @@ func (m *Handlers) Leagues(w http.ResponseWriter, r *http.Request) {
 
 // ShowLeagueForm renders the create a league page and displays form
 func (m *Handlers) ShowLeagueForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if user, err := m.UserService.GetUser(userID); err == nil && !m.isVerified(r, user) {
+		m.App.Session.Put(r.Context(), "error", "verify your email address before creating a league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
 	// send the data to the template
 	render.Template(w, r, "create-league.page.tmpl", &models.TemplateData{
 		Form: forms.New(nil),
@@ -112,7 +200,8 @@ func (m *Handlers) ShowLeague(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err = m.PlayerService.GetPlayerInLeague(userID, leagueID); err != nil {
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil {
 		m.App.Session.Put(r.Context(), "error", "user not in this league!")
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
@@ -134,8 +223,52 @@ func (m *Handlers) ShowLeague(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	seasons, err := m.SeasonService.GetSeasonsInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get seasons for league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	var rounds []models.Round
+	season, hasSeason := m.selectedSeason(r, league.ID, seasons)
+	if hasSeason {
+		rounds, err = m.ScoreService.GetRoundsInSeason(season.ID)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot get rounds for league")
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+	}
+
+	handicaps, err := m.HandicapService.GetHandicapRecords(players)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get handicaps for league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	// league managers see who hasn't claimed their account yet
+	var invitations []models.Invitation
+	if membership.CanManageLeague() {
+		invitations, err = m.InvitationService.GetOpenInvitationsInLeague(league.ID)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot get invitations for league")
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+	}
+
 	data["league"] = league
 	data["players"] = players
+	data["invitations"] = invitations
+	data["now"] = time.Now()
+	data["rounds"] = rounds
+	data["handicaps"] = handicaps
+	data["seasons"] = seasons
+	data["season"] = season
+	data["has_season"] = hasSeason
+	data["membership"] = membership
 
 	render.Template(w, r, "league.page.tmpl", &models.TemplateData{
 		Data: data,
@@ -143,26 +276,39 @@ func (m *Handlers) ShowLeague(w http.ResponseWriter, r *http.Request) {
 }
 
 func getLeagueIDFromURI(URI string) (int, error) {
-	exploded := strings.Split(URI, "/")
-	return strconv.Atoi(exploded[leagueIDIndex])
+	return getIDFromURI(URI, leagueIDIndex)
 }
 
 func getPlayerIDFromURI(URI string) (int, error) {
-	exploded := strings.Split(URI, "/")
-	return strconv.Atoi(exploded[playerIDIndex])
+	return getIDFromURI(URI, playerIDIndex)
+}
+
+// getIDFromURI returns the numeric path segment at index, ignoring any query string
+func getIDFromURI(URI string, index int) (int, error) {
+	exploded := strings.Split(strings.SplitN(URI, "?", 2)[0], "/")
+	if index >= len(exploded) {
+		return 0, fmt.Errorf("no url parameter at index %d", index)
+	}
+	return strconv.Atoi(exploded[index])
 }
 
 // CreateLeague handles request to create a league
 func (m *Handlers) CreateLeague(w http.ResponseWriter, r *http.Request) {
 	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
 
-	_, err := m.UserService.GetUser(userID)
+	user, err := m.UserService.GetUser(userID)
 	if err != nil {
 		m.App.Session.Put(r.Context(), "error", "user not found!")
 		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
 		return
 	}
 
+	if !m.isVerified(r, user) {
+		m.App.Session.Put(r.Context(), "error", "verify your email address before creating a league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
 	err = r.ParseForm()
 
 	if err != nil {
@@ -243,12 +389,19 @@ func (m *Handlers) ShowAddPlayerForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if _, err = m.PlayerService.GetPlayerInLeague(userID, leagueID); err != nil {
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil {
 		m.App.Session.Put(r.Context(), "error", "user not in this league!")
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
 	}
 
+	if !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to add players!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
 	data := make(map[string]interface{})
 
 	league, err := m.LeagueService.GetLeague(leagueID)
@@ -266,6 +419,17 @@ func (m *Handlers) ShowAddPlayerForm(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// validatePlayerForm checks the name and email of a player being added to a
+// league
+func validatePlayerForm(form *forms.Form) {
+	form.Required("first_name", "last_name", "email")
+	form.MinLength("first_name", 2)
+	form.MaxLength("first_name", 35)
+	form.MinLength("last_name", 2)
+	form.MaxLength("last_name", 35)
+	form.IsEmail("email")
+}
+
 func (m *Handlers) AddPlayer(w http.ResponseWriter, r *http.Request) {
 	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
 
@@ -283,15 +447,15 @@ func (m *Handlers) AddPlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	player, err := m.PlayerService.GetPlayerInLeague(userID, leagueID)
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
 	if err != nil {
 		m.App.Session.Put(r.Context(), "error", "you must be a member of this league to do that!")
 		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
 		return
 	}
 
-	if !player.IsCommissioner {
-		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to add players!")
+	if !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to add players!")
 		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 		return
 	}
@@ -302,12 +466,7 @@ func (m *Handlers) AddPlayer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	form := forms.New(r.PostForm)
-	form.Required("first_name", "last_name", "email")
-	form.MinLength("first_name", 2)
-	form.MaxLength("first_name", 35)
-	form.MinLength("last_name", 2)
-	form.MaxLength("last_name", 35)
-	form.IsEmail("email")
+	validatePlayerForm(form)
 
 	firstName := r.Form.Get("first_name")
 	lastName := r.Form.Get("last_name")
@@ -329,36 +488,47 @@ func (m *Handlers) AddPlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = m.LeagueService.GetLeague(leagueID)
+	league, err := m.LeagueService.GetLeague(leagueID)
 	if err != nil {
 		m.App.Session.Put(r.Context(), "error", "cannot find league")
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
 	}
 
-	playerUser, err = m.UserService.GetUserByEmail(email)
+	existingUser, err := m.UserService.GetUserByEmail(email)
 	if err == nil {
 		//user already exists
-		err = m.LeagueService.AddExistingUserToLeague(playerUser.ID, leagueID)
+		err = m.LeagueService.AddExistingUserToLeague(existingUser.ID, leagueID)
 		if err != nil {
 			m.App.Session.Put(r.Context(), "error", err.Error())
 			http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 			return
 		}
+		m.publishWebhookEvent(leagueID, models.WebhookEventPlayerAdded, webhookPlayer{
+			UserID:    existingUser.ID,
+			FirstName: existingUser.FirstName,
+			LastName:  existingUser.LastName,
+		})
 		m.App.Session.Put(r.Context(), "flash", "player added!")
 		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 		return
 	}
 
 	//user does not exist, need to create user and player records at same time
-	err = m.LeagueService.AddNewUserToLeague(playerUser, leagueID)
+	token, err := m.LeagueService.AddNewUserToLeague(playerUser, leagueID)
 	if err != nil {
 		m.App.Session.Put(r.Context(), "error", "error adding player to DB")
 		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 		return
 	}
 
-	m.App.Session.Put(r.Context(), "flash", "player added!")
+	m.sendInvitation(league, playerUser, token)
+	m.publishWebhookEvent(leagueID, models.WebhookEventPlayerAdded, webhookPlayer{
+		FirstName: playerUser.FirstName,
+		LastName:  playerUser.LastName,
+	})
+
+	m.App.Session.Put(r.Context(), "flash", "player added and invited!")
 	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 	return
 }
@@ -380,15 +550,15 @@ func (m *Handlers) RemovePlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	commissioner, err := m.PlayerService.GetPlayerInLeague(userID, leagueID)
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
 	if err != nil {
 		m.App.Session.Put(r.Context(), "error", "user not in this league!")
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
 	}
 
-	if !commissioner.IsCommissioner {
-		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to remove players!")
+	if !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to remove players!")
 		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 		return
 	}
@@ -407,8 +577,9 @@ func (m *Handlers) RemovePlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the player has to be in the league the user manages, not just any league
 	player, err := m.PlayerService.GetPlayer(playerID)
-	if err != nil || !player.IsActive {
+	if err != nil || !player.IsActive || player.LeagueID != leagueID {
 		m.App.Session.Put(r.Context(), "error", "cannot find player to remove")
 		http.Redirect(w, r, "/", http.StatusSeeOther)
 		return
@@ -422,6 +593,13 @@ func (m *Handlers) RemovePlayer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	removed := webhookPlayer{PlayerID: player.ID, UserID: player.UserID}
+	if user, err := m.UserService.GetUser(player.UserID); err == nil {
+		removed.FirstName = user.FirstName
+		removed.LastName = user.LastName
+	}
+	m.publishWebhookEvent(leagueID, models.WebhookEventPlayerRemoved, removed)
+
 	m.App.Session.Put(r.Context(), "flash", "player removed!")
 	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
 	return
@@ -486,9 +664,19 @@ func (m *Handlers) PostShowSignUp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	user.ID = id
+	token, err := m.UserService.RequestEmailVerification(user)
+	if err != nil {
+		log.Println(err)
+	} else {
+		m.sendVerificationEmail(user, token)
+	}
+
 	m.App.Session.Put(r.Context(), "user_id", id)
+	m.App.Session.Put(r.Context(), "logged_in_at", time.Now())
 	m.App.Session.Put(r.Context(), "access_level", models.AccessLevelPlayer)
-	m.App.Session.Put(r.Context(), "flash", "Signed up successfully")
+	m.App.Session.Put(r.Context(), "unverified", true)
+	m.App.Session.Put(r.Context(), "flash", "Signed up successfully, check your email to verify your address")
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
@@ -530,19 +718,116 @@ func (m *Handlers) PostShowLogin(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ip := clientIP(r)
+
+	// the login counts as failed until the password turns out to be right, and
+	// if the failures can't be counted it can't go ahead
+	wait, locked, err := m.LoginThrottleService.StartLogin(email, ip)
+	if err != nil {
+		log.Println(err)
+		m.App.Session.Put(r.Context(), "error", "Can't log in right now, try again later")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+	if wait > 0 {
+		m.App.Session.Put(r.Context(), "error", fmt.Sprintf("Too many failed logins, try again in %s", formatWait(wait)))
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
 	id, accessLevel, err := m.UserService.Authenticate(email, password)
 	if err != nil {
+		if locked {
+			m.notifyLockout(email)
+		}
 		m.App.Session.Put(r.Context(), "error", "Invalid login credentials")
 		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
 		return
 	}
 
-	m.App.Session.Put(r.Context(), "user_id", id)
+	user, err := m.UserService.GetUser(id)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	// the password was right, but the user isn't logged in until they enter a
+	// code from their authenticator app too
+	if user.HasTwoFactor() {
+		if err = m.LoginThrottleService.ReleaseLogin(email, ip); err != nil {
+			log.Println(err)
+		}
+		m.App.Session.Put(r.Context(), "two_factor_user_id", id)
+		m.App.Session.Put(r.Context(), "two_factor_access_level", accessLevel)
+		m.App.Session.Put(r.Context(), "two_factor_started_at", time.Now())
+		http.Redirect(w, r, "/user/login/two-factor", http.StatusSeeOther)
+		return
+	}
+
+	m.completeLogin(w, r, user, accessLevel)
+}
+
+// completeLogin starts the session of a user who has proven who they are.
+// Commissioners who are required to use two-factor authentication and haven't
+// turned it on are sent to set it up before they can do anything else
+func (m *Handlers) completeLogin(w http.ResponseWriter, r *http.Request, user models.User, accessLevel int) {
+	if err := m.LoginThrottleService.RecordSuccessfulLogin(user.Email, clientIP(r)); err != nil {
+		log.Println(err)
+	}
+
+	_ = m.App.Session.RenewToken(r.Context())
+	m.App.Session.Remove(r.Context(), "two_factor_user_id")
+	m.App.Session.Remove(r.Context(), "two_factor_access_level")
+	m.App.Session.Remove(r.Context(), "two_factor_started_at")
+	m.App.Session.Put(r.Context(), "user_id", user.ID)
+	m.App.Session.Put(r.Context(), "logged_in_at", time.Now())
 	m.App.Session.Put(r.Context(), "access_level", accessLevel)
+	m.isVerified(r, user)
+
+	if !user.HasTwoFactor() {
+		required, err := m.TwoFactorService.IsRequired(user.ID)
+		if err != nil {
+			log.Println(err)
+		}
+		if required {
+			m.App.Session.Put(r.Context(), "warning", "Commissioners must turn on two-factor authentication")
+			http.Redirect(w, r, "/user/profile/two-factor", http.StatusSeeOther)
+			return
+		}
+	}
+
 	m.App.Session.Put(r.Context(), "flash", "Logged in successfully")
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
-func (m *Handlers) AdminDashboard(w http.ResponseWriter, r *http.Request) {
-	render.Template(w, r, "admin-dashboard.page.tmpl", &models.TemplateData{})
+// SessionIsCurrent reports whether the logged in user's session started after
+// they last had all of their sessions ended, like when resetting their password
+func (m *Handlers) SessionIsCurrent(r *http.Request) bool {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		return false
+	}
+
+	return user.SessionIsCurrent(m.App.Session.GetTime(r.Context(), "logged_in_at"))
+}
+
+// NeedsTwoFactor reports whether a user has to turn on two-factor
+// authentication before they can do anything else. It's checked on every
+// request, so a player who becomes a commissioner after logging in has to set
+// it up right away. If it can't be checked, the user is asked to set it up
+func (m *Handlers) NeedsTwoFactor(userID int) bool {
+	user, err := m.UserService.GetUser(userID)
+	if err != nil || user.HasTwoFactor() {
+		return false
+	}
+
+	required, err := m.TwoFactorService.IsRequired(user.ID)
+	if err != nil {
+		log.Println(err)
+		return true
+	}
+
+	return required
 }
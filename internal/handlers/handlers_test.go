@@ -29,6 +29,8 @@ var theTests = []struct {
 	{"logout", "/user/logout", "GET", http.StatusOK},
 	{"sign up", "/user/sign-up", "GET", http.StatusOK},
 	{"dashboard", "/admin/dashboard", "GET", http.StatusOK},
+	{"admin users", "/admin/users", "GET", http.StatusOK},
+	{"admin leagues", "/admin/leagues?page=2", "GET", http.StatusOK},
 	{"create league", "/leagues/new", "GET", http.StatusOK},
 }
 
@@ -93,6 +95,36 @@ var leagueTests = []struct {
 		url:                "/leagues/2",
 		expectedStatusCode: http.StatusSeeOther,
 	},
+	{
+		name:               "league with round error",
+		userID:             1,
+		url:                "/leagues/5",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "league with season error",
+		userID:             1,
+		url:                "/leagues/7",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "league with invitations error",
+		userID:             1,
+		url:                "/leagues/8",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "league without a season",
+		userID:             1,
+		url:                "/leagues/6",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "past season selected",
+		userID:             1,
+		url:                "/leagues/1?season_id=1",
+		expectedStatusCode: http.StatusOK,
+	},
 }
 
 func TestShowLeague(t *testing.T) {
@@ -182,6 +214,12 @@ var postLeagueTests = []struct {
 		userID:             0,
 		expectedStatusCode: http.StatusSeeOther,
 	},
+	{
+		name:               "email not verified",
+		leagueName:         "league2",
+		userID:             7,
+		expectedStatusCode: http.StatusSeeOther,
+	},
 	{
 		name:               "name too short",
 		leagueName:         "l",
@@ -342,6 +380,24 @@ var postPlayerTests = []struct {
 		leagueID:           1,
 		expectedStatusCode: http.StatusSeeOther,
 	},
+	{
+		name:               "user is scorekeeper of league",
+		firstName:          "John",
+		lastName:           "Doe",
+		email:              "john@doe.com",
+		userID:             13,
+		leagueID:           1,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "co-commissioner with invalid form",
+		firstName:          "J",
+		lastName:           "Doe",
+		email:              "john@doe.com",
+		userID:             12,
+		leagueID:           1,
+		expectedStatusCode: http.StatusOK,
+	},
 	{
 		name:               "first name too short",
 		firstName:          "J",
@@ -483,6 +539,7 @@ var deletePlayerTests = []struct {
 	leagueID           int
 	playerID           int
 	expectedStatusCode int
+	expectedLocation   string
 }{
 	{
 		name:               "user not logged in",
@@ -525,34 +582,47 @@ var deletePlayerTests = []struct {
 		leagueID:           3,
 		playerID:           1,
 		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
 	},
 	{
 		name:               "player doesn't exist",
 		userID:             1,
-		leagueID:           2,
+		leagueID:           1,
 		playerID:           9,
 		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
 	},
 	{
 		name:               "player inactive in league",
 		userID:             1,
-		leagueID:           2,
+		leagueID:           1,
 		playerID:           8,
 		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
 	},
 	{
-		name:               "service error",
+		name:               "player in another league",
 		userID:             1,
 		leagueID:           2,
+		playerID:           1,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		leagueID:           1,
 		playerID:           10,
 		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
 	},
 	{
 		name:               "success",
 		userID:             1,
-		leagueID:           2,
+		leagueID:           1,
 		playerID:           1,
 		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
 	},
 }
 
@@ -586,6 +656,13 @@ func TestRemovePlayer(t *testing.T) {
 		if rr.Code != e.expectedStatusCode {
 			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
 		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
 	}
 }
 
@@ -698,6 +775,41 @@ var loginTests = []struct {
 		`action="/user/login"`,
 		"",
 	},
+	{
+		"locked-out",
+		"locked@here.ca",
+		http.StatusSeeOther,
+		"",
+		"/user/login",
+	},
+	{
+		"failure-that-locks-out",
+		"lockout@here.ca",
+		http.StatusSeeOther,
+		"",
+		"/user/login",
+	},
+	{
+		"throttle-error",
+		"error@here.com",
+		http.StatusSeeOther,
+		"",
+		"/user/login",
+	},
+	{
+		"two-factor",
+		"twofactor@here.ca",
+		http.StatusSeeOther,
+		"",
+		"/user/login/two-factor",
+	},
+	{
+		"two-factor-required",
+		"required@here.ca",
+		http.StatusSeeOther,
+		"",
+		"/user/profile/two-factor",
+	},
 }
 
 func TestPostShowLogin(t *testing.T) {
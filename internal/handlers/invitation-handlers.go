@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const invitationIDIndex = 4
+
+const claimTokenIndex = 3
+
+func getInvitationIDFromURI(URI string) (int, error) {
+	return getIDFromURI(URI, invitationIDIndex)
+}
+
+// getTokenFromURI returns the path segment at index, ignoring any query string
+func getTokenFromURI(URI string, index int) (string, error) {
+	exploded := strings.Split(strings.SplitN(URI, "?", 2)[0], "/")
+	if index >= len(exploded) || exploded[index] == "" {
+		return "", fmt.Errorf("no url parameter at index %d", index)
+	}
+	return exploded[index], nil
+}
+
+// sendInvitation emails a player added to a league a link to claim their account
+func (m *Handlers) sendInvitation(league models.League, user models.User, token string) {
+	link := fmt.Sprintf("%s/user/claim/%s", m.App.BaseURL, token)
+
+	content := fmt.Sprintf(`
+		<p>Hi %s,</p>
+		<p>You've been added to %s. Set a password to claim your account and see the league:</p>
+		<p><a href="%s">%s</a></p>
+		<p>This link expires in %d days.</p>`,
+		template.HTMLEscapeString(user.FirstName),
+		template.HTMLEscapeString(league.Name),
+		link,
+		link,
+		int(models.InvitationLifetime.Hours()/24),
+	)
+
+	m.sendEmail(user.Email, fmt.Sprintf("You're invited to %s", league.Name), content)
+}
+
+// ResendInvitation handles request to email a player a new link to claim their
+// account. The link in the earlier email stops working
+func (m *Handlers) ResendInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to send invitations!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	invitationID, err := getInvitationIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	invitation, err := m.InvitationService.GetInvitation(invitationID)
+	if err != nil || invitation.LeagueID != leagueID {
+		m.App.Session.Put(r.Context(), "error", "cannot find invitation")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	token, err := m.InvitationService.ResendInvitation(invitation)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.sendInvitation(invitation.League, invitation.User, token)
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("invitation resent to %s!", invitation.User.Email))
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+}
+
+// RevokeInvitation handles request to stop a player from claiming their
+// account with the link they were sent
+func (m *Handlers) RevokeInvitation(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to revoke invitations!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	invitationID, err := getInvitationIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	invitation, err := m.InvitationService.GetInvitation(invitationID)
+	if err != nil || invitation.LeagueID != leagueID {
+		m.App.Session.Put(r.Context(), "error", "cannot find invitation")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.InvitationService.RevokeInvitation(invitation)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "invitation revoked!")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+}
+
+// ShowClaimAccount shows the claim account page for the invitation in the link
+func (m *Handlers) ShowClaimAccount(w http.ResponseWriter, r *http.Request) {
+	token, err := getTokenFromURI(r.RequestURI, claimTokenIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	invitation, err := m.InvitationService.GetInvitationByToken(token)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this invitation is no longer valid, ask your commissioner to resend it")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	invitedUser, err := m.UserService.GetUser(invitation.UserID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this invitation is no longer valid, ask your commissioner to resend it")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+	if invitedUser.IsClaimed() {
+		m.App.Session.Put(r.Context(), "error", "this account has already been claimed, log in instead")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["invitation"] = invitation
+	data["user"] = invitation.User
+	data["token"] = token
+
+	render.Template(w, r, "claim-account.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// PostClaimAccount handles an invited player setting their name and password,
+// then logs them in to their league
+func (m *Handlers) PostClaimAccount(w http.ResponseWriter, r *http.Request) {
+	token, err := getTokenFromURI(r.RequestURI, claimTokenIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	invitation, err := m.InvitationService.GetInvitationByToken(token)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this invitation is no longer valid, ask your commissioner to resend it")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	invitedUser, err := m.UserService.GetUser(invitation.UserID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this invitation is no longer valid, ask your commissioner to resend it")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+	if invitedUser.IsClaimed() {
+		m.App.Session.Put(r.Context(), "error", "this account has already been claimed, log in instead")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("first_name", "last_name", "password")
+	form.MinLength("first_name", 2)
+	form.MaxLength("first_name", 35)
+	form.MinLength("last_name", 2)
+	form.MaxLength("last_name", 35)
+	form.MinLength("password", 2)
+	form.MaxLength("password", 35)
+
+	user := invitation.User
+	user.FirstName = r.Form.Get("first_name")
+	user.LastName = r.Form.Get("last_name")
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["invitation"] = invitation
+		data["user"] = user
+		data["token"] = token
+
+		render.Template(w, r, "claim-account.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	err = m.InvitationService.ClaimInvitation(invitation, user, r.Form.Get("password"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't claim account!")
+		http.Redirect(w, r, fmt.Sprintf("/user/claim/%s", token), http.StatusSeeOther)
+		return
+	}
+
+	_ = m.App.Session.RenewToken(r.Context())
+	m.App.Session.Put(r.Context(), "user_id", invitation.UserID)
+	m.App.Session.Put(r.Context(), "logged_in_at", time.Now())
+	m.App.Session.Put(r.Context(), "access_level", invitedUser.AccessLevel)
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("Welcome to %s!", invitation.League.Name))
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", invitation.LeagueID), http.StatusSeeOther)
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var invitationActionTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/invitations/1/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/invitations/1/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/invitations/1/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "bad invitation id",
+		userID:             1,
+		url:                "/leagues/1/invitations/x/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing invitation",
+		userID:             1,
+		url:                "/leagues/1/invitations/3/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "invitation in another league",
+		userID:             1,
+		url:                "/leagues/2/invitations/1/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2",
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		url:                "/leagues/1/invitations/5/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/invitations/1/%s",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+}
+
+func TestInvitationActions(t *testing.T) {
+	actions := map[string]http.HandlerFunc{
+		"resend": Handler.ResendInvitation,
+		"revoke": Handler.RevokeInvitation,
+	}
+
+	for action, handler := range actions {
+		for _, e := range invitationActionTests {
+			uri := strings.Replace(e.url, "%s", action, 1)
+			req, _ := http.NewRequest("POST", uri, nil)
+			req.RequestURI = uri
+
+			ctx := getCtx(req)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+
+			session.Put(req.Context(), "user_id", e.userID)
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != e.expectedStatusCode {
+				t.Errorf("failed %s %s: expected code %d, but got %d", action, e.name, e.expectedStatusCode, rr.Code)
+			}
+
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s %s: expected location %s, but got location %s", action, e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var showClaimAccountTests = []struct {
+	name               string
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "missing token",
+		url:                "/user/claim/",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "invalid token",
+		url:                "/user/claim/expired",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "already claimed",
+		url:                "/user/claim/claimed",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "valid token",
+		url:                "/user/claim/token",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowClaimAccount(t *testing.T) {
+	for _, e := range showClaimAccountTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowClaimAccount)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var postClaimAccountTests = []struct {
+	name               string
+	url                string
+	firstName          string
+	lastName           string
+	password           string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "invalid token",
+		url:                "/user/claim/expired",
+		firstName:          "New",
+		lastName:           "Player",
+		password:           "password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "already claimed",
+		url:                "/user/claim/claimed",
+		firstName:          "New",
+		lastName:           "Player",
+		password:           "password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "missing password",
+		url:                "/user/claim/token",
+		firstName:          "New",
+		lastName:           "Player",
+		password:           "",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "short first name",
+		url:                "/user/claim/token",
+		firstName:          "N",
+		lastName:           "Player",
+		password:           "password",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "service error",
+		url:                "/user/claim/token",
+		firstName:          "New",
+		lastName:           "Player",
+		password:           "error",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/claim/token",
+	},
+	{
+		name:               "happy path",
+		url:                "/user/claim/token",
+		firstName:          "New",
+		lastName:           "Player",
+		password:           "password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+}
+
+func TestPostClaimAccount(t *testing.T) {
+	for _, e := range postClaimAccountTests {
+		postedData := url.Values{}
+		postedData.Add("first_name", e.firstName)
+		postedData.Add("last_name", e.lastName)
+		postedData.Add("password", e.password)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		handler := http.HandlerFunc(Handler.PostClaimAccount)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// leagueEmailRecipients returns the users an email to the whole league goes
+// to, the active players who have verified their email address, and how many
+// active players were left out because they haven't
+func leagueEmailRecipients(players []models.Player) ([]models.User, int) {
+	var recipients []models.User
+	unverified := 0
+	for _, p := range players {
+		if !p.IsActive {
+			continue
+		}
+		if !p.User.IsVerified() {
+			unverified++
+			continue
+		}
+		recipients = append(recipients, p.User)
+	}
+	return recipients, unverified
+}
+
+// ShowLeagueEmail renders the page a commissioner writes an email to the
+// whole league on
+func (m *Handlers) ShowLeagueEmail(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	commissioner, err := m.PlayerService.GetPlayerInLeague(userID, leagueID)
+	if err != nil || !commissioner.IsCommissioner {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to email the league!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	if !m.isVerified(r, user) {
+		m.App.Session.Put(r.Context(), "error", "verify your email address before emailing your league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+
+	render.Template(w, r, "league-email.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// PostLeagueEmail handles request to email the league's active players. Only
+// players who have verified their email address get it
+func (m *Handlers) PostLeagueEmail(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	commissioner, err := m.PlayerService.GetPlayerInLeague(userID, leagueID)
+	if err != nil || !commissioner.IsCommissioner {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to email the league!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	if !m.isVerified(r, user) {
+		m.App.Session.Put(r.Context(), "error", "verify your email address before emailing your league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("subject", "message")
+	form.MaxLength("subject", 100)
+	form.MaxLength("message", 5000)
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["league"] = league
+		data["subject"] = r.Form.Get("subject")
+		data["message"] = r.Form.Get("message")
+
+		render.Template(w, r, "league-email.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/email", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	recipients, unverified := leagueEmailRecipients(players)
+	if len(recipients) == 0 {
+		m.App.Session.Put(r.Context(), "error", "none of the league's players have verified their email address yet")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/email", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	// a subject is one line, whatever was posted
+	subject := fmt.Sprintf("%s: %s", league.Name, strings.Join(strings.Fields(r.Form.Get("subject")), " "))
+	content := fmt.Sprintf(`
+		<p>%s</p>
+		<p>Sent by %s to the players of %s.</p>`,
+		strings.ReplaceAll(template.HTMLEscapeString(r.Form.Get("message")), "\n", "<br>"),
+		template.HTMLEscapeString(user.FirstName+" "+user.LastName),
+		template.HTMLEscapeString(league.Name),
+	)
+	for _, u := range recipients {
+		m.sendEmail(u.Email, subject, content)
+	}
+
+	flash := fmt.Sprintf("email sent to %d players!", len(recipients))
+	if unverified > 0 {
+		flash = fmt.Sprintf("email sent to %d players, %d haven't verified their email address and didn't get it", len(recipients), unverified)
+	}
+	m.App.Session.Put(r.Context(), "flash", flash)
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestLeagueEmailRecipients(t *testing.T) {
+	players := []models.Player{
+		{ID: 1, IsActive: true, User: models.User{Email: "verified@player.com", VerifiedAt: time.Now()}},
+		{ID: 2, IsActive: true, User: models.User{Email: "unverified@player.com"}},
+		{ID: 3, User: models.User{Email: "inactive@player.com", VerifiedAt: time.Now()}},
+	}
+
+	recipients, unverified := leagueEmailRecipients(players)
+	if len(recipients) != 1 || recipients[0].Email != "verified@player.com" {
+		t.Errorf("expected only the active verified player, but got %v", recipients)
+	}
+	if unverified != 1 {
+		t.Errorf("expected 1 unverified player, but got %d", unverified)
+	}
+}
+
+var showLeagueEmailTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/email",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/email",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/email",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "unverified commissioner",
+		userID:             7,
+		url:                "/leagues/1/email",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/email",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/email",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowLeagueEmail(t *testing.T) {
+	for _, e := range showLeagueEmailTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowLeagueEmail)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var postLeagueEmailTests = []struct {
+	name               string
+	userID             int
+	url                string
+	subject            string
+	message            string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/12/email",
+		subject:            "Tee times",
+		message:            "We start at 5:30 this week.",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/12/email",
+		subject:            "Tee times",
+		message:            "We start at 5:30 this week.",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/12",
+	},
+	{
+		name:               "unverified commissioner",
+		userID:             7,
+		url:                "/leagues/12/email",
+		subject:            "Tee times",
+		message:            "We start at 5:30 this week.",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/12",
+	},
+	{
+		name:               "missing message",
+		userID:             1,
+		url:                "/leagues/12/email",
+		subject:            "Tee times",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "players error",
+		userID:             1,
+		url:                "/leagues/2/email",
+		subject:            "Tee times",
+		message:            "We start at 5:30 this week.",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2/email",
+	},
+	{
+		name:               "no verified players",
+		userID:             1,
+		url:                "/leagues/1/email",
+		subject:            "Tee times",
+		message:            "We start at 5:30 this week.",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/email",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/12/email",
+		subject:            "Tee times",
+		message:            "We start at 5:30 this week.",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/12",
+	},
+}
+
+func TestPostLeagueEmail(t *testing.T) {
+	for _, e := range postLeagueEmailTests {
+		postedData := url.Values{}
+		postedData.Add("subject", e.subject)
+		postedData.Add("message", e.message)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.PostLeagueEmail)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
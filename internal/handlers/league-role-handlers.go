@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const leagueAdminIDIndex = 4
+
+func getLeagueAdminIDFromURI(URI string) (int, error) {
+	return getIDFromURI(URI, leagueAdminIDIndex)
+}
+
+// leagueRolesData returns what the roles page shows for a league
+func (m *Handlers) leagueRolesData(leagueID int) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		return data, err
+	}
+
+	admins, err := m.LeagueRoleService.GetLeagueAdmins(leagueID)
+	if err != nil {
+		return data, err
+	}
+
+	data["league"] = league
+	data["admins"] = admins
+	data["roles"] = models.GrantableLeagueRoles
+
+	return data, nil
+}
+
+// LeagueRoles renders the page where the commissioner gives people roles in
+// their league
+func (m *Handlers) LeagueRoles(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageRoles() {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to manage roles!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	data, err := m.leagueRolesData(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get roles for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "league-roles.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// GrantLeagueRole handles request to give someone with an account a role in
+// the league, replacing any role they already had
+func (m *Handlers) GrantLeagueRole(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageRoles() {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to manage roles!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("email", "role")
+	form.IsEmail("email")
+	if form.Has("role") && !models.IsGrantableLeagueRole(form.Get("role")) {
+		form.Errors.Add("role", "Choose one of the roles")
+	}
+
+	if !form.Valid() {
+		data, err := m.leagueRolesData(leagueID)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot get roles for league")
+			http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+			return
+		}
+
+		render.Template(w, r, "league-roles.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	email := form.Get("email")
+	role := form.Get("role")
+
+	err = m.LeagueRoleService.GrantLeagueRole(leagueID, email, role)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/roles", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("%s is now a %s!", email, render.RoleName(role)))
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/roles", leagueID), http.StatusSeeOther)
+}
+
+// RevokeLeagueRole handles request to take away a role someone was given in
+// the league
+func (m *Handlers) RevokeLeagueRole(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageRoles() {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to manage roles!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	adminID, err := getLeagueAdminIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/roles", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	admin, err := m.LeagueRoleService.GetLeagueAdmin(adminID)
+	if err != nil || admin.LeagueID != leagueID {
+		m.App.Session.Put(r.Context(), "error", "cannot find role")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/roles", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.LeagueRoleService.RevokeLeagueRole(admin)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot revoke role")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/roles", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("%s is no longer a %s", admin.User.Email, admin.RoleName()))
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/roles", leagueID), http.StatusSeeOther)
+}
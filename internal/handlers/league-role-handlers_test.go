@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var leagueRolesTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/roles",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/roles",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "user not in league",
+		userID:             4,
+		url:                "/leagues/4/roles",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/4",
+	},
+	{
+		name:               "co-commissioner",
+		userID:             12,
+		url:                "/leagues/1/roles",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/roles",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/3",
+	},
+	{
+		name:               "roles error",
+		userID:             1,
+		url:                "/leagues/2/roles",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2",
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/roles",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestLeagueRoles(t *testing.T) {
+	for _, e := range leagueRolesTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.LeagueRoles)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var grantLeagueRoleTests = []struct {
+	name               string
+	userID             int
+	url                string
+	email              string
+	role               string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/roles",
+		email:              "new@admin.com",
+		role:               models.LeagueRoleCoCommissioner,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/roles",
+		email:              "new@admin.com",
+		role:               models.LeagueRoleCoCommissioner,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/roles",
+		email:              "new@admin.com",
+		role:               models.LeagueRoleCoCommissioner,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "invalid email",
+		userID:             1,
+		url:                "/leagues/1/roles",
+		email:              "new",
+		role:               models.LeagueRoleCoCommissioner,
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid role",
+		userID:             1,
+		url:                "/leagues/1/roles",
+		email:              "new@admin.com",
+		role:               models.LeagueRoleCommissioner,
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid form with roles error",
+		userID:             1,
+		url:                "/leagues/2/roles",
+		email:              "new",
+		role:               models.LeagueRoleCoCommissioner,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2",
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		url:                "/leagues/1/roles",
+		email:              "error@here.com",
+		role:               models.LeagueRoleCoCommissioner,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/roles",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/roles",
+		email:              "new@admin.com",
+		role:               models.LeagueRoleScorekeeper,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/roles",
+	},
+}
+
+func TestGrantLeagueRole(t *testing.T) {
+	for _, e := range grantLeagueRoleTests {
+		postedData := url.Values{}
+		postedData.Add("email", e.email)
+		postedData.Add("role", e.role)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.GrantLeagueRole)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var revokeLeagueRoleTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/roles/1/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/roles/1/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             12,
+		url:                "/leagues/1/roles/1/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "bad role id",
+		userID:             1,
+		url:                "/leagues/1/roles/x/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/roles",
+	},
+	{
+		name:               "non-existing role",
+		userID:             1,
+		url:                "/leagues/1/roles/3/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/roles",
+	},
+	{
+		name:               "role in another league",
+		userID:             1,
+		url:                "/leagues/2/roles/1/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2/roles",
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		url:                "/leagues/1/roles/2/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/roles",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/roles/1/revoke",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/roles",
+	},
+}
+
+func TestRevokeLeagueRole(t *testing.T) {
+	for _, e := range revokeLeagueRoleTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.RevokeLeagueRole)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
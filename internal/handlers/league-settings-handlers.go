@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// The Stableford tables a commissioner can pick from on the settings page
+const (
+	stablefordStandard = "standard"
+	stablefordModified = "modified"
+	stablefordCustom   = "custom"
+)
+
+// leagueTimeZones are offered on the settings page. Any IANA time zone name
+// can be entered
+var leagueTimeZones = []string{
+	"America/New_York",
+	"America/Chicago",
+	"America/Denver",
+	"America/Phoenix",
+	"America/Los_Angeles",
+	"America/Anchorage",
+	"Pacific/Honolulu",
+	"Europe/London",
+	"UTC",
+}
+
+// stablefordPointField is the settings form field for the points awarded for
+// one score in a custom Stableford table
+type stablefordPointField struct {
+	Name  string
+	Label string
+	Value string
+}
+
+// stablefordPointFields returns the custom table fields filled in with a table's points
+func stablefordPointFields(table models.StablefordTable) []stablefordPointField {
+	return []stablefordPointField{
+		{"points_double_bogey", "Double bogey or worse", strconv.Itoa(table.DoubleBogeyOrWorse)},
+		{"points_bogey", "Bogey", strconv.Itoa(table.Bogey)},
+		{"points_par", "Par", strconv.Itoa(table.Par)},
+		{"points_birdie", "Birdie", strconv.Itoa(table.Birdie)},
+		{"points_eagle", "Eagle", strconv.Itoa(table.Eagle)},
+		{"points_albatross", "Albatross or better", strconv.Itoa(table.AlbatrossOrBetter)},
+	}
+}
+
+// matchPlayFields are the settings form fields for how matchups are scored
+var matchPlayFields = []string{"match_allowance", "win_points", "halve_points", "hole_points"}
+
+// stablefordTableName returns which of the settings page choices a table is
+func stablefordTableName(table models.StablefordTable) string {
+	switch table {
+	case models.StandardStableford:
+		return stablefordStandard
+	case models.ModifiedStableford:
+		return stablefordModified
+	}
+	return stablefordCustom
+}
+
+// ShowLeagueSettings renders the league settings page so the commissioner can
+// choose how the league is scored
+func (m *Handlers) ShowLeagueSettings(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to change league settings!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "league-settings.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: leagueSettingsData(league),
+	})
+}
+
+func leagueSettingsData(league models.League) map[string]interface{} {
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["formats"] = models.ScoringFormats
+	data["time_zones"] = leagueTimeZones
+	data["stableford_table"] = stablefordTableName(league.StablefordTable)
+	data["points"] = stablefordPointFields(league.StablefordTable)
+	data["match_allowance"] = strconv.Itoa(int(math.Round(league.MatchAllowance * 100)))
+	data["win_points"] = strconv.FormatFloat(league.WinPoints, 'f', -1, 64)
+	data["halve_points"] = strconv.FormatFloat(league.HalvePoints, 'f', -1, 64)
+	data["hole_points"] = strconv.FormatFloat(league.HolePoints, 'f', -1, 64)
+	return data
+}
+
+// UpdateLeagueSettings handles request to change a league's scoring format,
+// Stableford points, match play settings and time zone
+func (m *Handlers) UpdateLeagueSettings(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to change league settings!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("scoring_format", "stableford_table")
+	if form.Has("scoring_format") && !models.IsScoringFormat(r.Form.Get("scoring_format")) {
+		form.Errors.Add("scoring_format", "Choose one of the scoring formats")
+	}
+
+	league.ScoringFormat = r.Form.Get("scoring_format")
+
+	if form.IntBetween("match_allowance", 0, 100) {
+		allowance, _ := strconv.Atoi(strings.TrimSpace(r.Form.Get("match_allowance")))
+		league.MatchAllowance = float64(allowance) / 100
+	}
+	for field, points := range map[string]*float64{
+		"win_points":   &league.WinPoints,
+		"halve_points": &league.HalvePoints,
+		"hole_points":  &league.HolePoints,
+	} {
+		if form.FloatBetween(field, 0, 10) {
+			*points, _ = strconv.ParseFloat(strings.TrimSpace(r.Form.Get(field)), 64)
+		}
+	}
+
+	league.TimeZone = strings.TrimSpace(r.Form.Get("time_zone"))
+	if _, ok := league.Location(); league.TimeZone != "" && !ok {
+		form.Errors.Add("time_zone", "Enter a time zone like America/Chicago")
+	}
+
+	switch r.Form.Get("stableford_table") {
+	case stablefordStandard:
+		league.StablefordTable = models.StandardStableford
+	case stablefordModified:
+		league.StablefordTable = models.ModifiedStableford
+	case stablefordCustom:
+		fields := stablefordPointFields(league.StablefordTable)
+		var points []int
+		for _, field := range fields {
+			if form.IntBetween(field.Name, -10, 10) {
+				p, _ := strconv.Atoi(strings.TrimSpace(r.Form.Get(field.Name)))
+				points = append(points, p)
+			}
+		}
+		if len(points) == len(fields) {
+			league.StablefordTable = models.StablefordTable{
+				DoubleBogeyOrWorse: points[0],
+				Bogey:              points[1],
+				Par:                points[2],
+				Birdie:             points[3],
+				Eagle:              points[4],
+				AlbatrossOrBetter:  points[5],
+			}
+		}
+	default:
+		form.Errors.Add("stableford_table", "Choose a Stableford points table")
+	}
+
+	if !form.Valid() {
+		data := leagueSettingsData(league)
+		data["stableford_table"] = r.Form.Get("stableford_table")
+		for _, field := range matchPlayFields {
+			data[field] = r.Form.Get(field)
+		}
+		if r.Form.Get("stableford_table") == stablefordCustom {
+			// show the points as they were entered
+			points := stablefordPointFields(league.StablefordTable)
+			for i := range points {
+				points[i].Value = r.Form.Get(points[i].Name)
+			}
+			data["points"] = points
+		}
+
+		render.Template(w, r, "league-settings.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	err = m.LeagueService.UpdateLeagueSettings(league)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't update league settings!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/settings", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "league settings updated!")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+}
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var showLeagueSettingsTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/settings",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/settings",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/settings",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/settings",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowLeagueSettings(t *testing.T) {
+	for _, e := range showLeagueSettingsTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowLeagueSettings)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var updateLeagueSettingsTests = []struct {
+	name               string
+	userID             int
+	url                string
+	scoringFormat      string
+	stablefordTable    string
+	points             []string
+	matchPlay          []string
+	timeZone           string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "stableford",
+		stablefordTable:    "standard",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "stableford",
+		stablefordTable:    "standard",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/settings",
+		scoringFormat:      "stableford",
+		stablefordTable:    "standard",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "invalid format",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "scramble",
+		stablefordTable:    "standard",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "missing stableford table",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "stableford",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid custom points",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "stableford",
+		stablefordTable:    "custom",
+		points:             []string{"-1", "0", "1", "x", "3", "4"},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid match play settings",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "match",
+		stablefordTable:    "standard",
+		matchPlay:          []string{"150", "2", "-1", "x"},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid time zone",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "stableford",
+		stablefordTable:    "standard",
+		timeZone:           "Central",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "error updating league",
+		userID:             1,
+		url:                "/leagues/5/settings",
+		scoringFormat:      "stableford",
+		stablefordTable:    "modified",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/5/settings",
+	},
+	{
+		name:               "custom points",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "stableford",
+		stablefordTable:    "custom",
+		points:             []string{"-1", "0", "1", "2", "3", "4"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "match",
+		stablefordTable:    "standard",
+		matchPlay:          []string{"80", "3", "1.5", "0.5"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "time zone",
+		userID:             1,
+		url:                "/leagues/1/settings",
+		scoringFormat:      "match",
+		stablefordTable:    "standard",
+		timeZone:           "America/Chicago",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+}
+
+func TestUpdateLeagueSettings(t *testing.T) {
+	fields := []string{"points_double_bogey", "points_bogey", "points_par", "points_birdie", "points_eagle", "points_albatross"}
+	defaultMatchPlay := []string{"100", "2", "1", "0"}
+
+	for _, e := range updateLeagueSettingsTests {
+		postedData := url.Values{}
+		postedData.Add("scoring_format", e.scoringFormat)
+		postedData.Add("stableford_table", e.stablefordTable)
+		postedData.Add("time_zone", e.timeZone)
+		for i, p := range e.points {
+			postedData.Add(fields[i], p)
+		}
+		matchPlay := e.matchPlay
+		if matchPlay == nil {
+			matchPlay = defaultMatchPlay
+		}
+		for i, v := range matchPlay {
+			postedData.Add(matchPlayFields[i], v)
+		}
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.UpdateLeagueSettings)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
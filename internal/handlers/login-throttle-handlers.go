@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// clientIP returns the address a request came from, without its port
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// formatWait rounds a wait up to whole seconds or minutes for people to read
+func formatWait(wait time.Duration) string {
+	if wait < time.Minute {
+		seconds := int(math.Ceil(wait.Seconds()))
+		if seconds == 1 {
+			return "1 second"
+		}
+		return fmt.Sprintf("%d seconds", seconds)
+	}
+
+	minutes := int(math.Ceil(wait.Minutes()))
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+// notifyLockout emails the owner of the account email is for, if there is
+// one, that failed logins have locked them out
+func (m *Handlers) notifyLockout(email string) {
+	user, err := m.UserService.GetUserByEmail(email)
+	if err != nil {
+		return
+	}
+
+	m.sendLockoutEmail(user)
+}
+
+// sendLockoutEmail tells a user that their account is locked after too many
+// failed logins, in case it wasn't them
+func (m *Handlers) sendLockoutEmail(user models.User) {
+	link := fmt.Sprintf("%s/user/forgot-password", m.App.BaseURL)
+
+	content := fmt.Sprintf(`
+		<p>Hi %s,</p>
+		<p>There were too many failed logins to your account, so logging in is locked for %s.</p>
+		<p>If this wasn't you, someone may be trying to guess your password. You can reset it here:</p>
+		<p><a href="%s">%s</a></p>`,
+		template.HTMLEscapeString(user.FirstName),
+		formatWait(models.EmailLoginPolicy.LockoutDuration),
+		link,
+		link,
+	)
+
+	m.sendEmail(user.Email, "Your account is locked", content)
+}
+
+// ClearLockout handles a super admin letting logins for a locked out email or
+// client address go ahead again
+func (m *Handlers) ClearLockout(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	key := r.Form.Get("key")
+	if key == "" {
+		m.App.Session.Put(r.Context(), "error", "missing lockout")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	err = m.LoginThrottleService.ClearLockout(key)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't clear lockout!")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "lockout cleared!")
+	http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var clearLockoutTests = []struct {
+	name             string
+	key              string
+	expectedFlash    bool
+	expectedLocation string
+}{
+	{
+		name:             "missing key",
+		key:              "",
+		expectedLocation: "/admin/dashboard",
+	},
+	{
+		name:             "service error",
+		key:              "error",
+		expectedLocation: "/admin/dashboard",
+	},
+	{
+		name:             "happy path",
+		key:              "email:locked@here.ca",
+		expectedFlash:    true,
+		expectedLocation: "/admin/dashboard",
+	},
+}
+
+func TestClearLockout(t *testing.T) {
+	for _, e := range clearLockoutTests {
+		postedData := url.Values{}
+		postedData.Add("key", e.key)
+
+		req, _ := http.NewRequest("POST", "/admin/lockouts/clear", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		handler := http.HandlerFunc(Handler.ClearLockout)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+
+		if e.expectedFlash && session.GetString(req.Context(), "flash") == "" {
+			t.Errorf("failed %s: expected a flash message, but didn't get one", e.name)
+		}
+		if !e.expectedFlash && session.GetString(req.Context(), "error") == "" {
+			t.Errorf("failed %s: expected an error message, but didn't get one", e.name)
+		}
+	}
+}
+
+var formatWaitTests = []struct {
+	wait     time.Duration
+	expected string
+}{
+	{500 * time.Millisecond, "1 second"},
+	{30 * time.Second, "30 seconds"},
+	{time.Minute, "1 minute"},
+	{90 * time.Second, "2 minutes"},
+	{15 * time.Minute, "15 minutes"},
+}
+
+func TestFormatWait(t *testing.T) {
+	for _, e := range formatWaitTests {
+		if got := formatWait(e.wait); got != e.expected {
+			t.Errorf("failed %s: expected %s, but got %s", e.wait, e.expected, got)
+		}
+	}
+}
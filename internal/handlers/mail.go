@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// mailFrom is the address the app's emails are sent from
+const mailFrom = "do-not-reply@golfleague.app"
+
+// mailTemplate is the template in email-templates the app's emails are rendered in
+const mailTemplate = "basic.html"
+
+// sendEmail queues an email with the html content for the mail listener to send
+func (m *Handlers) sendEmail(to, subject, content string) {
+	m.App.MailChan <- models.MailData{
+		To:       to,
+		From:     mailFrom,
+		Subject:  subject,
+		Content:  content,
+		Template: mailTemplate,
+	}
+}
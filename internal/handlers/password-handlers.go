@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const resetTokenIndex = 3
+
+// sendPasswordReset emails a user a link to reset their password
+func (m *Handlers) sendPasswordReset(user models.User, token string) {
+	link := fmt.Sprintf("%s/user/reset-password/%s", m.App.BaseURL, token)
+
+	content := fmt.Sprintf(`
+		<p>Hi %s,</p>
+		<p>We got a request to reset your password. Choose a new one here:</p>
+		<p><a href="%s">%s</a></p>
+		<p>This link works once and expires in %d minutes. If you didn't ask to reset your password, you can ignore this email.</p>`,
+		template.HTMLEscapeString(user.FirstName),
+		link,
+		link,
+		int(models.PasswordResetLifetime.Minutes()),
+	)
+
+	m.sendEmail(user.Email, "Reset your password", content)
+}
+
+// ShowForgotPassword shows the form to request a password reset email
+func (m *Handlers) ShowForgotPassword(w http.ResponseWriter, r *http.Request) {
+	render.Template(w, r, "forgot-password.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+	})
+}
+
+// PostForgotPassword handles request to email a password reset link. The
+// response is the same whether or not the email has an account
+func (m *Handlers) PostForgotPassword(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("email")
+	form.IsEmail("email")
+
+	if !form.Valid() {
+		render.Template(w, r, "forgot-password.page.tmpl", &models.TemplateData{
+			Form: form,
+		})
+		return
+	}
+
+	user, err := m.UserService.GetUserByEmail(r.Form.Get("email"))
+	if err == nil {
+		token, err := m.UserService.RequestPasswordReset(user)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "can't reset password right now, try again later")
+			http.Redirect(w, r, "/user/forgot-password", http.StatusSeeOther)
+			return
+		}
+
+		m.sendPasswordReset(user, token)
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "If there's an account for that email, we've sent it a link to reset the password")
+	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+}
+
+// ShowResetPassword shows the form to choose a new password for the reset link
+func (m *Handlers) ShowResetPassword(w http.ResponseWriter, r *http.Request) {
+	token, err := getTokenFromURI(r.RequestURI, resetTokenIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/user/forgot-password", http.StatusSeeOther)
+		return
+	}
+
+	_, err = m.UserService.GetPasswordReset(token)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this reset link is no longer valid, request a new one")
+		http.Redirect(w, r, "/user/forgot-password", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["token"] = token
+
+	render.Template(w, r, "reset-password.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// PostResetPassword handles setting a new password from a reset link, then
+// logs the user out everywhere so they log in with the new password
+func (m *Handlers) PostResetPassword(w http.ResponseWriter, r *http.Request) {
+	token, err := getTokenFromURI(r.RequestURI, resetTokenIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/user/forgot-password", http.StatusSeeOther)
+		return
+	}
+
+	_, err = m.UserService.GetPasswordReset(token)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this reset link is no longer valid, request a new one")
+		http.Redirect(w, r, "/user/forgot-password", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("password", "confirm_password")
+	form.MinLength("password", 2)
+	form.MaxLength("password", 35)
+	if r.Form.Get("password") != r.Form.Get("confirm_password") {
+		form.Errors.Add("confirm_password", "Passwords don't match")
+	}
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["token"] = token
+
+		render.Template(w, r, "reset-password.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	err = m.UserService.ResetPassword(token, r.Form.Get("password"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't reset password!")
+		http.Redirect(w, r, "/user/forgot-password", http.StatusSeeOther)
+		return
+	}
+
+	_ = m.App.Session.Destroy(r.Context())
+	_ = m.App.Session.RenewToken(r.Context())
+
+	m.App.Session.Put(r.Context(), "flash", "Password reset, log in with your new password")
+	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShowForgotPassword(t *testing.T) {
+	req, _ := http.NewRequest("GET", "/user/forgot-password", nil)
+	ctx := getCtx(req)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(Handler.ShowForgotPassword)
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("ShowForgotPassword returned wrong response code: got %d, wanted %d", rr.Code, http.StatusOK)
+	}
+}
+
+var postForgotPasswordTests = []struct {
+	name               string
+	email              string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "invalid email",
+		email:              "not an email",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "no account for email",
+		email:              "me@here.ca",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "service error",
+		email:              "reset@error.com",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/forgot-password",
+	},
+	{
+		name:               "happy path",
+		email:              "me@here.com",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+}
+
+func TestPostForgotPassword(t *testing.T) {
+	for _, e := range postForgotPasswordTests {
+		postedData := url.Values{}
+		postedData.Add("email", e.email)
+
+		req, _ := http.NewRequest("POST", "/user/forgot-password", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		handler := http.HandlerFunc(Handler.PostForgotPassword)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var showResetPasswordTests = []struct {
+	name               string
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "missing token",
+		url:                "/user/reset-password/",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "invalid token",
+		url:                "/user/reset-password/expired",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "valid token",
+		url:                "/user/reset-password/token",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowResetPassword(t *testing.T) {
+	for _, e := range showResetPasswordTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowResetPassword)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var postResetPasswordTests = []struct {
+	name               string
+	url                string
+	password           string
+	confirmPassword    string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "invalid token",
+		url:                "/user/reset-password/expired",
+		password:           "password",
+		confirmPassword:    "password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/forgot-password",
+	},
+	{
+		name:               "missing password",
+		url:                "/user/reset-password/token",
+		password:           "",
+		confirmPassword:    "",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "passwords don't match",
+		url:                "/user/reset-password/token",
+		password:           "password",
+		confirmPassword:    "passwrod",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "service error",
+		url:                "/user/reset-password/token",
+		password:           "error",
+		confirmPassword:    "error",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/forgot-password",
+	},
+	{
+		name:               "happy path",
+		url:                "/user/reset-password/token",
+		password:           "password",
+		confirmPassword:    "password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+}
+
+func TestPostResetPassword(t *testing.T) {
+	for _, e := range postResetPasswordTests {
+		postedData := url.Values{}
+		postedData.Add("password", e.password)
+		postedData.Add("confirm_password", e.confirmPassword)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		handler := http.HandlerFunc(Handler.PostResetPassword)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var sessionIsCurrentTests = []struct {
+	name     string
+	userID   int
+	expected bool
+}{
+	{"user not found", 0, false},
+	{"sessions never ended", 1, true},
+	{"sessions ended after login", 6, false},
+}
+
+func TestSessionIsCurrent(t *testing.T) {
+	for _, e := range sessionIsCurrentTests {
+		req, _ := http.NewRequest("GET", "/", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		session.Put(req.Context(), "user_id", e.userID)
+		session.Put(req.Context(), "logged_in_at", time.Now().Add(-time.Hour))
+
+		if Handler.SessionIsCurrent(req) != e.expected {
+			t.Errorf("failed %s: expected %t, but got %t", e.name, e.expected, !e.expected)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// profileData gathers the user's account details for the profile page
+func (m *Handlers) profileData(r *http.Request, user models.User) map[string]interface{} {
+	data := make(map[string]interface{})
+	data["user"] = user
+	data["is_verified"] = m.isVerified(r, user)
+
+	apiTokens, err := m.APITokenService.GetAPITokens(user.ID)
+	if err != nil {
+		log.Println(err)
+	}
+	data["api_tokens"] = apiTokens
+	data["api_token_scopes"] = models.APITokenScopeNames
+	data["api_token_expiry_days"] = models.APITokenExpiryDays
+
+	calendarFeeds, err := m.CalendarFeedService.GetCalendarFeeds(user.ID)
+	if err != nil {
+		log.Println(err)
+	}
+	data["calendar_feeds"] = calendarFeeds
+
+	leagues, err := m.LeagueService.GetLeaguesByUser(user.ID)
+	if err != nil {
+		log.Println(err)
+	}
+	data["leagues"] = leagues
+	data["now"] = time.Now()
+	return data
+}
+
+// ShowProfile shows the logged in user's account settings
+func (m *Handlers) ShowProfile(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "profile.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: m.profileData(r, user),
+	})
+}
+
+// UpdateProfile handles request to change the logged in user's name and
+// email address. A new email address has to be verified again
+func (m *Handlers) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("first_name", "last_name", "email")
+	form.MinLength("first_name", 2)
+	form.MaxLength("first_name", 35)
+	form.MinLength("last_name", 2)
+	form.MaxLength("last_name", 35)
+	form.IsEmail("email")
+
+	updated := user
+	updated.FirstName = r.Form.Get("first_name")
+	updated.LastName = r.Form.Get("last_name")
+	updated.Email = r.Form.Get("email")
+
+	emailChanged := updated.Email != user.Email
+	if emailChanged {
+		other, err := m.UserService.GetUserByEmail(updated.Email)
+		if err == nil && other.ID != user.ID {
+			form.Errors.Add("email", "Account already exists with that email address")
+		}
+	}
+
+	if !form.Valid() {
+		render.Template(w, r, "profile.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: m.profileData(r, updated),
+		})
+		return
+	}
+
+	err = m.UserService.UpdateUser(updated)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't update profile!")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	if !emailChanged {
+		m.App.Session.Put(r.Context(), "flash", "Profile updated!")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "unverified", true)
+
+	token, err := m.UserService.RequestEmailVerification(updated)
+	if err != nil {
+		log.Println(err)
+	} else {
+		m.sendVerificationEmail(updated, token)
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "Profile updated, check your new email address to verify it")
+	http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+}
+
+// UpdatePassword handles request to change the logged in user's password. The
+// user stays logged in here but is logged out everywhere else
+func (m *Handlers) UpdatePassword(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("current_password", "new_password", "confirm_password")
+	form.MinLength("new_password", 2)
+	form.MaxLength("new_password", 35)
+	if r.Form.Get("new_password") != r.Form.Get("confirm_password") {
+		form.Errors.Add("confirm_password", "Passwords don't match")
+	}
+
+	if !form.Valid() {
+		render.Template(w, r, "profile.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: m.profileData(r, user),
+		})
+		return
+	}
+
+	err = m.UserService.ChangePassword(user.ID, r.Form.Get("current_password"), r.Form.Get("new_password"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	// changing the password ended every session, so start this one again
+	_ = m.App.Session.RenewToken(r.Context())
+	m.App.Session.Put(r.Context(), "logged_in_at", time.Now())
+
+	m.App.Session.Put(r.Context(), "flash", "Password changed, you've been logged out everywhere else")
+	http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+}
+
+// LogOutEverywhere handles request to end all of the logged in user's
+// sessions, on every device including this one
+func (m *Handlers) LogOutEverywhere(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err = m.UserService.LogOutEverywhere(user.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't log out of other devices!")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	_ = m.App.Session.Destroy(r.Context())
+	_ = m.App.Session.RenewToken(r.Context())
+	m.App.Session.Put(r.Context(), "flash", "You've been logged out of all devices")
+	http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+}
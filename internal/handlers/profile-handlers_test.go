@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var showProfileTests = []struct {
+	name               string
+	userID             int
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "verified user",
+		userID:             1,
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "unverified user",
+		userID:             7,
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowProfile(t *testing.T) {
+	for _, e := range showProfileTests {
+		req, _ := http.NewRequest("GET", "/user/profile", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowProfile)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var updateProfileTests = []struct {
+	name               string
+	userID             int
+	firstName          string
+	lastName           string
+	email              string
+	expectedStatusCode int
+	expectedLocation   string
+	expectedUnverified bool
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		firstName:          "First",
+		lastName:           "Last",
+		email:              "me@here.ca",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "short first name",
+		userID:             1,
+		firstName:          "F",
+		lastName:           "Last",
+		email:              "me@here.ca",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid email",
+		userID:             1,
+		firstName:          "First",
+		lastName:           "Last",
+		email:              "me",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "email belongs to another account",
+		userID:             1,
+		firstName:          "First",
+		lastName:           "Last",
+		email:              "taken@here.ca",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		firstName:          "error",
+		lastName:           "Last",
+		email:              "me@here.ca",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/profile",
+	},
+	{
+		name:               "missing email",
+		userID:             1,
+		firstName:          "First",
+		lastName:           "Last",
+		email:              "",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "new email",
+		userID:             1,
+		firstName:          "First",
+		lastName:           "Last",
+		email:              "me@here.ca",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/profile",
+		expectedUnverified: true,
+	},
+}
+
+func TestUpdateProfile(t *testing.T) {
+	for _, e := range updateProfileTests {
+		postedData := url.Values{}
+		postedData.Add("first_name", e.firstName)
+		postedData.Add("last_name", e.lastName)
+		postedData.Add("email", e.email)
+
+		req, _ := http.NewRequest("POST", "/user/profile", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.UpdateProfile)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+
+		if e.expectedUnverified && !session.GetBool(req.Context(), "unverified") {
+			t.Errorf("failed %s: expected the new email to need verifying", e.name)
+		}
+	}
+}
+
+var updatePasswordTests = []struct {
+	name               string
+	userID             int
+	currentPassword    string
+	newPassword        string
+	confirmPassword    string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		currentPassword:    "password",
+		newPassword:        "new password",
+		confirmPassword:    "new password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "missing current password",
+		userID:             1,
+		currentPassword:    "",
+		newPassword:        "new password",
+		confirmPassword:    "new password",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "passwords don't match",
+		userID:             1,
+		currentPassword:    "password",
+		newPassword:        "new password",
+		confirmPassword:    "new pasword",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "wrong current password",
+		userID:             1,
+		currentPassword:    "wrong",
+		newPassword:        "new password",
+		confirmPassword:    "new password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/profile",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		currentPassword:    "password",
+		newPassword:        "new password",
+		confirmPassword:    "new password",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/profile",
+	},
+}
+
+func TestUpdatePassword(t *testing.T) {
+	for _, e := range updatePasswordTests {
+		postedData := url.Values{}
+		postedData.Add("current_password", e.currentPassword)
+		postedData.Add("new_password", e.newPassword)
+		postedData.Add("confirm_password", e.confirmPassword)
+
+		req, _ := http.NewRequest("POST", "/user/profile/password", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.UpdatePassword)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var logOutEverywhereTests = []struct {
+	name             string
+	userID           int
+	expectedLocation string
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "service error",
+		userID:           3,
+		expectedLocation: "/user/profile",
+	},
+	{
+		name:             "happy path",
+		userID:           1,
+		expectedLocation: "/user/login",
+	},
+}
+
+func TestLogOutEverywhere(t *testing.T) {
+	for _, e := range logOutEverywhereTests {
+		req, _ := http.NewRequest("POST", "/user/profile/logout-everywhere", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.LogOutEverywhere)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+
+		if e.expectedLocation == "/user/login" && e.userID != 0 && session.Exists(req.Context(), "user_id") {
+			t.Errorf("failed %s: expected to be logged out, but wasn't", e.name)
+		}
+	}
+}
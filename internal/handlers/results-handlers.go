@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/matchplay"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// maxSkinsPot is the largest skins pot in dollars
+const maxSkinsPot = 10000
+
+func weekResultsURL(leagueID, weekID int) string {
+	return fmt.Sprintf("/leagues/%d/schedule/weeks/%d/results", leagueID, weekID)
+}
+
+// weekMatch is a matchup of a match play week as it stands from the rounds
+// its players have posted. Result is only set once both have posted
+type weekMatch struct {
+	Matchup models.Matchup
+	Posted  bool
+	Result  models.MatchResult
+}
+
+// weekMatches plays out a week's matchups when the week is match play, with
+// the league's handicap allowance and points
+func weekMatches(league models.League, week models.ScheduleWeek, rounds []models.Round) []weekMatch {
+	if week.ScoringFormat(league) != models.FormatMatchPlay {
+		return nil
+	}
+
+	byPlayer := make(map[int]models.Round)
+	for _, r := range rounds {
+		byPlayer[r.PlayerID] = r
+	}
+
+	var matches []weekMatch
+	for _, m := range week.Matchups {
+		if m.IsBye() {
+			continue
+		}
+		match := weekMatch{Matchup: m}
+		home, homePosted := byPlayer[m.HomePlayerID]
+		away, awayPosted := byPlayer[m.AwayPlayerID]
+		if homePosted && awayPosted {
+			match.Posted = true
+			match.Result = matchplay.Play(home.TeeSet.Holes, home.HoleScores, away.HoleScores, home.CourseHandicap, away.CourseHandicap, matchplay.LeagueOptions(league))
+		}
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+// weekResultsData gathers the rounds posted during a week, its match play
+// results and the week's skins game for the weekly results page
+func (m *Handlers) weekResultsData(league models.League, season models.Season, week models.ScheduleWeek, game models.SkinsGame, players []models.Player) (map[string]interface{}, error) {
+	rounds, err := m.SkinsService.GetWeekRounds(week)
+	if err != nil {
+		return nil, err
+	}
+
+	var active []models.Player
+	for _, p := range players {
+		if p.IsActive {
+			active = append(active, p)
+		}
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["season"] = season
+	data["week"] = week
+	data["rounds"] = rounds
+	data["matches"] = weekMatches(league, week, rounds)
+	data["players"] = active
+	data["game"] = game
+	data["pot"] = strconv.FormatFloat(float64(game.Pot)/100, 'f', 2, 64)
+	data["validations"] = models.SkinsValidations
+	data["validation_names"] = models.SkinsValidationNames
+	data["has_skins"] = len(game.PlayerIDs) > 0
+	data["skins"] = m.SkinsService.GetSkinsResult(game, rounds)
+	return data, nil
+}
+
+// WeekResults shows the rounds posted during a week of the schedule, how its
+// matches stand in a match play week and who won the week's skins
+func (m *Handlers) WeekResults(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not in this league!")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	week, season, err := m.scheduleWeekInLeague(r, league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find schedule week")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/schedule", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	if !week.IsPublished && !membership.CanKeepScore() {
+		m.App.Session.Put(r.Context(), "error", "this week hasn't been published yet")
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	game, err := m.SkinsService.GetSkinsGame(week.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get skins for week")
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	data, err := m.weekResultsData(league, season, week, game, players)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get rounds for week")
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+	data["can_keep_score"] = membership.CanKeepScore()
+
+	render.Template(w, r, "week-results.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// UpdateSkinsGame handles request to set up a week's skins game with the
+// checked players, the pot and the game's rules
+func (m *Handlers) UpdateSkinsGame(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanKeepScore() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner or scorekeeper to set up skins!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	week, season, err := m.scheduleWeekInLeague(r, league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find schedule week")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/schedule", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, weekResultsURL(league.ID, week.ID), http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("pot")
+
+	game := models.SkinsGame{
+		ScheduleWeekID: week.ID,
+		Net:            form.Has("net"),
+		Carryovers:     form.Has("carryovers"),
+		Validation:     r.Form.Get("validation"),
+	}
+
+	if form.FloatBetween("pot", 0, maxSkinsPot) {
+		pot, _ := strconv.ParseFloat(strings.TrimSpace(r.Form.Get("pot")), 64)
+		game.Pot = int(math.Round(pot * 100))
+	}
+
+	if !models.IsSkinsValidation(game.Validation) {
+		form.Errors.Add("validation", "Choose one of the validation rules")
+	}
+
+	for _, value := range r.PostForm["player_id"] {
+		playerID, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		game.PlayerIDs = append(game.PlayerIDs, playerID)
+	}
+	if len(game.PlayerIDs) < 2 {
+		form.Errors.Add("player_id", "Enter at least two players")
+	}
+
+	if !form.Valid() {
+		data, err := m.weekResultsData(league, season, week, game, players)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot get rounds for week")
+			http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+			return
+		}
+		data["can_keep_score"] = true
+		data["pot"] = r.Form.Get("pot")
+		// the entries haven't been saved, so there are no skins to show yet
+		data["has_skins"] = false
+
+		render.Template(w, r, "week-results.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	err = m.SkinsService.SaveSkinsGame(week, game, players)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, weekResultsURL(league.ID, week.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "skins updated!")
+	http.Redirect(w, r, weekResultsURL(league.ID, week.ID), http.StatusSeeOther)
+}
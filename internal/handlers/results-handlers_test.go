@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var weekResultsTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/schedule/weeks/2/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/schedule/weeks/2/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not in league",
+		userID:             4,
+		url:                "/leagues/4/schedule/weeks/2/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/schedule/weeks/2/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing week",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/3/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "unpublished week as player",
+		userID:             3,
+		url:                "/leagues/1/schedule/weeks/1/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "skins error",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/7/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "rounds error",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/8/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "unpublished week as commissioner",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/1/results",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "unpublished week as scorekeeper",
+		userID:             13,
+		url:                "/leagues/1/schedule/weeks/1/results",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "unpublished week as read-only member",
+		userID:             14,
+		url:                "/leagues/1/schedule/weeks/1/results",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success as player",
+		userID:             3,
+		url:                "/leagues/1/schedule/weeks/2/results",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestWeekResults(t *testing.T) {
+	for _, e := range weekResultsTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.WeekResults)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+func TestWeekResultsMatchPlay(t *testing.T) {
+	url := "/leagues/1/schedule/weeks/9/results"
+	req, _ := http.NewRequest("GET", url, nil)
+	ctx := getCtx(req)
+	req = req.WithContext(ctx)
+	req.RequestURI = url
+
+	session.Put(req.Context(), "user_id", 3)
+
+	rr := httptest.NewRecorder()
+	handler := http.HandlerFunc(Handler.WeekResults)
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("match play week returned wrong response code: got %d, wanted %d", rr.Code, http.StatusOK)
+	}
+
+	// each player birdies one hole, so the match is halved
+	if !strings.Contains(rr.Body.String(), "Halved") {
+		t.Error("expected the halved match on the results page")
+	}
+}
+
+var updateSkinsGameTests = []struct {
+	name               string
+	userID             int
+	url                string
+	pot                string
+	validation         string
+	playerIDs          []string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "20",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "20",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "user is read-only member",
+		userID:             14,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "20",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/schedule/weeks/2/skins",
+		pot:                "20",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "non-existing week",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/3/skins",
+		pot:                "20",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule",
+	},
+	{
+		name:               "invalid pot",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "lots",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "unknown validation",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "20",
+		validation:         "birdies",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "one player",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "20",
+		playerIDs:          []string{"1", "x"},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid form and rounds error",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/8/skins",
+		pot:                "",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule?season_id=1",
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "999",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule/weeks/2/results",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "20.50",
+		validation:         "next_hole",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule/weeks/2/results",
+	},
+	{
+		name:               "happy path as scorekeeper",
+		userID:             13,
+		url:                "/leagues/1/schedule/weeks/2/skins",
+		pot:                "20.50",
+		validation:         "next_hole",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule/weeks/2/results",
+	},
+}
+
+func TestUpdateSkinsGame(t *testing.T) {
+	for _, e := range updateSkinsGameTests {
+		postedData := url.Values{}
+		postedData.Add("pot", e.pot)
+		postedData.Add("validation", e.validation)
+		postedData.Add("net", "1")
+		postedData.Add("carryovers", "1")
+		for _, id := range e.playerIDs {
+			postedData.Add("player_id", id)
+		}
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.UpdateSkinsGame)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// rosterMaxBytes is the biggest roster file that can be uploaded
+const rosterMaxBytes = 1 << 20
+
+// rosterFields are the columns of a roster file, in order, with the names
+// used in their error messages
+var rosterFields = []struct {
+	Name  string
+	Label string
+}{
+	{"first_name", "First name"},
+	{"last_name", "Last name"},
+	{"email", "Email"},
+	{"handicap", "Handicap"},
+}
+
+// parseRoster reads the players in a roster file and checks each of them with
+// the same rules as adding one player. Each line has a first name, last name,
+// email and optionally a starting handicap, and a header line is skipped.
+// Players with something wrong are returned with their errors so they can be
+// shown with the rest
+func parseRoster(r io.Reader) ([]models.RosterRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []models.RosterRow
+	seen := make(map[string]int)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New("the file isn't a CSV file")
+		}
+
+		if line == 1 {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+			if isRosterHeader(record) {
+				continue
+			}
+		}
+
+		row := models.RosterRow{Line: line}
+		if len(record) < 3 || len(record) > 4 {
+			row.Errors = append(row.Errors, "Expected a first name, last name, email and optional handicap")
+			rows = append(rows, row)
+			continue
+		}
+
+		form := forms.New(url.Values{})
+		for i, value := range record {
+			form.Set(rosterFields[i].Name, strings.TrimSpace(value))
+		}
+		validatePlayerForm(form)
+		if form.Has("handicap") && form.IntBetween("handicap", models.MinStartingHandicap, models.MaxStartingHandicap) {
+			row.Handicap, _ = strconv.Atoi(form.Get("handicap"))
+			row.HasHandicap = true
+		}
+
+		row.User = models.User{
+			FirstName:   form.Get("first_name"),
+			LastName:    form.Get("last_name"),
+			Email:       form.Get("email"),
+			AccessLevel: models.AccessLevelPlayer,
+		}
+		for _, field := range rosterFields {
+			for _, message := range form.Errors[field.Name] {
+				row.Errors = append(row.Errors, field.Label+": "+message)
+			}
+		}
+
+		email := strings.ToLower(row.User.Email)
+		if first, ok := seen[email]; ok && email != "" {
+			row.Errors = append(row.Errors, fmt.Sprintf("Email: Also on line %d", first))
+		} else {
+			seen[email] = line
+		}
+
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("the file has no players")
+	}
+	if len(rows) > models.RosterImportMaxRows {
+		return nil, fmt.Errorf("the file can't have more than %d players", models.RosterImportMaxRows)
+	}
+
+	return rows, nil
+}
+
+// isRosterHeader reports whether a line of a roster file names its columns
+func isRosterHeader(record []string) bool {
+	name := strings.ToLower(strings.TrimSpace(record[0]))
+	name = strings.NewReplacer(" ", "", "_", "").Replace(name)
+	return name == "firstname"
+}
+
+// findRosterUsers marks the players in a roster who already have an account,
+// and rejects any already in the league. It fails if the league's players
+// can't be looked up
+func (m *Handlers) findRosterUsers(leagueID int, rows []models.RosterRow) error {
+	players, err := m.PlayerService.GetPlayersInLeague(leagueID)
+	if err != nil {
+		return err
+	}
+	inLeague := make(map[int]bool)
+	for _, p := range players {
+		if p.IsActive {
+			inLeague[p.UserID] = true
+		}
+	}
+
+	for i, row := range rows {
+		if !row.IsValid() {
+			continue
+		}
+
+		user, err := m.UserService.GetUserByEmail(row.User.Email)
+		if err != nil {
+			continue
+		}
+		rows[i].ExistingUser = true
+		rows[i].User.ID = user.ID
+		if inLeague[user.ID] {
+			rows[i].Errors = append(rows[i].Errors, "Email: This player is already in the league")
+		}
+	}
+
+	return nil
+}
+
+// invalidRosterRows counts the players in a roster that can't be imported
+func invalidRosterRows(rows []models.RosterRow) int {
+	invalid := 0
+	for _, row := range rows {
+		if !row.IsValid() {
+			invalid++
+		}
+	}
+	return invalid
+}
+
+// rosterData returns the template data for uploading and previewing a roster
+func rosterData(league models.League, roster string, rows []models.RosterRow) map[string]interface{} {
+	existing := 0
+	for _, row := range rows {
+		if row.IsValid() && row.ExistingUser {
+			existing++
+		}
+	}
+	invalid := invalidRosterRows(rows)
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["roster"] = roster
+	data["rows"] = rows
+	data["invalid"] = invalid
+	data["existing"] = existing
+	data["new"] = len(rows) - invalid - existing
+	data["max_rows"] = models.RosterImportMaxRows
+	return data
+}
+
+// rosterLeague returns the league in the URI after checking the user can add
+// players to it. It redirects and returns false if not
+func (m *Handlers) rosterLeague(w http.ResponseWriter, r *http.Request) (models.League, bool) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to add players!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	return league, true
+}
+
+// ShowImportPlayersForm renders the page where the commissioner uploads a
+// roster file to add many players at once
+func (m *Handlers) ShowImportPlayersForm(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.rosterLeague(w, r)
+	if !ok {
+		return
+	}
+
+	render.Template(w, r, "import-players.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: rosterData(league, "", nil),
+	})
+}
+
+// PreviewImportPlayers handles an uploaded roster file and shows each player
+// in it with anything that has to be fixed before it can be imported
+func (m *Handlers) PreviewImportPlayers(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.rosterLeague(w, r)
+	if !ok {
+		return
+	}
+
+	form := forms.New(nil)
+	roster, err := uploadedRoster(r)
+	var rows []models.RosterRow
+	if err == nil {
+		rows, err = parseRoster(strings.NewReader(roster))
+	}
+	if err != nil {
+		form.Errors.Add("roster", "Can't import this file: "+err.Error())
+		render.Template(w, r, "import-players.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: rosterData(league, "", nil),
+		})
+		return
+	}
+
+	err = m.findRosterUsers(league.ID, rows)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "import-players.page.tmpl", &models.TemplateData{
+		Form: form,
+		Data: rosterData(league, roster, rows),
+	})
+}
+
+// ImportPlayers handles request to add every player in a previewed roster to
+// the league. The roster is checked again, and if anything is wrong with it
+// nobody is added
+func (m *Handlers) ImportPlayers(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.rosterLeague(w, r)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	roster := r.Form.Get("roster")
+	rows, err := parseRoster(strings.NewReader(roster))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't import roster: "+err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/import-players", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.findRosterUsers(league.ID, rows)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	if invalidRosterRows(rows) > 0 {
+		m.App.Session.Put(r.Context(), "error", "fix the players below before importing the roster")
+		render.Template(w, r, "import-players.page.tmpl", &models.TemplateData{
+			Form: forms.New(nil),
+			Data: rosterData(league, roster, rows),
+		})
+		return
+	}
+
+	result, err := m.LeagueService.ImportRoster(league.ID, rows)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "no players were imported: "+err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/import-players", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	for _, row := range result.Rows {
+		if !row.ExistingUser {
+			m.sendInvitation(league, row.User, row.InvitationToken)
+		}
+		m.publishWebhookEvent(league.ID, models.WebhookEventPlayerAdded, webhookPlayer{
+			UserID:    row.User.ID,
+			FirstName: row.User.FirstName,
+			LastName:  row.User.LastName,
+		})
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["result"] = result
+
+	m.App.Session.Put(r.Context(), "flash", "roster imported!")
+	render.Template(w, r, "import-players-summary.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// uploadedRoster returns the contents of the roster file in a request
+func uploadedRoster(r *http.Request) (string, error) {
+	err := r.ParseMultipartForm(rosterMaxBytes)
+	if err != nil {
+		return "", errors.New("choose a CSV file to upload")
+	}
+
+	file, _, err := r.FormFile("roster")
+	if err != nil {
+		return "", errors.New("choose a CSV file to upload")
+	}
+	defer file.Close()
+
+	contents, err := ioutil.ReadAll(io.LimitReader(file, rosterMaxBytes+1))
+	if err != nil {
+		return "", errors.New("the file can't be read")
+	}
+	if len(contents) > rosterMaxBytes {
+		return "", errors.New("the file is too big")
+	}
+
+	return string(contents), nil
+}
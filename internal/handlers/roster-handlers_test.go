@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var parseRosterTests = []struct {
+	name            string
+	roster          string
+	expectedRows    int
+	expectedInvalid int
+	expectError     bool
+}{
+	{"empty file", "", 0, 0, true},
+	{"only a header", "First Name,Last Name,Email\n", 0, 0, true},
+	{"not a csv file", "Jane,\"Smith,jane@example.com\n", 0, 0, true},
+	{"too many players", strings.Repeat("Jane,Smith,jane@example.com\n", 201), 0, 0, true},
+	{"valid", "first_name,last_name,email,handicap\nJane,Smith,jane@example.com,12\nJohn,Doe,john@example.com\n", 2, 0, false},
+	{"byte order mark", "\ufeffFirst Name,Last Name,Email\nJane,Smith,jane@example.com\n", 1, 0, false},
+	{"wrong number of columns", "Jane,Smith\n", 1, 1, false},
+	{"invalid email", "Jane,Smith,jane\n", 1, 1, false},
+	{"short name", "J,Smith,jane@example.com\n", 1, 1, false},
+	{"invalid handicap", "Jane,Smith,jane@example.com,scratch\n", 1, 1, false},
+	{"handicap too high", "Jane,Smith,jane@example.com,60\n", 1, 1, false},
+	{"duplicate email", "Jane,Smith,jane@example.com\nJanet,Smith,JANE@example.com\n", 2, 1, false},
+}
+
+func TestParseRoster(t *testing.T) {
+	for _, e := range parseRosterTests {
+		rows, err := parseRoster(strings.NewReader(e.roster))
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if len(rows) != e.expectedRows {
+			t.Errorf("failed %s: expected %d rows, but got %d", e.name, e.expectedRows, len(rows))
+		}
+		if invalidRosterRows(rows) != e.expectedInvalid {
+			t.Errorf("failed %s: expected %d invalid rows, but got %d", e.name, e.expectedInvalid, invalidRosterRows(rows))
+		}
+	}
+}
+
+func TestParseRosterHandicap(t *testing.T) {
+	rows, _ := parseRoster(strings.NewReader("Jane,Smith,jane@example.com, -2\nJohn,Doe,john@example.com\n"))
+	if !rows[0].HasHandicap || rows[0].Handicap != -2 {
+		t.Errorf("failed handicap: expected -2, but got %d", rows[0].Handicap)
+	}
+	if rows[1].HasHandicap {
+		t.Error("failed no handicap: expected no handicap, but got one")
+	}
+}
+
+var showImportPlayersFormTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"user not found", 0, "/leagues/1/import-players", http.StatusSeeOther, "/user/login"},
+	{"bad url parameter", 1, "/leagues/s/import-players", http.StatusSeeOther, "/"},
+	{"not commissioner", 3, "/leagues/1/import-players", http.StatusSeeOther, "/leagues/1"},
+	{"league error", 1, "/leagues/3/import-players", http.StatusSeeOther, "/"},
+	{"valid", 1, "/leagues/1/import-players", http.StatusOK, ""},
+}
+
+func TestShowImportPlayersForm(t *testing.T) {
+	for _, e := range showImportPlayersFormTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.ShowImportPlayersForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var previewImportPlayersTests = []struct {
+	name               string
+	url                string
+	roster             string
+	upload             bool
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"no file", "/leagues/1/import-players/preview", "", false, http.StatusOK, ""},
+	{"empty file", "/leagues/1/import-players/preview", "", true, http.StatusOK, ""},
+	{"player error", "/leagues/2/import-players/preview", "Jane,Smith,jane@example.com\n", true, http.StatusSeeOther, "/leagues/2"},
+	{"invalid rows", "/leagues/1/import-players/preview", "Jane,Smith,jane\n", true, http.StatusOK, ""},
+	{"valid", "/leagues/1/import-players/preview", "Jane,Smith,jane@example.com\nMe,Here,me@here.ca\n", true, http.StatusOK, ""},
+}
+
+func TestPreviewImportPlayers(t *testing.T) {
+	for _, e := range previewImportPlayersTests {
+		body := new(bytes.Buffer)
+		writer := multipart.NewWriter(body)
+		if e.upload {
+			part, _ := writer.CreateFormFile("roster", "roster.csv")
+			part.Write([]byte(e.roster))
+		}
+		writer.Close()
+
+		req, _ := http.NewRequest("POST", e.url, body)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", 1)
+
+		handler := http.HandlerFunc(Handler.PreviewImportPlayers)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var importPlayersTests = []struct {
+	name               string
+	url                string
+	roster             string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"unreadable roster", "/leagues/1/import-players", "", http.StatusSeeOther, "/leagues/1/import-players"},
+	{"player error", "/leagues/2/import-players", "Jane,Smith,jane@example.com\n", http.StatusSeeOther, "/leagues/2"},
+	{"invalid rows", "/leagues/1/import-players", "Jane,Smith,jane\n", http.StatusOK, ""},
+	{"service error", "/leagues/5/import-players", "Jane,Smith,jane@example.com\n", http.StatusSeeOther, "/leagues/5/import-players"},
+	{"valid", "/leagues/1/import-players", "Jane,Smith,jane@example.com,12\nMe,Here,me@here.ca\n", http.StatusOK, ""},
+}
+
+func TestImportPlayers(t *testing.T) {
+	for _, e := range importPlayersTests {
+		postedData := url.Values{}
+		postedData.Add("roster", e.roster)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", 1)
+
+		handler := http.HandlerFunc(Handler.ImportPlayers)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
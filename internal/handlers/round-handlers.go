@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// ShowRoundForm renders the post a round page. A course is chosen first,
+// then the tees, date and hole by hole scores are entered for that course
+func (m *Handlers) ShowRoundForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	player, err := m.PlayerService.GetPlayerInLeague(userID, leagueID)
+	if err != nil || !player.IsActive {
+		m.App.Session.Put(r.Context(), "error", "you must be an active player in this league to post a round!")
+		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanPostRounds() {
+		m.App.Session.Put(r.Context(), "error", "read-only members can't post rounds!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.SeasonService.GetActiveSeason(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "rounds can only be posted while the league has an active season!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["season"] = season
+
+	if r.URL.Query().Get("course_id") == "" {
+		courses, err := m.CourseService.GetCourses()
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot get courses")
+			http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+			return
+		}
+		data["courses"] = courses
+	} else {
+		courseID, _ := strconv.Atoi(r.URL.Query().Get("course_id"))
+		course, err := m.CourseService.GetCourse(courseID)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot find course")
+			http.Redirect(w, r, fmt.Sprintf("/leagues/%d/rounds/new", leagueID), http.StatusSeeOther)
+			return
+		}
+		data["course"] = course
+	}
+
+	render.Template(w, r, "post-round.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// PostRound handles request to post a round's hole by hole scores
+func (m *Handlers) PostRound(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	player, err := m.PlayerService.GetPlayerInLeague(userID, leagueID)
+	if err != nil || !player.IsActive {
+		m.App.Session.Put(r.Context(), "error", "you must be an active player in this league to post a round!")
+		http.Redirect(w, r, "/leagues", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanPostRounds() {
+		m.App.Session.Put(r.Context(), "error", "read-only members can't post rounds!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.SeasonService.GetActiveSeason(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "rounds can only be posted while the league has an active season!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	courseID, _ := strconv.Atoi(r.Form.Get("course_id"))
+	course, err := m.CourseService.GetCourse(courseID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find course")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/rounds/new", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	form, round, teeSet := m.validateRound(r.PostForm, course, season)
+	round.LeagueID = league.ID
+	round.SeasonID = season.ID
+	round.PlayerID = player.ID
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["league"] = league
+		data["season"] = season
+		data["course"] = course
+		data["round"] = round
+
+		render.Template(w, r, "post-round.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	// the course handicap is fixed when the round is posted so later net
+	// scores use the index the player held when they played
+	courseHandicap, hasIndex, err := m.HandicapService.GetCourseHandicap(userID, teeSet)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot calculate handicap")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+	if !hasIndex {
+		courseHandicap = player.Handicap
+	}
+	round.CourseHandicap = courseHandicap
+
+	roundID, err := m.ScoreService.PostRound(round)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't insert round into database!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	// send the round as it was saved, with its scores totalled
+	posted, err := m.ScoreService.GetRound(roundID)
+	if err != nil {
+		log.Println(err)
+	} else {
+		m.publishWebhookEvent(league.ID, models.WebhookEventRoundPosted, newAPIRound(posted))
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "round posted!")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+}
+
+// validateRound checks a posted round's date, tees and hole by hole strokes
+// against its course and the season it's posted in, and returns the round
+// they make up along with its tees. The round form and the API both post
+// rounds through it
+func (m *Handlers) validateRound(values url.Values, course models.Course, season models.Season) (*forms.Form, models.Round, models.TeeSet) {
+	form := forms.New(values)
+	form.Required("tee_set_id", "played_on")
+	if form.IsDate("played_on") {
+		playedOn, _ := time.Parse("2006-01-02", values.Get("played_on"))
+		if playedOn.After(time.Now()) {
+			form.Errors.Add("played_on", "A round can't be posted for a future date")
+		} else if !season.Includes(playedOn) {
+			form.Errors.Add("played_on", fmt.Sprintf("The round must be played during the %s season", season.Name))
+		}
+	}
+
+	teeSetID, _ := strconv.Atoi(values.Get("tee_set_id"))
+	teeSet, err := m.CourseService.GetTeeSet(teeSetID)
+	if err != nil || teeSet.CourseID != course.ID {
+		form.Errors.Add("tee_set_id", "Choose the tees you played at this course")
+	}
+
+	playedOn, _ := time.Parse("2006-01-02", values.Get("played_on"))
+	round := models.Round{
+		TeeSetID: teeSetID,
+		PlayedOn: playedOn,
+	}
+
+	for number := 1; number <= course.NumberOfHoles; number++ {
+		field := fmt.Sprintf("strokes_%d", number)
+		form.Required(field)
+		form.IntBetween(field, 1, 15)
+
+		strokes, _ := strconv.Atoi(values.Get(field))
+		round.HoleScores = append(round.HoleScores, models.HoleScore{
+			HoleNumber: number,
+			Strokes:    strokes,
+		})
+	}
+
+	return form, round, teeSet
+}
@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var showRoundFormTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/rounds/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/rounds/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not in league",
+		userID:             4,
+		url:                "/leagues/4/rounds/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "player inactive in league",
+		userID:             2,
+		url:                "/leagues/1/rounds/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user is read-only member",
+		userID:             14,
+		url:                "/leagues/1/rounds/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/rounds/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "no active season",
+		userID:             1,
+		url:                "/leagues/6/rounds/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "choose a course",
+		userID:             1,
+		url:                "/leagues/1/rounds/new",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "non-existing course",
+		userID:             1,
+		url:                "/leagues/1/rounds/new?course_id=3",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "enter scores",
+		userID:             1,
+		url:                "/leagues/1/rounds/new?course_id=1",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowRoundForm(t *testing.T) {
+	for _, e := range showRoundFormTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowRoundForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+// roundPostData returns posted values for a round on the 9 hole test course
+func roundPostData(courseID, teeSetID string) url.Values {
+	postedData := url.Values{}
+	postedData.Add("course_id", courseID)
+	postedData.Add("tee_set_id", teeSetID)
+	postedData.Add("played_on", "2024-04-08")
+	for i := 1; i <= 9; i++ {
+		postedData.Add(fmt.Sprintf("strokes_%d", i), "5")
+	}
+	return postedData
+}
+
+var postRoundTests = []struct {
+	name               string
+	userID             int
+	leagueID           int
+	postedData         func() url.Values
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		leagueID:           1,
+		postedData:         func() url.Values { return roundPostData("1", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "invalid url param",
+		userID:             1,
+		leagueID:           0,
+		postedData:         func() url.Values { return roundPostData("1", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "player inactive in league",
+		userID:             2,
+		leagueID:           1,
+		postedData:         func() url.Values { return roundPostData("1", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues",
+	},
+	{
+		name:               "user is read-only member",
+		userID:             14,
+		leagueID:           1,
+		postedData:         func() url.Values { return roundPostData("1", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "league doesn't exist",
+		userID:             1,
+		leagueID:           3,
+		postedData:         func() url.Values { return roundPostData("1", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "no active season",
+		userID:             1,
+		leagueID:           6,
+		postedData:         func() url.Values { return roundPostData("1", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/6",
+	},
+	{
+		name:               "course doesn't exist",
+		userID:             1,
+		leagueID:           1,
+		postedData:         func() url.Values { return roundPostData("3", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/rounds/new",
+	},
+	{
+		name:     "invalid date",
+		userID:   1,
+		leagueID: 1,
+		postedData: func() url.Values {
+			postedData := roundPostData("1", "1")
+			postedData.Set("played_on", "yesterday")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:     "future date",
+		userID:   1,
+		leagueID: 1,
+		postedData: func() url.Values {
+			postedData := roundPostData("1", "1")
+			postedData.Set("played_on", "2999-01-01")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:     "date outside season",
+		userID:   1,
+		leagueID: 1,
+		postedData: func() url.Values {
+			postedData := roundPostData("1", "1")
+			postedData.Set("played_on", "2023-12-01")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "tees not at course",
+		userID:             1,
+		leagueID:           1,
+		postedData:         func() url.Values { return roundPostData("2", "1") },
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:     "hole not filled",
+		userID:   1,
+		leagueID: 1,
+		postedData: func() url.Values {
+			postedData := roundPostData("1", "1")
+			postedData.Del("strokes_9")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:     "strokes out of range",
+		userID:   1,
+		leagueID: 1,
+		postedData: func() url.Values {
+			postedData := roundPostData("1", "1")
+			postedData.Set("strokes_4", "16")
+			return postedData
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "error inserting round",
+		userID:             1,
+		leagueID:           1,
+		postedData:         func() url.Values { return roundPostData("1", "5") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		leagueID:           1,
+		postedData:         func() url.Values { return roundPostData("1", "1") },
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+}
+
+func TestPostRound(t *testing.T) {
+	for _, e := range postRoundTests {
+		URI := fmt.Sprintf("/leagues/%d/rounds", e.leagueID)
+		if e.leagueID == 0 {
+			URI = "/leagues/s/rounds"
+		}
+
+		req, _ := http.NewRequest("POST", URI, strings.NewReader(e.postedData().Encode()))
+		req.RequestURI = URI
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.PostRound)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,609 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const weekIDIndex = 5
+
+func getWeekIDFromURI(URI string) (int, error) {
+	return getIDFromURI(URI, weekIDIndex)
+}
+
+// scheduleSeason returns the season chosen with the season_id query parameter,
+// or the league's active season when none is chosen
+func (m *Handlers) scheduleSeason(r *http.Request, leagueID int) (models.Season, error) {
+	if r.URL.Query().Get("season_id") == "" {
+		return m.SeasonService.GetActiveSeason(leagueID)
+	}
+
+	seasonID, err := strconv.Atoi(r.URL.Query().Get("season_id"))
+	if err != nil {
+		return models.Season{}, err
+	}
+
+	season, err := m.SeasonService.GetSeason(seasonID)
+	if err != nil {
+		return season, err
+	}
+	if season.LeagueID != leagueID {
+		return models.Season{}, errors.New("season not in league")
+	}
+	return season, nil
+}
+
+func scheduleURL(leagueID, seasonID int) string {
+	return fmt.Sprintf("/leagues/%d/schedule?season_id=%d", leagueID, seasonID)
+}
+
+// scheduleCourses returns the courses a week can be played at for the schedule
+// forms, which still work without them
+func (m *Handlers) scheduleCourses() []models.Course {
+	courses, err := m.CourseService.GetCourses()
+	if err != nil {
+		log.Println(err)
+	}
+	return courses
+}
+
+// scheduleWeekDetails reads the course and tee time posted from a schedule
+// form. Both are optional, and errors are added to the form for ones that
+// aren't valid
+func (m *Handlers) scheduleWeekDetails(form *forms.Form) (int, string) {
+	courseID := 0
+	if form.Has("course_id") {
+		id, err := strconv.Atoi(form.Get("course_id"))
+		if err == nil {
+			_, err = m.CourseService.GetCourse(id)
+		}
+		if err != nil {
+			form.Errors.Add("course_id", "Choose one of the courses")
+		} else {
+			courseID = id
+		}
+	}
+
+	if form.Has("tee_time") {
+		form.IsTime("tee_time")
+	}
+
+	return courseID, form.Get("tee_time")
+}
+
+// Schedule shows a season's schedule. Players only see published weeks while
+// the commissioner also sees the draft they can edit, regenerate and publish
+func (m *Handlers) Schedule(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not in this league!")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	seasons, err := m.SeasonService.GetSeasonsInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get seasons for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	season, hasSeason := m.selectedSeason(r, league.ID, seasons)
+	if !hasSeason {
+		m.App.Session.Put(r.Context(), "error", "league doesn't have a season to schedule yet")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	weeks, err := m.ScheduleService.GetSchedule(season.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get schedule for season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	isPublished := false
+	var published []models.ScheduleWeek
+	for _, week := range weeks {
+		if week.IsPublished {
+			isPublished = true
+			published = append(published, week)
+		}
+	}
+	if !membership.CanManageLeague() {
+		weeks = published
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["seasons"] = seasons
+	data["season"] = season
+	data["weeks"] = weeks
+	data["is_published"] = isPublished
+	data["can_manage"] = membership.CanManageLeague()
+
+	render.Template(w, r, "schedule.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// ShowGenerateScheduleForm renders the generate a schedule page and displays form
+func (m *Handlers) ShowGenerateScheduleForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to generate a schedule!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.scheduleSeason(r, league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["season"] = season
+	data["options"] = models.ScheduleOptions{
+		StartDate: season.StartDate,
+		EndDate:   season.EndDate,
+	}
+	data["courses"] = m.scheduleCourses()
+
+	render.Template(w, r, "generate-schedule.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// GenerateSchedule handles request to replace a season's draft schedule with a
+// round-robin of the league's active players
+func (m *Handlers) GenerateSchedule(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to generate a schedule!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.scheduleSeason(r, league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("start_date", "end_date")
+	validDates := form.IsDate("start_date")
+	validDates = form.IsDate("end_date") && validDates
+
+	startDate, _ := time.Parse("2006-01-02", r.Form.Get("start_date"))
+	endDate, _ := time.Parse("2006-01-02", r.Form.Get("end_date"))
+	if validDates {
+		if endDate.Before(startDate) {
+			form.Errors.Add("end_date", "The schedule must end after it starts")
+		}
+		if !season.Includes(startDate) {
+			form.Errors.Add("start_date", "The schedule must start during the season")
+		}
+		if !season.Includes(endDate) {
+			form.Errors.Add("end_date", "The schedule must end during the season")
+		}
+	}
+
+	courseID, teeTime := m.scheduleWeekDetails(form)
+
+	options := models.ScheduleOptions{
+		StartDate:      startDate,
+		EndDate:        endDate,
+		AlternateNines: r.Form.Get("alternate_nines") != "",
+		CourseID:       courseID,
+		TeeTime:        teeTime,
+	}
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["league"] = league
+		data["season"] = season
+		data["options"] = options
+		data["courses"] = m.scheduleCourses()
+
+		render.Template(w, r, "generate-schedule.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	err = m.ScheduleService.GenerateSchedule(season, players, options)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "schedule generated! Review the weeks and publish it when you're ready")
+	http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+}
+
+// PublishSchedule handles request to make a season's schedule visible to the
+// league's players
+func (m *Handlers) PublishSchedule(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to publish a schedule!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.scheduleSeason(r, leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.ScheduleService.PublishSchedule(season.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, scheduleURL(leagueID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "schedule published!")
+	http.Redirect(w, r, scheduleURL(leagueID, season.ID), http.StatusSeeOther)
+}
+
+// scheduleWeekInLeague returns the week in the URI after checking it belongs to
+// one of the league's seasons
+func (m *Handlers) scheduleWeekInLeague(r *http.Request, leagueID int) (models.ScheduleWeek, models.Season, error) {
+	weekID, err := getWeekIDFromURI(r.RequestURI)
+	if err != nil {
+		return models.ScheduleWeek{}, models.Season{}, err
+	}
+
+	week, err := m.ScheduleService.GetScheduleWeek(weekID)
+	if err != nil {
+		return week, models.Season{}, err
+	}
+
+	season, err := m.SeasonService.GetSeason(week.SeasonID)
+	if err != nil {
+		return week, season, err
+	}
+	if season.LeagueID != leagueID {
+		return week, season, errors.New("week not in league")
+	}
+
+	return week, season, nil
+}
+
+// ShowEditScheduleWeekForm renders the edit week page so the commissioner can
+// change a week's matchups before the schedule is published
+func (m *Handlers) ShowEditScheduleWeekForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to edit the schedule!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	week, season, err := m.scheduleWeekInLeague(r, league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find schedule week")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/schedule", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	if week.IsPublished {
+		m.App.Session.Put(r.Context(), "error", "a published week can't be changed")
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, scheduleURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	// leave room for every active player to be moved into a new matchup
+	active := 0
+	for _, p := range players {
+		if p.IsActive {
+			active++
+		}
+	}
+	matchups := week.Matchups
+	for len(matchups) < (active+1)/2 {
+		matchups = append(matchups, models.Matchup{})
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["season"] = season
+	data["week"] = week
+	data["matchups"] = matchups
+	data["players"] = players
+	data["formats"] = models.ScoringFormats
+	data["courses"] = m.scheduleCourses()
+
+	render.Template(w, r, "edit-schedule-week.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// parseMatchups reads the home_N and away_N player IDs posted from the edit
+// week form. Empty rows are skipped and a row with one player is a bye
+func parseMatchups(form url.Values) ([]models.Matchup, error) {
+	var matchups []models.Matchup
+	for i := 0; form.Get(fmt.Sprintf("home_%d", i)) != ""; i++ {
+		home, err := strconv.Atoi(form.Get(fmt.Sprintf("home_%d", i)))
+		if err != nil {
+			return nil, err
+		}
+		away, err := strconv.Atoi(form.Get(fmt.Sprintf("away_%d", i)))
+		if err != nil {
+			return nil, err
+		}
+
+		if home == 0 {
+			home, away = away, home
+		}
+		if home == 0 {
+			continue
+		}
+
+		matchups = append(matchups, models.Matchup{HomePlayerID: home, AwayPlayerID: away})
+	}
+	return matchups, nil
+}
+
+// UpdateScheduleWeek handles request to replace the matchups, scoring format,
+// course and tee time of an unpublished week
+func (m *Handlers) UpdateScheduleWeek(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to edit the schedule!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	week, season, err := m.scheduleWeekInLeague(r, leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find schedule week")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/schedule", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	editURL := fmt.Sprintf("/leagues/%d/schedule/weeks/%d/edit", leagueID, week.ID)
+
+	players, err := m.PlayerService.GetPlayersInLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, editURL, http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	week.CourseID, week.TeeTime = m.scheduleWeekDetails(form)
+	if !form.Valid() {
+		m.App.Session.Put(r.Context(), "error", "choose one of the courses and a tee time like 17:30")
+		http.Redirect(w, r, editURL, http.StatusSeeOther)
+		return
+	}
+
+	week.Format = r.Form.Get("format")
+	week.Matchups, err = parseMatchups(r.PostForm)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "invalid matchup")
+		http.Redirect(w, r, editURL, http.StatusSeeOther)
+		return
+	}
+
+	err = m.ScheduleService.UpdateScheduleWeek(week, players)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, editURL, http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("week %d updated!", week.WeekNumber))
+	http.Redirect(w, r, scheduleURL(leagueID, season.ID), http.StatusSeeOther)
+}
+
+// CancelScheduleWeek handles request to call off a published week, such as for
+// bad weather
+func (m *Handlers) CancelScheduleWeek(w http.ResponseWriter, r *http.Request) {
+	m.updateScheduleWeekCancellation(w, r, m.ScheduleService.CancelScheduleWeek, "week %d cancelled!")
+}
+
+// ReinstateScheduleWeek handles request to put a cancelled week back on the
+// schedule
+func (m *Handlers) ReinstateScheduleWeek(w http.ResponseWriter, r *http.Request) {
+	m.updateScheduleWeekCancellation(w, r, m.ScheduleService.ReinstateScheduleWeek, "week %d is back on the schedule!")
+}
+
+// updateScheduleWeekCancellation checks the user is the league's commissioner
+// before cancelling or reinstating the week in the URI
+func (m *Handlers) updateScheduleWeekCancellation(w http.ResponseWriter, r *http.Request, update func(ID int) error, flash string) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to edit the schedule!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	week, season, err := m.scheduleWeekInLeague(r, leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find schedule week")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/schedule", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = update(week.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, scheduleURL(leagueID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf(flash, week.WeekNumber))
+	http.Redirect(w, r, scheduleURL(leagueID, season.ID), http.StatusSeeOther)
+}
@@ -0,0 +1,676 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var scheduleTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/schedule",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/schedule",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "user not in league",
+		userID:             4,
+		url:                "/leagues/4/schedule",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/schedule",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "season error",
+		userID:             1,
+		url:                "/leagues/7/schedule",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/7",
+	},
+	{
+		name:               "no seasons",
+		userID:             1,
+		url:                "/leagues/6/schedule",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/6",
+	},
+	{
+		name:               "schedule error",
+		userID:             1,
+		url:                "/leagues/8/schedule",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/8",
+	},
+	{
+		name:               "commissioner sees draft",
+		userID:             1,
+		url:                "/leagues/1/schedule",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "player",
+		userID:             3,
+		url:                "/leagues/1/schedule?season_id=1",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "published schedule",
+		userID:             3,
+		url:                "/leagues/2/schedule",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestSchedule(t *testing.T) {
+	for _, e := range scheduleTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.Schedule)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var showGenerateScheduleFormTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/schedule/generate",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/schedule/generate",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/schedule/generate",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/schedule/generate",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing season",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate?season_id=3",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "no active season",
+		userID:             1,
+		url:                "/leagues/6/schedule/generate",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "season in another league",
+		userID:             1,
+		url:                "/leagues/2/schedule/generate?season_id=1",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate?season_id=2",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowGenerateScheduleForm(t *testing.T) {
+	for _, e := range showGenerateScheduleFormTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowGenerateScheduleForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var generateScheduleTests = []struct {
+	name               string
+	userID             int
+	url                string
+	startDate          string
+	endDate            string
+	courseID           string
+	teeTime            string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "non-existing season",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate?season_id=3",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "players error",
+		userID:             1,
+		url:                "/leagues/2/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2/schedule?season_id=2",
+	},
+	{
+		name:               "invalid date",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate",
+		startDate:          "April",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "ends before it starts",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate",
+		startDate:          "2024-08-28",
+		endDate:            "2024-04-03",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "outside the season",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2025-08-28",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "non-existing course",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		courseID:           "3",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid tee time",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		teeTime:            "5:30 PM",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		url:                "/leagues/5/schedule/generate",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/5/schedule?season_id=5",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate?season_id=1",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule?season_id=1",
+	},
+	{
+		name:               "course and tee time",
+		userID:             1,
+		url:                "/leagues/1/schedule/generate?season_id=1",
+		startDate:          "2024-04-03",
+		endDate:            "2024-08-28",
+		courseID:           "1",
+		teeTime:            "17:30",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule?season_id=1",
+	},
+}
+
+func TestGenerateSchedule(t *testing.T) {
+	for _, e := range generateScheduleTests {
+		postedData := url.Values{}
+		postedData.Add("start_date", e.startDate)
+		postedData.Add("end_date", e.endDate)
+		postedData.Add("alternate_nines", "1")
+		postedData.Add("course_id", e.courseID)
+		postedData.Add("tee_time", e.teeTime)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.GenerateSchedule)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var publishScheduleTests = []struct {
+	name             string
+	userID           int
+	url              string
+	expectedLocation string
+	expectedFlash    bool
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		url:              "/leagues/1/schedule/publish",
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "bad url parameter",
+		userID:           1,
+		url:              "/leagues/s/schedule/publish",
+		expectedLocation: "/",
+	},
+	{
+		name:             "user not commissioner",
+		userID:           3,
+		url:              "/leagues/1/schedule/publish",
+		expectedLocation: "/leagues/1",
+	},
+	{
+		name:             "non-existing season",
+		userID:           1,
+		url:              "/leagues/1/schedule/publish?season_id=3",
+		expectedLocation: "/leagues/1",
+	},
+	{
+		name:             "service error",
+		userID:           1,
+		url:              "/leagues/5/schedule/publish",
+		expectedLocation: "/leagues/5/schedule?season_id=5",
+	},
+	{
+		name:             "success",
+		userID:           1,
+		url:              "/leagues/1/schedule/publish?season_id=1",
+		expectedLocation: "/leagues/1/schedule?season_id=1",
+		expectedFlash:    true,
+	},
+}
+
+func TestPublishSchedule(t *testing.T) {
+	for _, e := range publishScheduleTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.PublishSchedule)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+
+		if flash := session.GetString(req.Context(), "flash"); (flash != "") != e.expectedFlash {
+			t.Errorf("failed %s: expected flash %t, but got %q", e.name, e.expectedFlash, flash)
+		}
+	}
+}
+
+var showEditScheduleWeekFormTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/schedule/weeks/1/edit",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/schedule/weeks/1/edit",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/schedule/weeks/1/edit",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "bad week url parameter",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/s/edit",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule",
+	},
+	{
+		name:               "non-existing week",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/3/edit",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule",
+	},
+	{
+		name:               "week in another league",
+		userID:             1,
+		url:                "/leagues/4/schedule/weeks/1/edit",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/4/schedule",
+	},
+	{
+		name:               "published week",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/2/edit",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/schedule?season_id=1",
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/schedule/weeks/1/edit",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowEditScheduleWeekForm(t *testing.T) {
+	for _, e := range showEditScheduleWeekFormTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowEditScheduleWeekForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var updateScheduleWeekTests = []struct {
+	name             string
+	userID           int
+	url              string
+	postedData       url.Values
+	expectedLocation string
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		url:              "/leagues/1/schedule/weeks/1",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}},
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "user not commissioner",
+		userID:           3,
+		url:              "/leagues/1/schedule/weeks/1",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}},
+		expectedLocation: "/leagues/1",
+	},
+	{
+		name:             "non-existing week",
+		userID:           1,
+		url:              "/leagues/1/schedule/weeks/3",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}},
+		expectedLocation: "/leagues/1/schedule",
+	},
+	{
+		name:             "week in another league",
+		userID:           1,
+		url:              "/leagues/2/schedule/weeks/1",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}},
+		expectedLocation: "/leagues/2/schedule",
+	},
+	{
+		name:             "invalid matchup",
+		userID:           1,
+		url:              "/leagues/1/schedule/weeks/1",
+		postedData:       url.Values{"home_0": {"one"}, "away_0": {"2"}},
+		expectedLocation: "/leagues/1/schedule/weeks/1/edit",
+	},
+	{
+		name:             "invalid tee time",
+		userID:           1,
+		url:              "/leagues/1/schedule/weeks/1",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}, "tee_time": {"noon"}},
+		expectedLocation: "/leagues/1/schedule/weeks/1/edit",
+	},
+	{
+		name:             "service error",
+		userID:           1,
+		url:              "/leagues/1/schedule/weeks/5",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}},
+		expectedLocation: "/leagues/1/schedule/weeks/5/edit",
+	},
+	{
+		name:             "happy path",
+		userID:           1,
+		url:              "/leagues/1/schedule/weeks/1",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}, "home_1": {"0"}, "away_1": {"3"}, "home_2": {"0"}, "away_2": {"0"}},
+		expectedLocation: "/leagues/1/schedule?season_id=1",
+	},
+	{
+		name:             "course and tee time",
+		userID:           1,
+		url:              "/leagues/1/schedule/weeks/1",
+		postedData:       url.Values{"home_0": {"1"}, "away_0": {"2"}, "course_id": {"1"}, "tee_time": {"17:30"}},
+		expectedLocation: "/leagues/1/schedule?season_id=1",
+	},
+}
+
+func TestUpdateScheduleWeek(t *testing.T) {
+	for _, e := range updateScheduleWeekTests {
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(e.postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.UpdateScheduleWeek)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+var scheduleWeekCancellationTests = []struct {
+	name             string
+	handler          func(*Handlers, http.ResponseWriter, *http.Request)
+	userID           int
+	url              string
+	expectedLocation string
+}{
+	{"cancel: user not found", (*Handlers).CancelScheduleWeek, 0, "/leagues/1/schedule/weeks/2/cancel", "/user/login"},
+	{"cancel: user not commissioner", (*Handlers).CancelScheduleWeek, 3, "/leagues/1/schedule/weeks/2/cancel", "/leagues/1"},
+	{"cancel: non-existing week", (*Handlers).CancelScheduleWeek, 1, "/leagues/1/schedule/weeks/3/cancel", "/leagues/1/schedule"},
+	{"cancel: week in another league", (*Handlers).CancelScheduleWeek, 1, "/leagues/2/schedule/weeks/2/cancel", "/leagues/2/schedule"},
+	{"cancel: service error", (*Handlers).CancelScheduleWeek, 1, "/leagues/1/schedule/weeks/5/cancel", "/leagues/1/schedule?season_id=1"},
+	{"cancel: happy path", (*Handlers).CancelScheduleWeek, 1, "/leagues/1/schedule/weeks/2/cancel", "/leagues/1/schedule?season_id=1"},
+	{"reinstate: user not commissioner", (*Handlers).ReinstateScheduleWeek, 3, "/leagues/1/schedule/weeks/2/reinstate", "/leagues/1"},
+	{"reinstate: service error", (*Handlers).ReinstateScheduleWeek, 1, "/leagues/1/schedule/weeks/5/reinstate", "/leagues/1/schedule?season_id=1"},
+	{"reinstate: happy path", (*Handlers).ReinstateScheduleWeek, 1, "/leagues/1/schedule/weeks/2/reinstate", "/leagues/1/schedule?season_id=1"},
+}
+
+func TestScheduleWeekCancellation(t *testing.T) {
+	for _, e := range scheduleWeekCancellationTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		e.handler(Handler, rr, req)
+
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+func TestParseMatchups(t *testing.T) {
+	matchups, err := parseMatchups(url.Values{
+		"home_0": {"1"}, "away_0": {"2"},
+		"home_1": {"0"}, "away_1": {"3"},
+		"home_2": {"0"}, "away_2": {"0"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err)
+	}
+	if len(matchups) != 2 {
+		t.Fatalf("expected 2 matchups, but got %d", len(matchups))
+	}
+	if matchups[1].HomePlayerID != 3 || !matchups[1].IsBye() {
+		t.Errorf("expected a lone away player to become a bye, but got %+v", matchups[1])
+	}
+}
@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const seasonIDIndex = 4
+
+func getSeasonIDFromURI(URI string) (int, error) {
+	return getIDFromURI(URI, seasonIDIndex)
+}
+
+// selectedSeason returns the season chosen with the season_id query parameter,
+// falling back to the league's active season and then its most recent one
+func (m *Handlers) selectedSeason(r *http.Request, leagueID int, seasons []models.Season) (models.Season, bool) {
+	if r.URL.Query().Get("season_id") != "" {
+		seasonID, _ := strconv.Atoi(r.URL.Query().Get("season_id"))
+		for _, s := range seasons {
+			if s.ID == seasonID {
+				return s, true
+			}
+		}
+	}
+
+	if season, err := m.SeasonService.GetActiveSeason(leagueID); err == nil {
+		return season, true
+	}
+
+	if len(seasons) > 0 {
+		return seasons[0], true
+	}
+
+	return models.Season{}, false
+}
+
+// Seasons lists a league's seasons so the commissioner can open and close them
+func (m *Handlers) Seasons(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to manage seasons!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	seasons, err := m.SeasonService.GetSeasonsInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get seasons for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["seasons"] = seasons
+
+	render.Template(w, r, "seasons.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// ShowSeasonForm renders the add a season page and displays form
+func (m *Handlers) ShowSeasonForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to add seasons!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+
+	render.Template(w, r, "create-season.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// CreateSeason handles request to add a draft season to a league
+func (m *Handlers) CreateSeason(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to add seasons!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("name", "start_date", "end_date")
+	form.MinLength("name", 2)
+	form.MaxLength("name", 100)
+	validDates := form.IsDate("start_date")
+	validDates = form.IsDate("end_date") && validDates
+
+	startDate, _ := time.Parse("2006-01-02", r.Form.Get("start_date"))
+	endDate, _ := time.Parse("2006-01-02", r.Form.Get("end_date"))
+	if validDates && !endDate.After(startDate) {
+		form.Errors.Add("end_date", "A season must end after it starts")
+	}
+
+	season := models.Season{
+		LeagueID:  league.ID,
+		Name:      r.Form.Get("name"),
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+
+	if !form.Valid() {
+		data := make(map[string]interface{})
+		data["league"] = league
+		data["season"] = season
+
+		render.Template(w, r, "create-season.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	_, err = m.SeasonService.CreateSeason(season)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't insert season into database!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/seasons", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "season added! Open it when you're ready to start posting rounds")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/seasons", league.ID), http.StatusSeeOther)
+}
+
+// OpenSeason handles request to make a draft season the league's active season
+func (m *Handlers) OpenSeason(w http.ResponseWriter, r *http.Request) {
+	m.updateSeasonStatus(w, r, m.SeasonService.OpenSeason, "season opened!")
+}
+
+// CloseSeason handles request to complete the league's active season
+func (m *Handlers) CloseSeason(w http.ResponseWriter, r *http.Request) {
+	m.updateSeasonStatus(w, r, m.SeasonService.CloseSeason, "season closed!")
+}
+
+// updateSeasonStatus checks the user is the commissioner of the season's
+// league before opening or closing the season
+func (m *Handlers) updateSeasonStatus(w http.ResponseWriter, r *http.Request, update func(ID int) error, flash string) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to manage seasons!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	seasonID, err := getSeasonIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/seasons", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.SeasonService.GetSeason(seasonID)
+	if err != nil || season.LeagueID != leagueID {
+		m.App.Session.Put(r.Context(), "error", "cannot find season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/seasons", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = update(season.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/seasons", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", flash)
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/seasons", leagueID), http.StatusSeeOther)
+}
@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var seasonsTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/seasons",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/seasons",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/seasons",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/seasons",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "season error",
+		userID:             1,
+		url:                "/leagues/7/seasons",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/seasons",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestSeasons(t *testing.T) {
+	for _, e := range seasonsTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.Seasons)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var showSeasonFormTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/seasons/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/seasons/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/seasons/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/seasons/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/seasons/new",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowSeasonForm(t *testing.T) {
+	for _, e := range showSeasonFormTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowSeasonForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var postSeasonTests = []struct {
+	name               string
+	userID             int
+	url                string
+	seasonName         string
+	startDate          string
+	endDate            string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/seasons",
+		seasonName:         "2024",
+		startDate:          "2024-04-01",
+		endDate:            "2024-09-30",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/seasons",
+		seasonName:         "2024",
+		startDate:          "2024-04-01",
+		endDate:            "2024-09-30",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/seasons",
+		seasonName:         "2024",
+		startDate:          "2024-04-01",
+		endDate:            "2024-09-30",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "missing name",
+		userID:             1,
+		url:                "/leagues/1/seasons",
+		seasonName:         "",
+		startDate:          "2024-04-01",
+		endDate:            "2024-09-30",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid date",
+		userID:             1,
+		url:                "/leagues/1/seasons",
+		seasonName:         "2024",
+		startDate:          "April",
+		endDate:            "2024-09-30",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "ends before it starts",
+		userID:             1,
+		url:                "/leagues/1/seasons",
+		seasonName:         "2024",
+		startDate:          "2024-09-30",
+		endDate:            "2024-04-01",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "error inserting season",
+		userID:             1,
+		url:                "/leagues/1/seasons",
+		seasonName:         "Season Error",
+		startDate:          "2024-04-01",
+		endDate:            "2024-09-30",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/seasons",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/seasons",
+		seasonName:         "2024",
+		startDate:          "2024-04-01",
+		endDate:            "2024-09-30",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/seasons",
+	},
+}
+
+func TestCreateSeason(t *testing.T) {
+	for _, e := range postSeasonTests {
+		postedData := url.Values{}
+		postedData.Add("name", e.seasonName)
+		postedData.Add("start_date", e.startDate)
+		postedData.Add("end_date", e.endDate)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.CreateSeason)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var updateSeasonStatusTests = []struct {
+	name             string
+	userID           int
+	url              string
+	expectedLocation string
+	expectedFlash    bool
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		url:              "/leagues/1/seasons/1/open",
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "bad league url parameter",
+		userID:           1,
+		url:              "/leagues/s/seasons/1/open",
+		expectedLocation: "/",
+	},
+	{
+		name:             "user not commissioner",
+		userID:           3,
+		url:              "/leagues/1/seasons/1/open",
+		expectedLocation: "/leagues/1",
+	},
+	{
+		name:             "bad season url parameter",
+		userID:           1,
+		url:              "/leagues/1/seasons/s/open",
+		expectedLocation: "/leagues/1/seasons",
+	},
+	{
+		name:             "non-existing season",
+		userID:           1,
+		url:              "/leagues/1/seasons/3/open",
+		expectedLocation: "/leagues/1/seasons",
+	},
+	{
+		name:             "season in another league",
+		userID:           1,
+		url:              "/leagues/2/seasons/1/open",
+		expectedLocation: "/leagues/2/seasons",
+	},
+	{
+		name:             "service error",
+		userID:           1,
+		url:              "/leagues/1/seasons/5/open",
+		expectedLocation: "/leagues/1/seasons",
+	},
+	{
+		name:             "success",
+		userID:           1,
+		url:              "/leagues/1/seasons/2/open",
+		expectedLocation: "/leagues/1/seasons",
+		expectedFlash:    true,
+	},
+}
+
+func TestOpenAndCloseSeason(t *testing.T) {
+	handlers := map[string]http.HandlerFunc{
+		"open":  Handler.OpenSeason,
+		"close": Handler.CloseSeason,
+	}
+
+	for action, handler := range handlers {
+		for _, e := range updateSeasonStatusTests {
+			URI := strings.Replace(e.url, "/open", "/"+action, 1)
+
+			req, _ := http.NewRequest("POST", URI, nil)
+			req.RequestURI = URI
+
+			ctx := getCtx(req)
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+
+			session.Put(req.Context(), "user_id", e.userID)
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusSeeOther {
+				t.Errorf("failed %s %s: expected code %d, but got %d", action, e.name, http.StatusSeeOther, rr.Code)
+			}
+
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s %s: expected location %s, but got location %s", action, e.name, e.expectedLocation, actualLoc.String())
+			}
+
+			if flash := session.GetString(req.Context(), "flash"); (flash != "") != e.expectedFlash {
+				t.Errorf("failed %s %s: expected flash %t, but got %q", action, e.name, e.expectedFlash, flash)
+			}
+		}
+	}
+}
@@ -15,14 +15,44 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/jdonahue135/golf-league-app/internal/config"
+	"github.com/jdonahue135/golf-league-app/internal/helpers"
 	"github.com/jdonahue135/golf-league-app/internal/models"
 	"github.com/jdonahue135/golf-league-app/internal/render"
+	"github.com/jdonahue135/golf-league-app/internal/repository/apitokenrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/calendarfeedrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/commissionertransferrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/courserepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/invitationrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/leagueadminrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/leaguerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/loginattemptrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/playerrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/schedulerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/scorerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/seasonrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/skinsrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/teamrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/webhookrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services/apitokenservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/calendarfeedservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/commissionertransferservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/courseservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/handicapservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/invitationservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/leagueroleservice"
 	"github.com/jdonahue135/golf-league-app/internal/services/leagueservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/loginthrottleservice"
 	"github.com/jdonahue135/golf-league-app/internal/services/playerservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/scheduleservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/scoreservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/seasonservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/skinsservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/standingsservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/teamservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/twofactorservice"
 	"github.com/jdonahue135/golf-league-app/internal/services/userservice"
+	"github.com/jdonahue135/golf-league-app/internal/services/webhookservice"
 	"github.com/justinas/nosurf"
 )
 
@@ -31,18 +61,24 @@ var session *scs.SessionManager
 var pathToTemplates = "./../../templates"
 
 var functions = template.FuncMap{
-	"humanDate":  render.HumanDate,
-	"formatDate": render.FormatDate,
-	"iterate":    render.Iterate,
-	"add":        render.Add,
+	"humanDate":   render.HumanDate,
+	"formatDate":  render.FormatDate,
+	"iterate":     render.Iterate,
+	"add":         render.Add,
+	"formatIndex": render.FormatIndex,
+	"formatName":  render.FormatName,
+	"formatMoney": render.FormatMoney,
+	"roleName":    render.RoleName,
 }
 
 func TestMain(m *testing.M) {
 	gob.Register(models.User{})
 	gob.Register(map[string]int{})
+	gob.Register(time.Time{})
 
 	// change this to true when in production
 	app.InProduction = false
+	app.BaseURL = "http://localhost:8080"
 
 	infoLog := log.New(os.Stdout, "INFO\t", log.Ldate|log.Ltime)
 	app.InfoLog = infoLog
@@ -79,9 +115,39 @@ func TestMain(m *testing.M) {
 
 	leagueRepo := leaguerepo.NewTestLeagueRepo()
 	leagueService := leagueservice.NewTestLeagueService(leagueRepo, playerRepo, userRepo)
-	NewHandlers(&app, userService, leagueService, playerService)
+	courseRepo := courserepo.NewTestCourseRepo()
+	courseService := courseservice.NewTestCourseService(courseRepo)
+	scoreRepo := scorerepo.NewTestScoreRepo()
+	scoreService := scoreservice.NewTestScoreService(scoreRepo)
+	handicapService := handicapservice.NewTestHandicapService(scoreRepo)
+	seasonRepo := seasonrepo.NewTestSeasonRepo()
+	seasonService := seasonservice.NewTestSeasonService(seasonRepo)
+	scheduleRepo := schedulerepo.NewTestScheduleRepo()
+	scheduleService := scheduleservice.NewTestScheduleService(scheduleRepo)
+	standingsService := standingsservice.NewTestStandingsService(scoreRepo)
+	teamRepo := teamrepo.NewTestTeamRepo()
+	teamService := teamservice.NewTestTeamService(teamRepo)
+	skinsRepo := skinsrepo.NewTestSkinsRepo()
+	skinsService := skinsservice.NewTestSkinsService(skinsRepo)
+	invitationRepo := invitationrepo.NewTestInvitationRepo()
+	invitationService := invitationservice.NewTestInvitationService(invitationRepo)
+	loginAttemptRepo := loginattemptrepo.NewTestLoginAttemptRepo()
+	loginThrottleService := loginthrottleservice.NewTestLoginThrottleService(loginAttemptRepo)
+	twoFactorService := twofactorservice.NewTestTwoFactorService(userRepo)
+	leagueAdminRepo := leagueadminrepo.NewTestLeagueAdminRepo()
+	leagueRoleService := leagueroleservice.NewTestLeagueRoleService(leagueAdminRepo)
+	commissionerTransferRepo := commissionertransferrepo.NewTestCommissionerTransferRepo()
+	commissionerTransferService := commissionertransferservice.NewTestCommissionerTransferService(commissionerTransferRepo)
+	apiTokenRepo := apitokenrepo.NewTestAPITokenRepo()
+	apiTokenService := apitokenservice.NewTestAPITokenService(apiTokenRepo)
+	webhookRepo := webhookrepo.NewTestWebhookRepo()
+	webhookService := webhookservice.NewTestWebhookService(webhookRepo)
+	calendarFeedRepo := calendarfeedrepo.NewTestCalendarFeedRepo()
+	calendarFeedService := calendarfeedservice.NewTestCalendarFeedService(calendarFeedRepo)
+	NewHandlers(&app, userService, leagueService, playerService, courseService, scoreService, handicapService, seasonService, scheduleService, standingsService, teamService, skinsService, invitationService, loginThrottleService, twoFactorService, leagueRoleService, commissionerTransferService, apiTokenService, webhookService, calendarFeedService)
 
 	render.NewRenderer(&app)
+	helpers.NewHelpers(&app)
 
 	os.Exit(m.Run())
 }
@@ -103,6 +169,7 @@ func getRoutes() http.Handler {
 
 	mux.Get("/", Handler.Home)
 	mux.Get("/about", Handler.About)
+	mux.Get("/calendar/{token}", Handler.CalendarFeed)
 
 	mux.Route("/leagues", func(mux chi.Router) {
 		mux.Get("/", Handler.Leagues)
@@ -110,19 +177,118 @@ func getRoutes() http.Handler {
 		mux.Get("/new", Handler.ShowLeagueForm)
 		mux.Get("/{id}", Handler.ShowLeague)
 		mux.Get("/{id}/add-player", Handler.ShowAddPlayerForm)
+		mux.Get("/{id}/import-players", Handler.ShowImportPlayersForm)
+		mux.Post("/{id}/import-players/preview", Handler.PreviewImportPlayers)
+		mux.Post("/{id}/import-players", Handler.ImportPlayers)
 		mux.Post("/{id}/players", Handler.AddPlayer)
+		mux.Get("/{id}/players/{player_id}", Handler.ShowPlayer)
+		mux.Get("/{id}/seasons", Handler.Seasons)
+		mux.Post("/{id}/seasons", Handler.CreateSeason)
+		mux.Get("/{id}/seasons/new", Handler.ShowSeasonForm)
+		mux.Post("/{id}/seasons/{season_id}/open", Handler.OpenSeason)
+		mux.Post("/{id}/seasons/{season_id}/close", Handler.CloseSeason)
+		mux.Get("/{id}/settings", Handler.ShowLeagueSettings)
+		mux.Post("/{id}/settings", Handler.UpdateLeagueSettings)
+		mux.Get("/{id}/email", Handler.ShowLeagueEmail)
+		mux.Post("/{id}/email", Handler.PostLeagueEmail)
+		mux.Get("/{id}/schedule", Handler.Schedule)
+		mux.Get("/{id}/standings", Handler.Standings)
+		mux.Get("/{id}/teams", Handler.Teams)
+		mux.Post("/{id}/teams", Handler.CreateTeam)
+		mux.Get("/{id}/teams/new", Handler.ShowTeamForm)
+		mux.Post("/{id}/teams/{team_id}/delete", Handler.DeleteTeam)
+		mux.Get("/{id}/schedule/generate", Handler.ShowGenerateScheduleForm)
+		mux.Post("/{id}/schedule/generate", Handler.GenerateSchedule)
+		mux.Post("/{id}/schedule/publish", Handler.PublishSchedule)
+		mux.Get("/{id}/schedule/weeks/{week_id}/edit", Handler.ShowEditScheduleWeekForm)
+		mux.Post("/{id}/schedule/weeks/{week_id}", Handler.UpdateScheduleWeek)
+		mux.Post("/{id}/schedule/weeks/{week_id}/cancel", Handler.CancelScheduleWeek)
+		mux.Post("/{id}/schedule/weeks/{week_id}/reinstate", Handler.ReinstateScheduleWeek)
+		mux.Get("/{id}/schedule/weeks/{week_id}/results", Handler.WeekResults)
+		mux.Post("/{id}/schedule/weeks/{week_id}/skins", Handler.UpdateSkinsGame)
+		mux.Post("/{id}/invitations/{invitation_id}/resend", Handler.ResendInvitation)
+		mux.Post("/{id}/invitations/{invitation_id}/revoke", Handler.RevokeInvitation)
+		mux.Get("/{id}/roles", Handler.LeagueRoles)
+		mux.Post("/{id}/roles", Handler.GrantLeagueRole)
+		mux.Post("/{id}/roles/{role_id}/revoke", Handler.RevokeLeagueRole)
+		mux.Get("/{id}/commissioner-transfer", Handler.ShowCommissionerTransfer)
+		mux.Post("/{id}/commissioner-transfer", Handler.NominateCommissioner)
+		mux.Post("/{id}/commissioner-transfer/cancel", Handler.CancelCommissionerTransfer)
+		mux.Get("/{id}/webhooks", Handler.Webhooks)
+		mux.Post("/{id}/webhooks", Handler.CreateWebhook)
+		mux.Get("/{id}/webhooks/{webhook_id}", Handler.ShowWebhook)
+		mux.Post("/{id}/webhooks/{webhook_id}/test", Handler.SendTestWebhook)
+		mux.Post("/{id}/webhooks/{webhook_id}/delete", Handler.DeleteWebhook)
+		mux.Get("/{id}/rounds/new", Handler.ShowRoundForm)
+		mux.Post("/{id}/rounds", Handler.PostRound)
+	})
+
+	mux.Route("/courses", func(mux chi.Router) {
+		mux.Get("/", Handler.Courses)
+		mux.Post("/", Handler.CreateCourse)
+		mux.Get("/new", Handler.ShowCourseForm)
+		mux.Get("/{id}", Handler.ShowCourse)
+		mux.Get("/{id}/tee-sets/new", Handler.ShowTeeSetForm)
+		mux.Post("/{id}/tee-sets", Handler.CreateTeeSet)
 	})
 
 	mux.Route("/user", func(mux chi.Router) {
 		mux.Get("/login", Handler.ShowLogin)
 		mux.Post("/login", Handler.PostShowLogin)
+		mux.Get("/login/two-factor", Handler.ShowTwoFactorLogin)
+		mux.Post("/login/two-factor", Handler.PostTwoFactorLogin)
 		mux.Get("/logout", Handler.Logout)
 		mux.Get("/sign-up", Handler.ShowSignUp)
 		mux.Post("/sign-up", Handler.PostShowSignUp)
+		mux.Get("/claim/{token}", Handler.ShowClaimAccount)
+		mux.Post("/claim/{token}", Handler.PostClaimAccount)
+		mux.Get("/forgot-password", Handler.ShowForgotPassword)
+		mux.Post("/forgot-password", Handler.PostForgotPassword)
+		mux.Get("/reset-password/{token}", Handler.ShowResetPassword)
+		mux.Post("/reset-password/{token}", Handler.PostResetPassword)
+		mux.Get("/verify-email/{token}", Handler.VerifyEmail)
+		mux.Post("/verify-email", Handler.ResendVerificationEmail)
+		mux.Get("/profile", Handler.ShowProfile)
+		mux.Post("/profile", Handler.UpdateProfile)
+		mux.Post("/profile/password", Handler.UpdatePassword)
+		mux.Post("/profile/logout-everywhere", Handler.LogOutEverywhere)
+		mux.Post("/profile/api-tokens", Handler.CreateAPIToken)
+		mux.Post("/profile/api-tokens/{id}/revoke", Handler.RevokeAPIToken)
+		mux.Post("/profile/calendar-feeds", Handler.CreateCalendarFeed)
+		mux.Post("/profile/calendar-feeds/{id}/revoke", Handler.RevokeCalendarFeed)
+		mux.Get("/profile/two-factor", Handler.ShowTwoFactorSetup)
+		mux.Post("/profile/two-factor", Handler.EnableTwoFactor)
+		mux.Post("/profile/two-factor/disable", Handler.DisableTwoFactor)
+		mux.Get("/commissioner-transfer/{token}", Handler.ShowAcceptCommissionerTransfer)
+		mux.Post("/commissioner-transfer/{token}", Handler.AcceptCommissionerTransfer)
 	})
 
 	mux.Route("/admin", func(mux chi.Router) {
 		mux.Get("/dashboard", Handler.AdminDashboard)
+		mux.Get("/users", Handler.AdminUsers)
+		mux.Get("/users/{id}", Handler.AdminShowUser)
+		mux.Post("/users/{id}/access-level", Handler.AdminUpdateAccessLevel)
+		mux.Post("/users/{id}/deactivate", Handler.AdminDeactivateUser)
+		mux.Post("/users/{id}/reactivate", Handler.AdminReactivateUser)
+		mux.Post("/users/{id}/email", Handler.AdminFixEmail)
+		mux.Get("/leagues", Handler.AdminLeagues)
+		mux.Get("/leagues/{id}", Handler.AdminShowLeague)
+		mux.Post("/lockouts/clear", Handler.ClearLockout)
+		mux.Post("/settings/two-factor", Handler.UpdateTwoFactorRequirement)
+		mux.Get("/commissioner", Handler.ShowForceCommissionerTransfer)
+		mux.Post("/commissioner", Handler.ForceCommissionerTransfer)
+	})
+
+	mux.Route("/api/v1", func(mux chi.Router) {
+		mux.Get("/user", Handler.APIUser)
+		mux.Get("/leagues", Handler.APILeagues)
+		mux.Get("/leagues/{id}", Handler.APILeague)
+		mux.Get("/leagues/{id}/players", Handler.APIPlayers)
+		mux.Get("/leagues/{id}/players/{player_id}", Handler.APIPlayer)
+		mux.Get("/leagues/{id}/rounds", Handler.APIRounds)
+		mux.Post("/leagues/{id}/rounds", Handler.APIPostRound)
+		mux.Get("/leagues/{id}/rounds/{round_id}", Handler.APIRound)
+		mux.Get("/leagues/{id}/standings", Handler.APIStandings)
 	})
 
 	fileServer := http.FileServer(http.Dir("./static/"))
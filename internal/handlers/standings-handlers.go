@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// Standings shows a season's standings, ranked by the sort query parameter,
+// along with its gross and net leaderboards
+func (m *Handlers) Standings(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	if _, err := m.LeagueRoleService.GetMembership(userID, leagueID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not in this league!")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	seasons, err := m.SeasonService.GetSeasonsInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get seasons for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	season, hasSeason := m.selectedSeason(r, league.ID, seasons)
+	if !hasSeason {
+		m.App.Session.Put(r.Context(), "error", "league doesn't have a season yet")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	standings, err := m.StandingsService.GetStandings(league, season, players, r.URL.Query().Get("sort"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get standings for season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["seasons"] = seasons
+	data["season"] = season
+	data["standings"] = standings
+
+	render.Template(w, r, "standings.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var standingsTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "user not in league",
+		userID:             4,
+		url:                "/leagues/4/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "players error",
+		userID:             1,
+		url:                "/leagues/2/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2",
+	},
+	{
+		name:               "season error",
+		userID:             1,
+		url:                "/leagues/7/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/7",
+	},
+	{
+		name:               "no seasons",
+		userID:             1,
+		url:                "/leagues/6/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/6",
+	},
+	{
+		name:               "standings error",
+		userID:             1,
+		url:                "/leagues/5/standings",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/5",
+	},
+	{
+		name:               "success",
+		userID:             3,
+		url:                "/leagues/1/standings",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "success sorted by record",
+		userID:             3,
+		url:                "/leagues/1/standings?season_id=1&sort=record",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestStandings(t *testing.T) {
+	for _, e := range standingsTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.Standings)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+const teamIDIndex = 4
+
+func getTeamIDFromURI(URI string) (int, error) {
+	return getIDFromURI(URI, teamIDIndex)
+}
+
+func teamsURL(leagueID, seasonID int) string {
+	return fmt.Sprintf("/leagues/%d/teams?season_id=%d", leagueID, seasonID)
+}
+
+// Teams shows a season's teams ranked in the team format chosen with the
+// format query parameter
+func (m *Handlers) Teams(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not in this league!")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	seasons, err := m.SeasonService.GetSeasonsInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get seasons for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	season, hasSeason := m.selectedSeason(r, league.ID, seasons)
+	if !hasSeason {
+		m.App.Session.Put(r.Context(), "error", "league doesn't have a season yet")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	teams, err := m.TeamService.GetTeamsInSeason(season.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get teams for season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	standings, err := m.StandingsService.GetTeamStandings(season, teams, r.URL.Query().Get("format"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get team standings for season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["seasons"] = seasons
+	data["season"] = season
+	data["standings"] = standings
+	data["formats"] = models.TeamFormats
+	data["can_manage"] = membership.CanManageLeague()
+
+	render.Template(w, r, "teams.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// availablePlayers returns the league's active players who aren't on a team yet
+func availablePlayers(players []models.Player, teams []models.Team) []models.Player {
+	var available []models.Player
+	for _, p := range players {
+		if !p.IsActive {
+			continue
+		}
+		onTeam := false
+		for _, t := range teams {
+			if t.HasPlayer(p.ID) {
+				onTeam = true
+				break
+			}
+		}
+		if !onTeam {
+			available = append(available, p)
+		}
+	}
+	return available
+}
+
+// ShowTeamForm renders the add a team page so the commissioner can put
+// players who aren't on a team yet together
+func (m *Handlers) ShowTeamForm(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to add teams!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.scheduleSeason(r, league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, teamsURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	teams, err := m.TeamService.GetTeamsInSeason(season.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get teams for season")
+		http.Redirect(w, r, teamsURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["season"] = season
+	data["players"] = availablePlayers(players, teams)
+	data["team"] = models.Team{}
+
+	render.Template(w, r, "create-team.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// CreateTeam handles request to add a team of the checked players to a season
+func (m *Handlers) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to add teams!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	season, err := m.scheduleSeason(r, league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find season")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	players, err := m.PlayerService.GetPlayersInLeague(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get players for league")
+		http.Redirect(w, r, teamsURL(league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("name")
+	form.MinLength("name", 2)
+	form.MaxLength("name", 100)
+
+	team := models.Team{Name: r.Form.Get("name")}
+	for _, value := range r.PostForm["player_id"] {
+		playerID, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		team.Players = append(team.Players, models.Player{ID: playerID})
+	}
+	if len(team.Players) < 2 {
+		form.Errors.Add("player_id", "Choose at least two players")
+	}
+
+	if !form.Valid() {
+		teams, err := m.TeamService.GetTeamsInSeason(season.ID)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot get teams for season")
+			http.Redirect(w, r, teamsURL(league.ID, season.ID), http.StatusSeeOther)
+			return
+		}
+
+		data := make(map[string]interface{})
+		data["league"] = league
+		data["season"] = season
+		data["players"] = availablePlayers(players, teams)
+		data["team"] = team
+
+		render.Template(w, r, "create-team.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	_, err = m.TeamService.CreateTeam(season, team, players)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/teams/new?season_id=%d", league.ID, season.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "team added!")
+	http.Redirect(w, r, teamsURL(league.ID, season.ID), http.StatusSeeOther)
+}
+
+// DeleteTeam handles request to break up a team. Its players stay in the league
+func (m *Handlers) DeleteTeam(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageLeague() {
+		m.App.Session.Put(r.Context(), "error", "user must be a league commissioner to remove teams!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	teamID, err := getTeamIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/teams", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	team, err := m.TeamService.GetTeam(teamID)
+	if err != nil || team.LeagueID != leagueID {
+		m.App.Session.Put(r.Context(), "error", "cannot find team")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/teams", leagueID), http.StatusSeeOther)
+		return
+	}
+
+	err = m.TeamService.DeleteTeam(team.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't remove team!")
+		http.Redirect(w, r, teamsURL(leagueID, team.SeasonID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("%s removed!", team.Name))
+	http.Redirect(w, r, teamsURL(leagueID, team.SeasonID), http.StatusSeeOther)
+}
@@ -0,0 +1,391 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var teamsTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not in league",
+		userID:             4,
+		url:                "/leagues/4/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "season error",
+		userID:             1,
+		url:                "/leagues/7/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "no season",
+		userID:             1,
+		url:                "/leagues/6/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "teams error",
+		userID:             1,
+		url:                "/leagues/8/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "standings error",
+		userID:             1,
+		url:                "/leagues/5/teams",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/teams?format=aggregate",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "success as player",
+		userID:             3,
+		url:                "/leagues/1/teams",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestTeams(t *testing.T) {
+	for _, e := range teamsTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.Teams)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var showTeamFormTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/teams/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "bad url parameter",
+		userID:             1,
+		url:                "/leagues/s/teams/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/teams/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/teams/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "non-existing season",
+		userID:             1,
+		url:                "/leagues/1/teams/new?season_id=3",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "players error",
+		userID:             1,
+		url:                "/leagues/2/teams/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "teams error",
+		userID:             1,
+		url:                "/leagues/8/teams/new",
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "success",
+		userID:             1,
+		url:                "/leagues/1/teams/new?season_id=1",
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowTeamForm(t *testing.T) {
+	for _, e := range showTeamFormTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowTeamForm)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+	}
+}
+
+var createTeamTests = []struct {
+	name               string
+	userID             int
+	url                string
+	teamName           string
+	playerIDs          []string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		url:                "/leagues/1/teams?season_id=1",
+		teamName:           "Aces",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "user not commissioner",
+		userID:             3,
+		url:                "/leagues/1/teams?season_id=1",
+		teamName:           "Aces",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "non-existing league",
+		userID:             1,
+		url:                "/leagues/3/teams?season_id=1",
+		teamName:           "Aces",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "non-existing season",
+		userID:             1,
+		url:                "/leagues/1/teams?season_id=3",
+		teamName:           "Aces",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1",
+	},
+	{
+		name:               "players error",
+		userID:             1,
+		url:                "/leagues/2/teams",
+		teamName:           "Aces",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/2/teams?season_id=2",
+	},
+	{
+		name:               "missing name",
+		userID:             1,
+		url:                "/leagues/1/teams?season_id=1",
+		teamName:           "",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "one player",
+		userID:             1,
+		url:                "/leagues/1/teams?season_id=1",
+		teamName:           "Aces",
+		playerIDs:          []string{"1", "x"},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "invalid form and teams error",
+		userID:             1,
+		url:                "/leagues/8/teams",
+		teamName:           "",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/8/teams?season_id=8",
+	},
+	{
+		name:               "service error",
+		userID:             1,
+		url:                "/leagues/1/teams?season_id=1",
+		teamName:           "Team Error",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/teams/new?season_id=1",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		url:                "/leagues/1/teams?season_id=1",
+		teamName:           "Aces",
+		playerIDs:          []string{"1", "2"},
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/leagues/1/teams?season_id=1",
+	},
+}
+
+func TestCreateTeam(t *testing.T) {
+	for _, e := range createTeamTests {
+		postedData := url.Values{}
+		postedData.Add("name", e.teamName)
+		for _, id := range e.playerIDs {
+			postedData.Add("player_id", id)
+		}
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.CreateTeam)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var deleteTeamTests = []struct {
+	name             string
+	userID           int
+	url              string
+	expectedLocation string
+	expectedFlash    bool
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		url:              "/leagues/1/teams/1/delete",
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "bad url parameter",
+		userID:           1,
+		url:              "/leagues/s/teams/1/delete",
+		expectedLocation: "/",
+	},
+	{
+		name:             "user not commissioner",
+		userID:           3,
+		url:              "/leagues/1/teams/1/delete",
+		expectedLocation: "/leagues/1",
+	},
+	{
+		name:             "bad team parameter",
+		userID:           1,
+		url:              "/leagues/1/teams/x/delete",
+		expectedLocation: "/leagues/1/teams",
+	},
+	{
+		name:             "non-existing team",
+		userID:           1,
+		url:              "/leagues/1/teams/3/delete",
+		expectedLocation: "/leagues/1/teams",
+	},
+	{
+		name:             "team in another league",
+		userID:           1,
+		url:              "/leagues/2/teams/1/delete",
+		expectedLocation: "/leagues/2/teams",
+	},
+	{
+		name:             "service error",
+		userID:           1,
+		url:              "/leagues/1/teams/5/delete",
+		expectedLocation: "/leagues/1/teams?season_id=1",
+	},
+	{
+		name:             "success",
+		userID:           1,
+		url:              "/leagues/1/teams/1/delete",
+		expectedLocation: "/leagues/1/teams?season_id=1",
+		expectedFlash:    true,
+	},
+}
+
+func TestDeleteTeam(t *testing.T) {
+	for _, e := range deleteTeamTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		req.RequestURI = e.url
+
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.DeleteTeam)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+
+		if flash := session.GetString(req.Context(), "flash"); (flash != "") != e.expectedFlash {
+			t.Errorf("failed %s: expected flash %t, but got %q", e.name, e.expectedFlash, flash)
+		}
+	}
+}
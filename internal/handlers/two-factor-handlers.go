@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// pendingTwoFactorUser returns the user who entered their password but still
+// has to enter a code, if they did so within TwoFactorLoginLifetime
+func (m *Handlers) pendingTwoFactorUser(r *http.Request) (models.User, bool) {
+	userID, _ := m.App.Session.Get(r.Context(), "two_factor_user_id").(int)
+	startedAt := m.App.Session.GetTime(r.Context(), "two_factor_started_at")
+	if userID == 0 || time.Since(startedAt) > models.TwoFactorLoginLifetime {
+		return models.User{}, false
+	}
+
+	user, err := m.UserService.GetUser(userID)
+	if err != nil || !user.HasTwoFactor() {
+		return models.User{}, false
+	}
+
+	return user, true
+}
+
+// ShowTwoFactorLogin shows the page to enter a code after entering a password
+func (m *Handlers) ShowTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	if _, ok := m.pendingTwoFactorUser(r); !ok {
+		m.App.Session.Put(r.Context(), "error", "Log in again")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "two-factor-login.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+	})
+}
+
+// PostTwoFactorLogin handles the code from a user's authenticator app, or a
+// recovery code, and finishes logging them in. Wrong codes count as failed
+// logins
+func (m *Handlers) PostTwoFactorLogin(w http.ResponseWriter, r *http.Request) {
+	user, ok := m.pendingTwoFactorUser(r)
+	if !ok {
+		m.App.Session.Put(r.Context(), "error", "Log in again")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("code")
+
+	if !form.Valid() {
+		render.Template(w, r, "two-factor-login.page.tmpl", &models.TemplateData{
+			Form: form,
+		})
+		return
+	}
+
+	ip := clientIP(r)
+
+	wait, locked, err := m.LoginThrottleService.StartLogin(user.Email, ip)
+	if err != nil {
+		log.Println(err)
+		m.App.Session.Put(r.Context(), "error", "Can't log in right now, try again later")
+		http.Redirect(w, r, "/user/login/two-factor", http.StatusSeeOther)
+		return
+	}
+	if wait > 0 {
+		m.App.Session.Put(r.Context(), "error", fmt.Sprintf("Too many failed logins, try again in %s", formatWait(wait)))
+		http.Redirect(w, r, "/user/login/two-factor", http.StatusSeeOther)
+		return
+	}
+
+	err = m.TwoFactorService.VerifyCode(user, r.Form.Get("code"))
+	if err != nil {
+		if locked {
+			m.sendLockoutEmail(user)
+		}
+		m.App.Session.Put(r.Context(), "error", "Invalid code")
+		http.Redirect(w, r, "/user/login/two-factor", http.StatusSeeOther)
+		return
+	}
+
+	accessLevel, _ := m.App.Session.Get(r.Context(), "two_factor_access_level").(int)
+	m.completeLogin(w, r, user, accessLevel)
+}
+
+// twoFactorSetupData gathers what the two-factor page shows. A user who
+// hasn't turned it on gets a secret to set up their app with, kept in the
+// session so it stays the same if they get the code wrong
+func (m *Handlers) twoFactorSetupData(r *http.Request, user models.User) (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	data["user"] = user
+
+	if user.HasTwoFactor() {
+		codesLeft, err := m.TwoFactorService.RecoveryCodesLeft(user.ID)
+		if err != nil {
+			log.Println(err)
+		}
+		required, err := m.TwoFactorService.IsRequired(user.ID)
+		if err != nil {
+			log.Println(err)
+		}
+		data["recovery_codes_left"] = codesLeft
+		data["is_required"] = required
+		return data, nil
+	}
+
+	secret := m.App.Session.GetString(r.Context(), "two_factor_secret")
+	uri := ""
+	if secret == "" {
+		var err error
+		secret, uri, err = m.TwoFactorService.NewSecret(user)
+		if err != nil {
+			return data, err
+		}
+		m.App.Session.Put(r.Context(), "two_factor_secret", secret)
+		m.App.Session.Put(r.Context(), "two_factor_uri", uri)
+	} else {
+		uri = m.App.Session.GetString(r.Context(), "two_factor_uri")
+	}
+
+	data["secret"] = secret
+	// html/template only allows http links, so mark the otpauth one as safe
+	data["uri"] = template.URL(uri)
+	return data, nil
+}
+
+// ShowTwoFactorSetup shows the logged in user's two-factor settings, or how to
+// turn it on
+func (m *Handlers) ShowTwoFactorSetup(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	data, err := m.twoFactorSetupData(r, user)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't set up two-factor authentication!")
+		http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+		return
+	}
+
+	render.Template(w, r, "two-factor.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// EnableTwoFactor handles the first code from a user's newly set up
+// authenticator app, and turns on two-factor authentication. Their recovery
+// codes are shown this once
+func (m *Handlers) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	secret := m.App.Session.GetString(r.Context(), "two_factor_secret")
+	if secret == "" || user.HasTwoFactor() {
+		http.Redirect(w, r, "/user/profile/two-factor", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("code")
+
+	if !form.Valid() {
+		data, _ := m.twoFactorSetupData(r, user)
+		render.Template(w, r, "two-factor.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	codes, err := m.TwoFactorService.EnableTwoFactor(user.ID, secret, r.Form.Get("code"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, "/user/profile/two-factor", http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Remove(r.Context(), "two_factor_secret")
+	m.App.Session.Remove(r.Context(), "two_factor_uri")
+
+	data := make(map[string]interface{})
+	data["user"] = user
+	data["recovery_codes"] = codes
+
+	m.App.Session.Put(r.Context(), "flash", "Two-factor authentication is on")
+	render.Template(w, r, "two-factor.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// DisableTwoFactor handles request to turn off two-factor authentication for
+// the logged in user, who has to enter a code to do it
+func (m *Handlers) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	err = m.TwoFactorService.DisableTwoFactor(user, r.Form.Get("code"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, "/user/profile/two-factor", http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "Two-factor authentication is off")
+	http.Redirect(w, r, "/user/profile", http.StatusSeeOther)
+}
+
+// UpdateTwoFactorRequirement handles a super admin choosing whether every
+// commissioner has to use two-factor authentication
+func (m *Handlers) UpdateTwoFactorRequirement(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	required := r.Form.Get("required") == "true"
+
+	err = m.TwoFactorService.SetRequiredForCommissioners(required)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't save setting!")
+		http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+		return
+	}
+
+	if required {
+		m.App.Session.Put(r.Context(), "flash", "Commissioners now need two-factor authentication")
+	} else {
+		m.App.Session.Put(r.Context(), "flash", "Commissioners no longer need two-factor authentication")
+	}
+	http.Redirect(w, r, "/admin/dashboard", http.StatusSeeOther)
+}
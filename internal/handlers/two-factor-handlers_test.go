@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+var showTwoFactorLoginTests = []struct {
+	name               string
+	userID             int
+	startedAt          time.Time
+	expectedStatusCode int
+}{
+	{
+		name:               "no password entered",
+		userID:             0,
+		startedAt:          time.Now(),
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "password entered too long ago",
+		userID:             10,
+		startedAt:          time.Now().Add(-time.Hour),
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "user without two-factor",
+		userID:             1,
+		startedAt:          time.Now(),
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "happy path",
+		userID:             10,
+		startedAt:          time.Now(),
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowTwoFactorLogin(t *testing.T) {
+	for _, e := range showTwoFactorLoginTests {
+		req, _ := http.NewRequest("GET", "/user/login/two-factor", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		session.Put(req.Context(), "two_factor_user_id", e.userID)
+		session.Put(req.Context(), "two_factor_started_at", e.startedAt)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowTwoFactorLogin)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+	}
+}
+
+var postTwoFactorLoginTests = []struct {
+	name               string
+	userID             int
+	code               string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "no password entered",
+		userID:             0,
+		code:               "123456",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "missing code",
+		userID:             10,
+		code:               "",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "wrong code",
+		userID:             10,
+		code:               "000000",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login/two-factor",
+	},
+	{
+		name:               "happy path",
+		userID:             10,
+		code:               "123456",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+}
+
+func TestPostTwoFactorLogin(t *testing.T) {
+	for _, e := range postTwoFactorLoginTests {
+		postedData := url.Values{}
+		postedData.Add("code", e.code)
+
+		req, _ := http.NewRequest("POST", "/user/login/two-factor", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "two_factor_user_id", e.userID)
+		session.Put(req.Context(), "two_factor_started_at", time.Now())
+
+		handler := http.HandlerFunc(Handler.PostTwoFactorLogin)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+
+		loggedIn := session.GetInt(req.Context(), "user_id") == e.userID
+		if e.expectedLocation == "/" && !loggedIn {
+			t.Errorf("failed %s: expected to be logged in, but wasn't", e.name)
+		}
+		if e.expectedLocation != "/" && session.Exists(req.Context(), "user_id") {
+			t.Errorf("failed %s: expected not to be logged in, but was", e.name)
+		}
+	}
+}
+
+var showTwoFactorSetupTests = []struct {
+	name               string
+	userID             int
+	expectedStatusCode int
+	expectedSecret     bool
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "setting up",
+		userID:             1,
+		expectedStatusCode: http.StatusOK,
+		expectedSecret:     true,
+	},
+	{
+		name:               "already on",
+		userID:             10,
+		expectedStatusCode: http.StatusOK,
+	},
+}
+
+func TestShowTwoFactorSetup(t *testing.T) {
+	for _, e := range showTwoFactorSetupTests {
+		req, _ := http.NewRequest("GET", "/user/profile/two-factor", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ShowTwoFactorSetup)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		hasSecret := session.GetString(req.Context(), "two_factor_secret") != ""
+		if hasSecret != e.expectedSecret {
+			t.Errorf("failed %s: expected a secret in the session to be %t, but got %t", e.name, e.expectedSecret, hasSecret)
+		}
+	}
+}
+
+var enableTwoFactorTests = []struct {
+	name               string
+	userID             int
+	secret             string
+	code               string
+	expectedStatusCode int
+	expectedLocation   string
+	expectedHTML       string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		secret:             "JBSWY3DPEHPK3PXP",
+		code:               "123456",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "no secret",
+		userID:             1,
+		secret:             "",
+		code:               "123456",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/profile/two-factor",
+	},
+	{
+		name:               "missing code",
+		userID:             1,
+		secret:             "JBSWY3DPEHPK3PXP",
+		code:               "",
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "wrong code",
+		userID:             1,
+		secret:             "JBSWY3DPEHPK3PXP",
+		code:               "000000",
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/profile/two-factor",
+	},
+	{
+		name:               "happy path",
+		userID:             1,
+		secret:             "JBSWY3DPEHPK3PXP",
+		code:               "123456",
+		expectedStatusCode: http.StatusOK,
+		expectedHTML:       "abcd-2345",
+	},
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	for _, e := range enableTwoFactorTests {
+		postedData := url.Values{}
+		postedData.Add("code", e.code)
+
+		req, _ := http.NewRequest("POST", "/user/profile/two-factor", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+		session.Put(req.Context(), "two_factor_secret", e.secret)
+
+		handler := http.HandlerFunc(Handler.EnableTwoFactor)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+
+		if e.expectedHTML != "" {
+			if !strings.Contains(rr.Body.String(), e.expectedHTML) {
+				t.Errorf("failed %s: expected to find %s but did not", e.name, e.expectedHTML)
+			}
+		}
+	}
+}
+
+var disableTwoFactorTests = []struct {
+	name             string
+	userID           int
+	code             string
+	expectedLocation string
+}{
+	{
+		name:             "user not found",
+		userID:           0,
+		code:             "123456",
+		expectedLocation: "/user/login",
+	},
+	{
+		name:             "required for commissioners",
+		userID:           11,
+		code:             "123456",
+		expectedLocation: "/user/profile/two-factor",
+	},
+	{
+		name:             "wrong code",
+		userID:           10,
+		code:             "000000",
+		expectedLocation: "/user/profile/two-factor",
+	},
+	{
+		name:             "happy path",
+		userID:           10,
+		code:             "123456",
+		expectedLocation: "/user/profile",
+	},
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	for _, e := range disableTwoFactorTests {
+		postedData := url.Values{}
+		postedData.Add("code", e.code)
+
+		req, _ := http.NewRequest("POST", "/user/profile/two-factor/disable", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.DisableTwoFactor)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
+
+func TestUpdateTwoFactorRequirement(t *testing.T) {
+	for _, required := range []string{"true", "false"} {
+		postedData := url.Values{}
+		postedData.Add("required", required)
+
+		req, _ := http.NewRequest("POST", "/admin/settings/two-factor", strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		handler := http.HandlerFunc(Handler.UpdateTwoFactorRequirement)
+		handler.ServeHTTP(rr, req)
+
+		actualLoc, _ := rr.Result().Location()
+		if rr.Code != http.StatusSeeOther || actualLoc.String() != "/admin/dashboard" {
+			t.Errorf("failed required %s: expected a redirect to /admin/dashboard, but got %d to %s", required, rr.Code, actualLoc.String())
+		}
+	}
+}
+
+var needsTwoFactorTests = []struct {
+	name     string
+	userID   int
+	expected bool
+}{
+	{"user not found", 0, false},
+	{"not required", 1, false},
+	{"required and not turned on", 11, true},
+	{"required and turned on", 10, false},
+}
+
+func TestNeedsTwoFactor(t *testing.T) {
+	for _, e := range needsTwoFactorTests {
+		if Handler.NeedsTwoFactor(e.userID) != e.expected {
+			t.Errorf("failed %s: expected %t, but got %t", e.name, e.expected, !e.expected)
+		}
+	}
+}
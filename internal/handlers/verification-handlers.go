@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+const verifyTokenIndex = 3
+
+// isVerified reports whether the user has verified their email address, and
+// keeps the banner asking them to in step
+func (m *Handlers) isVerified(r *http.Request, user models.User) bool {
+	if user.IsVerified() {
+		m.App.Session.Remove(r.Context(), "unverified")
+		return true
+	}
+	m.App.Session.Put(r.Context(), "unverified", true)
+	return false
+}
+
+// sendVerificationEmail emails a user a link to verify their email address
+func (m *Handlers) sendVerificationEmail(user models.User, token string) {
+	link := fmt.Sprintf("%s/user/verify-email/%s", m.App.BaseURL, token)
+
+	content := fmt.Sprintf(`
+		<p>Hi %s,</p>
+		<p>Confirm this is your email address so you can create leagues and get league emails:</p>
+		<p><a href="%s">%s</a></p>
+		<p>This link expires in %d hours.</p>`,
+		template.HTMLEscapeString(user.FirstName),
+		link,
+		link,
+		int(models.EmailVerificationLifetime.Hours()),
+	)
+
+	m.sendEmail(user.Email, "Verify your email address", content)
+}
+
+// VerifyEmail handles the link emailed to verify a user's email address
+func (m *Handlers) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token, err := getTokenFromURI(r.RequestURI, verifyTokenIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	verifiedID, err := m.UserService.VerifyEmail(token)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "this verification link is no longer valid")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if userID == verifiedID {
+		m.App.Session.Remove(r.Context(), "unverified")
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "Email address verified!")
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// ResendVerificationEmail handles request to email the logged in user a new
+// link to verify their email address
+func (m *Handlers) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	user, err := m.UserService.GetUser(userID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return
+	}
+
+	if m.isVerified(r, user) {
+		m.App.Session.Put(r.Context(), "flash", "Your email address is already verified")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	token, err := m.UserService.RequestEmailVerification(user)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't send verification email right now, try again later")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	m.sendVerificationEmail(user, token)
+
+	m.App.Session.Put(r.Context(), "flash", fmt.Sprintf("Verification link sent to %s", user.Email))
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var verifyEmailTests = []struct {
+	name               string
+	url                string
+	userID             int
+	expectedStatusCode int
+	expectedVerified   bool
+}{
+	{
+		name:               "missing token",
+		url:                "/user/verify-email/",
+		userID:             1,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "invalid token",
+		url:                "/user/verify-email/expired",
+		userID:             1,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "verified from another account's session",
+		url:                "/user/verify-email/token",
+		userID:             3,
+		expectedStatusCode: http.StatusSeeOther,
+	},
+	{
+		name:               "happy path",
+		url:                "/user/verify-email/token",
+		userID:             1,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedVerified:   true,
+	},
+}
+
+func TestVerifyEmail(t *testing.T) {
+	for _, e := range verifyEmailTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		session.Put(req.Context(), "user_id", e.userID)
+		session.Put(req.Context(), "unverified", true)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.VerifyEmail)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("%s returned wrong response code: got %d, wanted %d", e.name, rr.Code, e.expectedStatusCode)
+		}
+
+		if session.GetBool(req.Context(), "unverified") == e.expectedVerified {
+			t.Errorf("failed %s: expected verified to be %t in the session", e.name, e.expectedVerified)
+		}
+	}
+}
+
+var resendVerificationEmailTests = []struct {
+	name               string
+	userID             int
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{
+		name:               "user not found",
+		userID:             0,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/user/login",
+	},
+	{
+		name:               "already verified",
+		userID:             1,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "service error",
+		userID:             9,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+	{
+		name:               "happy path",
+		userID:             7,
+		expectedStatusCode: http.StatusSeeOther,
+		expectedLocation:   "/",
+	},
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	for _, e := range resendVerificationEmailTests {
+		req, _ := http.NewRequest("POST", "/user/verify-email", nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		rr := httptest.NewRecorder()
+		handler := http.HandlerFunc(Handler.ResendVerificationEmail)
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/jdonahue135/golf-league-app/internal/forms"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/render"
+)
+
+// webhookIDIndex is where the webhook id is in /leagues/{id}/webhooks/{webhook_id}
+const webhookIDIndex = 4
+
+// webhookPlayer is who a player.added or player.removed event is about. A
+// player added without an account has no user id until they claim it
+type webhookPlayer struct {
+	PlayerID  int    `json:"player_id,omitempty"`
+	UserID    int    `json:"user_id,omitempty"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// publishWebhookEvent queues an event for the league's webhooks and wakes up
+// the webhook sender. The event is only logged if it can't be queued, since
+// whatever happened in the league already happened
+func (m *Handlers) publishWebhookEvent(leagueID int, event string, data interface{}) {
+	err := m.WebhookService.Publish(leagueID, event, data)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	m.sendWebhooks()
+}
+
+// sendWebhooks tells the webhook sender there are deliveries waiting, without
+// waiting for it if it's busy
+func (m *Handlers) sendWebhooks() {
+	select {
+	case m.App.WebhookChan <- true:
+	default:
+	}
+}
+
+// webhookLeague returns the league in the URI after checking the user is its
+// commissioner. It redirects and returns false if not
+func (m *Handlers) webhookLeague(w http.ResponseWriter, r *http.Request) (models.League, bool) {
+	userID, _ := m.App.Session.Get(r.Context(), "user_id").(int)
+	if _, err := m.UserService.GetUser(userID); err != nil {
+		m.App.Session.Put(r.Context(), "error", "user not found!")
+		http.Redirect(w, r, "/user/login", http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	leagueID, err := getLeagueIDFromURI(r.RequestURI)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	membership, err := m.LeagueRoleService.GetMembership(userID, leagueID)
+	if err != nil || !membership.CanManageWebhooks() {
+		m.App.Session.Put(r.Context(), "error", "user must be league commissioner to manage webhooks!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", leagueID), http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	league, err := m.LeagueService.GetLeague(leagueID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot find league")
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return models.League{}, false
+	}
+
+	return league, true
+}
+
+// leagueWebhook returns the webhook in the URI if it belongs to the league. It
+// redirects and returns false if not
+func (m *Handlers) leagueWebhook(w http.ResponseWriter, r *http.Request, league models.League) (models.Webhook, bool) {
+	webhookID, err := getIDFromURI(r.RequestURI, webhookIDIndex)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "missing url parameter")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks", league.ID), http.StatusSeeOther)
+		return models.Webhook{}, false
+	}
+
+	webhook, err := m.WebhookService.GetWebhook(webhookID)
+	if err != nil || webhook.LeagueID != league.ID {
+		m.App.Session.Put(r.Context(), "error", "cannot find webhook")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks", league.ID), http.StatusSeeOther)
+		return models.Webhook{}, false
+	}
+
+	return webhook, true
+}
+
+// Webhooks renders the page where the commissioner chooses which URLs are
+// told about what happens in the league
+func (m *Handlers) Webhooks(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.webhookLeague(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := m.WebhookService.GetWebhooks(league.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get webhooks for league")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["webhooks"] = webhooks
+
+	render.Template(w, r, "webhooks.page.tmpl", &models.TemplateData{
+		Form: forms.New(nil),
+		Data: data,
+	})
+}
+
+// CreateWebhook handles request to send the league's events to a new URL
+func (m *Handlers) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.webhookLeague(w, r)
+	if !ok {
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		log.Println(err)
+	}
+
+	form := forms.New(r.PostForm)
+	form.Required("url")
+	form.MaxLength("url", 2048)
+
+	if !form.Valid() {
+		webhooks, err := m.WebhookService.GetWebhooks(league.ID)
+		if err != nil {
+			m.App.Session.Put(r.Context(), "error", "cannot get webhooks for league")
+			http.Redirect(w, r, fmt.Sprintf("/leagues/%d", league.ID), http.StatusSeeOther)
+			return
+		}
+
+		data := make(map[string]interface{})
+		data["league"] = league
+		data["webhooks"] = webhooks
+
+		render.Template(w, r, "webhooks.page.tmpl", &models.TemplateData{
+			Form: form,
+			Data: data,
+		})
+		return
+	}
+
+	webhook, err := m.WebhookService.CreateWebhook(league.ID, form.Get("url"))
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", err.Error())
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "webhook added! Send a test event to check it works")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks/%d", league.ID, webhook.ID), http.StatusSeeOther)
+}
+
+// ShowWebhook renders a webhook's secret and the log of what was sent to it
+func (m *Handlers) ShowWebhook(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.webhookLeague(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, ok := m.leagueWebhook(w, r, league)
+	if !ok {
+		return
+	}
+
+	deliveries, err := m.WebhookService.GetDeliveries(webhook.ID)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "cannot get deliveries for webhook")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks", league.ID), http.StatusSeeOther)
+		return
+	}
+
+	data := make(map[string]interface{})
+	data["league"] = league
+	data["webhook"] = webhook
+	data["deliveries"] = deliveries
+	data["max_attempts"] = models.WebhookMaxAttempts
+
+	render.Template(w, r, "webhook.page.tmpl", &models.TemplateData{
+		Data: data,
+	})
+}
+
+// SendTestWebhook handles request to send a test event to a webhook
+func (m *Handlers) SendTestWebhook(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.webhookLeague(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, ok := m.leagueWebhook(w, r, league)
+	if !ok {
+		return
+	}
+
+	err := m.WebhookService.SendTestEvent(webhook)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't send test event!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks/%d", league.ID, webhook.ID), http.StatusSeeOther)
+		return
+	}
+	m.sendWebhooks()
+
+	m.App.Session.Put(r.Context(), "flash", "test event sent! Refresh to see how it went")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks/%d", league.ID, webhook.ID), http.StatusSeeOther)
+}
+
+// DeleteWebhook handles request to stop sending the league's events to a URL
+func (m *Handlers) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	league, ok := m.webhookLeague(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, ok := m.leagueWebhook(w, r, league)
+	if !ok {
+		return
+	}
+
+	err := m.WebhookService.DeleteWebhook(webhook)
+	if err != nil {
+		m.App.Session.Put(r.Context(), "error", "can't delete webhook!")
+		http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks/%d", league.ID, webhook.ID), http.StatusSeeOther)
+		return
+	}
+
+	m.App.Session.Put(r.Context(), "flash", "webhook deleted!")
+	http.Redirect(w, r, fmt.Sprintf("/leagues/%d/webhooks", league.ID), http.StatusSeeOther)
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var webhooksTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"user not found", 0, "/leagues/1/webhooks", http.StatusSeeOther, "/user/login"},
+	{"bad url parameter", 1, "/leagues/s/webhooks", http.StatusSeeOther, "/"},
+	{"not commissioner", 3, "/leagues/1/webhooks", http.StatusSeeOther, "/leagues/1"},
+	{"co-commissioner", 12, "/leagues/1/webhooks", http.StatusSeeOther, "/leagues/1"},
+	{"league not found", 1, "/leagues/3/webhooks", http.StatusSeeOther, "/"},
+	{"service error", 1, "/leagues/5/webhooks", http.StatusSeeOther, "/leagues/5"},
+	{"valid", 1, "/leagues/1/webhooks", http.StatusOK, ""},
+}
+
+func TestWebhooks(t *testing.T) {
+	for _, e := range webhooksTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.Webhooks)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var createWebhookTests = []struct {
+	name               string
+	userID             int
+	url                string
+	webhookURL         string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"user not found", 0, "/leagues/1/webhooks", "https://hooks.example.com", http.StatusSeeOther, "/user/login"},
+	{"not commissioner", 3, "/leagues/1/webhooks", "https://hooks.example.com", http.StatusSeeOther, "/leagues/1"},
+	{"missing url", 1, "/leagues/1/webhooks", "", http.StatusOK, ""},
+	{"missing url and service error", 1, "/leagues/5/webhooks", "", http.StatusSeeOther, "/leagues/5"},
+	{"service error", 1, "/leagues/1/webhooks", "https://error.example.com", http.StatusSeeOther, "/leagues/1/webhooks"},
+	{"valid", 1, "/leagues/1/webhooks", "https://hooks.example.com", http.StatusSeeOther, "/leagues/1/webhooks/1"},
+}
+
+func TestCreateWebhook(t *testing.T) {
+	for _, e := range createWebhookTests {
+		postedData := url.Values{}
+		postedData.Add("url", e.webhookURL)
+
+		req, _ := http.NewRequest("POST", e.url, strings.NewReader(postedData.Encode()))
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.CreateWebhook)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var showWebhookTests = []struct {
+	name               string
+	userID             int
+	url                string
+	expectedStatusCode int
+	expectedLocation   string
+}{
+	{"user not found", 0, "/leagues/1/webhooks/1", http.StatusSeeOther, "/user/login"},
+	{"not commissioner", 3, "/leagues/1/webhooks/1", http.StatusSeeOther, "/leagues/1"},
+	{"bad webhook parameter", 1, "/leagues/1/webhooks/s", http.StatusSeeOther, "/leagues/1/webhooks"},
+	{"webhook not found", 1, "/leagues/1/webhooks/3", http.StatusSeeOther, "/leagues/1/webhooks"},
+	{"webhook in another league", 1, "/leagues/1/webhooks/4", http.StatusSeeOther, "/leagues/1/webhooks"},
+	{"deliveries error", 1, "/leagues/1/webhooks/6", http.StatusSeeOther, "/leagues/1/webhooks"},
+	{"valid", 1, "/leagues/1/webhooks/1", http.StatusOK, ""},
+}
+
+func TestShowWebhook(t *testing.T) {
+	for _, e := range showWebhookTests {
+		req, _ := http.NewRequest("GET", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		handler := http.HandlerFunc(Handler.ShowWebhook)
+		handler.ServeHTTP(rr, req)
+		if rr.Code != e.expectedStatusCode {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, e.expectedStatusCode, rr.Code)
+		}
+
+		if e.expectedLocation != "" {
+			actualLoc, _ := rr.Result().Location()
+			if actualLoc.String() != e.expectedLocation {
+				t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+			}
+		}
+	}
+}
+
+var webhookActionTests = []struct {
+	name             string
+	handler          func(*Handlers, http.ResponseWriter, *http.Request)
+	userID           int
+	url              string
+	expectedLocation string
+}{
+	{"test: user not found", (*Handlers).SendTestWebhook, 0, "/leagues/1/webhooks/1/test", "/user/login"},
+	{"test: not commissioner", (*Handlers).SendTestWebhook, 3, "/leagues/1/webhooks/1/test", "/leagues/1"},
+	{"test: webhook not found", (*Handlers).SendTestWebhook, 1, "/leagues/1/webhooks/3/test", "/leagues/1/webhooks"},
+	{"test: service error", (*Handlers).SendTestWebhook, 1, "/leagues/1/webhooks/7/test", "/leagues/1/webhooks/7"},
+	{"test: valid", (*Handlers).SendTestWebhook, 1, "/leagues/1/webhooks/1/test", "/leagues/1/webhooks/1"},
+	{"delete: user not found", (*Handlers).DeleteWebhook, 0, "/leagues/1/webhooks/1/delete", "/user/login"},
+	{"delete: not commissioner", (*Handlers).DeleteWebhook, 3, "/leagues/1/webhooks/1/delete", "/leagues/1"},
+	{"delete: webhook in another league", (*Handlers).DeleteWebhook, 1, "/leagues/1/webhooks/4/delete", "/leagues/1/webhooks"},
+	{"delete: service error", (*Handlers).DeleteWebhook, 1, "/leagues/1/webhooks/5/delete", "/leagues/1/webhooks/5"},
+	{"delete: valid", (*Handlers).DeleteWebhook, 1, "/leagues/1/webhooks/1/delete", "/leagues/1/webhooks"},
+}
+
+func TestWebhookActions(t *testing.T) {
+	for _, e := range webhookActionTests {
+		req, _ := http.NewRequest("POST", e.url, nil)
+		ctx := getCtx(req)
+		req = req.WithContext(ctx)
+		req.RequestURI = e.url
+
+		rr := httptest.NewRecorder()
+
+		session.Put(req.Context(), "user_id", e.userID)
+
+		e.handler(Handler, rr, req)
+		if rr.Code != http.StatusSeeOther {
+			t.Errorf("failed %s: expected code %d, but got %d", e.name, http.StatusSeeOther, rr.Code)
+		}
+
+		actualLoc, _ := rr.Result().Location()
+		if actualLoc.String() != e.expectedLocation {
+			t.Errorf("failed %s: expected location %s, but got location %s", e.name, e.expectedLocation, actualLoc.String())
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package ical writes iCalendar (RFC 5545) feeds that calendar apps can
+// subscribe to
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID identifies the app as the maker of its feeds
+const ProdID = "-//Golf League App//Schedule//EN"
+
+// lineLength is the most octets a content line can have before it has to be
+// folded onto the next line
+const lineLength = 75
+
+const (
+	dateLayout      = "20060102"
+	localLayout     = "20060102T150405"
+	utcLayout       = "20060102T150405Z"
+	statusOK        = "CONFIRMED"
+	statusCancelled = "CANCELLED"
+)
+
+// Event is one event in a calendar. An all day event only uses the dates of
+// Start and End, and End is the day after it ends. A floating event happens
+// at the same clock time wherever the calendar is, otherwise times are sent
+// in UTC. Calendars replace an event with the one with the same UID and the
+// highest Sequence
+type Event struct {
+	UID         string
+	Sequence    int
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Floating    bool
+	Summary     string
+	Location    string
+	Description string
+	Cancelled   bool
+}
+
+// Calendar is a named list of events
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// WriteTo writes the calendar in iCalendar format
+func (c Calendar) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	writeLine(&buf, "BEGIN", "VCALENDAR")
+	writeLine(&buf, "VERSION", "2.0")
+	writeLine(&buf, "PRODID", ProdID)
+	writeLine(&buf, "CALSCALE", "GREGORIAN")
+	writeLine(&buf, "METHOD", "PUBLISH")
+	if c.Name != "" {
+		writeLine(&buf, "X-WR-CALNAME", Escape(c.Name))
+	}
+
+	for _, e := range c.Events {
+		e.write(&buf)
+	}
+
+	writeLine(&buf, "END", "VCALENDAR")
+
+	n, err := w.Write(buf.Bytes())
+	return int64(n), err
+}
+
+func (e Event) write(buf *bytes.Buffer) {
+	writeLine(buf, "BEGIN", "VEVENT")
+	writeLine(buf, "UID", e.UID)
+	writeLine(buf, "SEQUENCE", fmt.Sprint(e.Sequence))
+	writeLine(buf, "DTSTAMP", e.Stamp.UTC().Format(utcLayout))
+
+	switch {
+	case e.AllDay:
+		writeLine(buf, "DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+		writeLine(buf, "DTEND;VALUE=DATE", e.End.Format(dateLayout))
+	case e.Floating:
+		writeLine(buf, "DTSTART", e.Start.Format(localLayout))
+		writeLine(buf, "DTEND", e.End.Format(localLayout))
+	default:
+		writeLine(buf, "DTSTART", e.Start.UTC().Format(utcLayout))
+		writeLine(buf, "DTEND", e.End.UTC().Format(utcLayout))
+	}
+
+	writeLine(buf, "SUMMARY", Escape(e.Summary))
+	if e.Location != "" {
+		writeLine(buf, "LOCATION", Escape(e.Location))
+	}
+	if e.Description != "" {
+		writeLine(buf, "DESCRIPTION", Escape(e.Description))
+	}
+
+	status := statusOK
+	if e.Cancelled {
+		status = statusCancelled
+	}
+	writeLine(buf, "STATUS", status)
+
+	writeLine(buf, "END", "VEVENT")
+}
+
+// Escape escapes the characters that mean something in a text value
+func Escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// writeLine writes a content line ended with CRLF, folding it so no line is
+// longer than lineLength octets. Lines are never folded inside a character
+func writeLine(buf *bytes.Buffer, name, value string) {
+	line := name + ":" + value
+
+	limit := lineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+
+		// the space starting a folded line counts toward its length
+		limit = lineLength - 1
+	}
+
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,133 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func write(t *testing.T, c Calendar) string {
+	var buf bytes.Buffer
+	if _, err := c.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestCalendar_WriteTo(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 7, 3, 17, 30, 0, 0, chicago)
+	out := write(t, Calendar{
+		Name: "Tuesday League",
+		Events: []Event{
+			{
+				UID:      "week-1@golfleague.app",
+				Sequence: 2,
+				Stamp:    time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+				Start:    start,
+				End:      start.Add(2 * time.Hour),
+				Summary:  "Week 1 vs. Smith, Jane",
+				Location: "Pine Valley",
+			},
+		},
+	})
+
+	expected := []string{
+		"BEGIN:VCALENDAR\r\n",
+		"VERSION:2.0\r\n",
+		"X-WR-CALNAME:Tuesday League\r\n",
+		"UID:week-1@golfleague.app\r\n",
+		"SEQUENCE:2\r\n",
+		"DTSTAMP:20240701T120000Z\r\n",
+		// 5:30 pm in Chicago during daylight saving time
+		"DTSTART:20240703T223000Z\r\n",
+		"DTEND:20240704T003000Z\r\n",
+		`SUMMARY:Week 1 vs. Smith\, Jane` + "\r\n",
+		"LOCATION:Pine Valley\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(out, e) {
+			t.Errorf("expected %q in\n%s", e, out)
+		}
+	}
+	if strings.Contains(out, "DESCRIPTION") {
+		t.Error("expected no description for an event without one")
+	}
+}
+
+func TestCalendar_WriteTo_Cancelled(t *testing.T) {
+	out := write(t, Calendar{Events: []Event{{UID: "week-1@golfleague.app", Cancelled: true}}})
+
+	if !strings.Contains(out, "STATUS:CANCELLED\r\n") {
+		t.Errorf("expected a cancelled event, but got\n%s", out)
+	}
+}
+
+func TestCalendar_WriteTo_AllDay(t *testing.T) {
+	out := write(t, Calendar{Events: []Event{{
+		Start:  time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC),
+		End:    time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC),
+		AllDay: true,
+	}}})
+
+	for _, e := range []string{"DTSTART;VALUE=DATE:20240703\r\n", "DTEND;VALUE=DATE:20240704\r\n"} {
+		if !strings.Contains(out, e) {
+			t.Errorf("expected %q in\n%s", e, out)
+		}
+	}
+}
+
+func TestCalendar_WriteTo_Floating(t *testing.T) {
+	start := time.Date(2024, 7, 3, 17, 30, 0, 0, time.UTC)
+	out := write(t, Calendar{Events: []Event{{Start: start, End: start.Add(time.Hour), Floating: true}}})
+
+	for _, e := range []string{"DTSTART:20240703T173000\r\n", "DTEND:20240703T183000\r\n"} {
+		if !strings.Contains(out, e) {
+			t.Errorf("expected %q in\n%s", e, out)
+		}
+	}
+}
+
+func TestEscape(t *testing.T) {
+	got := Escape("a\\b;c,d\ne")
+	expected := `a\\b\;c\,d\ne`
+	if got != expected {
+		t.Errorf("expected %s, but got %s", expected, got)
+	}
+}
+
+func TestWriteLine(t *testing.T) {
+	var buf bytes.Buffer
+	value := strings.Repeat("é", 100)
+	writeLine(&buf, "DESCRIPTION", value)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("expected a long line to be folded, but got %d lines", len(lines))
+	}
+
+	var unfolded string
+	for i, line := range lines {
+		if len(line) > lineLength {
+			t.Errorf("expected at most %d octets, but line %d has %d", lineLength, i, len(line))
+		}
+		if i > 0 {
+			if !strings.HasPrefix(line, " ") {
+				t.Errorf("expected line %d to start with a space", i)
+			}
+			line = line[1:]
+		}
+		unfolded += line
+	}
+
+	if unfolded != "DESCRIPTION:"+value {
+		t.Error("expected the folded lines to unfold to the original line")
+	}
+}
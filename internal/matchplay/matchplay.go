@@ -0,0 +1,186 @@
+// Package matchplay scores net match play matches hole by hole, giving the
+// higher handicap player the difference in strokes by hole stroke index
+package matchplay
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/jdonahue135/golf-league-app/internal/handicap"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// Options are a league's match play settings
+type Options struct {
+	// Allowance is the share of the handicap difference given in strokes,
+	// 1 for the full difference
+	Allowance float64
+	// WinPoints are awarded for winning the match and HalvePoints to each
+	// player for a halved match
+	WinPoints   float64
+	HalvePoints float64
+	// HolePoints are awarded for each hole won, half each for a halved hole
+	HolePoints float64
+}
+
+// DefaultOptions give the full handicap difference and only award points for
+// the match result
+var DefaultOptions = Options{
+	Allowance:   1,
+	WinPoints:   2,
+	HalvePoints: 1,
+}
+
+// StrokesGiven returns the strokes the higher handicap player receives: the
+// difference between the course handicaps after the allowance, rounded
+func StrokesGiven(homeHandicap, awayHandicap int, allowance float64) int {
+	difference := math.Abs(float64(homeHandicap - awayHandicap))
+	return int(math.Round(difference * allowance))
+}
+
+// Play scores a match between the home and away players from their hole
+// scores. Holes are played in order until one is missing a score from either
+// player, and the match ends as soon as one player is up by more holes than
+// remain. Holes played after that don't count
+func Play(holes []models.Hole, home, away []models.HoleScore, homeHandicap, awayHandicap int, options Options) models.MatchResult {
+	holes = append([]models.Hole(nil), holes...)
+	sort.Slice(holes, func(i, j int) bool {
+		return holes[i].Number < holes[j].Number
+	})
+
+	homeScores := scoresByHole(home)
+	awayScores := scoresByHole(away)
+
+	result := models.MatchResult{HolesRemaining: len(holes)}
+	strokes := StrokesGiven(homeHandicap, awayHandicap, options.Allowance)
+	if homeHandicap > awayHandicap {
+		result.HomeStrokesReceived = strokes
+	} else {
+		result.AwayStrokesReceived = strokes
+	}
+
+	homeStrokes := handicap.StrokesByHole(result.HomeStrokesReceived, holes)
+	awayStrokes := handicap.StrokesByHole(result.AwayStrokesReceived, holes)
+
+	for _, h := range holes {
+		if homeScores[h.Number] <= 0 || awayScores[h.Number] <= 0 || isDecided(result) {
+			break
+		}
+
+		hole := models.HoleResult{
+			HoleNumber:          h.Number,
+			HomeStrokes:         homeScores[h.Number],
+			AwayStrokes:         awayScores[h.Number],
+			HomeStrokesReceived: homeStrokes[h.Number],
+			AwayStrokesReceived: awayStrokes[h.Number],
+		}
+		hole.HomeNet = hole.HomeStrokes - hole.HomeStrokesReceived
+		hole.AwayNet = hole.AwayStrokes - hole.AwayStrokesReceived
+
+		switch {
+		case hole.HomeNet < hole.AwayNet:
+			hole.Winner = models.MatchHome
+			result.HomeUp++
+		case hole.HomeNet > hole.AwayNet:
+			hole.Winner = models.MatchAway
+			result.HomeUp--
+		default:
+			hole.Winner = models.MatchHalved
+		}
+		hole.HomeUp = result.HomeUp
+
+		result.Holes = append(result.Holes, hole)
+		result.HolesPlayed++
+		result.HolesRemaining--
+	}
+
+	result.IsFinished = result.HolesPlayed > 0 && (isDecided(result) || result.HolesRemaining == 0)
+	result.Status = status(result)
+
+	result.HomePoints, result.AwayPoints = HolePoints(result, options.HolePoints)
+	if result.IsFinished {
+		switch {
+		case result.HomeUp > 0:
+			result.Winner = models.MatchHome
+			result.HomePoints += options.WinPoints
+		case result.HomeUp < 0:
+			result.Winner = models.MatchAway
+			result.AwayPoints += options.WinPoints
+		default:
+			result.Winner = models.MatchHalved
+			result.HomePoints += options.HalvePoints
+			result.AwayPoints += options.HalvePoints
+		}
+	}
+
+	return result
+}
+
+// HolePoints returns the points each player earns for the holes of a match
+// they won, with half each for a halved hole
+func HolePoints(result models.MatchResult, points float64) (home, away float64) {
+	for _, h := range result.Holes {
+		switch h.Winner {
+		case models.MatchHome:
+			home += points
+		case models.MatchAway:
+			away += points
+		default:
+			home += points / 2
+			away += points / 2
+		}
+	}
+	return home, away
+}
+
+// LeagueOptions returns the match play settings a league has chosen
+func LeagueOptions(league models.League) Options {
+	return Options{
+		Allowance:   league.MatchAllowance,
+		WinPoints:   league.WinPoints,
+		HalvePoints: league.HalvePoints,
+		HolePoints:  league.HolePoints,
+	}
+}
+
+func scoresByHole(scores []models.HoleScore) map[int]int {
+	byHole := make(map[int]int)
+	for _, s := range scores {
+		byHole[s.HoleNumber] = s.Strokes
+	}
+	return byHole
+}
+
+// isDecided reports whether the leader is up by more holes than remain
+func isDecided(result models.MatchResult) bool {
+	return abs(result.HomeUp) > result.HolesRemaining
+}
+
+// status describes the match the way it's read out on the course: "3&2" for a
+// match won early, "1 up" at the last hole, "Halved", or the lead through the
+// holes played so far
+func status(result models.MatchResult) string {
+	up := abs(result.HomeUp)
+
+	switch {
+	case result.HolesPlayed == 0:
+		return "Not started"
+	case !result.IsFinished && up == 0:
+		return fmt.Sprintf("All square thru %d", result.HolesPlayed)
+	case !result.IsFinished:
+		return fmt.Sprintf("%d up thru %d", up, result.HolesPlayed)
+	case up == 0:
+		return "Halved"
+	case result.HolesRemaining > 0:
+		return fmt.Sprintf("%d&%d", up, result.HolesRemaining)
+	}
+	return fmt.Sprintf("%d up", up)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
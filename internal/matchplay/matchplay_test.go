@@ -0,0 +1,307 @@
+package matchplay
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// strokeIndexes are the stroke indexes of holes one through nine
+var strokeIndexes = []int{3, 1, 5, 2, 4, 7, 9, 6, 8}
+
+func nine(indexes []int) []models.Hole {
+	var holes []models.Hole
+	for i, si := range indexes {
+		holes = append(holes, models.Hole{Number: i + 1, Par: 4, StrokeIndex: si})
+	}
+	return holes
+}
+
+func scores(strokes ...int) []models.HoleScore {
+	var s []models.HoleScore
+	for i, strokes := range strokes {
+		s = append(s, models.HoleScore{HoleNumber: i + 1, Strokes: strokes})
+	}
+	return s
+}
+
+var strokesGivenTests = []struct {
+	name         string
+	homeHandicap int
+	awayHandicap int
+	allowance    float64
+	expected     int
+}{
+	{"home gives strokes", 10, 4, 1, 6},
+	{"away gives strokes", 4, 10, 1, 6},
+	{"equal handicaps", 8, 8, 1, 0},
+	{"plus handicap", -2, 3, 1, 5},
+	{"ninety percent rounds down", 10, 4, 0.9, 5},
+	{"ninety percent rounds up", 15, 4, 0.9, 10},
+	{"half rounds away from zero", 9, 4, 0.5, 3},
+}
+
+func TestStrokesGiven(t *testing.T) {
+	for _, e := range strokesGivenTests {
+		if actual := StrokesGiven(e.homeHandicap, e.awayHandicap, e.allowance); actual != e.expected {
+			t.Errorf("failed %s: expected %d, but got %d", e.name, e.expected, actual)
+		}
+	}
+}
+
+var playTests = []struct {
+	name               string
+	home               []models.HoleScore
+	away               []models.HoleScore
+	homeHandicap       int
+	awayHandicap       int
+	options            Options
+	expectedStatus     string
+	expectedWinner     string
+	expectedHomeUp     int
+	expectedPlayed     int
+	expectedFinished   bool
+	expectedHomePoints float64
+	expectedAwayPoints float64
+}{
+	{
+		name:               "won early",
+		home:               scores(4, 4, 4, 4, 4, 4, 4, 4, 4),
+		away:               scores(5, 5, 5, 5, 4, 4, 3, 3, 3),
+		options:            DefaultOptions,
+		expectedStatus:     "4&3",
+		expectedWinner:     models.MatchHome,
+		expectedHomeUp:     4,
+		expectedPlayed:     6,
+		expectedFinished:   true,
+		expectedHomePoints: 2,
+	},
+	{
+		name:               "won on the last hole",
+		home:               scores(4, 4, 4, 4, 4, 4, 4, 4, 4),
+		away:               scores(4, 4, 4, 4, 4, 4, 4, 5, 5),
+		options:            DefaultOptions,
+		expectedStatus:     "2 up",
+		expectedWinner:     models.MatchHome,
+		expectedHomeUp:     2,
+		expectedPlayed:     9,
+		expectedFinished:   true,
+		expectedHomePoints: 2,
+	},
+	{
+		name:               "strokes on the hardest holes",
+		home:               scores(5, 5, 4, 5, 4, 4, 4, 4, 4),
+		away:               scores(4, 4, 4, 4, 4, 4, 4, 4, 4),
+		homeHandicap:       2,
+		options:            DefaultOptions,
+		expectedStatus:     "1 up",
+		expectedWinner:     models.MatchAway,
+		expectedHomeUp:     -1,
+		expectedPlayed:     9,
+		expectedFinished:   true,
+		expectedAwayPoints: 2,
+	},
+	{
+		name:               "halved",
+		home:               scores(4, 5, 4, 4, 4, 4, 4, 4, 4),
+		away:               scores(4, 4, 4, 4, 5, 4, 4, 4, 4),
+		homeHandicap:       5,
+		awayHandicap:       5,
+		options:            DefaultOptions,
+		expectedStatus:     "Halved",
+		expectedWinner:     models.MatchHalved,
+		expectedPlayed:     9,
+		expectedFinished:   true,
+		expectedHomePoints: 1,
+		expectedAwayPoints: 1,
+	},
+	{
+		name:               "full allowance",
+		home:               scores(5, 5, 5, 5, 5, 4, 4, 5, 4),
+		away:               scores(4, 4, 4, 4, 4, 4, 4, 4, 4),
+		homeHandicap:       10,
+		awayHandicap:       4,
+		options:            DefaultOptions,
+		expectedStatus:     "Halved",
+		expectedWinner:     models.MatchHalved,
+		expectedPlayed:     9,
+		expectedFinished:   true,
+		expectedHomePoints: 1,
+		expectedAwayPoints: 1,
+	},
+	{
+		name:               "percentage allowance",
+		home:               scores(5, 5, 5, 5, 5, 4, 4, 5, 4),
+		away:               scores(4, 4, 4, 4, 4, 4, 4, 4, 4),
+		homeHandicap:       10,
+		awayHandicap:       4,
+		options:            Options{Allowance: 0.5, WinPoints: 2, HalvePoints: 1},
+		expectedStatus:     "3&1",
+		expectedWinner:     models.MatchAway,
+		expectedHomeUp:     -3,
+		expectedPlayed:     8,
+		expectedFinished:   true,
+		expectedAwayPoints: 2,
+	},
+	{
+		name:               "more strokes than holes",
+		home:               scores(6, 6, 5, 6, 5, 5, 5, 5, 5),
+		away:               scores(4, 4, 4, 4, 4, 4, 4, 4, 4),
+		homeHandicap:       12,
+		options:            DefaultOptions,
+		expectedStatus:     "Halved",
+		expectedWinner:     models.MatchHalved,
+		expectedPlayed:     9,
+		expectedFinished:   true,
+		expectedHomePoints: 1,
+		expectedAwayPoints: 1,
+	},
+	{
+		name:               "hole points",
+		home:               scores(4, 4, 4, 4, 4, 4, 4, 4, 4),
+		away:               scores(5, 5, 5, 5, 4, 4, 3, 3, 3),
+		options:            Options{Allowance: 1, WinPoints: 2, HalvePoints: 1, HolePoints: 1},
+		expectedStatus:     "4&3",
+		expectedWinner:     models.MatchHome,
+		expectedHomeUp:     4,
+		expectedPlayed:     6,
+		expectedFinished:   true,
+		expectedHomePoints: 7,
+		expectedAwayPoints: 1,
+	},
+	{
+		name:             "in progress",
+		home:             scores(4, 4, 4, 4, 4, 4, 4),
+		away:             scores(4, 5, 4, 4, 4),
+		options:          Options{Allowance: 1, WinPoints: 2, HalvePoints: 1, HolePoints: 1},
+		expectedStatus:   "1 up thru 5",
+		expectedHomeUp:   1,
+		expectedPlayed:   5,
+		expectedFinished: false,
+		// hole points are awarded as holes are played
+		expectedHomePoints: 3,
+		expectedAwayPoints: 2,
+	},
+	{
+		name:           "all square in progress",
+		home:           scores(4, 5),
+		away:           scores(5, 4),
+		options:        DefaultOptions,
+		expectedStatus: "All square thru 2",
+		expectedPlayed: 2,
+	},
+	{
+		name:           "not started",
+		options:        DefaultOptions,
+		expectedStatus: "Not started",
+	},
+}
+
+func TestPlay(t *testing.T) {
+	for _, e := range playTests {
+		result := Play(nine(strokeIndexes), e.home, e.away, e.homeHandicap, e.awayHandicap, e.options)
+
+		if result.Status != e.expectedStatus {
+			t.Errorf("failed %s: expected status %q, but got %q", e.name, e.expectedStatus, result.Status)
+		}
+		if result.Winner != e.expectedWinner {
+			t.Errorf("failed %s: expected winner %q, but got %q", e.name, e.expectedWinner, result.Winner)
+		}
+		if result.HomeUp != e.expectedHomeUp {
+			t.Errorf("failed %s: expected home up %d, but got %d", e.name, e.expectedHomeUp, result.HomeUp)
+		}
+		if result.HolesPlayed != e.expectedPlayed || len(result.Holes) != e.expectedPlayed {
+			t.Errorf("failed %s: expected %d holes played, but got %d", e.name, e.expectedPlayed, result.HolesPlayed)
+		}
+		if result.HolesPlayed+result.HolesRemaining != 9 {
+			t.Errorf("failed %s: expected played and remaining holes to total 9, but got %d and %d", e.name, result.HolesPlayed, result.HolesRemaining)
+		}
+		if result.IsFinished != e.expectedFinished {
+			t.Errorf("failed %s: expected finished %t, but got %t", e.name, e.expectedFinished, result.IsFinished)
+		}
+		if result.HomePoints != e.expectedHomePoints || result.AwayPoints != e.expectedAwayPoints {
+			t.Errorf("failed %s: expected points %v-%v, but got %v-%v", e.name, e.expectedHomePoints, e.expectedAwayPoints, result.HomePoints, result.AwayPoints)
+		}
+	}
+}
+
+func TestPlayHoleResults(t *testing.T) {
+	result := Play(nine(strokeIndexes), scores(4, 5, 4, 5, 4, 4, 4, 4, 4), scores(5, 4, 4, 4, 4, 4, 4, 3, 4), 4, 7, DefaultOptions)
+
+	if result.HomeStrokesReceived != 0 || result.AwayStrokesReceived != 3 {
+		t.Fatalf("expected the away player to receive 3 strokes, but got %d and %d", result.HomeStrokesReceived, result.AwayStrokesReceived)
+	}
+
+	// the away player gets strokes on stroke indexes 1 to 3: holes 2, 4 and 1
+	expected := []struct {
+		received int
+		winner   string
+		homeUp   int
+	}{
+		{1, models.MatchHalved, 0},
+		{1, models.MatchAway, -1},
+		{0, models.MatchHalved, -1},
+		{1, models.MatchAway, -2},
+		{0, models.MatchHalved, -2},
+		{0, models.MatchHalved, -2},
+		{0, models.MatchHalved, -2},
+		{0, models.MatchAway, -3},
+	}
+
+	if len(result.Holes) != len(expected) {
+		t.Fatalf("expected the match to end after %d holes, but got %d", len(expected), len(result.Holes))
+	}
+	for i, e := range expected {
+		hole := result.Holes[i]
+		if hole.HoleNumber != i+1 || hole.AwayStrokesReceived != e.received || hole.HomeStrokesReceived != 0 {
+			t.Errorf("hole %d: expected the away player to receive %d, but got %+v", i+1, e.received, hole)
+		}
+		if hole.AwayNet != hole.AwayStrokes-hole.AwayStrokesReceived {
+			t.Errorf("hole %d: expected away net %d, but got %d", i+1, hole.AwayStrokes-hole.AwayStrokesReceived, hole.AwayNet)
+		}
+		if hole.Winner != e.winner || hole.HomeUp != e.homeUp {
+			t.Errorf("hole %d: expected %s with home %d up, but got %s with home %d up", i+1, e.winner, e.homeUp, hole.Winner, hole.HomeUp)
+		}
+	}
+	if result.Status != "3&1" || result.Winner != models.MatchAway {
+		t.Errorf("expected the away player to win 3&1, but got %s for %s", result.Status, result.Winner)
+	}
+}
+
+func TestPlayNineOfEighteen(t *testing.T) {
+	// the back nine of an eighteen hole course has even stroke indexes
+	holes := nine([]int{10, 2, 14, 6, 18, 4, 12, 16, 8})
+	for i := range holes {
+		holes[i].Number += 9
+	}
+	home := []models.HoleScore{}
+	away := []models.HoleScore{}
+	for _, h := range holes {
+		home = append(home, models.HoleScore{HoleNumber: h.Number, Strokes: 5})
+		away = append(away, models.HoleScore{HoleNumber: h.Number, Strokes: 4})
+	}
+
+	result := Play(holes, home, away, 2, 0, DefaultOptions)
+
+	// two strokes go to the two hardest holes of the nine: 11 and 15
+	for _, hole := range result.Holes {
+		received := 0
+		if hole.HoleNumber == 11 || hole.HoleNumber == 15 {
+			received = 1
+		}
+		if hole.HomeStrokesReceived != received {
+			t.Errorf("hole %d: expected %d strokes, but got %d", hole.HoleNumber, received, hole.HomeStrokesReceived)
+		}
+	}
+}
+
+func TestPlayIgnoresScoresAfterAMissingHole(t *testing.T) {
+	home := scores(4, 4, 4, 4)
+	away := []models.HoleScore{{HoleNumber: 1, Strokes: 5}, {HoleNumber: 3, Strokes: 5}}
+
+	result := Play(nine(strokeIndexes), home, away, 0, 0, DefaultOptions)
+
+	if result.HolesPlayed != 1 || result.Status != "1 up thru 1" {
+		t.Errorf("expected the match to stop at the missing hole, but got %d holes played and %q", result.HolesPlayed, result.Status)
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+)
+
+// API token scopes
+const (
+	APITokenScopeRead      = "read"
+	APITokenScopeReadWrite = "read_write"
+)
+
+// APITokenScopeNames describes each API token scope
+var APITokenScopeNames = map[string]string{
+	APITokenScopeRead:      "Read only",
+	APITokenScopeReadWrite: "Read and write",
+}
+
+// APITokenExpiryDays are the lifetimes a user can choose for a new API token,
+// besides never expiring
+var APITokenExpiryDays = []int{30, 90, 365}
+
+// APIToken lets a script or another app call the API as a user by sending it
+// as a bearer token. The token is only shown when it's created and only its
+// hash is kept
+type APIToken struct {
+	ID         int
+	UserID     int
+	Name       string
+	TokenHash  string
+	Scope      string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsAPITokenScope reports whether scope is one of the API token scopes
+func IsAPITokenScope(scope string) bool {
+	_, ok := APITokenScopeNames[scope]
+	return ok
+}
+
+// IsExpired reports whether the token can no longer be used because it's too
+// old. Tokens without an expiry never expire
+func (t APIToken) IsExpired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// CanWrite reports whether the token can be used for requests that change
+// anything
+func (t APIToken) CanWrite() bool {
+	return t.Scope == APITokenScopeReadWrite
+}
+
+// ScopeName describes the token's scope
+func (t APIToken) ScopeName() string {
+	return APITokenScopeNames[t.Scope]
+}
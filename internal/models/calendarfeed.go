@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarFeed lets a calendar app subscribe to a user's schedule at a secret
+// URL, without logging in. A feed is for one of the user's leagues, or for all
+// of them when it has no LeagueID. Only the hash of the token in the URL is
+// kept
+type CalendarFeed struct {
+	ID        int
+	UserID    int
+	LeagueID  int
+	TokenHash string
+	League    League
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsLeagueFeed reports whether the feed is for a single league
+func (f CalendarFeed) IsLeagueFeed() bool {
+	return f.LeagueID != 0
+}
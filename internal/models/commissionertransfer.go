@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// CommissionerTransferLifetime is how long a nominee has to accept running
+// the league
+const CommissionerTransferLifetime = 7 * 24 * time.Hour
+
+// CommissionerTransfer is a commissioner's nomination of another player to
+// take over their league. The nominee accepts with an emailed link, and only
+// the hash of the token in it is kept
+type CommissionerTransfer struct {
+	ID           int
+	LeagueID     int
+	FromPlayerID int
+	ToPlayerID   int
+	TokenHash    string
+	ExpiresAt    time.Time
+	AcceptedAt   time.Time
+	CancelledAt  time.Time
+	League       League
+	FromUser     User
+	ToUser       User
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsExpired reports whether the nominee can no longer accept because the
+// nomination is too old
+func (t CommissionerTransfer) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsPending reports whether the nominee can still accept
+func (t CommissionerTransfer) IsPending(now time.Time) bool {
+	return t.AcceptedAt.IsZero() && t.CancelledAt.IsZero() && !t.IsExpired(now)
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// Course is the course model
+type Course struct {
+	ID            int
+	Name          string
+	Location      string
+	NumberOfHoles int
+	TeeSets       []TeeSet
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
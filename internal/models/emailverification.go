@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// EmailVerificationLifetime is how long an email verification link works for
+// after it's sent
+const EmailVerificationLifetime = 24 * time.Hour
+
+// EmailVerification is an emailed link for a user to confirm they own Email.
+// Only the hash of the token in the link is kept, and the link works once
+type EmailVerification struct {
+	ID        int
+	UserID    int
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsValid reports whether the verification link can still be used
+func (e EmailVerification) IsValid(now time.Time) bool {
+	return e.UsedAt.IsZero() && now.Before(e.ExpiresAt)
+}
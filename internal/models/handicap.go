@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// HandicapRecord is a golfer's World Handicap System scoring record
+type HandicapRecord struct {
+	Index          float64
+	HasIndex       bool
+	LowIndex       float64
+	HasLowIndex    bool
+	SoftCapApplied bool
+	HardCapApplied bool
+	Differentials  []Differential
+}
+
+// Differential is one score in a handicap record. Two nine hole rounds are
+// combined into a single differential
+type Differential struct {
+	RoundID            int
+	PlayedOn           time.Time
+	CourseName         string
+	TeeName            string
+	AdjustedGrossScore int
+	CourseRating       float64
+	Slope              int
+	ScoreDifferential  float64
+	Adjustment         float64
+	IsCombined         bool
+	Counted            bool
+}
+
+// Value returns the score differential after any exceptional score reductions
+func (d Differential) Value() float64 {
+	return d.ScoreDifferential + d.Adjustment
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// Hole is the hole model, one hole as played from a tee set
+type Hole struct {
+	ID          int
+	TeeSetID    int
+	Number      int
+	Par         int
+	Yardage     int
+	StrokeIndex int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
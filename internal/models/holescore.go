@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// HoleScore is the hole score model, the gross strokes taken on one hole of a round
+type HoleScore struct {
+	ID         int
+	RoundID    int
+	HoleNumber int
+	Strokes    int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
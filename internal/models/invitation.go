@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+)
+
+// InvitationLifetime is how long an invitation can be claimed for after it's sent
+const InvitationLifetime = 7 * 24 * time.Hour
+
+// Invitation is an emailed invitation for a player added to a league without
+// an account to claim it by setting a password. Only the hash of the token in
+// the emailed link is kept
+type Invitation struct {
+	ID         int
+	UserID     int
+	LeagueID   int
+	TokenHash  string
+	ExpiresAt  time.Time
+	AcceptedAt time.Time
+	RevokedAt  time.Time
+	User       User
+	League     League
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// IsExpired reports whether the invitation can no longer be claimed because
+// it's too old
+func (i Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// IsPending reports whether the invitation can still be claimed
+func (i Invitation) IsPending(now time.Time) bool {
+	return i.AcceptedAt.IsZero() && i.RevokedAt.IsZero() && !i.IsExpired(now)
+}
@@ -4,10 +4,36 @@ import (
 	"time"
 )
 
-// League is the league model
+// League is the league model. ScoringFormat is how weeks are played unless a
+// schedule week says otherwise. WinPoints and HalvePoints are what a matchup
+// result is worth in any format, while MatchAllowance, the share of the
+// handicap difference given in strokes, and HolePoints, awarded for each hole
+// won, only apply to match play. TimeZone is the IANA name of the zone the
+// league's tee times are in, or empty if the commissioner hasn't chosen one
 type League struct {
-	ID        int
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              int
+	Name            string
+	ScoringFormat   string
+	StablefordTable StablefordTable
+	MatchAllowance  float64
+	WinPoints       float64
+	HalvePoints     float64
+	HolePoints      float64
+	TimeZone        string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Location returns the league's time zone. It returns false if the league
+// doesn't have one, or has one that can't be loaded
+func (l League) Location() (*time.Location, bool) {
+	if l.TimeZone == "" {
+		return nil, false
+	}
+
+	loc, err := time.LoadLocation(l.TimeZone)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
 }
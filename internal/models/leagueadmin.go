@@ -4,11 +4,102 @@ import (
 	"time"
 )
 
-// LeagueAdmin is the league admin model
+const (
+	LeagueRoleCommissioner   = "commissioner"
+	LeagueRoleCoCommissioner = "co_commissioner"
+	LeagueRoleScorekeeper    = "scorekeeper"
+	LeagueRolePlayer         = "player"
+	LeagueRoleMember         = "member"
+)
+
+// GrantableLeagueRoles are the roles a commissioner can give someone in their
+// league. Commissioner comes from the players table and player from being on
+// the roster, so neither is granted
+var GrantableLeagueRoles = []string{
+	LeagueRoleCoCommissioner,
+	LeagueRoleScorekeeper,
+	LeagueRoleMember,
+}
+
+// LeagueRoleNames are how each league role is shown to people
+var LeagueRoleNames = map[string]string{
+	LeagueRoleCommissioner:   "Commissioner",
+	LeagueRoleCoCommissioner: "Co-commissioner",
+	LeagueRoleScorekeeper:    "Scorekeeper",
+	LeagueRolePlayer:         "Player",
+	LeagueRoleMember:         "Read-only member",
+}
+
+// IsGrantableLeagueRole reports whether a commissioner can give someone role
+func IsGrantableLeagueRole(role string) bool {
+	for _, r := range GrantableLeagueRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// LeagueAdmin is a role a commissioner has given a user in their league. It
+// takes the place of the player role for a user who is also on the roster
 type LeagueAdmin struct {
 	ID        int
 	LeagueID  int
 	UserID    int
+	Role      string
+	User      User
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// RoleName returns the admin's role as it's shown to people
+func (a LeagueAdmin) RoleName() string {
+	return LeagueRoleNames[a.Role]
+}
+
+// LeagueMembership is a user's role in a league, which decides what they can
+// do there
+type LeagueMembership struct {
+	LeagueID int
+	UserID   int
+	Role     string
+}
+
+// RoleName returns the member's role as it's shown to people
+func (m LeagueMembership) RoleName() string {
+	return LeagueRoleNames[m.Role]
+}
+
+// IsCommissioner reports whether the member runs the league
+func (m LeagueMembership) IsCommissioner() bool {
+	return m.Role == LeagueRoleCommissioner
+}
+
+// CanManageRoles reports whether the member can give and take away roles
+func (m LeagueMembership) CanManageRoles() bool {
+	return m.IsCommissioner()
+}
+
+// CanManageWebhooks reports whether the member can choose where the league's
+// events are sent
+func (m LeagueMembership) CanManageWebhooks() bool {
+	return m.IsCommissioner()
+}
+
+// CanManageLeague reports whether the member can change the roster, seasons,
+// schedule, teams and settings
+func (m LeagueMembership) CanManageLeague() bool {
+	return m.IsCommissioner() || m.Role == LeagueRoleCoCommissioner
+}
+
+// CanKeepScore reports whether the member can run a week's results, like
+// setting up skins and seeing results before they're published
+func (m LeagueMembership) CanKeepScore() bool {
+	return m.CanManageLeague() || m.Role == LeagueRoleScorekeeper
+}
+
+// CanPostRounds reports whether the member can post their own rounds. Read-only
+// members can only look
+func (m LeagueMembership) CanPostRounds() bool {
+	return m.Role != LeagueRoleMember
+}
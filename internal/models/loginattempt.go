@@ -0,0 +1,159 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// LoginAttempt counts the failed logins for an email address or for a client
+// address. Key says which, like "email:me@here.ca" or "ip:10.0.0.1"
+type LoginAttempt struct {
+	ID           int
+	Key          string
+	Failures     int
+	LastFailedAt time.Time
+	LockedUntil  time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// LoginThrottlePolicy is how many failed logins are allowed before each new
+// try has to wait, and before logins are locked out for a while
+type LoginThrottlePolicy struct {
+	FreeAttempts    int
+	LockoutAttempts int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	LockoutDuration time.Duration
+	// Window is how long after the last failure the count starts over
+	Window time.Duration
+}
+
+// EmailLoginPolicy throttles logins to a single account
+var EmailLoginPolicy = LoginThrottlePolicy{
+	FreeAttempts:    3,
+	LockoutAttempts: 10,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Minute,
+	LockoutDuration: 15 * time.Minute,
+	Window:          time.Hour,
+}
+
+// IPLoginPolicy throttles logins from a single client address. It allows more
+// failures than EmailLoginPolicy since a club's players may share an address
+var IPLoginPolicy = LoginThrottlePolicy{
+	FreeAttempts:    10,
+	LockoutAttempts: 50,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Minute,
+	LockoutDuration: time.Hour,
+	Window:          time.Hour,
+}
+
+// EmailLoginKey is the LoginAttempt key for an email address
+func EmailLoginKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// IPLoginKey is the LoginAttempt key for a client address
+func IPLoginKey(ip string) string {
+	return "ip:" + ip
+}
+
+// IsEmail reports whether the attempts are for an email address
+func (a LoginAttempt) IsEmail() bool {
+	return strings.HasPrefix(a.Key, "email:")
+}
+
+// Subject is the email or client address the attempts are for
+func (a LoginAttempt) Subject() string {
+	return a.Key[strings.Index(a.Key, ":")+1:]
+}
+
+// IsLocked reports whether logins are locked out
+func (a LoginAttempt) IsLocked(now time.Time) bool {
+	return now.Before(a.LockedUntil)
+}
+
+// IsStale reports whether the failures are too old to count anymore, either
+// because the last one was outside the policy's window or a lockout has ended
+func (a LoginAttempt) IsStale(p LoginThrottlePolicy, now time.Time) bool {
+	if !a.LockedUntil.IsZero() && !a.IsLocked(now) {
+		return true
+	}
+	return now.Sub(a.LastFailedAt) > p.Window
+}
+
+// Delay is how long after the last failure the next login has to wait. It
+// doubles with each failure past the free ones, up to the policy's max
+func (a LoginAttempt) Delay(p LoginThrottlePolicy) time.Duration {
+	extra := a.Failures - p.FreeAttempts
+	if extra <= 0 {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < extra && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// Wait is how much longer the next login has to wait, or zero if it can go
+// ahead now
+func (a LoginAttempt) Wait(p LoginThrottlePolicy, now time.Time) time.Duration {
+	if a.IsLocked(now) {
+		return a.LockedUntil.Sub(now)
+	}
+	if a.IsStale(p, now) {
+		return 0
+	}
+
+	wait := a.LastFailedAt.Add(a.Delay(p)).Sub(now)
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
+// Reserve counts a login that's about to be tried as a failure before the
+// password is checked, so logins tried at the same time can't all get in
+// under the limit. It returns the attempts with the login counted, or how long
+// to wait instead if the login can't go ahead yet, and whether counting it
+// locked logins out
+func (a LoginAttempt) Reserve(p LoginThrottlePolicy, now time.Time) (LoginAttempt, time.Duration, bool) {
+	if wait := a.Wait(p, now); wait > 0 {
+		return a, wait, false
+	}
+
+	if a.IsStale(p, now) {
+		a.Failures = 0
+		a.LockedUntil = time.Time{}
+	}
+
+	a.Failures++
+	a.LastFailedAt = now
+
+	locked := false
+	if a.Failures >= p.LockoutAttempts {
+		a.LockedUntil = now.Add(p.LockoutDuration)
+		locked = true
+	}
+
+	return a, 0, locked
+}
+
+// Release takes back a login counted by Reserve that didn't fail after all,
+// lifting the lockout it caused if it caused one
+func (a LoginAttempt) Release(p LoginThrottlePolicy) LoginAttempt {
+	if a.Failures > 0 {
+		a.Failures--
+	}
+	if a.Failures < p.LockoutAttempts {
+		a.LockedUntil = time.Time{}
+	}
+	return a
+}
@@ -0,0 +1,38 @@
+package models
+
+// The sides of a net match, and the result when neither side wins
+const (
+	MatchHome   = "home"
+	MatchAway   = "away"
+	MatchHalved = "halved"
+)
+
+// HoleResult is one hole of a net match. HomeUp is the home player's lead
+// after the hole, negative when the away player leads
+type HoleResult struct {
+	HoleNumber          int
+	HomeStrokes         int
+	AwayStrokes         int
+	HomeStrokesReceived int
+	AwayStrokesReceived int
+	HomeNet             int
+	AwayNet             int
+	Winner              string
+	HomeUp              int
+}
+
+// MatchResult is a net match play match between the home and away players of
+// a matchup. Winner stays empty until the match is finished
+type MatchResult struct {
+	Holes               []HoleResult
+	HomeStrokesReceived int
+	AwayStrokesReceived int
+	HolesPlayed         int
+	HolesRemaining      int
+	HomeUp              int
+	IsFinished          bool
+	Winner              string
+	Status              string
+	HomePoints          float64
+	AwayPoints          float64
+}
@@ -0,0 +1,52 @@
+package models
+
+// Page is one page of a list that's too long to show at once. Number starts
+// at 1, and Total is how many items there are on every page together
+type Page struct {
+	Number int
+	Size   int
+	Total  int
+}
+
+// NewPage returns page number of a list shown size items at a time. Numbers
+// before the first page are the first page
+func NewPage(number, size int) Page {
+	if number < 1 {
+		number = 1
+	}
+	return Page{Number: number, Size: size}
+}
+
+// Offset returns how many items come before the page
+func (p Page) Offset() int {
+	return (p.Number - 1) * p.Size
+}
+
+// Pages returns how many pages there are, which is at least one even when the
+// list is empty
+func (p Page) Pages() int {
+	if p.Size < 1 || p.Total <= p.Size {
+		return 1
+	}
+	return (p.Total + p.Size - 1) / p.Size
+}
+
+// HasPrevious reports whether there is a page before this one
+func (p Page) HasPrevious() bool {
+	return p.Number > 1
+}
+
+// HasNext reports whether there is a page after this one
+func (p Page) HasNext() bool {
+	return p.Number < p.Pages()
+}
+
+// Previous returns the number of the page before this one
+func (p Page) Previous() int {
+	return p.Number - 1
+}
+
+// Next returns the number of the page after this one
+func (p Page) Next() int {
+	return p.Number + 1
+}
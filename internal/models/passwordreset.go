@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// PasswordResetLifetime is how long a password reset link works for after
+// it's sent
+const PasswordResetLifetime = time.Hour
+
+// PasswordReset is a request to reset a user's password from an emailed link.
+// Only the hash of the token in the link is kept, and the link works once
+type PasswordReset struct {
+	ID        int
+	UserID    int
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsValid reports whether the reset link can still be used
+func (p PasswordReset) IsValid(now time.Time) bool {
+	return p.UsedAt.IsZero() && now.Before(p.ExpiresAt)
+}
@@ -0,0 +1,36 @@
+package models
+
+// RosterImportMaxRows is the most players a roster file can add at once
+const RosterImportMaxRows = 200
+
+// Starting handicaps a commissioner can give players in a roster file
+const (
+	MinStartingHandicap = -10
+	MaxStartingHandicap = 54
+)
+
+// RosterRow is a player listed in a roster file a commissioner uploads to add
+// players to a league. Players with an account are added to the league, and
+// everyone else is invited to claim one
+type RosterRow struct {
+	Line         int
+	User         User
+	Handicap     int
+	HasHandicap  bool
+	ExistingUser bool
+	Errors       []string
+	// InvitationToken is set once a player without an account is added
+	InvitationToken string
+}
+
+// IsValid reports whether the row can be imported
+func (r RosterRow) IsValid() bool {
+	return len(r.Errors) == 0
+}
+
+// RosterImport is what happened when a roster was imported
+type RosterImport struct {
+	Rows    []RosterRow
+	Added   int
+	Invited int
+}
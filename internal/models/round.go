@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// Round is the round model, one posted scorecard for a player in a league
+type Round struct {
+	ID             int
+	LeagueID       int
+	SeasonID       int
+	PlayerID       int
+	TeeSetID       int
+	PlayedOn       time.Time
+	GrossScore     int
+	CourseHandicap int
+	HoleScores     []HoleScore
+	Player         Player
+	TeeSet         TeeSet
+	Course         Course
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NetScore returns the round's gross score less the player's course handicap
+func (r Round) NetScore() int {
+	return r.GrossScore - r.CourseHandicap
+}
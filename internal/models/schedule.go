@@ -0,0 +1,133 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	NineFront = "front"
+	NineBack  = "back"
+)
+
+// WeekLength is how long after a week's play date a round counts toward that
+// week
+const WeekLength = 7 * 24 * time.Hour
+
+// TeeTimeLayout is how a week's tee time is entered and kept
+const TeeTimeLayout = "15:04"
+
+// ScheduledRoundLength is how long a week's round is expected to take from
+// its tee time
+const ScheduledRoundLength = 2*time.Hour + 30*time.Minute
+
+// ScheduleWeek is one week of a season's schedule. Weeks can be regenerated
+// or edited by the commissioner until the schedule is published. A week
+// without a Format is played in its league's format. TeeTime is in the
+// league's time zone, or empty if the week doesn't have one. Sequence counts
+// the changes made to the week since it was published, so calendars
+// subscribed to the schedule pick them up
+type ScheduleWeek struct {
+	ID          int
+	SeasonID    int
+	WeekNumber  int
+	PlayDate    time.Time
+	Nine        string
+	Format      string
+	CourseID    int
+	TeeTime     string
+	IsPublished bool
+	CancelledAt time.Time
+	Sequence    int
+	Course      Course
+	Matchups    []Matchup
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsTeeTime reports whether s is a tee time in TeeTimeLayout
+func IsTeeTime(s string) bool {
+	_, err := time.Parse(TeeTimeLayout, s)
+	return err == nil
+}
+
+// IsCancelled reports whether the commissioner called the week off after the
+// schedule was published
+func (w ScheduleWeek) IsCancelled() bool {
+	return !w.CancelledAt.IsZero()
+}
+
+// TeesOffAt returns when the week's round starts in a time zone. It returns
+// false if the week doesn't have a tee time
+func (w ScheduleWeek) TeesOffAt(loc *time.Location) (time.Time, bool) {
+	teeTime, err := time.Parse(TeeTimeLayout, w.TeeTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	year, month, day := w.PlayDate.Date()
+	return time.Date(year, month, day, teeTime.Hour(), teeTime.Minute(), 0, 0, loc), true
+}
+
+// PlayerMatchup returns the matchup a user plays in during the week. It
+// returns false if they aren't scheduled
+func (w ScheduleWeek) PlayerMatchup(userID int) (Matchup, bool) {
+	for _, m := range w.Matchups {
+		if m.HomePlayer.UserID == userID || (!m.IsBye() && m.AwayPlayer.UserID == userID) {
+			return m, true
+		}
+	}
+	return Matchup{}, false
+}
+
+// ScoringFormat returns the format the week is played in
+func (w ScheduleWeek) ScoringFormat(league League) string {
+	if w.Format != "" {
+		return w.Format
+	}
+	return league.ScoringFormat
+}
+
+// Includes reports whether a round played on the given date counts toward the week
+func (w ScheduleWeek) Includes(playedOn time.Time) bool {
+	return !playedOn.Before(w.PlayDate) && playedOn.Before(w.PlayDate.Add(WeekLength))
+}
+
+// Matchup is a pairing of two players in a schedule week. A matchup without
+// an away player is a bye for the home player
+type Matchup struct {
+	ID             int
+	ScheduleWeekID int
+	HomePlayerID   int
+	AwayPlayerID   int
+	HomePlayer     Player
+	AwayPlayer     Player
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsBye reports whether the home player has the week off
+func (m Matchup) IsBye() bool {
+	return m.AwayPlayerID == 0
+}
+
+// Opponent returns who a user plays against in the matchup. It returns false
+// for a bye
+func (m Matchup) Opponent(userID int) (Player, bool) {
+	if m.IsBye() {
+		return Player{}, false
+	}
+	if m.HomePlayer.UserID == userID {
+		return m.AwayPlayer, true
+	}
+	return m.HomePlayer, true
+}
+
+// ScheduleOptions are the commissioner's choices when generating a schedule.
+// The course and tee time are given to every week
+type ScheduleOptions struct {
+	StartDate      time.Time
+	EndDate        time.Time
+	AlternateNines bool
+	CourseID       int
+	TeeTime        string
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The formats a league plays its weeks in. A schedule week can override its
+// league's format
+const (
+	FormatStrokePlay = "stroke"
+	FormatMatchPlay  = "match"
+	FormatStableford = "stableford"
+)
+
+// ScoringFormats are the formats a league or week can be played in, in the
+// order they're offered
+var ScoringFormats = []string{FormatStrokePlay, FormatMatchPlay, FormatStableford}
+
+// ScoringFormatNames are how each scoring format is shown to players
+var ScoringFormatNames = map[string]string{
+	FormatStrokePlay: "Stroke play",
+	FormatMatchPlay:  "Match play",
+	FormatStableford: "Stableford",
+}
+
+// IsScoringFormat reports whether format is one of the ScoringFormats
+func IsScoringFormat(format string) bool {
+	for _, f := range ScoringFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// StablefordTable is the points awarded for a net score on a hole by how it
+// compares to par
+type StablefordTable struct {
+	DoubleBogeyOrWorse int
+	Bogey              int
+	Par                int
+	Birdie             int
+	Eagle              int
+	AlbatrossOrBetter  int
+}
+
+// StandardStableford is the standard Stableford points table
+var StandardStableford = StablefordTable{0, 1, 2, 3, 4, 5}
+
+// ModifiedStableford is the usual modified Stableford points table, which
+// rewards birdies and punishes big numbers
+var ModifiedStableford = StablefordTable{-3, -1, 0, 2, 5, 8}
+
+// Points returns the points for a net score the given number of strokes over
+// par, negative when under par
+func (t StablefordTable) Points(overPar int) int {
+	switch {
+	case overPar >= 2:
+		return t.DoubleBogeyOrWorse
+	case overPar == 1:
+		return t.Bogey
+	case overPar == 0:
+		return t.Par
+	case overPar == -1:
+		return t.Birdie
+	case overPar == -2:
+		return t.Eagle
+	}
+	return t.AlbatrossOrBetter
+}
+
+// IsStandard reports whether the table awards standard Stableford points
+func (t StablefordTable) IsStandard() bool {
+	return t == StandardStableford
+}
+
+// String returns the points from double bogey or worse to albatross or better
+// separated by commas, the way the table is stored
+func (t StablefordTable) String() string {
+	return fmt.Sprintf("%d,%d,%d,%d,%d,%d", t.DoubleBogeyOrWorse, t.Bogey, t.Par, t.Birdie, t.Eagle, t.AlbatrossOrBetter)
+}
+
+// ParseStablefordTable reads a table written by StablefordTable.String
+func ParseStablefordTable(s string) (StablefordTable, error) {
+	var t StablefordTable
+
+	fields := strings.Split(s, ",")
+	if len(fields) != 6 {
+		return t, errors.New("a stableford table needs six points values")
+	}
+
+	points := make([]int, len(fields))
+	for i, f := range fields {
+		p, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			return t, err
+		}
+		points[i] = p
+	}
+
+	return StablefordTable{points[0], points[1], points[2], points[3], points[4], points[5]}, nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	SeasonStatusDraft     = "draft"
+	SeasonStatusActive    = "active"
+	SeasonStatusCompleted = "completed"
+)
+
+// Season is a league's season. Rounds, schedules and standings belong to a
+// season and a league can only have one active season at a time
+type Season struct {
+	ID        int
+	LeagueID  int
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+	Status    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsActive reports whether rounds can be posted to the season
+func (s Season) IsActive() bool {
+	return s.Status == SeasonStatusActive
+}
+
+// Includes reports whether a date falls within the season
+func (s Season) Includes(date time.Time) bool {
+	return !date.Before(s.StartDate) && !date.After(s.EndDate)
+}
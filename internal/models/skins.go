@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+)
+
+// The rules a skins game can make a winning score pass before the skin counts
+const (
+	SkinsValidationNone     = ""
+	SkinsValidationNextHole = "next_hole"
+	SkinsValidationGrossPar = "gross_par"
+)
+
+// SkinsValidations are the validation rules a skins game can be played with,
+// in the order they're offered
+var SkinsValidations = []string{SkinsValidationNone, SkinsValidationNextHole, SkinsValidationGrossPar}
+
+// SkinsValidationNames are how each validation rule is shown to players
+var SkinsValidationNames = map[string]string{
+	SkinsValidationNone:     "None",
+	SkinsValidationNextHole: "Par or better on the next hole",
+	SkinsValidationGrossPar: "Par or better gross",
+}
+
+// IsSkinsValidation reports whether validation is one of the SkinsValidations
+func IsSkinsValidation(validation string) bool {
+	_, ok := SkinsValidationNames[validation]
+	return ok
+}
+
+// SkinsGame is a week's skins pot, played for by the players entered in it.
+// The pot is in cents
+type SkinsGame struct {
+	ID             int
+	ScheduleWeekID int
+	Pot            int
+	Net            bool
+	Carryovers     bool
+	Validation     string
+	PlayerIDs      []int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// HasPlayer reports whether the player is entered in the game
+func (g SkinsGame) HasPlayer(playerID int) bool {
+	for _, id := range g.PlayerIDs {
+		if id == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// The ways a hole of a skins game can end
+const (
+	SkinsHoleWon          = "won"
+	SkinsHoleTied         = "tied"
+	SkinsHoleCarried      = "carried"
+	SkinsHoleNotValidated = "not_validated"
+)
+
+// SkinsHole is how one hole of a skins game was decided. Skins counts the
+// skins at stake on the hole, including any carried over to it
+type SkinsHole struct {
+	Number  int
+	Skins   int
+	Outcome string
+	Winner  Player
+	Score   int
+}
+
+// SkinsWinner is a player who won at least one skin and what they're paid
+type SkinsWinner struct {
+	Player Player
+	Holes  []int
+	Skins  int
+	Payout int
+}
+
+// SkinsResult is the outcome of a week's skins game. The pot is split evenly
+// between the skins won, so Value is what each skin pays in cents. Unclaimed
+// counts the skins still carried over after the last hole
+type SkinsResult struct {
+	Game      SkinsGame
+	Holes     []SkinsHole
+	Winners   []SkinsWinner
+	Skins     int
+	Value     int
+	Unclaimed int
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"time"
+)
+
+// The criteria standings can be ranked by
+const (
+	StandingsByPoints     = "points"
+	StandingsByNetAverage = "net"
+	StandingsByRecord     = "record"
+)
+
+// Standing is one player's line in a season's standings
+type Standing struct {
+	Player       Player
+	Rank         int
+	PreviousRank int
+	Points       float64
+	Wins         int
+	Losses       int
+	Ties         int
+	Rounds       int
+	GrossTotal   int
+	NetTotal     int
+	LowGross     int
+	LowNet       int
+	// StablefordPoints are totalled from the rounds played in Stableford weeks
+	StablefordPoints int
+	StablefordRounds int
+}
+
+// GrossAverage returns the player's average gross score, or 0 without rounds
+func (s Standing) GrossAverage() float64 {
+	if s.Rounds == 0 {
+		return 0
+	}
+	return float64(s.GrossTotal) / float64(s.Rounds)
+}
+
+// NetAverage returns the player's average net score, or 0 without rounds
+func (s Standing) NetAverage() float64 {
+	if s.Rounds == 0 {
+		return 0
+	}
+	return float64(s.NetTotal) / float64(s.Rounds)
+}
+
+// Matches returns the number of matchups the player has a result in
+func (s Standing) Matches() int {
+	return s.Wins + s.Losses + s.Ties
+}
+
+// WinPercentage returns the share of matches won, counting ties as half a win
+func (s Standing) WinPercentage() float64 {
+	if s.Matches() == 0 {
+		return 0
+	}
+	return (float64(s.Wins) + float64(s.Ties)/2) / float64(s.Matches())
+}
+
+// Movement returns how many places the player has climbed since the previous
+// standings. It is negative for a drop and 0 when there were no previous standings
+func (s Standing) Movement() int {
+	if s.PreviousRank == 0 {
+		return 0
+	}
+	return s.PreviousRank - s.Rank
+}
+
+// Standings are a season's standings ranked by SortBy along with gross and
+// net leaderboards of the same players. HasStableford is set once a round has
+// been played in a Stableford week
+type Standings struct {
+	SortBy        string
+	AsOf          time.Time
+	Players       []Standing
+	Gross         []Standing
+	Net           []Standing
+	HasStableford bool
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// The formats a team's score can be played in
+const (
+	TeamFormatBestBallNet   = "best_ball_net"
+	TeamFormatBestBallGross = "best_ball_gross"
+	TeamFormatAggregate     = "aggregate"
+)
+
+// TeamFormats are the formats team standings can be scored in, in the order
+// they're offered
+var TeamFormats = []string{TeamFormatBestBallNet, TeamFormatBestBallGross, TeamFormatAggregate}
+
+// TeamFormatNames are how each team format is shown to players
+var TeamFormatNames = map[string]string{
+	TeamFormatBestBallNet:   "Best ball (net)",
+	TeamFormatBestBallGross: "Best ball (gross)",
+	TeamFormatAggregate:     "Aggregate (net)",
+}
+
+// IsTeamFormat reports whether format is one of the TeamFormats
+func IsTeamFormat(format string) bool {
+	_, ok := TeamFormatNames[format]
+	return ok
+}
+
+// Team is a group of two or more of a league's players who play together for
+// a season
+type Team struct {
+	ID        int
+	LeagueID  int
+	SeasonID  int
+	Name      string
+	Players   []Player
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// HasPlayer reports whether the player is on the team
+func (t Team) HasPlayer(playerID int) bool {
+	for _, p := range t.Players {
+		if p.ID == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamStanding is one team's line in a season's team standings
+type TeamStanding struct {
+	Team  Team
+	Rank  int
+	Weeks int
+	Total int
+	Low   int
+}
+
+// Average returns the team's average score per week played, 0 before it has
+// played
+func (s TeamStanding) Average() float64 {
+	if s.Weeks == 0 {
+		return 0
+	}
+	return float64(s.Total) / float64(s.Weeks)
+}
+
+// TeamStandings are a season's teams ranked by their scores in Format
+type TeamStandings struct {
+	Format string
+	Teams  []TeamStanding
+}
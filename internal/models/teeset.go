@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// TeeSet is the tee set model, a set of tees on a course with its own rating
+type TeeSet struct {
+	ID           int
+	CourseID     int
+	Name         string
+	CourseRating float64
+	Slope        int
+	Holes        []Hole
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Par returns the total par of the tee set
+func (t TeeSet) Par() int {
+	par := 0
+	for _, h := range t.Holes {
+		par += h.Par
+	}
+	return par
+}
+
+// Yardage returns the total yardage of the tee set
+func (t TeeSet) Yardage() int {
+	yardage := 0
+	for _, h := range t.Holes {
+		yardage += h.Yardage
+	}
+	return yardage
+}
@@ -15,4 +15,5 @@ type TemplateData struct {
 	Form            *forms.Form
 	IsSuperAdmin    int
 	IsAuthenticated int
+	IsUnverified    int
 }
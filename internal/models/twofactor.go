@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// TwoFactorIssuer is the name authenticator apps show next to the app's codes
+const TwoFactorIssuer = "Golf League"
+
+// RecoveryCodeCount is how many recovery codes a user gets when they turn on
+// two-factor authentication
+const RecoveryCodeCount = 10
+
+// TwoFactorLoginLifetime is how long after entering their password a user
+// has to enter their code
+const TwoFactorLoginLifetime = 5 * time.Minute
+
+// SettingRequireCommissionerTwoFactor is the setting that makes every league
+// commissioner turn on two-factor authentication
+const SettingRequireCommissionerTwoFactor = "require_commissioner_two_factor"
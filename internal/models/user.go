@@ -10,14 +10,74 @@ const (
 	AccessLevelSuperAdmin
 )
 
-// User is the user model
+// AccessLevelNames are how access levels are shown to super admins
+var AccessLevelNames = map[int]string{
+	AccessLevelPlayer:     "Player",
+	AccessLevelAdmin:      "Admin",
+	AccessLevelSuperAdmin: "Super Admin",
+}
+
+// IsAccessLevel reports whether level is one a user can have
+func IsAccessLevel(level int) bool {
+	_, ok := AccessLevelNames[level]
+	return ok
+}
+
+// User is the user model. Sessions started before SessionsRevokedAt are no
+// longer valid, VerifiedAt is zero until the user confirms their email,
+// TOTPEnabledAt is zero until they turn on two-factor authentication, and
+// DeactivatedAt is zero unless a super admin has shut the account off
 type User struct {
-	ID          int
-	FirstName   string
-	LastName    string
-	Email       string
-	Password    string
-	AccessLevel int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID                int
+	FirstName         string
+	LastName          string
+	Email             string
+	Password          string
+	AccessLevel       int
+	VerifiedAt        time.Time
+	SessionsRevokedAt time.Time
+	TOTPSecret        string
+	TOTPEnabledAt     time.Time
+	DeactivatedAt     time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// SessionIsCurrent reports whether a session started at loggedInAt is still
+// valid for the user. Deactivated users have no valid sessions
+func (u User) SessionIsCurrent(loggedInAt time.Time) bool {
+	if u.IsDeactivated() {
+		return false
+	}
+	return u.SessionsRevokedAt.IsZero() || loggedInAt.After(u.SessionsRevokedAt)
+}
+
+// IsClaimed reports whether the user has set a password. Users added to a
+// league without an account aren't claimed until they accept their invitation
+func (u User) IsClaimed() bool {
+	return u.Password != ""
+}
+
+// IsVerified reports whether the user has confirmed they own their email
+// address. Unverified users can't create leagues or get emails sent to a
+// whole league
+func (u User) IsVerified() bool {
+	return !u.VerifiedAt.IsZero()
+}
+
+// HasTwoFactor reports whether the user has to enter a code from their
+// authenticator app to log in
+func (u User) HasTwoFactor() bool {
+	return !u.TOTPEnabledAt.IsZero()
+}
+
+// IsDeactivated reports whether a super admin has shut the account off, so
+// the user can't log in
+func (u User) IsDeactivated() bool {
+	return !u.DeactivatedAt.IsZero()
+}
+
+// AccessLevelName returns how the user's access level is shown to super admins
+func (u User) AccessLevelName() string {
+	return AccessLevelNames[u.AccessLevel]
 }
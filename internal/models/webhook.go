@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// Webhook events. Webhooks belong to a league, so there's no event for a
+// league being created: it can't have any webhooks yet
+const (
+	WebhookEventPlayerAdded   = "player.added"
+	WebhookEventPlayerRemoved = "player.removed"
+	WebhookEventRoundPosted   = "round.posted"
+	WebhookEventTest          = "webhook.test"
+)
+
+// WebhookMaxAttempts is how many times a delivery is tried before giving up
+const WebhookMaxAttempts = 6
+
+// WebhookRetryDelay is how long after the first failed attempt a delivery is
+// tried again. The wait doubles after every failed attempt after that
+const WebhookRetryDelay = time.Minute
+
+// Webhook is a URL a league's commissioner wants told about what happens in
+// the league. Every delivery is signed with the secret so the receiver can
+// check it came from the app
+type Webhook struct {
+	ID        int
+	LeagueID  int
+	URL       string
+	Secret    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery is one event sent, or waiting to be sent, to a webhook.
+// Failed deliveries are retried until they succeed or run out of attempts
+type WebhookDelivery struct {
+	ID            int
+	WebhookID     int
+	Event         string
+	Payload       string
+	Attempts      int
+	StatusCode    int
+	LastError     string
+	NextAttemptAt time.Time
+	DeliveredAt   time.Time
+	Webhook       Webhook
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsDelivered reports whether the webhook accepted the delivery
+func (d WebhookDelivery) IsDelivered() bool {
+	return !d.DeliveredAt.IsZero()
+}
+
+// IsPending reports whether the delivery will be tried again
+func (d WebhookDelivery) IsPending() bool {
+	return !d.IsDelivered() && !d.NextAttemptAt.IsZero()
+}
+
+// RetryDelay returns how long to wait before trying the delivery again after
+// its latest attempt failed
+func (d WebhookDelivery) RetryDelay() time.Duration {
+	delay := WebhookRetryDelay
+	for i := 1; i < d.Attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
@@ -16,10 +16,14 @@ import (
 )
 
 var functions = template.FuncMap{
-	"humanDate":  HumanDate,
-	"formatDate": FormatDate,
-	"iterate":    Iterate,
-	"add":        Add,
+	"humanDate":   HumanDate,
+	"formatDate":  FormatDate,
+	"iterate":     Iterate,
+	"add":         Add,
+	"formatIndex": FormatIndex,
+	"formatName":  FormatName,
+	"formatMoney": FormatMoney,
+	"roleName":    RoleName,
 }
 
 var app *config.AppConfig
@@ -54,6 +58,44 @@ func FormatDate(t time.Time, f string) string {
 	return t.Format(f)
 }
 
+// FormatIndex formats a handicap index to one decimal place, showing a plus
+// handicap (an index below zero) as "+x.x"
+func FormatIndex(index float64) string {
+	if index < 0 {
+		return fmt.Sprintf("+%.1f", -index)
+	}
+	return fmt.Sprintf("%.1f", index)
+}
+
+// RoleName returns the display name of a league role
+func RoleName(role string) string {
+	if name, ok := models.LeagueRoleNames[role]; ok {
+		return name
+	}
+	return role
+}
+
+// FormatName returns the display name of a scoring or team format
+func FormatName(format string) string {
+	if name, ok := models.ScoringFormatNames[format]; ok {
+		return name
+	}
+	if name, ok := models.TeamFormatNames[format]; ok {
+		return name
+	}
+	return format
+}
+
+// FormatMoney formats an amount in cents as dollars and cents
+func FormatMoney(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
+}
+
 // AddDefaultData adds data for all templates
 func AddDefaultData(td *models.TemplateData, r *http.Request) *models.TemplateData {
 	td.Flash = app.Session.PopString(r.Context(), "flash")
@@ -63,6 +105,9 @@ func AddDefaultData(td *models.TemplateData, r *http.Request) *models.TemplateDa
 	if app.Session.Exists(r.Context(), "user_id") {
 		td.IsAuthenticated = 1
 	}
+	if app.Session.GetBool(r.Context(), "unverified") {
+		td.IsUnverified = 1
+	}
 	if app.Session.Exists(r.Context(), "access_level") {
 		if app.Session.GetInt(r.Context(), "access_level") == models.AccessLevelSuperAdmin {
 			td.IsSuperAdmin = 1
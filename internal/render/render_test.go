@@ -108,3 +108,45 @@ func TestCreateTemplateCache(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestFormatIndex(t *testing.T) {
+	if FormatIndex(12.4) != "12.4" {
+		t.Errorf("expected 12.4, but got %s", FormatIndex(12.4))
+	}
+	if FormatIndex(-1.5) != "+1.5" {
+		t.Errorf("expected +1.5, but got %s", FormatIndex(-1.5))
+	}
+}
+
+func TestFormatName(t *testing.T) {
+	if FormatName(models.FormatStableford) != "Stableford" {
+		t.Errorf("expected Stableford, but got %s", FormatName(models.FormatStableford))
+	}
+	if FormatName(models.TeamFormatBestBallNet) != "Best ball (net)" {
+		t.Errorf("expected Best ball (net), but got %s", FormatName(models.TeamFormatBestBallNet))
+	}
+	if FormatName("scramble") != "scramble" {
+		t.Errorf("expected scramble, but got %s", FormatName("scramble"))
+	}
+}
+
+func TestRoleName(t *testing.T) {
+	if RoleName(models.LeagueRoleCoCommissioner) != "Co-commissioner" {
+		t.Errorf("expected Co-commissioner, but got %s", RoleName(models.LeagueRoleCoCommissioner))
+	}
+	if RoleName("owner") != "owner" {
+		t.Errorf("expected owner, but got %s", RoleName("owner"))
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	if FormatMoney(2050) != "$20.50" {
+		t.Errorf("expected $20.50, but got %s", FormatMoney(2050))
+	}
+	if FormatMoney(5) != "$0.05" {
+		t.Errorf("expected $0.05, but got %s", FormatMoney(5))
+	}
+	if FormatMoney(-1000) != "-$10.00" {
+		t.Errorf("expected -$10.00, but got %s", FormatMoney(-1000))
+	}
+}
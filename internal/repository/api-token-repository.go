@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type APITokenRepo interface {
+	GetAPITokenByTokenHash(tokenHash string) (models.APIToken, error)
+	GetAPITokensByUserID(userID int) ([]models.APIToken, error)
+	CreateAPIToken(token models.APIToken) (int, error)
+	DeleteAPIToken(id, userID int) error
+	TouchAPIToken(id int) error
+}
@@ -0,0 +1,156 @@
+package apitokenrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresAPITokenRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresAPITokenRepo(conn *sql.DB) repository.APITokenRepo {
+	return &postgresAPITokenRepo{
+		DB: conn,
+	}
+}
+
+const apiTokenSelect = `
+	select 
+		id,
+		user_id,
+		name,
+		token_hash,
+		scope,
+		expires_at,
+		last_used_at,
+		created_at,
+		updated_at
+	from api_tokens`
+
+func scanAPIToken(row repository.Scanner) (models.APIToken, error) {
+	var t models.APIToken
+	var expiresAt, lastUsedAt sql.NullTime
+
+	err := row.Scan(
+		&t.ID,
+		&t.UserID,
+		&t.Name,
+		&t.TokenHash,
+		&t.Scope,
+		&expiresAt,
+		&lastUsedAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+
+	t.ExpiresAt = expiresAt.Time
+	t.LastUsedAt = lastUsedAt.Time
+
+	return t, err
+}
+
+func (m *postgresAPITokenRepo) GetAPITokenByTokenHash(tokenHash string) (models.APIToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := apiTokenSelect + ` where token_hash = $1`
+
+	return scanAPIToken(m.DB.QueryRowContext(ctx, query, tokenHash))
+}
+
+func (m *postgresAPITokenRepo) GetAPITokensByUserID(userID int) ([]models.APIToken, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var apiTokens []models.APIToken
+
+	query := apiTokenSelect + ` where user_id = $1 order by created_at desc`
+
+	rows, err := m.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return apiTokens, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanAPIToken(rows)
+		if err != nil {
+			return apiTokens, err
+		}
+		apiTokens = append(apiTokens, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return apiTokens, err
+	}
+
+	return apiTokens, nil
+}
+
+func (m *postgresAPITokenRepo) CreateAPIToken(token models.APIToken) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var tokenID int
+	stmt := `insert into api_tokens 
+		(user_id, name, token_hash, scope, expires_at, created_at, updated_at) 
+		values ($1, $2, $3, $4, $5, $6, $7) returning id`
+
+	expiresAt := sql.NullTime{Time: token.ExpiresAt, Valid: !token.ExpiresAt.IsZero()}
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		token.UserID,
+		token.Name,
+		token.TokenHash,
+		token.Scope,
+		expiresAt,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&tokenID)
+
+	return tokenID, err
+}
+
+// DeleteAPIToken deletes one of a user's tokens. It fails if the user has no
+// token with that id
+func (m *postgresAPITokenRepo) DeleteAPIToken(id, userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `delete from api_tokens where id = $1 and user_id = $2`
+
+	result, err := m.DB.ExecContext(ctx, stmt, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("token not found")
+	}
+
+	return nil
+}
+
+// TouchAPIToken records that a token was just used
+func (m *postgresAPITokenRepo) TouchAPIToken(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update api_tokens set last_used_at = $1 where id = $2`
+
+	_, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), id)
+
+	return err
+}
@@ -0,0 +1,70 @@
+package apitokenrepo
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type testAPITokenRepo struct{}
+
+func NewTestAPITokenRepo() repository.APITokenRepo {
+	return &testAPITokenRepo{}
+}
+
+func (m *testAPITokenRepo) GetAPITokenByTokenHash(tokenHash string) (models.APIToken, error) {
+	t := models.APIToken{
+		ID:        1,
+		UserID:    1,
+		Name:      "Script",
+		TokenHash: tokenHash,
+		Scope:     models.APITokenScopeRead,
+	}
+	switch tokenHash {
+	case tokens.Hash("error"):
+		return models.APIToken{}, errors.New("some error")
+	case tokens.Hash("unknown"):
+		return models.APIToken{}, sql.ErrNoRows
+	case tokens.Hash("expired"):
+		t.ExpiresAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("deactivated"):
+		t.UserID = 11
+	case tokens.Hash("user error"):
+		t.UserID = 0
+	case tokens.Hash("touch error"):
+		t.ID = 3
+	}
+	return t, nil
+}
+
+func (m *testAPITokenRepo) GetAPITokensByUserID(userID int) ([]models.APIToken, error) {
+	if userID == 3 {
+		return nil, errors.New("some error")
+	}
+	return []models.APIToken{{ID: 1, UserID: userID, Name: "Script", Scope: models.APITokenScopeRead}}, nil
+}
+
+func (m *testAPITokenRepo) CreateAPIToken(token models.APIToken) (int, error) {
+	if token.Name == "error" {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
+}
+
+func (m *testAPITokenRepo) DeleteAPIToken(id, userID int) error {
+	if id == 2 {
+		return errors.New("token not found")
+	}
+	return nil
+}
+
+func (m *testAPITokenRepo) TouchAPIToken(id int) error {
+	if id == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type CalendarFeedRepo interface {
+	GetCalendarFeedByTokenHash(tokenHash string) (models.CalendarFeed, error)
+	GetCalendarFeedsByUserID(userID int) ([]models.CalendarFeed, error)
+	CreateCalendarFeed(feed models.CalendarFeed) (int, error)
+	DeleteCalendarFeed(id, userID int) error
+}
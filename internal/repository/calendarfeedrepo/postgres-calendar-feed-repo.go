@@ -0,0 +1,149 @@
+package calendarfeedrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresCalendarFeedRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresCalendarFeedRepo(conn *sql.DB) repository.CalendarFeedRepo {
+	return &postgresCalendarFeedRepo{
+		DB: conn,
+	}
+}
+
+// calendarFeedSelect selects a feed along with the name of its league, if it's
+// for one
+const calendarFeedSelect = `
+	select 
+		f.id,
+		f.user_id,
+		coalesce(f.league_id, 0),
+		f.token_hash,
+		f.created_at,
+		f.updated_at,
+		coalesce(l.name, '')
+	from calendar_feeds f 
+	left join leagues l on f.league_id = l.id`
+
+func scanCalendarFeed(row repository.Scanner) (models.CalendarFeed, error) {
+	var f models.CalendarFeed
+
+	err := row.Scan(
+		&f.ID,
+		&f.UserID,
+		&f.LeagueID,
+		&f.TokenHash,
+		&f.CreatedAt,
+		&f.UpdatedAt,
+		&f.League.Name,
+	)
+
+	f.League.ID = f.LeagueID
+
+	return f, err
+}
+
+func (m *postgresCalendarFeedRepo) GetCalendarFeedByTokenHash(tokenHash string) (models.CalendarFeed, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := calendarFeedSelect + ` where f.token_hash = $1`
+
+	return scanCalendarFeed(m.DB.QueryRowContext(ctx, query, tokenHash))
+}
+
+func (m *postgresCalendarFeedRepo) GetCalendarFeedsByUserID(userID int) ([]models.CalendarFeed, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var feeds []models.CalendarFeed
+
+	query := calendarFeedSelect + ` where f.user_id = $1 order by f.created_at desc`
+
+	rows, err := m.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return feeds, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		f, err := scanCalendarFeed(rows)
+		if err != nil {
+			return feeds, err
+		}
+		feeds = append(feeds, f)
+	}
+
+	if err = rows.Err(); err != nil {
+		return feeds, err
+	}
+
+	return feeds, nil
+}
+
+// CreateCalendarFeed adds a feed, replacing the one the user already had for
+// the same league so its old URL stops working
+func (m *postgresCalendarFeedRepo) CreateCalendarFeed(feed models.CalendarFeed) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var feedID int
+	stmt := `
+	with replaced as (
+		delete from calendar_feeds where user_id = $1 and league_id is not distinct from $2
+	)
+	insert into calendar_feeds 
+		(user_id, league_id, token_hash, created_at, updated_at) 
+		values ($1, $2, $3, $4, $5) returning id`
+
+	// a feed for all of the user's leagues has no league
+	var leagueID interface{}
+	if feed.IsLeagueFeed() {
+		leagueID = feed.LeagueID
+	}
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		feed.UserID,
+		leagueID,
+		feed.TokenHash,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&feedID)
+
+	return feedID, err
+}
+
+// DeleteCalendarFeed deletes one of a user's feeds. It fails if the user has
+// no feed with that id
+func (m *postgresCalendarFeedRepo) DeleteCalendarFeed(id, userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `delete from calendar_feeds where id = $1 and user_id = $2`
+
+	result, err := m.DB.ExecContext(ctx, stmt, id, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("calendar feed not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package calendarfeedrepo
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type testCalendarFeedRepo struct{}
+
+func NewTestCalendarFeedRepo() repository.CalendarFeedRepo {
+	return &testCalendarFeedRepo{}
+}
+
+func (m *testCalendarFeedRepo) GetCalendarFeedByTokenHash(tokenHash string) (models.CalendarFeed, error) {
+	f := models.CalendarFeed{
+		ID:        1,
+		UserID:    1,
+		TokenHash: tokenHash,
+	}
+	switch tokenHash {
+	case tokens.Hash("error"):
+		return models.CalendarFeed{}, errors.New("some error")
+	case tokens.Hash("unknown"):
+		return models.CalendarFeed{}, sql.ErrNoRows
+	case tokens.Hash("deactivated"):
+		f.UserID = 11
+	case tokens.Hash("user error"):
+		f.UserID = 0
+	case tokens.Hash("league"):
+		f.LeagueID = 1
+	}
+	return f, nil
+}
+
+func (m *testCalendarFeedRepo) GetCalendarFeedsByUserID(userID int) ([]models.CalendarFeed, error) {
+	if userID == 3 {
+		return nil, errors.New("some error")
+	}
+	return []models.CalendarFeed{{ID: 1, UserID: userID}}, nil
+}
+
+func (m *testCalendarFeedRepo) CreateCalendarFeed(feed models.CalendarFeed) (int, error) {
+	if feed.LeagueID == 3 {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
+}
+
+func (m *testCalendarFeedRepo) DeleteCalendarFeed(id, userID int) error {
+	if id == 2 {
+		return errors.New("calendar feed not found")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type CommissionerTransferRepo interface {
+	GetCommissionerTransferByTokenHash(tokenHash string) (models.CommissionerTransfer, error)
+	GetOpenCommissionerTransferByLeagueID(leagueID int) (models.CommissionerTransfer, error)
+	CreateCommissionerTransferTransaction(transfer models.CommissionerTransfer, ctx context.Context, tx *sql.Tx) (int, error)
+	CancelCommissionerTransfer(id int) error
+	CancelOpenCommissionerTransfersTransaction(leagueID int, ctx context.Context, tx *sql.Tx) error
+	AcceptCommissionerTransferTransaction(id int, ctx context.Context, tx *sql.Tx) error
+}
@@ -0,0 +1,185 @@
+package commissionertransferrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresCommissionerTransferRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresCommissionerTransferRepo(conn *sql.DB) repository.CommissionerTransferRepo {
+	return &postgresCommissionerTransferRepo{
+		DB: conn,
+	}
+}
+
+// commissionerTransferSelect selects a transfer along with its league and the
+// users on both ends of it
+const commissionerTransferSelect = `
+	select 
+		t.id,
+		t.league_id,
+		t.from_player_id,
+		t.to_player_id,
+		t.token_hash,
+		t.expires_at,
+		t.accepted_at,
+		t.cancelled_at,
+		t.created_at,
+		t.updated_at,
+		l.name,
+		fu.id,
+		fu.first_name,
+		fu.last_name,
+		fu.email,
+		tu.id,
+		tu.first_name,
+		tu.last_name,
+		tu.email
+	from commissioner_transfers t 
+	join leagues l on t.league_id = l.id 
+	join players fp on t.from_player_id = fp.id 
+	join users fu on fp.user_id = fu.id 
+	join players tp on t.to_player_id = tp.id 
+	join users tu on tp.user_id = tu.id`
+
+func scanCommissionerTransfer(row repository.Scanner) (models.CommissionerTransfer, error) {
+	var t models.CommissionerTransfer
+	var acceptedAt, cancelledAt sql.NullTime
+
+	err := row.Scan(
+		&t.ID,
+		&t.LeagueID,
+		&t.FromPlayerID,
+		&t.ToPlayerID,
+		&t.TokenHash,
+		&t.ExpiresAt,
+		&acceptedAt,
+		&cancelledAt,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&t.League.Name,
+		&t.FromUser.ID,
+		&t.FromUser.FirstName,
+		&t.FromUser.LastName,
+		&t.FromUser.Email,
+		&t.ToUser.ID,
+		&t.ToUser.FirstName,
+		&t.ToUser.LastName,
+		&t.ToUser.Email,
+	)
+
+	t.AcceptedAt = acceptedAt.Time
+	t.CancelledAt = cancelledAt.Time
+	t.League.ID = t.LeagueID
+
+	return t, err
+}
+
+func (m *postgresCommissionerTransferRepo) GetCommissionerTransferByTokenHash(tokenHash string) (models.CommissionerTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := commissionerTransferSelect + ` where t.token_hash=$1`
+
+	return scanCommissionerTransfer(m.DB.QueryRowContext(ctx, query, tokenHash))
+}
+
+// GetOpenCommissionerTransferByLeagueID returns the league's latest transfer
+// that hasn't been accepted or cancelled, including an expired one
+func (m *postgresCommissionerTransferRepo) GetOpenCommissionerTransferByLeagueID(leagueID int) (models.CommissionerTransfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := commissionerTransferSelect + ` 
+		where t.league_id=$1 and t.accepted_at is null and t.cancelled_at is null 
+		order by t.created_at desc 
+		limit 1`
+
+	return scanCommissionerTransfer(m.DB.QueryRowContext(ctx, query, leagueID))
+}
+
+func (m *postgresCommissionerTransferRepo) CreateCommissionerTransferTransaction(transfer models.CommissionerTransfer, ctx context.Context, tx *sql.Tx) (int, error) {
+	var transferID int
+	stmt := `insert into commissioner_transfers 
+		(league_id, from_player_id, to_player_id, token_hash, expires_at, created_at, updated_at) 
+		values ($1, $2, $3, $4, $5, $6, $7) returning id`
+
+	err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		transfer.LeagueID,
+		transfer.FromPlayerID,
+		transfer.ToPlayerID,
+		transfer.TokenHash,
+		transfer.ExpiresAt,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&transferID)
+
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return transferID, nil
+}
+
+func (m *postgresCommissionerTransferRepo) CancelCommissionerTransfer(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update commissioner_transfers set cancelled_at = $1, updated_at = $1 where id = $2`
+
+	_, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), id)
+
+	return err
+}
+
+// CancelOpenCommissionerTransfersTransaction cancels every transfer in the
+// league that hasn't been accepted or cancelled, so older links stop working
+func (m *postgresCommissionerTransferRepo) CancelOpenCommissionerTransfersTransaction(leagueID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update commissioner_transfers set cancelled_at = $1, updated_at = $1 
+		where league_id = $2 and accepted_at is null and cancelled_at is null`
+
+	_, err := tx.ExecContext(ctx, stmt, time.Now().UTC(), leagueID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// AcceptCommissionerTransferTransaction marks a transfer accepted. It fails
+// unless the transfer is still pending, so it can only be accepted once and
+// not after it's been cancelled or has expired
+func (m *postgresCommissionerTransferRepo) AcceptCommissionerTransferTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update commissioner_transfers set accepted_at = $1, updated_at = $1 
+		where id = $2 and accepted_at is null and cancelled_at is null and expires_at > $1`
+
+	result, err := tx.ExecContext(ctx, stmt, time.Now().UTC(), id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return errors.New("this link is no longer valid")
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package commissionertransferrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type testCommissionerTransferRepo struct{}
+
+func NewTestCommissionerTransferRepo() repository.CommissionerTransferRepo {
+	return &testCommissionerTransferRepo{}
+}
+
+func testCommissionerTransfer() models.CommissionerTransfer {
+	return models.CommissionerTransfer{
+		ID:           1,
+		LeagueID:     1,
+		FromPlayerID: 21,
+		ToPlayerID:   20,
+		ExpiresAt:    time.Now().Add(models.CommissionerTransferLifetime),
+		League:       models.League{ID: 1, Name: "League"},
+		FromUser:     models.User{ID: 21, FirstName: "Old", LastName: "Commissioner", Email: "old@commissioner.com"},
+		ToUser:       models.User{ID: 20, FirstName: "New", LastName: "Commissioner", Email: "new@commissioner.com"},
+	}
+}
+
+func (m *testCommissionerTransferRepo) GetCommissionerTransferByTokenHash(tokenHash string) (models.CommissionerTransfer, error) {
+	t := testCommissionerTransfer()
+	switch tokenHash {
+	case tokens.Hash("error"):
+		return models.CommissionerTransfer{}, errors.New("some error")
+	case tokens.Hash("expired"):
+		t.ExpiresAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("accepted"):
+		t.AcceptedAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("cancelled"):
+		t.CancelledAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("inactive"):
+		t.ToPlayerID = 22
+	case tokens.Hash("update error"):
+		t.ToPlayerID = 23
+	case tokens.Hash("not commissioner"):
+		t.FromPlayerID = 20
+		t.ToPlayerID = 24
+	case tokens.Hash("accept error"):
+		t.ID = 7
+	}
+	t.TokenHash = tokenHash
+	return t, nil
+}
+
+func (m *testCommissionerTransferRepo) GetOpenCommissionerTransferByLeagueID(leagueID int) (models.CommissionerTransfer, error) {
+	if leagueID == 3 {
+		return models.CommissionerTransfer{}, errors.New("some error")
+	}
+	if leagueID == 4 {
+		return models.CommissionerTransfer{}, sql.ErrNoRows
+	}
+	t := testCommissionerTransfer()
+	t.LeagueID = leagueID
+	return t, nil
+}
+
+func (m *testCommissionerTransferRepo) CreateCommissionerTransferTransaction(transfer models.CommissionerTransfer, ctx context.Context, tx *sql.Tx) (int, error) {
+	if transfer.ToPlayerID == 25 {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
+}
+
+func (m *testCommissionerTransferRepo) CancelCommissionerTransfer(id int) error {
+	if id == 5 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testCommissionerTransferRepo) CancelOpenCommissionerTransfersTransaction(leagueID int, ctx context.Context, tx *sql.Tx) error {
+	if leagueID == 5 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testCommissionerTransferRepo) AcceptCommissionerTransferTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	if id == 7 {
+		return errors.New("some error")
+	}
+	return nil
+}
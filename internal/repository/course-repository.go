@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type CourseRepo interface {
+	GetAllCourses() ([]models.Course, error)
+	GetCourseByID(id int) (models.Course, error)
+	GetCourseByName(name string) (models.Course, error)
+	CreateCourse(course models.Course) (int, error)
+	GetTeeSetByID(id int) (models.TeeSet, error)
+	GetTeeSetsByCourseID(courseID int) ([]models.TeeSet, error)
+	CreateTeeSetTransaction(teeSet models.TeeSet, ctx context.Context, tx *sql.Tx) (int, error)
+	CreateHoleTransaction(hole models.Hole, ctx context.Context, tx *sql.Tx) error
+}
@@ -0,0 +1,314 @@
+package courserepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresCourseRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresCourseRepo(conn *sql.DB) repository.CourseRepo {
+	return &postgresCourseRepo{
+		DB: conn,
+	}
+}
+
+// GetAllCourses returns all courses ordered by name
+func (m *postgresCourseRepo) GetAllCourses() ([]models.Course, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, name, location, number_of_holes, created_at, updated_at from courses order by name`
+
+	var courses []models.Course
+
+	rows, err := m.DB.QueryContext(ctx, query)
+	if err != nil {
+		return courses, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var c models.Course
+
+		err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.Location,
+			&c.NumberOfHoles,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return courses, err
+		}
+
+		courses = append(courses, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return courses, err
+	}
+
+	return courses, nil
+}
+
+// GetCourseByID returns a course by ID
+func (m *postgresCourseRepo) GetCourseByID(id int) (models.Course, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, name, location, number_of_holes, created_at, updated_at from courses where id=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, id)
+
+	var c models.Course
+
+	err := row.Scan(
+		&c.ID,
+		&c.Name,
+		&c.Location,
+		&c.NumberOfHoles,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// GetCourseByName returns a course by name
+func (m *postgresCourseRepo) GetCourseByName(name string) (models.Course, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, name, location, number_of_holes, created_at, updated_at from courses where name=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, name)
+
+	var c models.Course
+
+	err := row.Scan(
+		&c.ID,
+		&c.Name,
+		&c.Location,
+		&c.NumberOfHoles,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+// CreateCourse inserts a course and returns its ID
+func (m *postgresCourseRepo) CreateCourse(course models.Course) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var courseID int
+	stmt := `insert into courses (name, location, number_of_holes, created_at, updated_at) values ($1, $2, $3, $4, $5) returning id`
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		course.Name,
+		course.Location,
+		course.NumberOfHoles,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&courseID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return courseID, nil
+}
+
+// GetTeeSetByID returns a tee set and its holes by ID
+func (m *postgresCourseRepo) GetTeeSetByID(id int) (models.TeeSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, course_id, name, course_rating, slope, created_at, updated_at from tee_sets where id=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, id)
+
+	var t models.TeeSet
+
+	err := row.Scan(
+		&t.ID,
+		&t.CourseID,
+		&t.Name,
+		&t.CourseRating,
+		&t.Slope,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return t, err
+	}
+
+	t.Holes, err = m.getHolesByTeeSetID(ctx, t.ID)
+	if err != nil {
+		return t, err
+	}
+
+	return t, nil
+}
+
+// GetTeeSetsByCourseID returns all tee sets and their holes for a course
+func (m *postgresCourseRepo) GetTeeSetsByCourseID(courseID int) ([]models.TeeSet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `
+	select 
+		id, course_id, name, course_rating, slope, created_at, updated_at 
+	from tee_sets 
+	where course_id=$1 
+	order by course_rating desc`
+
+	var teeSets []models.TeeSet
+
+	rows, err := m.DB.QueryContext(ctx, query, courseID)
+	if err != nil {
+		return teeSets, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var t models.TeeSet
+
+		err := rows.Scan(
+			&t.ID,
+			&t.CourseID,
+			&t.Name,
+			&t.CourseRating,
+			&t.Slope,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return teeSets, err
+		}
+
+		teeSets = append(teeSets, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return teeSets, err
+	}
+
+	for i := range teeSets {
+		teeSets[i].Holes, err = m.getHolesByTeeSetID(ctx, teeSets[i].ID)
+		if err != nil {
+			return teeSets, err
+		}
+	}
+
+	return teeSets, nil
+}
+
+func (m *postgresCourseRepo) getHolesByTeeSetID(ctx context.Context, teeSetID int) ([]models.Hole, error) {
+	query := `
+	select 
+		id, tee_set_id, number, par, yardage, stroke_index, created_at, updated_at 
+	from holes 
+	where tee_set_id=$1 
+	order by number`
+
+	var holes []models.Hole
+
+	rows, err := m.DB.QueryContext(ctx, query, teeSetID)
+	if err != nil {
+		return holes, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var h models.Hole
+
+		err := rows.Scan(
+			&h.ID,
+			&h.TeeSetID,
+			&h.Number,
+			&h.Par,
+			&h.Yardage,
+			&h.StrokeIndex,
+			&h.CreatedAt,
+			&h.UpdatedAt,
+		)
+		if err != nil {
+			return holes, err
+		}
+
+		holes = append(holes, h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return holes, err
+	}
+
+	return holes, nil
+}
+
+func (m *postgresCourseRepo) CreateTeeSetTransaction(teeSet models.TeeSet, ctx context.Context, tx *sql.Tx) (int, error) {
+	var teeSetID int
+	stmt := `insert into tee_sets (course_id, name, course_rating, slope, created_at, updated_at) values ($1, $2, $3, $4, $5, $6) returning id`
+
+	err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		teeSet.CourseID,
+		teeSet.Name,
+		teeSet.CourseRating,
+		teeSet.Slope,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&teeSetID)
+
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return teeSetID, nil
+}
+
+func (m *postgresCourseRepo) CreateHoleTransaction(hole models.Hole, ctx context.Context, tx *sql.Tx) error {
+	stmt := `insert into holes (tee_set_id, number, par, yardage, stroke_index, created_at, updated_at) values ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		stmt,
+		hole.TeeSetID,
+		hole.Number,
+		hole.Par,
+		hole.Yardage,
+		hole.StrokeIndex,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package courserepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testCourseRepo struct{}
+
+func NewTestCourseRepo() repository.CourseRepo {
+	return &testCourseRepo{}
+}
+
+func (m *testCourseRepo) GetAllCourses() ([]models.Course, error) {
+	var c []models.Course
+	return c, nil
+}
+
+func (m *testCourseRepo) GetCourseByID(id int) (models.Course, error) {
+	var c models.Course
+	if id == 3 {
+		return c, errors.New("some error")
+	}
+	c.ID = id
+	c.NumberOfHoles = 9
+	return c, nil
+}
+
+func (m *testCourseRepo) GetCourseByName(name string) (models.Course, error) {
+	var c models.Course
+	if name == "course0" {
+		return c, nil
+	}
+	return c, errors.New("some error")
+}
+
+func (m *testCourseRepo) CreateCourse(course models.Course) (int, error) {
+	if course.Name == "course1" {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
+}
+
+func (m *testCourseRepo) GetTeeSetByID(id int) (models.TeeSet, error) {
+	var t models.TeeSet
+	if id == 3 {
+		return t, errors.New("some error")
+	}
+	t.ID = id
+	t.CourseID = 1
+	t.CourseRating = 36.0
+	t.Slope = 113
+	for i := 1; i <= 9; i++ {
+		t.Holes = append(t.Holes, models.Hole{TeeSetID: id, Number: i, Par: 4, Yardage: 400, StrokeIndex: i})
+	}
+	return t, nil
+}
+
+func (m *testCourseRepo) GetTeeSetsByCourseID(courseID int) ([]models.TeeSet, error) {
+	if courseID == 2 {
+		return nil, errors.New("some error")
+	}
+	var t []models.TeeSet
+	return t, nil
+}
+
+func (m *testCourseRepo) CreateTeeSetTransaction(teeSet models.TeeSet, ctx context.Context, tx *sql.Tx) (int, error) {
+	if teeSet.Name == "Tee Set Error" {
+		return 0, errors.New("tee set creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testCourseRepo) CreateHoleTransaction(hole models.Hole, ctx context.Context, tx *sql.Tx) error {
+	if hole.Yardage == 999 {
+		return errors.New("hole creation failed")
+	}
+	return nil
+}
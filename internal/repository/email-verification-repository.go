@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type EmailVerificationRepo interface {
+	GetEmailVerificationByTokenHash(tokenHash string) (models.EmailVerification, error)
+	CreateEmailVerification(verification models.EmailVerification) (int, error)
+	UseEmailVerificationTransaction(id int, ctx context.Context, tx *sql.Tx) error
+}
@@ -0,0 +1,95 @@
+package emailverificationrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresEmailVerificationRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresEmailVerificationRepo(conn *sql.DB) repository.EmailVerificationRepo {
+	return &postgresEmailVerificationRepo{
+		DB: conn,
+	}
+}
+
+func (m *postgresEmailVerificationRepo) GetEmailVerificationByTokenHash(tokenHash string) (models.EmailVerification, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, user_id, email, token_hash, expires_at, used_at, created_at, updated_at from email_verifications where token_hash=$1`
+
+	var e models.EmailVerification
+	var usedAt sql.NullTime
+
+	err := m.DB.QueryRowContext(ctx, query, tokenHash).Scan(
+		&e.ID,
+		&e.UserID,
+		&e.Email,
+		&e.TokenHash,
+		&e.ExpiresAt,
+		&usedAt,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+
+	e.UsedAt = usedAt.Time
+
+	return e, err
+}
+
+func (m *postgresEmailVerificationRepo) CreateEmailVerification(verification models.EmailVerification) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var id int
+	stmt := `insert into email_verifications (user_id, email, token_hash, expires_at, created_at, updated_at) values ($1, $2, $3, $4, $5, $6) returning id`
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		verification.UserID,
+		verification.Email,
+		verification.TokenHash,
+		verification.ExpiresAt,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UseEmailVerificationTransaction marks a verification as used. It fails if
+// the verification was already used, so a link can't be used twice
+func (m *postgresEmailVerificationRepo) UseEmailVerificationTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update email_verifications set used_at = $1, updated_at = $1 where id = $2 and used_at is null`
+
+	result, err := tx.ExecContext(ctx, stmt, time.Now().UTC(), id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return errors.New("this verification link has already been used")
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package emailverificationrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type testEmailVerificationRepo struct{}
+
+func NewTestEmailVerificationRepo() repository.EmailVerificationRepo {
+	return &testEmailVerificationRepo{}
+}
+
+func (m *testEmailVerificationRepo) GetEmailVerificationByTokenHash(tokenHash string) (models.EmailVerification, error) {
+	e := models.EmailVerification{
+		ID:        1,
+		UserID:    1,
+		Email:     "me@here.com",
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(models.EmailVerificationLifetime),
+	}
+	switch tokenHash {
+	case tokens.Hash("error"):
+		return models.EmailVerification{}, errors.New("some error")
+	case tokens.Hash("expired"):
+		e.ExpiresAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("used"):
+		e.UsedAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("use error"):
+		e.ID = 7
+	case tokens.Hash("email changed"):
+		e.Email = "error@here.com"
+	}
+	return e, nil
+}
+
+func (m *testEmailVerificationRepo) CreateEmailVerification(verification models.EmailVerification) (int, error) {
+	if verification.UserID == 3 {
+		return 0, errors.New("email verification creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testEmailVerificationRepo) UseEmailVerificationTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	if id == 7 {
+		return errors.New("this verification link has already been used")
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type InvitationRepo interface {
+	GetInvitationByID(id int) (models.Invitation, error)
+	GetInvitationByTokenHash(tokenHash string) (models.Invitation, error)
+	GetOpenInvitationsByLeagueID(leagueID int) ([]models.Invitation, error)
+	CreateInvitationTransaction(invitation models.Invitation, ctx context.Context, tx *sql.Tx) (int, error)
+	RenewInvitation(id int, tokenHash string, expiresAt time.Time) error
+	RevokeInvitation(id int) error
+	AcceptInvitationTransaction(id int, ctx context.Context, tx *sql.Tx) error
+}
@@ -0,0 +1,193 @@
+package invitationrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresInvitationRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresInvitationRepo(conn *sql.DB) repository.InvitationRepo {
+	return &postgresInvitationRepo{
+		DB: conn,
+	}
+}
+
+// invitationSelect selects an invitation along with its user and league
+const invitationSelect = `
+	select 
+		i.id,
+		i.user_id,
+		i.league_id,
+		i.token_hash,
+		i.expires_at,
+		i.accepted_at,
+		i.revoked_at,
+		i.created_at,
+		i.updated_at,
+		u.first_name,
+		u.last_name,
+		u.email,
+		l.name
+	from invitations i 
+	join users u on i.user_id = u.id 
+	join leagues l on i.league_id = l.id`
+
+func scanInvitation(row repository.Scanner) (models.Invitation, error) {
+	var i models.Invitation
+	var acceptedAt, revokedAt sql.NullTime
+
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.LeagueID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&acceptedAt,
+		&revokedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.User.FirstName,
+		&i.User.LastName,
+		&i.User.Email,
+		&i.League.Name,
+	)
+
+	i.AcceptedAt = acceptedAt.Time
+	i.RevokedAt = revokedAt.Time
+	i.User.ID = i.UserID
+	i.League.ID = i.LeagueID
+
+	return i, err
+}
+
+func (m *postgresInvitationRepo) GetInvitationByID(id int) (models.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := invitationSelect + ` where i.id=$1`
+
+	return scanInvitation(m.DB.QueryRowContext(ctx, query, id))
+}
+
+func (m *postgresInvitationRepo) GetInvitationByTokenHash(tokenHash string) (models.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := invitationSelect + ` where i.token_hash=$1`
+
+	return scanInvitation(m.DB.QueryRowContext(ctx, query, tokenHash))
+}
+
+// GetOpenInvitationsByLeagueID returns a league's invitations that haven't
+// been claimed or revoked, including expired ones, by the invitee's name
+func (m *postgresInvitationRepo) GetOpenInvitationsByLeagueID(leagueID int) ([]models.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := invitationSelect + ` 
+		where i.league_id=$1 and i.accepted_at is null and i.revoked_at is null 
+		order by u.last_name, u.first_name`
+
+	var invitations []models.Invitation
+
+	rows, err := m.DB.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return invitations, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		i, err := scanInvitation(rows)
+		if err != nil {
+			return invitations, err
+		}
+
+		invitations = append(invitations, i)
+	}
+
+	if err = rows.Err(); err != nil {
+		return invitations, err
+	}
+
+	return invitations, nil
+}
+
+func (m *postgresInvitationRepo) CreateInvitationTransaction(invitation models.Invitation, ctx context.Context, tx *sql.Tx) (int, error) {
+	var invitationID int
+	stmt := `insert into invitations (user_id, league_id, token_hash, expires_at, created_at, updated_at) values ($1, $2, $3, $4, $5, $6) returning id`
+
+	err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		invitation.UserID,
+		invitation.LeagueID,
+		invitation.TokenHash,
+		invitation.ExpiresAt,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&invitationID)
+
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return invitationID, nil
+}
+
+// RenewInvitation gives an invitation a new token and expiry, so the link in
+// any earlier email stops working
+func (m *postgresInvitationRepo) RenewInvitation(id int, tokenHash string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update invitations set token_hash = $1, expires_at = $2, updated_at = $3 where id = $4`
+
+	_, err := m.DB.ExecContext(ctx, stmt, tokenHash, expiresAt, time.Now().UTC(), id)
+
+	return err
+}
+
+func (m *postgresInvitationRepo) RevokeInvitation(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update invitations set revoked_at = $1, updated_at = $1 where id = $2`
+
+	_, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), id)
+
+	return err
+}
+
+// AcceptInvitationTransaction marks an invitation accepted. It fails if the
+// invitation has already been accepted or revoked, so it can only be used once
+func (m *postgresInvitationRepo) AcceptInvitationTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update invitations set accepted_at = $1, updated_at = $1 where id = $2 and accepted_at is null and revoked_at is null`
+
+	result, err := tx.ExecContext(ctx, stmt, time.Now().UTC(), id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return errors.New("this invitation has already been used")
+	}
+
+	return nil
+}
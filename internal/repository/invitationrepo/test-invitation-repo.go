@@ -0,0 +1,90 @@
+package invitationrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type testInvitationRepo struct{}
+
+func NewTestInvitationRepo() repository.InvitationRepo {
+	return &testInvitationRepo{}
+}
+
+func testInvitation(id int) models.Invitation {
+	return models.Invitation{
+		ID:        id,
+		UserID:    5,
+		LeagueID:  1,
+		ExpiresAt: time.Now().Add(models.InvitationLifetime),
+		User:      models.User{ID: 5, FirstName: "New", LastName: "Player", Email: "new@player.com"},
+		League:    models.League{ID: 1, Name: "League"},
+	}
+}
+
+func (m *testInvitationRepo) GetInvitationByID(id int) (models.Invitation, error) {
+	if id == 3 {
+		return models.Invitation{}, errors.New("some error")
+	}
+	return testInvitation(id), nil
+}
+
+func (m *testInvitationRepo) GetInvitationByTokenHash(tokenHash string) (models.Invitation, error) {
+	i := testInvitation(1)
+	switch tokenHash {
+	case tokens.Hash("error"):
+		return models.Invitation{}, errors.New("some error")
+	case tokens.Hash("expired"):
+		i.ExpiresAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("accepted"):
+		i.AcceptedAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("revoked"):
+		i.RevokedAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("accept error"):
+		i.ID = 7
+	}
+	i.TokenHash = tokenHash
+	return i, nil
+}
+
+func (m *testInvitationRepo) GetOpenInvitationsByLeagueID(leagueID int) ([]models.Invitation, error) {
+	var i []models.Invitation
+	if leagueID == 3 {
+		return i, errors.New("some error")
+	}
+	return append(i, testInvitation(1)), nil
+}
+
+func (m *testInvitationRepo) CreateInvitationTransaction(invitation models.Invitation, ctx context.Context, tx *sql.Tx) (int, error) {
+	if invitation.UserID == 3 {
+		return 0, errors.New("invitation creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testInvitationRepo) RenewInvitation(id int, tokenHash string, expiresAt time.Time) error {
+	if id == 5 {
+		return errors.New("invitation update failed")
+	}
+	return nil
+}
+
+func (m *testInvitationRepo) RevokeInvitation(id int) error {
+	if id == 5 {
+		return errors.New("invitation update failed")
+	}
+	return nil
+}
+
+func (m *testInvitationRepo) AcceptInvitationTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	if id == 7 {
+		return errors.New("invitation update failed")
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type LeagueAdminRepo interface {
+	GetLeagueAdminByID(ID int) (models.LeagueAdmin, error)
+	GetLeagueAdmin(leagueID, userID int) (models.LeagueAdmin, error)
+	GetLeagueAdminsByLeagueID(leagueID int) ([]models.LeagueAdmin, error)
+	SaveLeagueAdmin(admin models.LeagueAdmin) error
+	DeleteLeagueAdmin(ID int) error
+}
@@ -11,6 +11,9 @@ type LeagueRepo interface {
 	GetLeagueByName(name string) (models.League, error)
 	GetLeagueByID(id int) (models.League, error)
 	GetLeaguesByUserID(userID int) ([]models.League, error)
+	AllLeagues(search string, limit, offset int) ([]models.League, error)
+	CountLeagues(search string) (int, error)
 	CreateLeague(league models.League, commissioner models.Player) (int, error)
 	CreateLeagueTransaction(league models.League, ctx context.Context, tx *sql.Tx) (int, error)
+	UpdateLeagueSettings(league models.League) error
 }
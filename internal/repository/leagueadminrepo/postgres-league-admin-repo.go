@@ -0,0 +1,135 @@
+package leagueadminrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresLeagueAdminRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresLeagueAdminRepo(conn *sql.DB) repository.LeagueAdminRepo {
+	return &postgresLeagueAdminRepo{
+		DB: conn,
+	}
+}
+
+// leagueAdminSelect selects a league admin along with their user
+const leagueAdminSelect = `
+	select 
+		a.id,
+		a.league_id,
+		a.user_id,
+		a.role,
+		a.created_at,
+		a.updated_at,
+		u.first_name,
+		u.last_name,
+		u.email
+	from league_admins a 
+	join users u on a.user_id = u.id`
+
+func scanLeagueAdmin(row repository.Scanner) (models.LeagueAdmin, error) {
+	var a models.LeagueAdmin
+
+	err := row.Scan(
+		&a.ID,
+		&a.LeagueID,
+		&a.UserID,
+		&a.Role,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+		&a.User.FirstName,
+		&a.User.LastName,
+		&a.User.Email,
+	)
+
+	a.User.ID = a.UserID
+
+	return a, err
+}
+
+func (m *postgresLeagueAdminRepo) GetLeagueAdminByID(ID int) (models.LeagueAdmin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := leagueAdminSelect + ` where a.id=$1`
+
+	return scanLeagueAdmin(m.DB.QueryRowContext(ctx, query, ID))
+}
+
+// GetLeagueAdmin returns the role a user has been given in a league, or
+// sql.ErrNoRows if they haven't been given one
+func (m *postgresLeagueAdminRepo) GetLeagueAdmin(leagueID, userID int) (models.LeagueAdmin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := leagueAdminSelect + ` where a.league_id=$1 and a.user_id=$2`
+
+	return scanLeagueAdmin(m.DB.QueryRowContext(ctx, query, leagueID, userID))
+}
+
+// GetLeagueAdminsByLeagueID returns everyone who has been given a role in a
+// league, by name
+func (m *postgresLeagueAdminRepo) GetLeagueAdminsByLeagueID(leagueID int) ([]models.LeagueAdmin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := leagueAdminSelect + ` where a.league_id=$1 order by u.last_name, u.first_name`
+
+	var admins []models.LeagueAdmin
+
+	rows, err := m.DB.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return admins, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		a, err := scanLeagueAdmin(rows)
+		if err != nil {
+			return admins, err
+		}
+
+		admins = append(admins, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return admins, err
+	}
+
+	return admins, nil
+}
+
+// SaveLeagueAdmin gives a user a role in a league, replacing any role they
+// already had there
+func (m *postgresLeagueAdminRepo) SaveLeagueAdmin(admin models.LeagueAdmin) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `
+		insert into league_admins (league_id, user_id, role, created_at, updated_at) 
+		values ($1, $2, $3, $4, $5) 
+		on conflict (league_id, user_id) do update set 
+			role = excluded.role, 
+			updated_at = excluded.updated_at`
+
+	_, err := m.DB.ExecContext(ctx, stmt, admin.LeagueID, admin.UserID, admin.Role, time.Now().UTC(), time.Now().UTC())
+
+	return err
+}
+
+func (m *postgresLeagueAdminRepo) DeleteLeagueAdmin(ID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	_, err := m.DB.ExecContext(ctx, `delete from league_admins where id = $1`, ID)
+
+	return err
+}
@@ -0,0 +1,60 @@
+package leagueadminrepo
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testLeagueAdminRepo struct{}
+
+func NewTestLeagueAdminRepo() repository.LeagueAdminRepo {
+	return &testLeagueAdminRepo{}
+}
+
+func (m *testLeagueAdminRepo) GetLeagueAdminByID(ID int) (models.LeagueAdmin, error) {
+	if ID == 0 {
+		return models.LeagueAdmin{}, errors.New("some error")
+	}
+	return models.LeagueAdmin{ID: ID, LeagueID: 1, UserID: 12, Role: models.LeagueRoleCoCommissioner}, nil
+}
+
+func (m *testLeagueAdminRepo) GetLeagueAdmin(leagueID, userID int) (models.LeagueAdmin, error) {
+	a := models.LeagueAdmin{ID: 1, LeagueID: leagueID, UserID: userID}
+	switch userID {
+	case 5:
+		return models.LeagueAdmin{}, errors.New("some error")
+	case 12:
+		a.Role = models.LeagueRoleCoCommissioner
+	case 13:
+		a.Role = models.LeagueRoleScorekeeper
+	case 14:
+		a.Role = models.LeagueRoleMember
+	default:
+		return models.LeagueAdmin{}, sql.ErrNoRows
+	}
+	return a, nil
+}
+
+func (m *testLeagueAdminRepo) GetLeagueAdminsByLeagueID(leagueID int) ([]models.LeagueAdmin, error) {
+	if leagueID == 0 {
+		return nil, errors.New("some error")
+	}
+	return []models.LeagueAdmin{{ID: 1, LeagueID: leagueID, UserID: 12, Role: models.LeagueRoleCoCommissioner}}, nil
+}
+
+func (m *testLeagueAdminRepo) SaveLeagueAdmin(admin models.LeagueAdmin) error {
+	if admin.UserID == 4 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testLeagueAdminRepo) DeleteLeagueAdmin(ID int) error {
+	if ID == 2 {
+		return errors.New("some error")
+	}
+	return nil
+}
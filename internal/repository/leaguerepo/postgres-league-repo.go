@@ -19,20 +19,20 @@ func NewPostgresLeagueRepo(conn *sql.DB) repository.LeagueRepo {
 	}
 }
 
-// GetLeagueByName returns a league by name
-func (m *postgresLeagueRepo) GetLeagueByName(name string) (models.League, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-	defer cancel()
-
-	query := `select id, name, created_at, updated_at from leagues where name=$1`
-
-	row := m.DB.QueryRowContext(ctx, query, name)
-
+func scanLeague(row repository.Scanner) (models.League, error) {
 	var l models.League
+	var stablefordPoints string
 
 	err := row.Scan(
 		&l.ID,
 		&l.Name,
+		&l.ScoringFormat,
+		&stablefordPoints,
+		&l.MatchAllowance,
+		&l.WinPoints,
+		&l.HalvePoints,
+		&l.HolePoints,
+		&l.TimeZone,
 		&l.CreatedAt,
 		&l.UpdatedAt,
 	)
@@ -40,7 +40,20 @@ func (m *postgresLeagueRepo) GetLeagueByName(name string) (models.League, error)
 		return l, err
 	}
 
-	return l, nil
+	l.StablefordTable, err = models.ParseStablefordTable(stablefordPoints)
+	return l, err
+}
+
+// GetLeagueByName returns a league by name
+func (m *postgresLeagueRepo) GetLeagueByName(name string) (models.League, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, name, scoring_format, stableford_points, match_allowance, win_points, halve_points, hole_points, time_zone, created_at, updated_at from leagues where name=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, name)
+
+	return scanLeague(row)
 }
 
 // GetLeagueByID returns a league by ID
@@ -48,23 +61,11 @@ func (m *postgresLeagueRepo) GetLeagueByID(id int) (models.League, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	query := `select id, name, created_at, updated_at from leagues where id=$1`
+	query := `select id, name, scoring_format, stableford_points, match_allowance, win_points, halve_points, hole_points, time_zone, created_at, updated_at from leagues where id=$1`
 
 	row := m.DB.QueryRowContext(ctx, query, id)
 
-	var l models.League
-
-	err := row.Scan(
-		&l.ID,
-		&l.Name,
-		&l.CreatedAt,
-		&l.UpdatedAt,
-	)
-	if err != nil {
-		return l, err
-	}
-
-	return l, nil
+	return scanLeague(row)
 }
 
 func (m *postgresLeagueRepo) GetLeaguesByUserID(userID int) ([]models.League, error) {
@@ -72,7 +73,7 @@ func (m *postgresLeagueRepo) GetLeaguesByUserID(userID int) ([]models.League, er
 	defer cancel()
 
 	query := `select
-	l.id, l.name, l.created_at, l.updated_at 
+	l.id, l.name, l.scoring_format, l.stableford_points, l.match_allowance, l.win_points, l.halve_points, l.hole_points, l.time_zone, l.created_at, l.updated_at 
 	from leagues l 
 	join players p on l.id = p.league_id
 	where p.user_id=$1`
@@ -87,14 +88,46 @@ func (m *postgresLeagueRepo) GetLeaguesByUserID(userID int) ([]models.League, er
 	defer rows.Close()
 
 	for rows.Next() {
-		var l models.League
-
-		err := rows.Scan(
-			&l.ID,
-			&l.Name,
-			&l.CreatedAt,
-			&l.UpdatedAt,
-		)
+		l, err := scanLeague(rows)
+		if err != nil {
+			return leagues, err
+		}
+
+		leagues = append(leagues, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return leagues, err
+	}
+
+	return leagues, nil
+}
+
+// AllLeagues returns a page of the leagues whose name contains search, newest
+// first
+func (m *postgresLeagueRepo) AllLeagues(search string, limit, offset int) ([]models.League, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `
+	select 
+		id, name, scoring_format, stableford_points, time_zone, created_at, updated_at 
+	from leagues 
+	where name ilike $1 
+	order by created_at desc, id desc 
+	limit $2 offset $3`
+
+	var leagues []models.League
+
+	rows, err := m.DB.QueryContext(ctx, query, "%"+search+"%", limit, offset)
+	if err != nil {
+		return leagues, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		l, err := scanLeague(rows)
 		if err != nil {
 			return leagues, err
 		}
@@ -109,6 +142,19 @@ func (m *postgresLeagueRepo) GetLeaguesByUserID(userID int) ([]models.League, er
 	return leagues, nil
 }
 
+// CountLeagues returns how many leagues' names contain search
+func (m *postgresLeagueRepo) CountLeagues(search string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select count(id) from leagues where name ilike $1`
+
+	var count int
+	err := m.DB.QueryRowContext(ctx, query, "%"+search+"%").Scan(&count)
+
+	return count, err
+}
+
 func (m *postgresLeagueRepo) CreateLeagueTransaction(league models.League, ctx context.Context, tx *sql.Tx) (int, error) {
 	var leagueID int
 	stmt := `insert into leagues (name, created_at, updated_at) values ($1, $2, $3) returning id`
@@ -117,8 +163,8 @@ func (m *postgresLeagueRepo) CreateLeagueTransaction(league models.League, ctx c
 		ctx,
 		stmt,
 		league.Name,
-		time.Now(),
-		time.Now(),
+		time.Now().UTC(),
+		time.Now().UTC(),
 	).Scan(&leagueID)
 
 	if err != nil {
@@ -147,8 +193,8 @@ func (m *postgresLeagueRepo) CreateLeague(league models.League, commissioner mod
 		ctx,
 		stmt,
 		league.Name,
-		time.Now(),
-		time.Now(),
+		time.Now().UTC(),
+		time.Now().UTC(),
 	).Scan(&leagueID)
 
 	if err != nil {
@@ -164,8 +210,8 @@ func (m *postgresLeagueRepo) CreateLeague(league models.League, commissioner mod
 		commissioner.UserID,
 		commissioner.IsCommissioner,
 		commissioner.IsActive,
-		time.Now(),
-		time.Now(),
+		time.Now().UTC(),
+		time.Now().UTC(),
 	)
 
 	if err != nil {
@@ -180,3 +226,29 @@ func (m *postgresLeagueRepo) CreateLeague(league models.League, commissioner mod
 
 	return leagueID, nil
 }
+
+// UpdateLeagueSettings updates how a league's weeks and matchups are scored
+// and the time zone its tee times are in
+func (m *postgresLeagueRepo) UpdateLeagueSettings(league models.League) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update leagues set scoring_format=$1, stableford_points=$2, match_allowance=$3, win_points=$4,
+	halve_points=$5, hole_points=$6, time_zone=$7, updated_at=$8 where id=$9`
+
+	_, err := m.DB.ExecContext(
+		ctx,
+		stmt,
+		league.ScoringFormat,
+		league.StablefordTable.String(),
+		league.MatchAllowance,
+		league.WinPoints,
+		league.HalvePoints,
+		league.HolePoints,
+		league.TimeZone,
+		time.Now().UTC(),
+		league.ID,
+	)
+
+	return err
+}
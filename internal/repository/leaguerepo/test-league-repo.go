@@ -40,6 +40,11 @@ func (m *testLeagueRepo) GetLeagueByID(id int) (models.League, error) {
 	}
 
 	l.ID = id
+	l.ScoringFormat = models.FormatStrokePlay
+	l.StablefordTable = models.StandardStableford
+	l.MatchAllowance = 1
+	l.WinPoints = 2
+	l.HalvePoints = 1
 	return l, nil
 }
 
@@ -48,9 +53,32 @@ func (m *testLeagueRepo) GetLeaguesByUserID(userID int) ([]models.League, error)
 	return l, nil
 }
 
+func (m *testLeagueRepo) AllLeagues(search string, limit, offset int) ([]models.League, error) {
+	var l []models.League
+	if search == "error" {
+		return l, errors.New("some error")
+	}
+	l = append(l, models.League{ID: 1})
+	return l, nil
+}
+
+func (m *testLeagueRepo) CountLeagues(search string) (int, error) {
+	if search == "count error" {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
+}
+
 func (m *testLeagueRepo) CreateLeague(league models.League, commissioner models.Player) (int, error) {
 	if league.Name == "league1" {
 		return 0, errors.New("some error")
 	}
 	return 1, nil
 }
+
+func (m *testLeagueRepo) UpdateLeagueSettings(league models.League) error {
+	if league.ID == 5 {
+		return errors.New("league update failed")
+	}
+	return nil
+}
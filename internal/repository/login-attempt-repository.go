@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type LoginAttemptRepo interface {
+	ReserveLoginAttempt(key string, p models.LoginThrottlePolicy, now time.Time) (time.Duration, bool, error)
+	ReleaseLoginAttempt(key string, p models.LoginThrottlePolicy) error
+	DeleteLoginAttempt(key string) error
+	GetLockedLoginAttempts(now time.Time) ([]models.LoginAttempt, error)
+}
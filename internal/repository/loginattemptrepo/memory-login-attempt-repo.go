@@ -0,0 +1,94 @@
+package loginattemptrepo
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type memoryLoginAttemptRepo struct {
+	mu       sync.Mutex
+	attempts map[string]models.LoginAttempt
+}
+
+// NewMemoryLoginAttemptRepo keeps failed logins in memory. They're lost on
+// restart and aren't shared between app servers
+func NewMemoryLoginAttemptRepo() repository.LoginAttemptRepo {
+	return &memoryLoginAttemptRepo{
+		attempts: make(map[string]models.LoginAttempt),
+	}
+}
+
+// ReserveLoginAttempt counts a login for key as failed until it's released,
+// or returns how long it has to wait instead. It reports whether counting the
+// login locked key out
+func (m *memoryLoginAttemptRepo) ReserveLoginAttempt(key string, p models.LoginThrottlePolicy, now time.Time) (time.Duration, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.attempts[key]
+	if !ok {
+		a = models.LoginAttempt{Key: key, CreatedAt: now}
+	}
+
+	a, wait, locked := a.Reserve(p, now)
+	if wait > 0 {
+		return wait, false, nil
+	}
+
+	a.UpdatedAt = now
+	m.attempts[key] = a
+	return 0, locked, nil
+}
+
+// ReleaseLoginAttempt takes back a login reserved for key
+func (m *memoryLoginAttemptRepo) ReleaseLoginAttempt(key string, p models.LoginThrottlePolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	a, ok := m.attempts[key]
+	if !ok {
+		return nil
+	}
+
+	a = a.Release(p)
+	a.UpdatedAt = time.Now()
+	m.attempts[key] = a
+	return nil
+}
+
+func (m *memoryLoginAttemptRepo) DeleteLoginAttempt(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.attempts, key)
+	return nil
+}
+
+// GetLockedLoginAttempts returns the keys that are locked out at now, the
+// ones that unlock soonest first. Keys with old failures are dropped along
+// the way so the map doesn't grow forever
+func (m *memoryLoginAttemptRepo) GetLockedLoginAttempts(now time.Time) ([]models.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var attempts []models.LoginAttempt
+	for key, a := range m.attempts {
+		if a.IsLocked(now) {
+			attempts = append(attempts, a)
+			continue
+		}
+		if now.Sub(a.LastFailedAt) > 24*time.Hour {
+			delete(m.attempts, key)
+		}
+	}
+
+	sort.Slice(attempts, func(i, j int) bool {
+		return attempts[i].LockedUntil.Before(attempts[j].LockedUntil)
+	})
+
+	return attempts, nil
+}
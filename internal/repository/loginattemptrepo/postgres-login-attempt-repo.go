@@ -0,0 +1,166 @@
+package loginattemptrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresLoginAttemptRepo struct {
+	DB *sql.DB
+}
+
+// NewPostgresLoginAttemptRepo keeps failed logins in the database, so they
+// survive restarts and are shared between app servers
+func NewPostgresLoginAttemptRepo(conn *sql.DB) repository.LoginAttemptRepo {
+	return &postgresLoginAttemptRepo{
+		DB: conn,
+	}
+}
+
+// ReserveLoginAttempt counts a login for key as failed until it's released,
+// or returns how long it has to wait instead. It reports whether counting the
+// login locked key out. The key's row is locked while it's counted, so logins
+// tried at the same time are counted one after the other
+func (m *postgresLoginAttemptRepo) ReserveLoginAttempt(key string, p models.LoginThrottlePolicy, now time.Time) (time.Duration, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	tx, err := m.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	stmt := `
+		insert into login_attempts (throttle_key, failures, last_failed_at, created_at, updated_at) 
+		values ($1, 0, $2, $3, $4) 
+		on conflict (throttle_key) do nothing`
+
+	_, err = tx.ExecContext(ctx, stmt, key, now, time.Now().UTC(), time.Now().UTC())
+	if err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+
+	query := `select id, throttle_key, failures, last_failed_at, locked_until, created_at, updated_at from login_attempts where throttle_key=$1 for update`
+
+	var a models.LoginAttempt
+	var lockedUntil sql.NullTime
+
+	err = tx.QueryRowContext(ctx, query, key).Scan(
+		&a.ID,
+		&a.Key,
+		&a.Failures,
+		&a.LastFailedAt,
+		&lockedUntil,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+
+	a.LockedUntil = lockedUntil.Time
+
+	a, wait, locked := a.Reserve(p, now)
+	if wait > 0 {
+		tx.Rollback()
+		return wait, false, nil
+	}
+
+	lockedUntil = sql.NullTime{}
+	if !a.LockedUntil.IsZero() {
+		lockedUntil = sql.NullTime{Time: a.LockedUntil, Valid: true}
+	}
+
+	stmt = `update login_attempts set failures=$1, last_failed_at=$2, locked_until=$3, updated_at=$4 where id=$5`
+
+	_, err = tx.ExecContext(ctx, stmt, a.Failures, a.LastFailedAt, lockedUntil, time.Now().UTC(), a.ID)
+	if err != nil {
+		tx.Rollback()
+		return 0, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, err
+	}
+
+	return 0, locked, nil
+}
+
+// ReleaseLoginAttempt takes back a login reserved for key, lifting the lockout
+// it caused if it caused one
+func (m *postgresLoginAttemptRepo) ReleaseLoginAttempt(key string, p models.LoginThrottlePolicy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `
+		update login_attempts set 
+			failures = greatest(failures - 1, 0), 
+			locked_until = case when failures - 1 < $1 then null else locked_until end, 
+			updated_at = $2 
+		where throttle_key=$3`
+
+	_, err := m.DB.ExecContext(ctx, stmt, p.LockoutAttempts, time.Now().UTC(), key)
+
+	return err
+}
+
+func (m *postgresLoginAttemptRepo) DeleteLoginAttempt(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `delete from login_attempts where throttle_key=$1`
+
+	_, err := m.DB.ExecContext(ctx, stmt, key)
+
+	return err
+}
+
+// GetLockedLoginAttempts returns the keys that are locked out at now, the
+// ones that unlock soonest first
+func (m *postgresLoginAttemptRepo) GetLockedLoginAttempts(now time.Time) ([]models.LoginAttempt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var attempts []models.LoginAttempt
+
+	query := `
+		select id, throttle_key, failures, last_failed_at, locked_until, created_at, updated_at 
+		from login_attempts 
+		where locked_until > $1 
+		order by locked_until`
+
+	rows, err := m.DB.QueryContext(ctx, query, now)
+	if err != nil {
+		return attempts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.LoginAttempt
+		err := rows.Scan(
+			&a.ID,
+			&a.Key,
+			&a.Failures,
+			&a.LastFailedAt,
+			&a.LockedUntil,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+		)
+		if err != nil {
+			return attempts, err
+		}
+		attempts = append(attempts, a)
+	}
+
+	if err = rows.Err(); err != nil {
+		return attempts, err
+	}
+
+	return attempts, nil
+}
@@ -0,0 +1,56 @@
+package loginattemptrepo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testLoginAttemptRepo struct{}
+
+func NewTestLoginAttemptRepo() repository.LoginAttemptRepo {
+	return &testLoginAttemptRepo{}
+}
+
+func (m *testLoginAttemptRepo) ReserveLoginAttempt(key string, p models.LoginThrottlePolicy, now time.Time) (time.Duration, bool, error) {
+	if key == models.EmailLoginKey("error@here.com") {
+		return 0, false, errors.New("some error")
+	}
+	if key == models.EmailLoginKey("locked@here.ca") {
+		return models.EmailLoginPolicy.LockoutDuration, false, nil
+	}
+	if key == models.EmailLoginKey("lockout@here.ca") {
+		return 0, true, nil
+	}
+	if key == models.IPLoginKey("10.0.0.9") {
+		return models.IPLoginPolicy.LockoutDuration, false, nil
+	}
+	return 0, false, nil
+}
+
+func (m *testLoginAttemptRepo) ReleaseLoginAttempt(key string, p models.LoginThrottlePolicy) error {
+	if key == models.EmailLoginKey("release@error.com") {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testLoginAttemptRepo) DeleteLoginAttempt(key string) error {
+	if key == "error" {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testLoginAttemptRepo) GetLockedLoginAttempts(now time.Time) ([]models.LoginAttempt, error) {
+	return []models.LoginAttempt{
+		{
+			Key:          models.EmailLoginKey("locked@here.ca"),
+			Failures:     models.EmailLoginPolicy.LockoutAttempts,
+			LastFailedAt: now,
+			LockedUntil:  now.Add(models.EmailLoginPolicy.LockoutDuration),
+		},
+	}, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type PasswordResetRepo interface {
+	GetPasswordResetByTokenHash(tokenHash string) (models.PasswordReset, error)
+	CreatePasswordReset(reset models.PasswordReset) (int, error)
+	UsePasswordResetTransaction(id int, ctx context.Context, tx *sql.Tx) error
+}
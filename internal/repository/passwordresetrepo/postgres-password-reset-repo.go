@@ -0,0 +1,93 @@
+package passwordresetrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresPasswordResetRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresPasswordResetRepo(conn *sql.DB) repository.PasswordResetRepo {
+	return &postgresPasswordResetRepo{
+		DB: conn,
+	}
+}
+
+func (m *postgresPasswordResetRepo) GetPasswordResetByTokenHash(tokenHash string) (models.PasswordReset, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, user_id, token_hash, expires_at, used_at, created_at, updated_at from password_resets where token_hash=$1`
+
+	var p models.PasswordReset
+	var usedAt sql.NullTime
+
+	err := m.DB.QueryRowContext(ctx, query, tokenHash).Scan(
+		&p.ID,
+		&p.UserID,
+		&p.TokenHash,
+		&p.ExpiresAt,
+		&usedAt,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+
+	p.UsedAt = usedAt.Time
+
+	return p, err
+}
+
+func (m *postgresPasswordResetRepo) CreatePasswordReset(reset models.PasswordReset) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var id int
+	stmt := `insert into password_resets (user_id, token_hash, expires_at, created_at, updated_at) values ($1, $2, $3, $4, $5) returning id`
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		reset.UserID,
+		reset.TokenHash,
+		reset.ExpiresAt,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UsePasswordResetTransaction marks a reset as used. It fails if the reset
+// was already used, so a link can't be used twice at the same time
+func (m *postgresPasswordResetRepo) UsePasswordResetTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update password_resets set used_at = $1, updated_at = $1 where id = $2 and used_at is null`
+
+	result, err := tx.ExecContext(ctx, stmt, time.Now().UTC(), id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return errors.New("this reset link has already been used")
+	}
+
+	return nil
+}
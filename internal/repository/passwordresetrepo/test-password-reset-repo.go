@@ -0,0 +1,52 @@
+package passwordresetrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type testPasswordResetRepo struct{}
+
+func NewTestPasswordResetRepo() repository.PasswordResetRepo {
+	return &testPasswordResetRepo{}
+}
+
+func (m *testPasswordResetRepo) GetPasswordResetByTokenHash(tokenHash string) (models.PasswordReset, error) {
+	p := models.PasswordReset{
+		ID:        1,
+		UserID:    1,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(models.PasswordResetLifetime),
+	}
+	switch tokenHash {
+	case tokens.Hash("error"):
+		return models.PasswordReset{}, errors.New("some error")
+	case tokens.Hash("expired"):
+		p.ExpiresAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("used"):
+		p.UsedAt = time.Now().Add(-time.Hour)
+	case tokens.Hash("use error"):
+		p.ID = 7
+	}
+	return p, nil
+}
+
+func (m *testPasswordResetRepo) CreatePasswordReset(reset models.PasswordReset) (int, error) {
+	if reset.UserID == 3 {
+		return 0, errors.New("password reset creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testPasswordResetRepo) UsePasswordResetTransaction(id int, ctx context.Context, tx *sql.Tx) error {
+	if id == 7 {
+		return errors.New("this reset link has already been used")
+	}
+	return nil
+}
@@ -12,6 +12,8 @@ type PlayerRepo interface {
 	UpdatePlayer(p models.Player) error
 	GetPlayerByID(ID int) (models.Player, error)
 	GetPlayersByLeagueID(leagueID int) ([]models.Player, error)
+	GetPlayersByUserID(userID int) ([]models.Player, error)
 	GetPlayerByUserAndLeagueID(userID, leagueID int) (models.Player, error)
 	CreatePlayerTransaction(player models.Player, ctx context.Context, tx *sql.Tx) error
+	UpdatePlayerTransaction(p models.Player, ctx context.Context, tx *sql.Tx) error
 }
@@ -30,7 +30,7 @@ func (m *postgresPlayerRepo) UpdatePlayer(p models.Player) error {
 		p.Handicap,
 		p.IsCommissioner,
 		p.IsActive,
-		time.Now(),
+		time.Now().UTC(),
 		p.ID,
 	)
 
@@ -57,8 +57,8 @@ func (m *postgresPlayerRepo) CreatePlayer(player models.Player) error {
 		player.Handicap,
 		player.IsCommissioner,
 		player.IsActive,
-		time.Now(),
-		time.Now(),
+		time.Now().UTC(),
+		time.Now().UTC(),
 	)
 
 	if err != nil {
@@ -157,7 +157,9 @@ func (m *postgresPlayerRepo) GetPlayersByLeagueID(leagueID int) ([]models.Player
 		p.updated_at,
 		u.id,
 		u.first_name,
-		u.last_name
+		u.last_name,
+		u.email,
+		u.verified_at
 	from players p join users u on p.user_id = u.id 
 	where league_id=$1`
 
@@ -172,6 +174,7 @@ func (m *postgresPlayerRepo) GetPlayersByLeagueID(leagueID int) ([]models.Player
 
 	for rows.Next() {
 		var p models.Player
+		var verifiedAt sql.NullTime
 
 		err := rows.Scan(
 			&p.ID,
@@ -184,6 +187,60 @@ func (m *postgresPlayerRepo) GetPlayersByLeagueID(leagueID int) ([]models.Player
 			&p.User.ID,
 			&p.User.FirstName,
 			&p.User.LastName,
+			&p.User.Email,
+			&verifiedAt,
+		)
+		if err != nil {
+			return players, err
+		}
+		p.User.VerifiedAt = verifiedAt.Time
+
+		players = append(players, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return players, err
+	}
+
+	return players, nil
+}
+
+func (m *postgresPlayerRepo) GetPlayersByUserID(userID int) ([]models.Player, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `
+	select 
+		id,
+		league_id,
+		user_id,
+		is_commissioner,
+		is_active,
+		created_at,
+		updated_at
+	from players 
+	where user_id=$1`
+
+	var players []models.Player
+
+	rows, err := m.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return players, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var p models.Player
+
+		err := rows.Scan(
+			&p.ID,
+			&p.LeagueID,
+			&p.UserID,
+			&p.IsCommissioner,
+			&p.IsActive,
+			&p.CreatedAt,
+			&p.UpdatedAt,
 		)
 		if err != nil {
 			return players, err
@@ -200,16 +257,36 @@ func (m *postgresPlayerRepo) GetPlayersByLeagueID(leagueID int) ([]models.Player
 }
 
 func (m *postgresPlayerRepo) CreatePlayerTransaction(player models.Player, ctx context.Context, tx *sql.Tx) error {
-	stmt := `insert into players (league_id, user_id, is_commissioner, is_active, created_at, updated_at) values ($1, $2, $3, $4, $5, $6)`
+	stmt := `insert into players (league_id, user_id, handicap, is_commissioner, is_active, created_at, updated_at) values ($1, $2, $3, $4, $5, $6, $7)`
 	_, err := tx.ExecContext(
 		ctx,
 		stmt,
 		player.LeagueID,
 		player.UserID,
+		player.Handicap,
 		player.IsCommissioner,
 		player.IsActive,
-		time.Now(),
-		time.Now(),
+		time.Now().UTC(),
+		time.Now().UTC(),
+	)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *postgresPlayerRepo) UpdatePlayerTransaction(p models.Player, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update players set handicap = $1, is_commissioner = $2, is_active = $3, updated_at = $4 where id = $5`
+
+	_, err := tx.ExecContext(ctx, stmt,
+		p.Handicap,
+		p.IsCommissioner,
+		p.IsActive,
+		time.Now().UTC(),
+		p.ID,
 	)
 
 	if err != nil {
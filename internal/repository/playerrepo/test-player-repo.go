@@ -31,6 +31,16 @@ func (m *testPlayerRepo) UpdatePlayer(p models.Player) error {
 
 func (m *testPlayerRepo) GetPlayerByID(ID int) (models.Player, error) {
 	var p models.Player
+	if ID == 9 {
+		return p, errors.New("some error")
+	}
+	if ID >= 20 {
+		p.ID = ID
+		p.LeagueID = 1
+		p.UserID = ID
+		p.IsActive = ID != 22
+		p.IsCommissioner = ID == 21
+	}
 	return p, nil
 }
 
@@ -42,11 +52,25 @@ func (m *testPlayerRepo) GetPlayersByLeagueID(leagueID int) ([]models.Player, er
 	return p, nil
 }
 
+func (m *testPlayerRepo) GetPlayersByUserID(userID int) ([]models.Player, error) {
+	if userID == 0 {
+		return nil, errors.New("some error")
+	}
+	var p []models.Player
+	if userID == 1 {
+		p = append(p, models.Player{UserID: userID, IsCommissioner: true, IsActive: true})
+	}
+	return p, nil
+}
+
 func (m *testPlayerRepo) GetPlayerByUserAndLeagueID(userID, leagueID int) (models.Player, error) {
 	var p models.Player
 	if userID == 0 {
 		return p, errors.New("some error")
 	}
+	if userID == 15 {
+		return p, sql.ErrNoRows
+	}
 	if userID == 1 {
 		p.IsCommissioner = true
 	} else {
@@ -66,8 +90,15 @@ func (m *testPlayerRepo) CreatePlayerTransaction(player models.Player, ctx conte
 	if player.Handicap == 100 {
 		return errors.New("player error")
 	}
-	if player.UserID == 2 {
+	if player.UserID == 2 || player.UserID == 15 {
 		return errors.New("player error")
 	}
 	return nil
 }
+
+func (m *testPlayerRepo) UpdatePlayerTransaction(p models.Player, ctx context.Context, tx *sql.Tx) error {
+	if p.UserID == 2 || p.UserID == 23 {
+		return errors.New("some error")
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type RecoveryCodeRepo interface {
+	CountUnusedRecoveryCodes(userID int) (int, error)
+	UseRecoveryCode(userID int, codeHash string) error
+	CreateRecoveryCodesTransaction(userID int, codeHashes []string, ctx context.Context, tx *sql.Tx) error
+	DeleteRecoveryCodesTransaction(userID int, ctx context.Context, tx *sql.Tx) error
+}
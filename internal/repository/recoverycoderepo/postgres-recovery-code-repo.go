@@ -0,0 +1,82 @@
+package recoverycoderepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresRecoveryCodeRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresRecoveryCodeRepo(conn *sql.DB) repository.RecoveryCodeRepo {
+	return &postgresRecoveryCodeRepo{
+		DB: conn,
+	}
+}
+
+func (m *postgresRecoveryCodeRepo) CountUnusedRecoveryCodes(userID int) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select count(id) from recovery_codes where user_id = $1 and used_at is null`
+
+	var count int
+	err := m.DB.QueryRowContext(ctx, query, userID).Scan(&count)
+
+	return count, err
+}
+
+// UseRecoveryCode marks one of a user's recovery codes as used. It fails if
+// the user has no unused code with that hash
+func (m *postgresRecoveryCodeRepo) UseRecoveryCode(userID int, codeHash string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update recovery_codes set used_at = $1, updated_at = $1 where user_id = $2 and code_hash = $3 and used_at is null`
+
+	result, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), userID, codeHash)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("recovery code is incorrect or already used")
+	}
+
+	return nil
+}
+
+func (m *postgresRecoveryCodeRepo) CreateRecoveryCodesTransaction(userID int, codeHashes []string, ctx context.Context, tx *sql.Tx) error {
+	stmt := `insert into recovery_codes (user_id, code_hash, created_at, updated_at) values ($1, $2, $3, $4)`
+
+	for _, codeHash := range codeHashes {
+		_, err := tx.ExecContext(ctx, stmt, userID, codeHash, time.Now().UTC(), time.Now().UTC())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *postgresRecoveryCodeRepo) DeleteRecoveryCodesTransaction(userID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `delete from recovery_codes where user_id = $1`
+
+	_, err := tx.ExecContext(ctx, stmt, userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package recoverycoderepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type testRecoveryCodeRepo struct{}
+
+func NewTestRecoveryCodeRepo() repository.RecoveryCodeRepo {
+	return &testRecoveryCodeRepo{}
+}
+
+func (m *testRecoveryCodeRepo) CountUnusedRecoveryCodes(userID int) (int, error) {
+	if userID == 3 {
+		return 0, errors.New("some error")
+	}
+	return 10, nil
+}
+
+func (m *testRecoveryCodeRepo) UseRecoveryCode(userID int, codeHash string) error {
+	if codeHash != tokens.Hash("abcd2345") {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testRecoveryCodeRepo) CreateRecoveryCodesTransaction(userID int, codeHashes []string, ctx context.Context, tx *sql.Tx) error {
+	if userID == 4 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testRecoveryCodeRepo) DeleteRecoveryCodesTransaction(userID int, ctx context.Context, tx *sql.Tx) error {
+	if userID == 5 {
+		return errors.New("some error")
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package repository
+
+// Scanner is implemented by both *sql.Row and *sql.Rows, so one function can
+// scan a model out of either
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type ScheduleRepo interface {
+	GetScheduleWeeksBySeasonID(seasonID int) ([]models.ScheduleWeek, error)
+	GetScheduleWeekByID(id int) (models.ScheduleWeek, error)
+	CreateScheduleWeekTransaction(week models.ScheduleWeek, ctx context.Context, tx *sql.Tx) (int, error)
+	UpdateScheduleWeekTransaction(week models.ScheduleWeek, ctx context.Context, tx *sql.Tx) error
+	CreateMatchupTransaction(matchup models.Matchup, ctx context.Context, tx *sql.Tx) error
+	DeleteUnpublishedScheduleWeeksTransaction(seasonID int, ctx context.Context, tx *sql.Tx) error
+	DeleteMatchupsByScheduleWeekIDTransaction(weekID int, ctx context.Context, tx *sql.Tx) error
+	PublishScheduleWeeks(seasonID int) error
+	UpdateScheduleWeekCancellation(id int, cancelledAt time.Time) error
+}
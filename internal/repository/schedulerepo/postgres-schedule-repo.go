@@ -0,0 +1,346 @@
+package schedulerepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresScheduleRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresScheduleRepo(conn *sql.DB) repository.ScheduleRepo {
+	return &postgresScheduleRepo{
+		DB: conn,
+	}
+}
+
+// scheduleWeekSelect selects a week along with the course it's played at, if
+// it has one
+const scheduleWeekSelect = `
+	select 
+		w.id,
+		w.season_id,
+		w.week_number,
+		w.play_date,
+		w.nine,
+		w.format,
+		coalesce(w.course_id, 0),
+		w.tee_time,
+		w.is_published,
+		w.cancelled_at,
+		w.sequence,
+		w.created_at,
+		w.updated_at,
+		coalesce(c.name, ''),
+		coalesce(c.location, '')
+	from schedule_weeks w 
+	left join courses c on w.course_id = c.id`
+
+// matchupSelect selects a matchup along with the names of both players. The
+// away player is missing for a bye
+const matchupSelect = `
+	select 
+		m.id,
+		m.schedule_week_id,
+		m.home_player_id,
+		coalesce(m.away_player_id, 0),
+		m.created_at,
+		m.updated_at,
+		hu.id,
+		hu.first_name,
+		hu.last_name,
+		coalesce(au.id, 0),
+		coalesce(au.first_name, ''),
+		coalesce(au.last_name, '')
+	from matchups m 
+	join schedule_weeks w on m.schedule_week_id = w.id 
+	join players hp on m.home_player_id = hp.id 
+	join users hu on hp.user_id = hu.id 
+	left join players ap on m.away_player_id = ap.id 
+	left join users au on ap.user_id = au.id`
+
+func scanScheduleWeek(row repository.Scanner) (models.ScheduleWeek, error) {
+	var w models.ScheduleWeek
+	var cancelledAt sql.NullTime
+
+	err := row.Scan(
+		&w.ID,
+		&w.SeasonID,
+		&w.WeekNumber,
+		&w.PlayDate,
+		&w.Nine,
+		&w.Format,
+		&w.CourseID,
+		&w.TeeTime,
+		&w.IsPublished,
+		&cancelledAt,
+		&w.Sequence,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+		&w.Course.Name,
+		&w.Course.Location,
+	)
+
+	w.CancelledAt = cancelledAt.Time
+	w.Course.ID = w.CourseID
+
+	return w, err
+}
+
+// nullCourseID returns the course id to store for a week, which is null when
+// the week doesn't have a course
+func nullCourseID(courseID int) interface{} {
+	if courseID == 0 {
+		return nil
+	}
+	return courseID
+}
+
+func scanMatchup(row repository.Scanner) (models.Matchup, error) {
+	var m models.Matchup
+
+	err := row.Scan(
+		&m.ID,
+		&m.ScheduleWeekID,
+		&m.HomePlayerID,
+		&m.AwayPlayerID,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&m.HomePlayer.User.ID,
+		&m.HomePlayer.User.FirstName,
+		&m.HomePlayer.User.LastName,
+		&m.AwayPlayer.User.ID,
+		&m.AwayPlayer.User.FirstName,
+		&m.AwayPlayer.User.LastName,
+	)
+
+	m.HomePlayer.ID = m.HomePlayerID
+	m.HomePlayer.UserID = m.HomePlayer.User.ID
+	m.AwayPlayer.ID = m.AwayPlayerID
+	m.AwayPlayer.UserID = m.AwayPlayer.User.ID
+
+	return m, err
+}
+
+// GetScheduleWeeksBySeasonID returns a season's schedule with each week's matchups
+func (m *postgresScheduleRepo) GetScheduleWeeksBySeasonID(seasonID int) ([]models.ScheduleWeek, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := scheduleWeekSelect + ` where w.season_id=$1 order by w.week_number`
+
+	var weeks []models.ScheduleWeek
+
+	rows, err := m.DB.QueryContext(ctx, query, seasonID)
+	if err != nil {
+		return weeks, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		w, err := scanScheduleWeek(rows)
+		if err != nil {
+			return weeks, err
+		}
+
+		weeks = append(weeks, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return weeks, err
+	}
+
+	matchups, err := m.getMatchups(ctx, matchupSelect+` where w.season_id=$1 order by m.id`, seasonID)
+	if err != nil {
+		return weeks, err
+	}
+
+	for i := range weeks {
+		for _, matchup := range matchups {
+			if matchup.ScheduleWeekID == weeks[i].ID {
+				weeks[i].Matchups = append(weeks[i].Matchups, matchup)
+			}
+		}
+	}
+
+	return weeks, nil
+}
+
+// GetScheduleWeekByID returns a schedule week with its matchups
+func (m *postgresScheduleRepo) GetScheduleWeekByID(id int) (models.ScheduleWeek, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := scheduleWeekSelect + ` where w.id=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, id)
+
+	w, err := scanScheduleWeek(row)
+	if err != nil {
+		return w, err
+	}
+
+	w.Matchups, err = m.getMatchups(ctx, matchupSelect+` where m.schedule_week_id=$1 order by m.id`, w.ID)
+	if err != nil {
+		return w, err
+	}
+
+	return w, nil
+}
+
+func (m *postgresScheduleRepo) getMatchups(ctx context.Context, query string, args ...interface{}) ([]models.Matchup, error) {
+	var matchups []models.Matchup
+
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return matchups, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		matchup, err := scanMatchup(rows)
+		if err != nil {
+			return matchups, err
+		}
+
+		matchups = append(matchups, matchup)
+	}
+
+	if err = rows.Err(); err != nil {
+		return matchups, err
+	}
+
+	return matchups, nil
+}
+
+func (m *postgresScheduleRepo) CreateScheduleWeekTransaction(week models.ScheduleWeek, ctx context.Context, tx *sql.Tx) (int, error) {
+	var weekID int
+	stmt := `insert into schedule_weeks (season_id, week_number, play_date, nine, format, course_id, tee_time, is_published, created_at, updated_at) values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) returning id`
+
+	err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		week.SeasonID,
+		week.WeekNumber,
+		week.PlayDate,
+		week.Nine,
+		week.Format,
+		nullCourseID(week.CourseID),
+		week.TeeTime,
+		week.IsPublished,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&weekID)
+
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return weekID, nil
+}
+
+// UpdateScheduleWeekTransaction updates the scoring format, course and tee
+// time of a week. Its sequence goes up so subscribed calendars update it
+func (m *postgresScheduleRepo) UpdateScheduleWeekTransaction(week models.ScheduleWeek, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update schedule_weeks set format=$1, course_id=$2, tee_time=$3, sequence=sequence+1, updated_at=$4 where id=$5`
+
+	_, err := tx.ExecContext(ctx, stmt, week.Format, nullCourseID(week.CourseID), week.TeeTime, time.Now().UTC(), week.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *postgresScheduleRepo) CreateMatchupTransaction(matchup models.Matchup, ctx context.Context, tx *sql.Tx) error {
+	stmt := `insert into matchups (schedule_week_id, home_player_id, away_player_id, created_at, updated_at) values ($1, $2, $3, $4, $5)`
+
+	// a bye has no away player
+	var awayPlayerID interface{}
+	if !matchup.IsBye() {
+		awayPlayerID = matchup.AwayPlayerID
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		stmt,
+		matchup.ScheduleWeekID,
+		matchup.HomePlayerID,
+		awayPlayerID,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// DeleteUnpublishedScheduleWeeksTransaction removes the weeks of a season that
+// have not been published, along with their matchups
+func (m *postgresScheduleRepo) DeleteUnpublishedScheduleWeeksTransaction(seasonID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `delete from schedule_weeks where season_id=$1 and is_published=false`
+
+	_, err := tx.ExecContext(ctx, stmt, seasonID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *postgresScheduleRepo) DeleteMatchupsByScheduleWeekIDTransaction(weekID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `delete from matchups where schedule_week_id=$1`
+
+	_, err := tx.ExecContext(ctx, stmt, weekID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// PublishScheduleWeeks publishes every week of a season's schedule
+func (m *postgresScheduleRepo) PublishScheduleWeeks(seasonID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update schedule_weeks set is_published=true, updated_at=$1 where season_id=$2`
+
+	_, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), seasonID)
+
+	return err
+}
+
+// UpdateScheduleWeekCancellation cancels a published week, or puts it back on
+// the schedule when cancelledAt is zero. Either way the week's sequence goes
+// up so subscribed calendars update it
+func (m *postgresScheduleRepo) UpdateScheduleWeekCancellation(id int, cancelledAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update schedule_weeks set cancelled_at=$1, sequence=sequence+1, updated_at=$2 where id=$3`
+
+	var cancelled interface{}
+	if !cancelledAt.IsZero() {
+		cancelled = cancelledAt
+	}
+
+	_, err := m.DB.ExecContext(ctx, stmt, cancelled, time.Now().UTC(), id)
+
+	return err
+}
@@ -0,0 +1,102 @@
+package schedulerepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testScheduleRepo struct{}
+
+func NewTestScheduleRepo() repository.ScheduleRepo {
+	return &testScheduleRepo{}
+}
+
+func testScheduleWeek(id, seasonID int, isPublished bool) models.ScheduleWeek {
+	return models.ScheduleWeek{
+		ID:          id,
+		SeasonID:    seasonID,
+		WeekNumber:  1,
+		PlayDate:    time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC),
+		IsPublished: isPublished,
+		Matchups: []models.Matchup{
+			{ID: 1, ScheduleWeekID: id, HomePlayerID: 1, AwayPlayerID: 2},
+			{ID: 2, ScheduleWeekID: id, HomePlayerID: 3},
+		},
+	}
+}
+
+func (m *testScheduleRepo) GetScheduleWeeksBySeasonID(seasonID int) ([]models.ScheduleWeek, error) {
+	var w []models.ScheduleWeek
+	if seasonID == 3 {
+		return w, errors.New("some error")
+	}
+	if seasonID == 8 {
+		return w, nil
+	}
+	return append(w, testScheduleWeek(1, seasonID, seasonID == 2)), nil
+}
+
+func (m *testScheduleRepo) GetScheduleWeekByID(id int) (models.ScheduleWeek, error) {
+	if id == 3 {
+		return models.ScheduleWeek{}, errors.New("some error")
+	}
+	w := testScheduleWeek(id, 1, id == 2 || id == 7 || id == 8)
+	if id == 8 {
+		w.CancelledAt = time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+	}
+	return w, nil
+}
+
+func (m *testScheduleRepo) CreateScheduleWeekTransaction(week models.ScheduleWeek, ctx context.Context, tx *sql.Tx) (int, error) {
+	if week.SeasonID == 4 {
+		return 0, errors.New("schedule week creation failed")
+	}
+	return week.WeekNumber, nil
+}
+
+func (m *testScheduleRepo) UpdateScheduleWeekTransaction(week models.ScheduleWeek, ctx context.Context, tx *sql.Tx) error {
+	if week.ID == 6 {
+		return errors.New("schedule week update failed")
+	}
+	return nil
+}
+
+func (m *testScheduleRepo) CreateMatchupTransaction(matchup models.Matchup, ctx context.Context, tx *sql.Tx) error {
+	if matchup.HomePlayerID == 99 || matchup.AwayPlayerID == 99 {
+		return errors.New("matchup creation failed")
+	}
+	return nil
+}
+
+func (m *testScheduleRepo) DeleteUnpublishedScheduleWeeksTransaction(seasonID int, ctx context.Context, tx *sql.Tx) error {
+	if seasonID == 6 {
+		return errors.New("schedule deletion failed")
+	}
+	return nil
+}
+
+func (m *testScheduleRepo) DeleteMatchupsByScheduleWeekIDTransaction(weekID int, ctx context.Context, tx *sql.Tx) error {
+	if weekID == 5 {
+		return errors.New("matchup deletion failed")
+	}
+	return nil
+}
+
+func (m *testScheduleRepo) PublishScheduleWeeks(seasonID int) error {
+	if seasonID == 5 {
+		return errors.New("schedule publish failed")
+	}
+	return nil
+}
+
+func (m *testScheduleRepo) UpdateScheduleWeekCancellation(id int, cancelledAt time.Time) error {
+	if id == 7 {
+		return errors.New("schedule week cancellation failed")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type ScoreRepo interface {
+	GetRoundByID(id int) (models.Round, error)
+	GetRoundsByLeagueID(leagueID int) ([]models.Round, error)
+	GetRoundsBySeasonID(seasonID int) ([]models.Round, error)
+	GetRoundsByUserID(userID int) ([]models.Round, error)
+	CreateRoundTransaction(round models.Round, ctx context.Context, tx *sql.Tx) (int, error)
+	CreateHoleScoreTransaction(holeScore models.HoleScore, ctx context.Context, tx *sql.Tx) error
+}
@@ -0,0 +1,336 @@
+package scorerepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresScoreRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresScoreRepo(conn *sql.DB) repository.ScoreRepo {
+	return &postgresScoreRepo{
+		DB: conn,
+	}
+}
+
+// roundSelect selects a round along with its player, tee set and course
+const roundSelect = `
+	select 
+		r.id,
+		r.league_id,
+		coalesce(r.season_id, 0),
+		r.player_id,
+		r.tee_set_id,
+		r.played_on,
+		r.gross_score,
+		r.course_handicap,
+		r.created_at,
+		r.updated_at,
+		u.id,
+		u.first_name,
+		u.last_name,
+		t.name,
+		t.course_rating,
+		t.slope,
+		c.id,
+		c.name
+	from rounds r 
+	join players p on r.player_id = p.id 
+	join users u on p.user_id = u.id 
+	join tee_sets t on r.tee_set_id = t.id 
+	join courses c on t.course_id = c.id`
+
+func scanRound(row repository.Scanner) (models.Round, error) {
+	var r models.Round
+
+	err := row.Scan(
+		&r.ID,
+		&r.LeagueID,
+		&r.SeasonID,
+		&r.PlayerID,
+		&r.TeeSetID,
+		&r.PlayedOn,
+		&r.GrossScore,
+		&r.CourseHandicap,
+		&r.CreatedAt,
+		&r.UpdatedAt,
+		&r.Player.User.ID,
+		&r.Player.User.FirstName,
+		&r.Player.User.LastName,
+		&r.TeeSet.Name,
+		&r.TeeSet.CourseRating,
+		&r.TeeSet.Slope,
+		&r.Course.ID,
+		&r.Course.Name,
+	)
+
+	r.Player.ID = r.PlayerID
+	r.Player.LeagueID = r.LeagueID
+	r.Player.UserID = r.Player.User.ID
+	r.TeeSet.ID = r.TeeSetID
+	r.TeeSet.CourseID = r.Course.ID
+
+	return r, err
+}
+
+// GetRoundByID returns a round with its hole scores by ID
+func (m *postgresScoreRepo) GetRoundByID(id int) (models.Round, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := roundSelect + ` where r.id=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, id)
+
+	r, err := scanRound(row)
+	if err != nil {
+		return r, err
+	}
+
+	r.HoleScores, err = m.getHoleScoresByRoundID(ctx, r.ID)
+	if err != nil {
+		return r, err
+	}
+
+	return r, nil
+}
+
+// GetRoundsByLeagueID returns the rounds posted in a league, most recent first
+func (m *postgresScoreRepo) GetRoundsByLeagueID(leagueID int) ([]models.Round, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := roundSelect + ` where r.league_id=$1 order by r.played_on desc, r.id desc`
+
+	return m.getRounds(ctx, query, leagueID)
+}
+
+// GetRoundsBySeasonID returns the rounds posted in a season with their hole
+// scores, most recent first
+func (m *postgresScoreRepo) GetRoundsBySeasonID(seasonID int) ([]models.Round, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := roundSelect + ` where r.season_id=$1 order by r.played_on desc, r.id desc`
+
+	rounds, err := m.getRounds(ctx, query, seasonID)
+	if err != nil {
+		return rounds, err
+	}
+
+	holeScores, err := m.getHoleScoresBySeasonID(ctx, seasonID)
+	if err != nil {
+		return rounds, err
+	}
+
+	for i := range rounds {
+		rounds[i].HoleScores = holeScores[rounds[i].ID]
+	}
+
+	return rounds, nil
+}
+
+// GetRoundsByUserID returns every round a user has posted in any league with
+// its hole scores, oldest first
+func (m *postgresScoreRepo) GetRoundsByUserID(userID int) ([]models.Round, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := roundSelect + ` where p.user_id=$1 order by r.played_on, r.id`
+
+	rounds, err := m.getRounds(ctx, query, userID)
+	if err != nil {
+		return rounds, err
+	}
+
+	holeScores, err := m.getHoleScoresByUserID(ctx, userID)
+	if err != nil {
+		return rounds, err
+	}
+
+	for i := range rounds {
+		rounds[i].HoleScores = holeScores[rounds[i].ID]
+	}
+
+	return rounds, nil
+}
+
+func (m *postgresScoreRepo) getRounds(ctx context.Context, query string, args ...interface{}) ([]models.Round, error) {
+	var rounds []models.Round
+
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return rounds, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		r, err := scanRound(rows)
+		if err != nil {
+			return rounds, err
+		}
+
+		rounds = append(rounds, r)
+	}
+
+	if err = rows.Err(); err != nil {
+		return rounds, err
+	}
+
+	return rounds, nil
+}
+
+func (m *postgresScoreRepo) getHoleScoresByRoundID(ctx context.Context, roundID int) ([]models.HoleScore, error) {
+	query := `
+	select 
+		id, round_id, hole_number, strokes, created_at, updated_at 
+	from hole_scores 
+	where round_id=$1 
+	order by hole_number`
+
+	var holeScores []models.HoleScore
+
+	rows, err := m.DB.QueryContext(ctx, query, roundID)
+	if err != nil {
+		return holeScores, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		h, err := scanHoleScore(rows)
+		if err != nil {
+			return holeScores, err
+		}
+
+		holeScores = append(holeScores, h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return holeScores, err
+	}
+
+	return holeScores, nil
+}
+
+// getHoleScoresByUserID returns the hole scores of all of a user's rounds keyed by round ID
+func (m *postgresScoreRepo) getHoleScoresByUserID(ctx context.Context, userID int) (map[int][]models.HoleScore, error) {
+	query := `
+	select 
+		h.id, h.round_id, h.hole_number, h.strokes, h.created_at, h.updated_at 
+	from hole_scores h 
+	join rounds r on h.round_id = r.id 
+	join players p on r.player_id = p.id 
+	where p.user_id=$1 
+	order by h.round_id, h.hole_number`
+
+	return m.getHoleScoresByRound(ctx, query, userID)
+}
+
+// getHoleScoresBySeasonID returns the hole scores of all of a season's rounds keyed by round ID
+func (m *postgresScoreRepo) getHoleScoresBySeasonID(ctx context.Context, seasonID int) (map[int][]models.HoleScore, error) {
+	query := `
+	select 
+		h.id, h.round_id, h.hole_number, h.strokes, h.created_at, h.updated_at 
+	from hole_scores h 
+	join rounds r on h.round_id = r.id 
+	where r.season_id=$1 
+	order by h.round_id, h.hole_number`
+
+	return m.getHoleScoresByRound(ctx, query, seasonID)
+}
+
+func (m *postgresScoreRepo) getHoleScoresByRound(ctx context.Context, query string, args ...interface{}) (map[int][]models.HoleScore, error) {
+	holeScores := make(map[int][]models.HoleScore)
+
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return holeScores, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		h, err := scanHoleScore(rows)
+		if err != nil {
+			return holeScores, err
+		}
+
+		holeScores[h.RoundID] = append(holeScores[h.RoundID], h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return holeScores, err
+	}
+
+	return holeScores, nil
+}
+
+func scanHoleScore(row repository.Scanner) (models.HoleScore, error) {
+	var h models.HoleScore
+
+	err := row.Scan(
+		&h.ID,
+		&h.RoundID,
+		&h.HoleNumber,
+		&h.Strokes,
+		&h.CreatedAt,
+		&h.UpdatedAt,
+	)
+
+	return h, err
+}
+
+func (m *postgresScoreRepo) CreateRoundTransaction(round models.Round, ctx context.Context, tx *sql.Tx) (int, error) {
+	var roundID int
+	stmt := `insert into rounds (league_id, season_id, player_id, tee_set_id, played_on, gross_score, course_handicap, created_at, updated_at) values ($1, $2, $3, $4, $5, $6, $7, $8, $9) returning id`
+
+	err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		round.LeagueID,
+		round.SeasonID,
+		round.PlayerID,
+		round.TeeSetID,
+		round.PlayedOn,
+		round.GrossScore,
+		round.CourseHandicap,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&roundID)
+
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return roundID, nil
+}
+
+func (m *postgresScoreRepo) CreateHoleScoreTransaction(holeScore models.HoleScore, ctx context.Context, tx *sql.Tx) error {
+	stmt := `insert into hole_scores (round_id, hole_number, strokes, created_at, updated_at) values ($1, $2, $3, $4, $5)`
+
+	_, err := tx.ExecContext(
+		ctx,
+		stmt,
+		holeScore.RoundID,
+		holeScore.HoleNumber,
+		holeScore.Strokes,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	)
+
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
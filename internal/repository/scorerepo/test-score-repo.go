@@ -0,0 +1,80 @@
+package scorerepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testScoreRepo struct{}
+
+func NewTestScoreRepo() repository.ScoreRepo {
+	return &testScoreRepo{}
+}
+
+func (m *testScoreRepo) GetRoundByID(id int) (models.Round, error) {
+	var r models.Round
+	if id == 3 {
+		return r, errors.New("some error")
+	}
+	r.ID = id
+	return r, nil
+}
+
+func (m *testScoreRepo) GetRoundsByLeagueID(leagueID int) ([]models.Round, error) {
+	if leagueID == 5 {
+		return nil, errors.New("some error")
+	}
+	var r []models.Round
+	return r, nil
+}
+
+func (m *testScoreRepo) GetRoundsBySeasonID(seasonID int) ([]models.Round, error) {
+	if seasonID == 5 {
+		return nil, errors.New("some error")
+	}
+	var r []models.Round
+	if seasonID == 9 {
+		// a round on a tee set the test course repo can't load
+		r = append(r, models.Round{ID: 1, SeasonID: seasonID, PlayerID: 1, TeeSetID: 3})
+	}
+	return r, nil
+}
+
+func (m *testScoreRepo) GetRoundsByUserID(userID int) ([]models.Round, error) {
+	var r []models.Round
+	if userID == 0 {
+		return r, errors.New("some error")
+	}
+	for i := 1; i <= 3; i++ {
+		round := models.Round{
+			ID:       i,
+			TeeSetID: 1,
+			PlayedOn: time.Date(2024, 4, i, 0, 0, 0, 0, time.UTC),
+			TeeSet:   models.TeeSet{ID: 1, Name: "White", CourseRating: 36.0, Slope: 113},
+		}
+		for number := 1; number <= 9; number++ {
+			round.HoleScores = append(round.HoleScores, models.HoleScore{RoundID: i, HoleNumber: number, Strokes: 5})
+		}
+		r = append(r, round)
+	}
+	return r, nil
+}
+
+func (m *testScoreRepo) CreateRoundTransaction(round models.Round, ctx context.Context, tx *sql.Tx) (int, error) {
+	if round.PlayerID == 2 {
+		return 0, errors.New("round creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testScoreRepo) CreateHoleScoreTransaction(holeScore models.HoleScore, ctx context.Context, tx *sql.Tx) error {
+	if holeScore.Strokes == 14 {
+		return errors.New("hole score creation failed")
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type SeasonRepo interface {
+	GetSeasonByID(id int) (models.Season, error)
+	GetSeasonsByLeagueID(leagueID int) ([]models.Season, error)
+	GetActiveSeasonByLeagueID(leagueID int) (models.Season, error)
+	CreateSeason(season models.Season) (int, error)
+	UpdateSeasonStatus(id int, status string) error
+}
@@ -0,0 +1,135 @@
+package seasonrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresSeasonRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresSeasonRepo(conn *sql.DB) repository.SeasonRepo {
+	return &postgresSeasonRepo{
+		DB: conn,
+	}
+}
+
+const seasonSelect = `
+	select 
+		id, league_id, name, start_date, end_date, status, created_at, updated_at 
+	from seasons`
+
+func scanSeason(row repository.Scanner) (models.Season, error) {
+	var s models.Season
+
+	err := row.Scan(
+		&s.ID,
+		&s.LeagueID,
+		&s.Name,
+		&s.StartDate,
+		&s.EndDate,
+		&s.Status,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+	)
+
+	return s, err
+}
+
+// GetSeasonByID returns a season by ID
+func (m *postgresSeasonRepo) GetSeasonByID(id int) (models.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := seasonSelect + ` where id=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, id)
+
+	return scanSeason(row)
+}
+
+// GetSeasonsByLeagueID returns a league's seasons, most recent first
+func (m *postgresSeasonRepo) GetSeasonsByLeagueID(leagueID int) ([]models.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := seasonSelect + ` where league_id=$1 order by start_date desc, id desc`
+
+	var seasons []models.Season
+
+	rows, err := m.DB.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return seasons, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		s, err := scanSeason(rows)
+		if err != nil {
+			return seasons, err
+		}
+
+		seasons = append(seasons, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return seasons, err
+	}
+
+	return seasons, nil
+}
+
+// GetActiveSeasonByLeagueID returns a league's active season
+func (m *postgresSeasonRepo) GetActiveSeasonByLeagueID(leagueID int) (models.Season, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := seasonSelect + ` where league_id=$1 and status=$2`
+
+	row := m.DB.QueryRowContext(ctx, query, leagueID, models.SeasonStatusActive)
+
+	return scanSeason(row)
+}
+
+func (m *postgresSeasonRepo) CreateSeason(season models.Season) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var seasonID int
+	stmt := `insert into seasons (league_id, name, start_date, end_date, status, created_at, updated_at) values ($1, $2, $3, $4, $5, $6, $7) returning id`
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		season.LeagueID,
+		season.Name,
+		season.StartDate,
+		season.EndDate,
+		season.Status,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&seasonID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return seasonID, nil
+}
+
+func (m *postgresSeasonRepo) UpdateSeasonStatus(id int, status string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update seasons set status=$1, updated_at=$2 where id=$3`
+
+	_, err := m.DB.ExecContext(ctx, stmt, status, time.Now().UTC(), id)
+
+	return err
+}
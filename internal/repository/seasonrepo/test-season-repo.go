@@ -0,0 +1,72 @@
+package seasonrepo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testSeasonRepo struct{}
+
+func NewTestSeasonRepo() repository.SeasonRepo {
+	return &testSeasonRepo{}
+}
+
+func testSeason(id int, status string) models.Season {
+	return models.Season{
+		ID:        id,
+		LeagueID:  1,
+		Name:      "2024",
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		Status:    status,
+	}
+}
+
+func (m *testSeasonRepo) GetSeasonByID(id int) (models.Season, error) {
+	switch id {
+	case 3:
+		return models.Season{}, errors.New("some error")
+	case 2:
+		return testSeason(id, models.SeasonStatusDraft), nil
+	case 4:
+		return testSeason(id, models.SeasonStatusCompleted), nil
+	case 5, 7:
+		// draft seasons in a league without an active season
+		season := testSeason(id, models.SeasonStatusDraft)
+		season.LeagueID = 6
+		return season, nil
+	}
+	return testSeason(id, models.SeasonStatusActive), nil
+}
+
+func (m *testSeasonRepo) GetSeasonsByLeagueID(leagueID int) ([]models.Season, error) {
+	var s []models.Season
+	if leagueID == 2 {
+		return s, errors.New("some error")
+	}
+	return append(s, testSeason(1, models.SeasonStatusActive)), nil
+}
+
+func (m *testSeasonRepo) GetActiveSeasonByLeagueID(leagueID int) (models.Season, error) {
+	if leagueID == 6 {
+		return models.Season{}, errors.New("no active season")
+	}
+	return testSeason(1, models.SeasonStatusActive), nil
+}
+
+func (m *testSeasonRepo) CreateSeason(season models.Season) (int, error) {
+	if season.Name == "Season Error" {
+		return 0, errors.New("season creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testSeasonRepo) UpdateSeasonStatus(id int, status string) error {
+	if id == 5 {
+		return errors.New("season update failed")
+	}
+	return nil
+}
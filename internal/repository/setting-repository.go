@@ -0,0 +1,6 @@
+package repository
+
+type SettingRepo interface {
+	GetSetting(name string) (string, error)
+	SaveSetting(name, value string) error
+}
@@ -0,0 +1,52 @@
+package settingrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresSettingRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresSettingRepo(conn *sql.DB) repository.SettingRepo {
+	return &postgresSettingRepo{
+		DB: conn,
+	}
+}
+
+// GetSetting returns a site-wide setting's value, or an empty string if it
+// has never been set
+func (m *postgresSettingRepo) GetSetting(name string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select value from settings where name = $1`
+
+	var value string
+	err := m.DB.QueryRowContext(ctx, query, name).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return value, err
+}
+
+func (m *postgresSettingRepo) SaveSetting(name, value string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `
+		insert into settings (name, value, created_at, updated_at) 
+		values ($1, $2, $3, $4) 
+		on conflict (name) do update set 
+			value = excluded.value, 
+			updated_at = excluded.updated_at`
+
+	_, err := m.DB.ExecContext(ctx, stmt, name, value, time.Now().UTC(), time.Now().UTC())
+
+	return err
+}
@@ -0,0 +1,30 @@
+package settingrepo
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testSettingRepo struct {
+	settings map[string]string
+}
+
+func NewTestSettingRepo() repository.SettingRepo {
+	return &testSettingRepo{settings: make(map[string]string)}
+}
+
+func (m *testSettingRepo) GetSetting(name string) (string, error) {
+	if name == "error" {
+		return "", errors.New("some error")
+	}
+	return m.settings[name], nil
+}
+
+func (m *testSettingRepo) SaveSetting(name, value string) error {
+	if value == "error" {
+		return errors.New("some error")
+	}
+	m.settings[name] = value
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type SkinsRepo interface {
+	GetSkinsGameByWeekID(weekID int) (models.SkinsGame, error)
+	SaveSkinsGameTransaction(game models.SkinsGame, ctx context.Context, tx *sql.Tx) (int, error)
+	DeleteSkinsEntriesTransaction(gameID int, ctx context.Context, tx *sql.Tx) error
+	AddSkinsEntryTransaction(gameID, playerID int, ctx context.Context, tx *sql.Tx) error
+}
@@ -0,0 +1,130 @@
+package skinsrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresSkinsRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresSkinsRepo(conn *sql.DB) repository.SkinsRepo {
+	return &postgresSkinsRepo{
+		DB: conn,
+	}
+}
+
+// GetSkinsGameByWeekID returns a week's skins game with the IDs of the players
+// entered in it
+func (m *postgresSkinsRepo) GetSkinsGameByWeekID(weekID int) (models.SkinsGame, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var g models.SkinsGame
+
+	query := `
+		select 
+			id, schedule_week_id, pot, net, carryovers, validation, created_at, updated_at 
+		from skins_games 
+		where schedule_week_id=$1`
+
+	err := m.DB.QueryRowContext(ctx, query, weekID).Scan(
+		&g.ID,
+		&g.ScheduleWeekID,
+		&g.Pot,
+		&g.Net,
+		&g.Carryovers,
+		&g.Validation,
+		&g.CreatedAt,
+		&g.UpdatedAt,
+	)
+	if err != nil {
+		return g, err
+	}
+
+	rows, err := m.DB.QueryContext(ctx, `select player_id from skins_entries where skins_game_id=$1 order by player_id`, g.ID)
+	if err != nil {
+		return g, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerID int
+		err := rows.Scan(&playerID)
+		if err != nil {
+			return g, err
+		}
+		g.PlayerIDs = append(g.PlayerIDs, playerID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return g, err
+	}
+
+	return g, nil
+}
+
+// SaveSkinsGameTransaction inserts a week's skins game, or updates it when the
+// week already has one, and returns its ID
+func (m *postgresSkinsRepo) SaveSkinsGameTransaction(game models.SkinsGame, ctx context.Context, tx *sql.Tx) (int, error) {
+	var gameID int
+	stmt := `
+		insert into skins_games (schedule_week_id, pot, net, carryovers, validation, created_at, updated_at) 
+		values ($1, $2, $3, $4, $5, $6, $7) 
+		on conflict (schedule_week_id) do update set 
+			pot = excluded.pot, 
+			net = excluded.net, 
+			carryovers = excluded.carryovers, 
+			validation = excluded.validation, 
+			updated_at = excluded.updated_at 
+		returning id`
+
+	err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		game.ScheduleWeekID,
+		game.Pot,
+		game.Net,
+		game.Carryovers,
+		game.Validation,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&gameID)
+
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return gameID, nil
+}
+
+func (m *postgresSkinsRepo) DeleteSkinsEntriesTransaction(gameID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `delete from skins_entries where skins_game_id=$1`
+
+	_, err := tx.ExecContext(ctx, stmt, gameID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+func (m *postgresSkinsRepo) AddSkinsEntryTransaction(gameID, playerID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `insert into skins_entries (skins_game_id, player_id, created_at, updated_at) values ($1, $2, $3, $4)`
+
+	_, err := tx.ExecContext(ctx, stmt, gameID, playerID, time.Now().UTC(), time.Now().UTC())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
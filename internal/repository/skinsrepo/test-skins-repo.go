@@ -0,0 +1,50 @@
+package skinsrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testSkinsRepo struct{}
+
+func NewTestSkinsRepo() repository.SkinsRepo {
+	return &testSkinsRepo{}
+}
+
+func (m *testSkinsRepo) GetSkinsGameByWeekID(weekID int) (models.SkinsGame, error) {
+	switch weekID {
+	case 4:
+		return models.SkinsGame{}, sql.ErrNoRows
+	case 7:
+		return models.SkinsGame{}, errors.New("some error")
+	}
+	return models.SkinsGame{
+		ID:             1,
+		ScheduleWeekID: weekID,
+		Pot:            2000,
+		Carryovers:     true,
+		PlayerIDs:      []int{1, 2},
+	}, nil
+}
+
+func (m *testSkinsRepo) SaveSkinsGameTransaction(game models.SkinsGame, ctx context.Context, tx *sql.Tx) (int, error) {
+	if game.ScheduleWeekID == 5 {
+		return 0, errors.New("skins game creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testSkinsRepo) DeleteSkinsEntriesTransaction(gameID int, ctx context.Context, tx *sql.Tx) error {
+	return nil
+}
+
+func (m *testSkinsRepo) AddSkinsEntryTransaction(gameID, playerID int, ctx context.Context, tx *sql.Tx) error {
+	if playerID == 99 {
+		return errors.New("skins entry creation failed")
+	}
+	return nil
+}
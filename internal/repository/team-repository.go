@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type TeamRepo interface {
+	GetTeamByID(id int) (models.Team, error)
+	GetTeamsBySeasonID(seasonID int) ([]models.Team, error)
+	CreateTeamTransaction(team models.Team, ctx context.Context, tx *sql.Tx) (int, error)
+	AddPlayerToTeamTransaction(teamID, playerID int, ctx context.Context, tx *sql.Tx) error
+	DeleteTeam(id int) error
+}
@@ -0,0 +1,206 @@
+package teamrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresTeamRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresTeamRepo(conn *sql.DB) repository.TeamRepo {
+	return &postgresTeamRepo{
+		DB: conn,
+	}
+}
+
+const teamSelect = `
+	select 
+		id, league_id, season_id, name, created_at, updated_at 
+	from teams`
+
+// teamPlayerSelect selects the players on teams along with their names
+const teamPlayerSelect = `
+	select 
+		tp.team_id,
+		p.id,
+		p.league_id,
+		p.user_id,
+		p.is_commissioner,
+		p.is_active,
+		u.first_name,
+		u.last_name
+	from team_players tp 
+	join teams t on tp.team_id = t.id 
+	join players p on tp.player_id = p.id 
+	join users u on p.user_id = u.id`
+
+func scanTeam(row repository.Scanner) (models.Team, error) {
+	var t models.Team
+
+	err := row.Scan(
+		&t.ID,
+		&t.LeagueID,
+		&t.SeasonID,
+		&t.Name,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+
+	return t, err
+}
+
+// GetTeamByID returns a team with its players
+func (m *postgresTeamRepo) GetTeamByID(id int) (models.Team, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := teamSelect + ` where id=$1`
+
+	row := m.DB.QueryRowContext(ctx, query, id)
+
+	t, err := scanTeam(row)
+	if err != nil {
+		return t, err
+	}
+
+	players, err := m.getTeamPlayers(ctx, teamPlayerSelect+` where tp.team_id=$1 order by u.last_name, u.first_name`, t.ID)
+	if err != nil {
+		return t, err
+	}
+	t.Players = players[t.ID]
+
+	return t, nil
+}
+
+// GetTeamsBySeasonID returns a season's teams with their players, by name
+func (m *postgresTeamRepo) GetTeamsBySeasonID(seasonID int) ([]models.Team, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := teamSelect + ` where season_id=$1 order by name`
+
+	var teams []models.Team
+
+	rows, err := m.DB.QueryContext(ctx, query, seasonID)
+	if err != nil {
+		return teams, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanTeam(rows)
+		if err != nil {
+			return teams, err
+		}
+
+		teams = append(teams, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return teams, err
+	}
+
+	players, err := m.getTeamPlayers(ctx, teamPlayerSelect+` where t.season_id=$1 order by u.last_name, u.first_name`, seasonID)
+	if err != nil {
+		return teams, err
+	}
+
+	for i := range teams {
+		teams[i].Players = players[teams[i].ID]
+	}
+
+	return teams, nil
+}
+
+// getTeamPlayers returns the players of the selected teams keyed by team ID
+func (m *postgresTeamRepo) getTeamPlayers(ctx context.Context, query string, args ...interface{}) (map[int][]models.Player, error) {
+	players := make(map[int][]models.Player)
+
+	rows, err := m.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return players, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var teamID int
+		var p models.Player
+
+		err := rows.Scan(
+			&teamID,
+			&p.ID,
+			&p.LeagueID,
+			&p.UserID,
+			&p.IsCommissioner,
+			&p.IsActive,
+			&p.User.FirstName,
+			&p.User.LastName,
+		)
+		if err != nil {
+			return players, err
+		}
+		p.User.ID = p.UserID
+
+		players[teamID] = append(players[teamID], p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return players, err
+	}
+
+	return players, nil
+}
+
+func (m *postgresTeamRepo) CreateTeamTransaction(team models.Team, ctx context.Context, tx *sql.Tx) (int, error) {
+	var teamID int
+	stmt := `insert into teams (league_id, season_id, name, created_at, updated_at) values ($1, $2, $3, $4, $5) returning id`
+
+	err := tx.QueryRowContext(
+		ctx,
+		stmt,
+		team.LeagueID,
+		team.SeasonID,
+		team.Name,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&teamID)
+
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	return teamID, nil
+}
+
+func (m *postgresTeamRepo) AddPlayerToTeamTransaction(teamID, playerID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `insert into team_players (team_id, player_id, created_at, updated_at) values ($1, $2, $3, $4)`
+
+	_, err := tx.ExecContext(ctx, stmt, teamID, playerID, time.Now().UTC(), time.Now().UTC())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTeam removes a team. Its players stay in the league
+func (m *postgresTeamRepo) DeleteTeam(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `delete from teams where id=$1`
+
+	_, err := m.DB.ExecContext(ctx, stmt, id)
+
+	return err
+}
@@ -0,0 +1,65 @@
+package teamrepo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testTeamRepo struct{}
+
+func NewTestTeamRepo() repository.TeamRepo {
+	return &testTeamRepo{}
+}
+
+func testTeam(id, seasonID int) models.Team {
+	return models.Team{
+		ID:       id,
+		LeagueID: 1,
+		SeasonID: seasonID,
+		Name:     "Team",
+		Players: []models.Player{
+			{ID: 1, LeagueID: 1, IsActive: true},
+			{ID: 2, LeagueID: 1, IsActive: true},
+		},
+	}
+}
+
+func (m *testTeamRepo) GetTeamByID(id int) (models.Team, error) {
+	if id == 3 {
+		return models.Team{}, errors.New("some error")
+	}
+	return testTeam(id, 1), nil
+}
+
+func (m *testTeamRepo) GetTeamsBySeasonID(seasonID int) ([]models.Team, error) {
+	var t []models.Team
+	if seasonID == 3 {
+		return t, errors.New("some error")
+	}
+	return append(t, testTeam(1, seasonID)), nil
+}
+
+func (m *testTeamRepo) CreateTeamTransaction(team models.Team, ctx context.Context, tx *sql.Tx) (int, error) {
+	if team.Name == "Team Error" {
+		return 0, errors.New("team creation failed")
+	}
+	return 1, nil
+}
+
+func (m *testTeamRepo) AddPlayerToTeamTransaction(teamID, playerID int, ctx context.Context, tx *sql.Tx) error {
+	if playerID == 99 {
+		return errors.New("team player creation failed")
+	}
+	return nil
+}
+
+func (m *testTeamRepo) DeleteTeam(id int) error {
+	if id == 5 {
+		return errors.New("team deletion failed")
+	}
+	return nil
+}
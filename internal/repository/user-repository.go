@@ -10,9 +10,21 @@ import (
 type UserRepo interface {
 	CreateUser(u models.User, password string) (int, error)
 	Authenticate(email, password string) (int, int, error)
-	AllUsers() bool
+	AllUsers(search string, limit, offset int) ([]models.User, error)
+	CountUsers(search string) (int, error)
 	GetUserByID(id int) (models.User, error)
 	GetUserByEmail(email string) (models.User, error)
 	UpdateUser(u models.User) error
+	UpdatePassword(userID int, password string) error
+	RevokeSessions(userID int) error
+	SetAccessLevel(userID, accessLevel int) error
+	DeactivateUser(userID int) error
+	ReactivateUser(userID int) error
 	CreateInactiveUserTransaction(u models.User, ctx context.Context, tx *sql.Tx) (int, error)
+	ActivateUserTransaction(u models.User, password string, ctx context.Context, tx *sql.Tx) error
+	ResetPasswordTransaction(userID int, password string, ctx context.Context, tx *sql.Tx) error
+	VerifyEmailTransaction(userID int, email string, ctx context.Context, tx *sql.Tx) error
+	EnableTwoFactorTransaction(userID int, secret string, ctx context.Context, tx *sql.Tx) error
+	DisableTwoFactorTransaction(userID int, ctx context.Context, tx *sql.Tx) error
+	UseTOTPStep(userID int, step int64) error
 }
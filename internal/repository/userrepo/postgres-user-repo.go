@@ -21,8 +21,76 @@ func NewPostgresUserRepo(conn *sql.DB) repository.UserRepo {
 	}
 }
 
-func (m *postgresUserRepo) AllUsers() bool {
-	return true
+// searchPattern returns the ilike pattern that matches anything containing
+// search, which is everything when search is empty
+func searchPattern(search string) string {
+	return "%" + search + "%"
+}
+
+// AllUsers returns a page of the users whose name or email contains search,
+// newest first
+func (m *postgresUserRepo) AllUsers(search string, limit, offset int) ([]models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `
+	select 
+		id, first_name, last_name, email, access_level_id, verified_at, deactivated_at, created_at, updated_at 
+	from users 
+	where first_name || ' ' || last_name ilike $1 or email ilike $1 
+	order by created_at desc, id desc 
+	limit $2 offset $3`
+
+	var users []models.User
+
+	rows, err := m.DB.QueryContext(ctx, query, searchPattern(search), limit, offset)
+	if err != nil {
+		return users, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u models.User
+		var verifiedAt, deactivatedAt sql.NullTime
+
+		err := rows.Scan(
+			&u.ID,
+			&u.FirstName,
+			&u.LastName,
+			&u.Email,
+			&u.AccessLevel,
+			&verifiedAt,
+			&deactivatedAt,
+			&u.CreatedAt,
+			&u.UpdatedAt,
+		)
+		if err != nil {
+			return users, err
+		}
+		u.VerifiedAt = verifiedAt.Time
+		u.DeactivatedAt = deactivatedAt.Time
+
+		users = append(users, u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return users, err
+	}
+
+	return users, nil
+}
+
+// CountUsers returns how many users' name or email contains search
+func (m *postgresUserRepo) CountUsers(search string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select count(id) from users where first_name || ' ' || last_name ilike $1 or email ilike $1`
+
+	var count int
+	err := m.DB.QueryRowContext(ctx, query, searchPattern(search)).Scan(&count)
+
+	return count, err
 }
 
 // GetUserByID returns a user by id
@@ -30,11 +98,12 @@ func (m *postgresUserRepo) GetUserByID(id int) (models.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	query := `select id, first_name, last_name, email, password, access_level_id, created_at, updated_at from users where id=$1`
+	query := `select id, first_name, last_name, email, coalesce(password, ''), access_level_id, verified_at, sessions_revoked_at, coalesce(totp_secret, ''), totp_enabled_at, deactivated_at, created_at, updated_at from users where id=$1`
 
 	row := m.DB.QueryRowContext(ctx, query, id)
 
 	var u models.User
+	var verifiedAt, sessionsRevokedAt, totpEnabledAt, deactivatedAt sql.NullTime
 
 	err := row.Scan(
 		&u.ID,
@@ -43,12 +112,21 @@ func (m *postgresUserRepo) GetUserByID(id int) (models.User, error) {
 		&u.Email,
 		&u.Password,
 		&u.AccessLevel,
+		&verifiedAt,
+		&sessionsRevokedAt,
+		&u.TOTPSecret,
+		&totpEnabledAt,
+		&deactivatedAt,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
 	if err != nil {
 		return u, err
 	}
+	u.VerifiedAt = verifiedAt.Time
+	u.SessionsRevokedAt = sessionsRevokedAt.Time
+	u.TOTPEnabledAt = totpEnabledAt.Time
+	u.DeactivatedAt = deactivatedAt.Time
 
 	return u, nil
 }
@@ -58,11 +136,12 @@ func (m *postgresUserRepo) GetUserByEmail(email string) (models.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	query := `select id, first_name, last_name, email, access_level_id, created_at, updated_at from users where email=$1`
+	query := `select id, first_name, last_name, email, access_level_id, verified_at, created_at, updated_at from users where email=$1`
 
 	row := m.DB.QueryRowContext(ctx, query, email)
 
 	var u models.User
+	var verifiedAt sql.NullTime
 
 	err := row.Scan(
 		&u.ID,
@@ -70,29 +149,41 @@ func (m *postgresUserRepo) GetUserByEmail(email string) (models.User, error) {
 		&u.LastName,
 		&u.Email,
 		&u.AccessLevel,
+		&verifiedAt,
 		&u.CreatedAt,
 		&u.UpdatedAt,
 	)
 	if err != nil {
 		return u, err
 	}
+	u.VerifiedAt = verifiedAt.Time
 
 	return u, nil
 }
 
-// UpdateUser updates a user in the db
+// UpdateUser updates a user in the db. Changing the email address means it
+// has to be verified again
 func (m *postgresUserRepo) UpdateUser(u models.User) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	query := `update users set first_name = $1, last_name = $2, email = $3, access_level = $4, updated_at = $5`
+	query := `
+		update users set 
+			first_name = $1, 
+			last_name = $2, 
+			verified_at = case when email = $3 then verified_at else null end, 
+			email = $3, 
+			access_level_id = $4, 
+			updated_at = $5 
+		where id = $6`
 
 	_, err := m.DB.ExecContext(ctx, query,
 		u.FirstName,
 		u.LastName,
 		u.Email,
 		u.AccessLevel,
-		time.Now(),
+		time.Now().UTC(),
+		u.ID,
 	)
 
 	if err != nil {
@@ -102,7 +193,74 @@ func (m *postgresUserRepo) UpdateUser(u models.User) error {
 	return nil
 }
 
-// Authenticate authenticates a user
+// UpdatePassword sets a new password for a user and ends all of their sessions
+func (m *postgresUserRepo) UpdatePassword(userID int, password string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	stmt := `update users set password = $1, sessions_revoked_at = $2, updated_at = $2 where id = $3`
+
+	_, err = m.DB.ExecContext(ctx, stmt, string(hashedPassword), time.Now().UTC(), userID)
+
+	return err
+}
+
+// RevokeSessions ends all of a user's sessions, on every device
+func (m *postgresUserRepo) RevokeSessions(userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update users set sessions_revoked_at = $1, updated_at = $1 where id = $2`
+
+	_, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), userID)
+
+	return err
+}
+
+// SetAccessLevel changes what a user is allowed to do on the site. It ends all
+// of their sessions, which keep the access level the user logged in with
+func (m *postgresUserRepo) SetAccessLevel(userID, accessLevel int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update users set access_level_id = $1, sessions_revoked_at = $2, updated_at = $2 where id = $3`
+
+	_, err := m.DB.ExecContext(ctx, stmt, accessLevel, time.Now().UTC(), userID)
+
+	return err
+}
+
+// DeactivateUser shuts a user's account off, which also ends all of their
+// sessions
+func (m *postgresUserRepo) DeactivateUser(userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update users set deactivated_at = $1, sessions_revoked_at = $1, updated_at = $1 where id = $2`
+
+	_, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), userID)
+
+	return err
+}
+
+// ReactivateUser lets a deactivated user log in again
+func (m *postgresUserRepo) ReactivateUser(userID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update users set deactivated_at = null, updated_at = $1 where id = $2`
+
+	_, err := m.DB.ExecContext(ctx, stmt, time.Now().UTC(), userID)
+
+	return err
+}
+
+// Authenticate authenticates a user. Deactivated users can't log in
 func (m *postgresUserRepo) Authenticate(email, password string) (int, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
@@ -111,7 +269,7 @@ func (m *postgresUserRepo) Authenticate(email, password string) (int, int, error
 	var hashedPassword string
 	var accessLevel int
 
-	row := m.DB.QueryRowContext(ctx, "select id, access_level_id, password from users where email = $1", email)
+	row := m.DB.QueryRowContext(ctx, "select id, access_level_id, password from users where email = $1 and deactivated_at is null", email)
 	err := row.Scan(&id, &accessLevel, &hashedPassword)
 	if err != nil {
 		return id, accessLevel, err
@@ -148,8 +306,8 @@ func (m *postgresUserRepo) CreateUser(u models.User, password string) (int, erro
 		u.Email,
 		string(hashedPassword),
 		models.AccessLevelPlayer,
-		time.Now(),
-		time.Now(),
+		time.Now().UTC(),
+		time.Now().UTC(),
 	).Scan(&id)
 
 	if err != nil {
@@ -164,15 +322,15 @@ func (m *postgresUserRepo) CreateInactiveUserTransaction(u models.User, ctx cont
 
 	stmt := `insert into users (first_name, last_name, email, access_level_id, created_at, updated_at) values ($1, $2, $3, $4, $5, $6) returning id`
 
-	err := m.DB.QueryRowContext(
+	err := tx.QueryRowContext(
 		ctx,
 		stmt,
 		u.FirstName,
 		u.LastName,
 		u.Email,
 		u.AccessLevel,
-		time.Now(),
-		time.Now(),
+		time.Now().UTC(),
+		time.Now().UTC(),
 	).Scan(&userID)
 
 	if err != nil {
@@ -182,3 +340,134 @@ func (m *postgresUserRepo) CreateInactiveUserTransaction(u models.User, ctx cont
 
 	return userID, nil
 }
+
+// ActivateUserTransaction sets the name and password of a user added to a
+// league without an account, so they can log in. The user got the invitation
+// by email, so their address is verified too. It fails if the user already has
+// a password, so an invitation can't take over a claimed account
+func (m *postgresUserRepo) ActivateUserTransaction(u models.User, password string, ctx context.Context, tx *sql.Tx) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	stmt := `update users set first_name = $1, last_name = $2, password = $3, verified_at = coalesce(verified_at, $4), updated_at = $4
+	where id = $5 and (password is null or password = '')`
+
+	result, err := tx.ExecContext(ctx, stmt, u.FirstName, u.LastName, string(hashedPassword), time.Now().UTC(), u.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return errors.New("this account has already been claimed")
+	}
+
+	return nil
+}
+
+// ResetPasswordTransaction sets a new password for a user and ends all of
+// their sessions. The user got the reset link by email, so their address is
+// verified too
+func (m *postgresUserRepo) ResetPasswordTransaction(userID int, password string, ctx context.Context, tx *sql.Tx) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	stmt := `update users set password = $1, verified_at = coalesce(verified_at, $2), sessions_revoked_at = $2, updated_at = $2 where id = $3`
+
+	_, err = tx.ExecContext(ctx, stmt, string(hashedPassword), time.Now().UTC(), userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// VerifyEmailTransaction marks a user's email as verified. It fails if the
+// user's email is no longer the one that was verified
+func (m *postgresUserRepo) VerifyEmailTransaction(userID int, email string, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update users set verified_at = $1, updated_at = $1 where id = $2 and email = $3`
+
+	result, err := tx.ExecContext(ctx, stmt, time.Now().UTC(), userID, email)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if rows == 0 {
+		tx.Rollback()
+		return errors.New("this email address is no longer on the account")
+	}
+
+	return nil
+}
+
+// EnableTwoFactorTransaction turns on two-factor authentication for a user
+// with the secret their authenticator app was set up with
+func (m *postgresUserRepo) EnableTwoFactorTransaction(userID int, secret string, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update users set totp_secret = $1, totp_enabled_at = $2, totp_last_step = null, updated_at = $2 where id = $3`
+
+	_, err := tx.ExecContext(ctx, stmt, secret, time.Now().UTC(), userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// DisableTwoFactorTransaction turns off two-factor authentication for a user
+// and forgets their secret
+func (m *postgresUserRepo) DisableTwoFactorTransaction(userID int, ctx context.Context, tx *sql.Tx) error {
+	stmt := `update users set totp_secret = null, totp_enabled_at = null, totp_last_step = null, updated_at = $1 where id = $2`
+
+	_, err := tx.ExecContext(ctx, stmt, time.Now().UTC(), userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return nil
+}
+
+// UseTOTPStep records the period of the code a user just logged in with. It
+// fails if they already used a code from that period or a later one, so a
+// code can't be used twice
+func (m *postgresUserRepo) UseTOTPStep(userID int, step int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update users set totp_last_step = $1 where id = $2 and (totp_last_step is null or totp_last_step < $1)`
+
+	result, err := m.DB.ExecContext(ctx, stmt, step, userID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("this code has already been used")
+	}
+
+	return nil
+}
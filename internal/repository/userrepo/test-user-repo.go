@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/jdonahue135/golf-league-app/internal/models"
 	"github.com/jdonahue135/golf-league-app/internal/repository"
@@ -23,14 +24,26 @@ func (m *testUserRepo) CreateUser(u models.User, password string) (int, error) {
 }
 
 func (m *testUserRepo) Authenticate(email, password string) (int, int, error) {
-	if email == "jack@nimble.com" {
+	if email == "jack@nimble.com" || password == "wrong" {
 		return 0, 0, errors.New("some error")
 	}
 	return 1, 1, nil
 }
 
-func (m *testUserRepo) AllUsers() bool {
-	return true
+func (m *testUserRepo) AllUsers(search string, limit, offset int) ([]models.User, error) {
+	var users []models.User
+	if search == "error" {
+		return users, errors.New("some error")
+	}
+	users = append(users, models.User{ID: 1})
+	return users, nil
+}
+
+func (m *testUserRepo) CountUsers(search string) (int, error) {
+	if search == "count error" {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
 }
 
 func (m *testUserRepo) GetUserByEmail(email string) (models.User, error) {
@@ -38,6 +51,14 @@ func (m *testUserRepo) GetUserByEmail(email string) (models.User, error) {
 	if email == "me@here.ca" {
 		return u, errors.New("some error")
 	}
+	switch email {
+	case "commissioner@here.ca":
+		u.ID = 1
+	case "player@here.ca":
+		u.ID = 3
+	case "saveerror@here.ca":
+		u.ID = 4
+	}
 	return u, nil
 }
 
@@ -46,10 +67,55 @@ func (m *testUserRepo) GetUserByID(id int) (models.User, error) {
 	if id == 0 {
 		return u, errors.New("some error")
 	}
+	u.ID = id
+	if id == 8 || id == 55 {
+		u.Password = "hashed password"
+	}
+	if id == 11 {
+		u.DeactivatedAt = time.Now()
+	}
 	return u, nil
 }
 
 func (m *testUserRepo) UpdateUser(u models.User) error {
+	if u.FirstName == "error" {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) UpdatePassword(userID int, password string) error {
+	if password == "error" {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) RevokeSessions(userID int) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) SetAccessLevel(userID, accessLevel int) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) DeactivateUser(userID int) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) ReactivateUser(userID int) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
 	return nil
 }
 
@@ -60,6 +126,51 @@ func (m *testUserRepo) CreateInactiveUserTransaction(u models.User, ctx context.
 	if u.FirstName == "player create error" {
 		return 2, nil
 	}
+	if u.FirstName == "invitation create error" {
+		return 3, nil
+	}
 
 	return 1, nil
 }
+
+func (m *testUserRepo) ActivateUserTransaction(u models.User, password string, ctx context.Context, tx *sql.Tx) error {
+	if password == "error" {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) ResetPasswordTransaction(userID int, password string, ctx context.Context, tx *sql.Tx) error {
+	if password == "error" {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) VerifyEmailTransaction(userID int, email string, ctx context.Context, tx *sql.Tx) error {
+	if email == "error@here.com" {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) EnableTwoFactorTransaction(userID int, secret string, ctx context.Context, tx *sql.Tx) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) DisableTwoFactorTransaction(userID int, ctx context.Context, tx *sql.Tx) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testUserRepo) UseTOTPStep(userID int, step int64) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type WebhookRepo interface {
+	GetWebhookByID(id int) (models.Webhook, error)
+	GetWebhooksByLeagueID(leagueID int) ([]models.Webhook, error)
+	CreateWebhook(webhook models.Webhook) (int, error)
+	DeleteWebhook(id int) error
+	GetWebhookDeliveriesByWebhookID(webhookID, limit int) ([]models.WebhookDelivery, error)
+	CreateWebhookDelivery(delivery models.WebhookDelivery) (int, error)
+	ClaimDueWebhookDeliveries(now, claimedUntil time.Time, limit int) ([]models.WebhookDelivery, error)
+	UpdateWebhookDeliveryAttempt(delivery models.WebhookDelivery) error
+}
@@ -0,0 +1,293 @@
+package webhookrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type postgresWebhookRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresWebhookRepo(conn *sql.DB) repository.WebhookRepo {
+	return &postgresWebhookRepo{
+		DB: conn,
+	}
+}
+
+// nullTime stores the zero time as null
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func (m *postgresWebhookRepo) GetWebhookByID(id int) (models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select id, league_id, url, secret, created_at, updated_at from webhooks where id = $1`
+
+	var w models.Webhook
+	err := m.DB.QueryRowContext(ctx, query, id).Scan(
+		&w.ID,
+		&w.LeagueID,
+		&w.URL,
+		&w.Secret,
+		&w.CreatedAt,
+		&w.UpdatedAt,
+	)
+
+	return w, err
+}
+
+func (m *postgresWebhookRepo) GetWebhooksByLeagueID(leagueID int) ([]models.Webhook, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var webhooks []models.Webhook
+
+	query := `select id, league_id, url, secret, created_at, updated_at from webhooks where league_id = $1 order by created_at`
+
+	rows, err := m.DB.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return webhooks, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var w models.Webhook
+		err := rows.Scan(
+			&w.ID,
+			&w.LeagueID,
+			&w.URL,
+			&w.Secret,
+			&w.CreatedAt,
+			&w.UpdatedAt,
+		)
+		if err != nil {
+			return webhooks, err
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		return webhooks, err
+	}
+
+	return webhooks, nil
+}
+
+func (m *postgresWebhookRepo) CreateWebhook(webhook models.Webhook) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var webhookID int
+	stmt := `insert into webhooks (league_id, url, secret, created_at, updated_at) 
+		values ($1, $2, $3, $4, $5) returning id`
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		webhook.LeagueID,
+		webhook.URL,
+		webhook.Secret,
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&webhookID)
+
+	return webhookID, err
+}
+
+// DeleteWebhook deletes a webhook along with its deliveries
+func (m *postgresWebhookRepo) DeleteWebhook(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `delete from webhooks where id = $1`
+
+	_, err := m.DB.ExecContext(ctx, stmt, id)
+
+	return err
+}
+
+// GetWebhookDeliveriesByWebhookID returns a webhook's latest deliveries,
+// newest first
+func (m *postgresWebhookRepo) GetWebhookDeliveriesByWebhookID(webhookID, limit int) ([]models.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var deliveries []models.WebhookDelivery
+
+	query := `
+		select 
+			id,
+			webhook_id,
+			event,
+			payload,
+			attempts,
+			status_code,
+			last_error,
+			next_attempt_at,
+			delivered_at,
+			created_at,
+			updated_at
+		from webhook_deliveries 
+		where webhook_id = $1 
+		order by created_at desc, id desc 
+		limit $2`
+
+	rows, err := m.DB.QueryContext(ctx, query, webhookID, limit)
+	if err != nil {
+		return deliveries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var nextAttemptAt, deliveredAt sql.NullTime
+		err := rows.Scan(
+			&d.ID,
+			&d.WebhookID,
+			&d.Event,
+			&d.Payload,
+			&d.Attempts,
+			&d.StatusCode,
+			&d.LastError,
+			&nextAttemptAt,
+			&deliveredAt,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		)
+		if err != nil {
+			return deliveries, err
+		}
+		d.NextAttemptAt = nextAttemptAt.Time
+		d.DeliveredAt = deliveredAt.Time
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return deliveries, err
+	}
+
+	return deliveries, nil
+}
+
+func (m *postgresWebhookRepo) CreateWebhookDelivery(delivery models.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var deliveryID int
+	stmt := `insert into webhook_deliveries 
+		(webhook_id, event, payload, next_attempt_at, created_at, updated_at) 
+		values ($1, $2, $3, $4, $5, $6) returning id`
+
+	err := m.DB.QueryRowContext(
+		ctx,
+		stmt,
+		delivery.WebhookID,
+		delivery.Event,
+		delivery.Payload,
+		nullTime(delivery.NextAttemptAt),
+		time.Now().UTC(),
+		time.Now().UTC(),
+	).Scan(&deliveryID)
+
+	return deliveryID, err
+}
+
+// ClaimDueWebhookDeliveries returns up to limit deliveries that are due to be
+// tried, along with their webhooks. They aren't due again until claimedUntil,
+// so another server sending deliveries at the same time skips them
+func (m *postgresWebhookRepo) ClaimDueWebhookDeliveries(now, claimedUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var deliveries []models.WebhookDelivery
+
+	query := `
+		with claimed as (
+			update webhook_deliveries set next_attempt_at = $2, updated_at = $1 
+			where id in (
+				select id from webhook_deliveries 
+				where next_attempt_at <= $1 
+				order by next_attempt_at 
+				limit $3 
+				for update skip locked
+			) 
+			returning id, webhook_id, event, payload, attempts, created_at
+		)
+		select 
+			c.id,
+			c.webhook_id,
+			c.event,
+			c.payload,
+			c.attempts,
+			c.created_at,
+			w.league_id,
+			w.url,
+			w.secret
+		from claimed c 
+		join webhooks w on c.webhook_id = w.id 
+		order by c.id`
+
+	rows, err := m.DB.QueryContext(ctx, query, now, claimedUntil, limit)
+	if err != nil {
+		return deliveries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var d models.WebhookDelivery
+		err := rows.Scan(
+			&d.ID,
+			&d.WebhookID,
+			&d.Event,
+			&d.Payload,
+			&d.Attempts,
+			&d.CreatedAt,
+			&d.Webhook.LeagueID,
+			&d.Webhook.URL,
+			&d.Webhook.Secret,
+		)
+		if err != nil {
+			return deliveries, err
+		}
+		d.Webhook.ID = d.WebhookID
+		deliveries = append(deliveries, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return deliveries, err
+	}
+
+	return deliveries, nil
+}
+
+// UpdateWebhookDeliveryAttempt saves how the latest attempt at a delivery
+// went and when, if ever, it's tried next
+func (m *postgresWebhookRepo) UpdateWebhookDeliveryAttempt(delivery models.WebhookDelivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `update webhook_deliveries set 
+		attempts = $1, status_code = $2, last_error = $3, next_attempt_at = $4, delivered_at = $5, updated_at = $6 
+		where id = $7`
+
+	_, err := m.DB.ExecContext(
+		ctx,
+		stmt,
+		delivery.Attempts,
+		delivery.StatusCode,
+		delivery.LastError,
+		nullTime(delivery.NextAttemptAt),
+		nullTime(delivery.DeliveredAt),
+		time.Now().UTC(),
+		delivery.ID,
+	)
+
+	return err
+}
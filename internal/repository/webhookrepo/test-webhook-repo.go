@@ -0,0 +1,103 @@
+package webhookrepo
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+)
+
+type testWebhookRepo struct{}
+
+func NewTestWebhookRepo() repository.WebhookRepo {
+	return &testWebhookRepo{}
+}
+
+func testWebhook(ID, leagueID int) models.Webhook {
+	return models.Webhook{
+		ID:       ID,
+		LeagueID: leagueID,
+		URL:      "https://hooks.example.com/ok",
+		Secret:   "secret",
+	}
+}
+
+func (m *testWebhookRepo) GetWebhookByID(id int) (models.Webhook, error) {
+	if id == 0 {
+		return models.Webhook{}, errors.New("some error")
+	}
+	return testWebhook(id, 1), nil
+}
+
+func (m *testWebhookRepo) GetWebhooksByLeagueID(leagueID int) ([]models.Webhook, error) {
+	switch leagueID {
+	case 3:
+		return nil, errors.New("some error")
+	case 4:
+		return nil, nil
+	case 5:
+		return []models.Webhook{testWebhook(1, leagueID), testWebhook(5, leagueID)}, nil
+	}
+	return []models.Webhook{testWebhook(1, leagueID), testWebhook(2, leagueID)}, nil
+}
+
+func (m *testWebhookRepo) CreateWebhook(webhook models.Webhook) (int, error) {
+	if webhook.LeagueID == 3 {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
+}
+
+func (m *testWebhookRepo) DeleteWebhook(id int) error {
+	if id == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testWebhookRepo) GetWebhookDeliveriesByWebhookID(webhookID, limit int) ([]models.WebhookDelivery, error) {
+	if webhookID == 3 {
+		return nil, errors.New("some error")
+	}
+	return []models.WebhookDelivery{{ID: 1, WebhookID: webhookID, Event: models.WebhookEventTest, Attempts: 1, StatusCode: 200, DeliveredAt: time.Now()}}, nil
+}
+
+func (m *testWebhookRepo) CreateWebhookDelivery(delivery models.WebhookDelivery) (int, error) {
+	if delivery.WebhookID == 5 {
+		return 0, errors.New("some error")
+	}
+	return 1, nil
+}
+
+// ClaimDueWebhookDeliveries returns a delivery for each way sending one can
+// go, addressed to paths a stand-in receiver answers accordingly
+func (m *testWebhookRepo) ClaimDueWebhookDeliveries(now, claimedUntil time.Time, limit int) ([]models.WebhookDelivery, error) {
+	delivery := func(ID int, path string, attempts int) models.WebhookDelivery {
+		webhook := testWebhook(ID, 1)
+		webhook.URL = "http://hooks.example.com" + path
+		return models.WebhookDelivery{
+			ID:        ID,
+			WebhookID: ID,
+			Event:     models.WebhookEventTest,
+			Payload:   `{"event":"webhook.test"}`,
+			Attempts:  attempts,
+			Webhook:   webhook,
+		}
+	}
+
+	return []models.WebhookDelivery{
+		delivery(1, "/ok", 0),
+		delivery(2, "/fail", 0),
+		delivery(3, "/fail", 2),
+		delivery(4, "/fail", models.WebhookMaxAttempts-1),
+		delivery(5, "/ok", 0),
+	}, nil
+}
+
+func (m *testWebhookRepo) UpdateWebhookDeliveryAttempt(delivery models.WebhookDelivery) error {
+	if delivery.ID == 5 {
+		return errors.New("some error")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Package schedule builds balanced round-robin matchups for a league's weeks
+package schedule
+
+import (
+	"time"
+)
+
+// Bye is the opponent of a player who sits out a week
+const Bye = 0
+
+// Pairing is one matchup in a week. When Away is Bye the home player sits out
+type Pairing struct {
+	Home int
+	Away int
+}
+
+// IsBye reports whether the pairing is a week off for the home player
+func (p Pairing) IsBye() bool {
+	return p.Away == Bye
+}
+
+// RoundRobin pairs the players for each of the given number of weeks using the
+// circle method. Every player meets every other player once before any pairing
+// repeats, and an odd number of players gives one player a bye each week.
+// Home and away are alternated so each player is home about half the time
+func RoundRobin(playerIDs []int, weeks int) [][]Pairing {
+	if len(playerIDs) < 2 || weeks <= 0 {
+		return nil
+	}
+
+	circle := append([]int(nil), playerIDs...)
+	if len(circle)%2 == 1 {
+		circle = append(circle, Bye)
+	}
+
+	n := len(circle)
+	rounds := make([][]Pairing, n-1)
+	for r := range rounds {
+		for i := 0; i < n/2; i++ {
+			home, away := circle[i], circle[n-1-i]
+
+			// the fixed player alternates each round, the rest by board
+			if (i == 0 && r%2 == 1) || (i > 0 && i%2 == 1) {
+				home, away = away, home
+			}
+			if home == Bye {
+				home, away = away, home
+			}
+
+			rounds[r] = append(rounds[r], Pairing{Home: home, Away: away})
+		}
+
+		// keep the first player fixed and rotate everyone else one place
+		last := circle[n-1]
+		copy(circle[2:], circle[1:n-1])
+		circle[1] = last
+	}
+
+	schedule := make([][]Pairing, weeks)
+	for w := range schedule {
+		cycle := w / len(rounds)
+		for _, p := range rounds[w%len(rounds)] {
+			// swap sides each time through the rotation
+			if cycle%2 == 1 && !p.IsBye() {
+				p.Home, p.Away = p.Away, p.Home
+			}
+			schedule[w] = append(schedule[w], p)
+		}
+	}
+
+	return schedule
+}
+
+// WeeklyDates returns the start date and every seventh day after it up to and
+// including the end date
+func WeeklyDates(start, end time.Time) []time.Time {
+	var dates []time.Time
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 7) {
+		dates = append(dates, d)
+	}
+	return dates
+}
@@ -0,0 +1,160 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func players(count int) []int {
+	var ids []int
+	for i := 1; i <= count; i++ {
+		ids = append(ids, i)
+	}
+	return ids
+}
+
+type pair struct {
+	a, b int
+}
+
+func key(p Pairing) pair {
+	if p.Home < p.Away {
+		return pair{p.Home, p.Away}
+	}
+	return pair{p.Away, p.Home}
+}
+
+var roundRobinTests = []struct {
+	name            string
+	players         int
+	weeks           int
+	expectedWeeks   int
+	expectedMatches int
+	expectedByes    int
+}{
+	{"no players", 0, 5, 0, 0, 0},
+	{"one player", 1, 5, 0, 0, 0},
+	{"no weeks", 4, 0, 0, 0, 0},
+	{"even players", 6, 5, 5, 3, 0},
+	{"odd players", 5, 5, 5, 3, 1},
+	{"two players", 2, 3, 3, 1, 0},
+	{"longer than one rotation", 4, 7, 7, 2, 0},
+}
+
+func TestRoundRobin(t *testing.T) {
+	for _, e := range roundRobinTests {
+		schedule := RoundRobin(players(e.players), e.weeks)
+		if len(schedule) != e.expectedWeeks {
+			t.Errorf("failed %s: expected %d weeks, but got %d", e.name, e.expectedWeeks, len(schedule))
+			continue
+		}
+
+		for w, week := range schedule {
+			if len(week) != e.expectedMatches {
+				t.Errorf("failed %s: expected %d matches in week %d, but got %d", e.name, e.expectedMatches, w+1, len(week))
+			}
+
+			seen := make(map[int]bool)
+			byes := 0
+			for _, p := range week {
+				if p.Home == Bye {
+					t.Errorf("failed %s: bye listed as the home player in week %d", e.name, w+1)
+				}
+				if p.IsBye() {
+					byes++
+				} else if seen[p.Away] {
+					t.Errorf("failed %s: player %d plays twice in week %d", e.name, p.Away, w+1)
+				}
+				if seen[p.Home] {
+					t.Errorf("failed %s: player %d plays twice in week %d", e.name, p.Home, w+1)
+				}
+				seen[p.Home] = true
+				seen[p.Away] = true
+			}
+			if byes != e.expectedByes {
+				t.Errorf("failed %s: expected %d byes in week %d, but got %d", e.name, e.expectedByes, w+1, byes)
+			}
+		}
+	}
+}
+
+func TestRoundRobinEveryoneMeetsOnce(t *testing.T) {
+	for _, count := range []int{2, 3, 4, 7, 8, 12} {
+		weeks := count - 1
+		if count%2 == 1 {
+			weeks = count
+		}
+
+		met := make(map[pair]int)
+		byes := make(map[int]int)
+		for _, week := range RoundRobin(players(count), weeks) {
+			for _, p := range week {
+				if p.IsBye() {
+					byes[p.Home]++
+					continue
+				}
+				met[key(p)]++
+			}
+		}
+
+		if len(met) != count*(count-1)/2 {
+			t.Errorf("%d players: expected %d pairings, but got %d", count, count*(count-1)/2, len(met))
+		}
+		for p, times := range met {
+			if times != 1 {
+				t.Errorf("%d players: %d and %d met %d times", count, p.a, p.b, times)
+			}
+		}
+		for id, times := range byes {
+			if times != 1 {
+				t.Errorf("%d players: player %d had %d byes", count, id, times)
+			}
+		}
+	}
+}
+
+func TestRoundRobinHomeAndAwayBalanced(t *testing.T) {
+	for _, count := range []int{4, 6, 8, 10} {
+		home := make(map[int]int)
+		for _, week := range RoundRobin(players(count), count-1) {
+			for _, p := range week {
+				home[p.Home]++
+			}
+		}
+
+		for _, id := range players(count) {
+			// count-1 matches can't split evenly, so allow one extra either way
+			if diff := 2*home[id] - (count - 1); diff > 2 || diff < -2 {
+				t.Errorf("%d players: player %d is home %d of %d weeks", count, id, home[id], count-1)
+			}
+		}
+	}
+}
+
+func TestRoundRobinRepeatsSwapSides(t *testing.T) {
+	schedule := RoundRobin(players(4), 6)
+	for w := 0; w < 3; w++ {
+		for i, p := range schedule[w] {
+			repeat := schedule[w+3][i]
+			if repeat.Home != p.Away || repeat.Away != p.Home {
+				t.Errorf("expected week %d to swap sides of week %d: got %v and %v", w+4, w+1, repeat, p)
+			}
+		}
+	}
+}
+
+func TestWeeklyDates(t *testing.T) {
+	start := time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)
+
+	dates := WeeklyDates(start, time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC))
+	if len(dates) != 4 {
+		t.Errorf("expected 4 dates, but got %d", len(dates))
+	}
+	if !dates[3].Equal(time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected last date to be the end date, but got %s", dates[3])
+	}
+
+	if len(WeeklyDates(start, start.AddDate(0, 0, -1))) != 0 {
+		t.Error("expected no dates when the range ends before it starts")
+	}
+}
@@ -0,0 +1,10 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type APITokenService interface {
+	GetAPITokens(userID int) ([]models.APIToken, error)
+	CreateAPIToken(userID int, name, scope string, expiresInDays int) (models.APIToken, string, error)
+	RevokeAPIToken(userID, tokenID int) error
+	Authenticate(token string) (models.User, models.APIToken, error)
+}
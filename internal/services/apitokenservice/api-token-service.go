@@ -0,0 +1,95 @@
+package apitokenservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type apiTokenService struct {
+	APITokenRepo repository.APITokenRepo
+	UserRepo     repository.UserRepo
+}
+
+func NewAPITokenService(t repository.APITokenRepo, u repository.UserRepo) services.APITokenService {
+	return &apiTokenService{
+		APITokenRepo: t,
+		UserRepo:     u,
+	}
+}
+
+func (m *apiTokenService) GetAPITokens(userID int) ([]models.APIToken, error) {
+	return m.APITokenRepo.GetAPITokensByUserID(userID)
+}
+
+// CreateAPIToken makes a new token for the user and returns it along with the
+// token itself, which can't be looked up again. A token that expires in 0
+// days never expires
+func (m *apiTokenService) CreateAPIToken(userID int, name, scope string, expiresInDays int) (models.APIToken, string, error) {
+	if !models.IsAPITokenScope(scope) {
+		return models.APIToken{}, "", errors.New("choose what the token can do")
+	}
+
+	apiToken := models.APIToken{
+		UserID: userID,
+		Name:   name,
+		Scope:  scope,
+	}
+
+	if expiresInDays != 0 {
+		valid := false
+		for _, days := range models.APITokenExpiryDays {
+			valid = valid || days == expiresInDays
+		}
+		if !valid {
+			return models.APIToken{}, "", errors.New("choose when the token expires")
+		}
+		apiToken.ExpiresAt = time.Now().UTC().AddDate(0, 0, expiresInDays)
+	}
+
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return models.APIToken{}, "", err
+	}
+	apiToken.TokenHash = tokenHash
+
+	apiToken.ID, err = m.APITokenRepo.CreateAPIToken(apiToken)
+	if err != nil {
+		return models.APIToken{}, "", err
+	}
+
+	return apiToken, token, nil
+}
+
+// RevokeAPIToken deletes one of the user's tokens, so it stops working
+func (m *apiTokenService) RevokeAPIToken(userID, tokenID int) error {
+	return m.APITokenRepo.DeleteAPIToken(tokenID, userID)
+}
+
+// Authenticate returns the user a bearer token belongs to, as long as the
+// token hasn't expired and the user's account is active
+func (m *apiTokenService) Authenticate(token string) (models.User, models.APIToken, error) {
+	apiToken, err := m.APITokenRepo.GetAPITokenByTokenHash(tokens.Hash(token))
+	if err != nil {
+		return models.User{}, models.APIToken{}, errors.New("invalid API token")
+	}
+
+	if apiToken.IsExpired(time.Now().UTC()) {
+		return models.User{}, models.APIToken{}, errors.New("this API token has expired")
+	}
+
+	user, err := m.UserRepo.GetUserByID(apiToken.UserID)
+	if err != nil || user.IsDeactivated() {
+		return models.User{}, models.APIToken{}, errors.New("invalid API token")
+	}
+
+	// when a token was last used is only shown to its owner, so it's no
+	// reason to turn the request away
+	_ = m.APITokenRepo.TouchAPIToken(apiToken.ID)
+
+	return user, apiToken, nil
+}
@@ -0,0 +1,124 @@
+package apitokenservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+var getAPITokensTests = []struct {
+	name          string
+	userID        int
+	expectedCount int
+	expectError   bool
+}{
+	{"valid", 1, 1, false},
+	{"repo error", 3, 0, true},
+}
+
+func TestGetAPITokens(t *testing.T) {
+	for _, e := range getAPITokensTests {
+		apiTokens, err := service.GetAPITokens(e.userID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if len(apiTokens) != e.expectedCount {
+			t.Errorf("failed %s: expected %d tokens, but got %d", e.name, e.expectedCount, len(apiTokens))
+		}
+	}
+}
+
+var createAPITokenTests = []struct {
+	name          string
+	tokenName     string
+	scope         string
+	expiresInDays int
+	expectExpiry  bool
+	expectError   bool
+}{
+	{"never expires", "Script", models.APITokenScopeRead, 0, false, false},
+	{"expires", "Script", models.APITokenScopeReadWrite, 30, true, false},
+	{"invalid scope", "Script", "admin", 0, false, true},
+	{"invalid expiry", "Script", models.APITokenScopeRead, 7, false, true},
+	{"repo error", "error", models.APITokenScopeRead, 0, false, true},
+}
+
+func TestCreateAPIToken(t *testing.T) {
+	for _, e := range createAPITokenTests {
+		apiToken, token, err := service.CreateAPIToken(1, e.tokenName, e.scope, e.expiresInDays)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if e.expectError {
+			continue
+		}
+		if token == "" || apiToken.TokenHash != tokens.Hash(token) {
+			t.Errorf("failed %s: expected the token's hash to be kept, but got %s", e.name, apiToken.TokenHash)
+		}
+		if apiToken.ExpiresAt.IsZero() == e.expectExpiry {
+			t.Errorf("failed %s: expected expiry %t, but got %s", e.name, e.expectExpiry, apiToken.ExpiresAt)
+		}
+		if e.expectExpiry && apiToken.ExpiresAt.Before(time.Now().AddDate(0, 0, e.expiresInDays-1)) {
+			t.Errorf("failed %s: expected token to expire in %d days, but got %s", e.name, e.expiresInDays, apiToken.ExpiresAt)
+		}
+	}
+}
+
+var revokeAPITokenTests = []struct {
+	name        string
+	tokenID     int
+	expectError bool
+}{
+	{"valid", 1, false},
+	{"not the user's token", 2, true},
+}
+
+func TestRevokeAPIToken(t *testing.T) {
+	for _, e := range revokeAPITokenTests {
+		err := service.RevokeAPIToken(1, e.tokenID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var authenticateTests = []struct {
+	name           string
+	token          string
+	expectedUserID int
+	expectError    bool
+}{
+	{"valid", "valid", 1, false},
+	{"last used not saved", "touch error", 1, false},
+	{"unknown token", "unknown", 0, true},
+	{"repo error", "error", 0, true},
+	{"expired", "expired", 0, true},
+	{"user deactivated", "deactivated", 0, true},
+	{"user not found", "user error", 0, true},
+}
+
+func TestAuthenticate(t *testing.T) {
+	for _, e := range authenticateTests {
+		user, _, err := service.Authenticate(e.token)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if user.ID != e.expectedUserID {
+			t.Errorf("failed %s: expected user %d, but got %d", e.name, e.expectedUserID, user.ID)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package apitokenservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/apitokenrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.APITokenService
+
+func TestMain(m *testing.M) {
+	apiTokenRepo := apitokenrepo.NewTestAPITokenRepo()
+	userRepo := userrepo.NewTestUserRepo()
+	service = NewAPITokenService(apiTokenRepo, userRepo)
+
+	os.Exit(m.Run())
+}
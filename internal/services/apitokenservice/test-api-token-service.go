@@ -0,0 +1,49 @@
+package apitokenservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testAPITokenService struct {
+	APITokenRepo repository.APITokenRepo
+}
+
+func NewTestAPITokenService(t repository.APITokenRepo) services.APITokenService {
+	return &testAPITokenService{APITokenRepo: t}
+}
+
+func (m *testAPITokenService) GetAPITokens(userID int) ([]models.APIToken, error) {
+	if userID == 3 {
+		return nil, errors.New("some error")
+	}
+	return []models.APIToken{{ID: 1, UserID: userID, Name: "Script", Scope: models.APITokenScopeRead}}, nil
+}
+
+func (m *testAPITokenService) CreateAPIToken(userID int, name, scope string, expiresInDays int) (models.APIToken, string, error) {
+	if name == "error" {
+		return models.APIToken{}, "", errors.New("some error")
+	}
+	return models.APIToken{ID: 1, UserID: userID, Name: name, Scope: scope}, "token", nil
+}
+
+func (m *testAPITokenService) RevokeAPIToken(userID, tokenID int) error {
+	if tokenID == 2 {
+		return errors.New("token not found")
+	}
+	return nil
+}
+
+func (m *testAPITokenService) Authenticate(token string) (models.User, models.APIToken, error) {
+	apiToken := models.APIToken{ID: 1, UserID: 1, Scope: models.APITokenScopeRead}
+	switch token {
+	case "invalid":
+		return models.User{}, models.APIToken{}, errors.New("invalid API token")
+	case "write":
+		apiToken.Scope = models.APITokenScopeReadWrite
+	}
+	return models.User{ID: 1}, apiToken, nil
+}
@@ -0,0 +1,10 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type CalendarFeedService interface {
+	GetCalendarFeeds(userID int) ([]models.CalendarFeed, error)
+	CreateCalendarFeed(userID, leagueID int) (models.CalendarFeed, string, error)
+	RevokeCalendarFeed(userID, feedID int) error
+	Authenticate(token string) (models.User, models.CalendarFeed, error)
+}
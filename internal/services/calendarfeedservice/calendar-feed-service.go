@@ -0,0 +1,71 @@
+package calendarfeedservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type calendarFeedService struct {
+	CalendarFeedRepo repository.CalendarFeedRepo
+	UserRepo         repository.UserRepo
+}
+
+func NewCalendarFeedService(f repository.CalendarFeedRepo, u repository.UserRepo) services.CalendarFeedService {
+	return &calendarFeedService{
+		CalendarFeedRepo: f,
+		UserRepo:         u,
+	}
+}
+
+func (m *calendarFeedService) GetCalendarFeeds(userID int) ([]models.CalendarFeed, error) {
+	return m.CalendarFeedRepo.GetCalendarFeedsByUserID(userID)
+}
+
+// CreateCalendarFeed makes a new feed for one of the user's leagues, or all of
+// them when leagueID is 0, and returns it along with the token for its URL,
+// which can't be looked up again. It replaces the feed the user already had
+// for the same league
+func (m *calendarFeedService) CreateCalendarFeed(userID, leagueID int) (models.CalendarFeed, string, error) {
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return models.CalendarFeed{}, "", err
+	}
+
+	feed := models.CalendarFeed{
+		UserID:    userID,
+		LeagueID:  leagueID,
+		TokenHash: tokenHash,
+	}
+
+	feed.ID, err = m.CalendarFeedRepo.CreateCalendarFeed(feed)
+	if err != nil {
+		return models.CalendarFeed{}, "", err
+	}
+
+	return feed, token, nil
+}
+
+// RevokeCalendarFeed deletes one of the user's feeds, so its URL stops working
+func (m *calendarFeedService) RevokeCalendarFeed(userID, feedID int) error {
+	return m.CalendarFeedRepo.DeleteCalendarFeed(feedID, userID)
+}
+
+// Authenticate returns the feed a token is for along with its user, as long as
+// the user's account is active
+func (m *calendarFeedService) Authenticate(token string) (models.User, models.CalendarFeed, error) {
+	feed, err := m.CalendarFeedRepo.GetCalendarFeedByTokenHash(tokens.Hash(token))
+	if err != nil {
+		return models.User{}, models.CalendarFeed{}, errors.New("invalid calendar feed")
+	}
+
+	user, err := m.UserRepo.GetUserByID(feed.UserID)
+	if err != nil || user.IsDeactivated() {
+		return models.User{}, models.CalendarFeed{}, errors.New("invalid calendar feed")
+	}
+
+	return user, feed, nil
+}
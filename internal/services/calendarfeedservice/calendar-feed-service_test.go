@@ -0,0 +1,119 @@
+package calendarfeedservice
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+var getCalendarFeedsTests = []struct {
+	name          string
+	userID        int
+	expectedCount int
+	expectError   bool
+}{
+	{"valid", 1, 1, false},
+	{"repo error", 3, 0, true},
+}
+
+func TestGetCalendarFeeds(t *testing.T) {
+	for _, e := range getCalendarFeedsTests {
+		feeds, err := service.GetCalendarFeeds(e.userID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if len(feeds) != e.expectedCount {
+			t.Errorf("failed %s: expected %d feeds, but got %d", e.name, e.expectedCount, len(feeds))
+		}
+	}
+}
+
+var createCalendarFeedTests = []struct {
+	name        string
+	leagueID    int
+	expectError bool
+}{
+	{"all leagues", 0, false},
+	{"one league", 1, false},
+	{"repo error", 3, true},
+}
+
+func TestCreateCalendarFeed(t *testing.T) {
+	for _, e := range createCalendarFeedTests {
+		feed, token, err := service.CreateCalendarFeed(1, e.leagueID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if e.expectError {
+			continue
+		}
+		if token == "" || feed.TokenHash != tokens.Hash(token) {
+			t.Errorf("failed %s: expected the token's hash to be kept, but got %s", e.name, feed.TokenHash)
+		}
+		if feed.LeagueID != e.leagueID || feed.IsLeagueFeed() != (e.leagueID != 0) {
+			t.Errorf("failed %s: expected a feed for league %d, but got %d", e.name, e.leagueID, feed.LeagueID)
+		}
+	}
+}
+
+var revokeCalendarFeedTests = []struct {
+	name        string
+	feedID      int
+	expectError bool
+}{
+	{"valid", 1, false},
+	{"not found", 2, true},
+}
+
+func TestRevokeCalendarFeed(t *testing.T) {
+	for _, e := range revokeCalendarFeedTests {
+		err := service.RevokeCalendarFeed(1, e.feedID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var authenticateTests = []struct {
+	name             string
+	token            string
+	expectedLeagueID int
+	expectError      bool
+}{
+	{"valid", "valid", 0, false},
+	{"league feed", "league", 1, false},
+	{"repo error", "error", 0, true},
+	{"unknown token", "unknown", 0, true},
+	{"deactivated user", "deactivated", 0, true},
+	{"user error", "user error", 0, true},
+}
+
+func TestAuthenticate(t *testing.T) {
+	for _, e := range authenticateTests {
+		user, feed, err := service.Authenticate(e.token)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if e.expectError {
+			continue
+		}
+		if user.ID != feed.UserID {
+			t.Errorf("failed %s: expected the feed's user %d, but got %d", e.name, feed.UserID, user.ID)
+		}
+		if feed.LeagueID != e.expectedLeagueID {
+			t.Errorf("failed %s: expected league %d, but got %d", e.name, e.expectedLeagueID, feed.LeagueID)
+		}
+	}
+}
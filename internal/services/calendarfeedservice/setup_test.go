@@ -0,0 +1,20 @@
+package calendarfeedservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/calendarfeedrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.CalendarFeedService
+
+func TestMain(m *testing.M) {
+	calendarFeedRepo := calendarfeedrepo.NewTestCalendarFeedRepo()
+	userRepo := userrepo.NewTestUserRepo()
+	service = NewCalendarFeedService(calendarFeedRepo, userRepo)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,66 @@
+package calendarfeedservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testCalendarFeedService struct {
+	CalendarFeedRepo repository.CalendarFeedRepo
+}
+
+func NewTestCalendarFeedService(f repository.CalendarFeedRepo) services.CalendarFeedService {
+	return &testCalendarFeedService{CalendarFeedRepo: f}
+}
+
+func (m *testCalendarFeedService) GetCalendarFeeds(userID int) ([]models.CalendarFeed, error) {
+	if userID == 3 {
+		return nil, errors.New("some error")
+	}
+	return []models.CalendarFeed{
+		{ID: 1, UserID: userID},
+		{ID: 2, UserID: userID, LeagueID: 1, League: models.League{ID: 1, Name: "Test League"}},
+	}, nil
+}
+
+func (m *testCalendarFeedService) CreateCalendarFeed(userID, leagueID int) (models.CalendarFeed, string, error) {
+	if leagueID == 5 {
+		return models.CalendarFeed{}, "", errors.New("some error")
+	}
+	return models.CalendarFeed{ID: 1, UserID: userID, LeagueID: leagueID}, "token", nil
+}
+
+func (m *testCalendarFeedService) RevokeCalendarFeed(userID, feedID int) error {
+	if feedID == 2 {
+		return errors.New("calendar feed not found")
+	}
+	return nil
+}
+
+func (m *testCalendarFeedService) Authenticate(token string) (models.User, models.CalendarFeed, error) {
+	user := models.User{ID: 1, FirstName: "Test", LastName: "User"}
+	feed := models.CalendarFeed{ID: 1, UserID: 1}
+	switch token {
+	case "invalid":
+		return models.User{}, models.CalendarFeed{}, errors.New("invalid calendar feed")
+	case "leagues-error":
+		user.ID, feed.UserID = 2, 2
+	case "left-league":
+		user.ID, feed.UserID = 4, 4
+	case "not-a-member":
+		user.ID, feed.UserID = 4, 4
+		feed.LeagueID = 4
+	case "league-error":
+		feed.LeagueID = 3
+	case "seasons-error":
+		feed.LeagueID = 7
+	case "schedule-error":
+		feed.LeagueID = 8
+	case "league":
+		feed.LeagueID = 2
+	}
+	return user, feed, nil
+}
@@ -0,0 +1,12 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type CommissionerTransferService interface {
+	GetOpenTransfer(leagueID int) (models.CommissionerTransfer, error)
+	GetTransferByToken(token string) (models.CommissionerTransfer, error)
+	NominateCommissioner(leagueID, commissionerUserID, nomineePlayerID int) (models.CommissionerTransfer, string, error)
+	CancelTransfer(transfer models.CommissionerTransfer) error
+	AcceptTransfer(transfer models.CommissionerTransfer, userID int) error
+	ForceTransfer(leagueID, playerID int) error
+}
@@ -0,0 +1,233 @@
+package commissionertransferservice
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type commissionerTransferService struct {
+	CommissionerTransferRepo repository.CommissionerTransferRepo
+	PlayerRepo               repository.PlayerRepo
+	DBManager                repository.DBManager
+}
+
+func NewCommissionerTransferService(t repository.CommissionerTransferRepo, p repository.PlayerRepo, m repository.DBManager) services.CommissionerTransferService {
+	return &commissionerTransferService{
+		CommissionerTransferRepo: t,
+		PlayerRepo:               p,
+		DBManager:                m,
+	}
+}
+
+// GetOpenTransfer returns the league's transfer that hasn't been accepted or
+// cancelled, including an expired one. A transfer with no ID means there
+// isn't one
+func (m *commissionerTransferService) GetOpenTransfer(leagueID int) (models.CommissionerTransfer, error) {
+	transfer, err := m.CommissionerTransferRepo.GetOpenCommissionerTransferByLeagueID(leagueID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.CommissionerTransfer{}, nil
+	}
+
+	return transfer, err
+}
+
+// GetTransferByToken returns the transfer an emailed link is for, as long as
+// it can still be accepted
+func (m *commissionerTransferService) GetTransferByToken(token string) (models.CommissionerTransfer, error) {
+	transfer, err := m.CommissionerTransferRepo.GetCommissionerTransferByTokenHash(tokens.Hash(token))
+	if err != nil {
+		return transfer, err
+	}
+
+	if !transfer.IsPending(time.Now().UTC()) {
+		return models.CommissionerTransfer{}, errors.New("this link is no longer valid")
+	}
+
+	return transfer, nil
+}
+
+// activePlayerInLeague returns the player if they're still on the league's roster
+func (m *commissionerTransferService) activePlayerInLeague(playerID, leagueID int) (models.Player, error) {
+	player, err := m.PlayerRepo.GetPlayerByID(playerID)
+	if err != nil {
+		return player, err
+	}
+
+	if player.LeagueID != leagueID || !player.IsActive {
+		return player, errors.New("the new commissioner has to be an active player in the league")
+	}
+
+	return player, nil
+}
+
+// NominateCommissioner has the league's commissioner nominate another active
+// player to take over, and returns the transfer along with the token for the
+// nominee's link. Any earlier nomination is cancelled
+func (m *commissionerTransferService) NominateCommissioner(leagueID, commissionerUserID, nomineePlayerID int) (models.CommissionerTransfer, string, error) {
+	commissioner, err := m.PlayerRepo.GetPlayerByUserAndLeagueID(commissionerUserID, leagueID)
+	if err != nil {
+		return models.CommissionerTransfer{}, "", err
+	}
+	if !commissioner.IsCommissioner {
+		return models.CommissionerTransfer{}, "", errors.New("only the commissioner can hand over the league")
+	}
+
+	nominee, err := m.activePlayerInLeague(nomineePlayerID, leagueID)
+	if err != nil {
+		return models.CommissionerTransfer{}, "", err
+	}
+	if nominee.ID == commissioner.ID {
+		return models.CommissionerTransfer{}, "", errors.New("you're already the commissioner")
+	}
+
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return models.CommissionerTransfer{}, "", err
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return models.CommissionerTransfer{}, "", err
+	}
+
+	err = m.CommissionerTransferRepo.CancelOpenCommissionerTransfersTransaction(leagueID, ctx, tx)
+	if err != nil {
+		return models.CommissionerTransfer{}, "", err
+	}
+
+	_, err = m.CommissionerTransferRepo.CreateCommissionerTransferTransaction(models.CommissionerTransfer{
+		LeagueID:     leagueID,
+		FromPlayerID: commissioner.ID,
+		ToPlayerID:   nominee.ID,
+		TokenHash:    tokenHash,
+		ExpiresAt:    time.Now().UTC().Add(models.CommissionerTransferLifetime),
+	}, ctx, tx)
+	if err != nil {
+		return models.CommissionerTransfer{}, "", err
+	}
+
+	err = m.DBManager.CommitTransaction(tx)
+	if err != nil {
+		return models.CommissionerTransfer{}, "", err
+	}
+
+	transfer, err := m.CommissionerTransferRepo.GetCommissionerTransferByTokenHash(tokenHash)
+	if err != nil {
+		return transfer, "", err
+	}
+
+	return transfer, token, nil
+}
+
+// CancelTransfer stops a nominee from accepting. The commissioner keeps the league
+func (m *commissionerTransferService) CancelTransfer(transfer models.CommissionerTransfer) error {
+	if !transfer.AcceptedAt.IsZero() {
+		return errors.New("this transfer has already been accepted")
+	}
+	if !transfer.CancelledAt.IsZero() {
+		return errors.New("this transfer has already been cancelled")
+	}
+
+	return m.CommissionerTransferRepo.CancelCommissionerTransfer(transfer.ID)
+}
+
+// AcceptTransfer makes the nominee the commissioner and the old commissioner
+// a player, all in one transaction. Only the nominee can accept
+func (m *commissionerTransferService) AcceptTransfer(transfer models.CommissionerTransfer, userID int) error {
+	if !transfer.IsPending(time.Now().UTC()) {
+		return errors.New("this link is no longer valid")
+	}
+
+	nominee, err := m.activePlayerInLeague(transfer.ToPlayerID, transfer.LeagueID)
+	if err != nil {
+		return err
+	}
+	if nominee.UserID != userID {
+		return errors.New("this nomination is for someone else")
+	}
+
+	commissioner, err := m.PlayerRepo.GetPlayerByID(transfer.FromPlayerID)
+	if err != nil {
+		return err
+	}
+	if !commissioner.IsCommissioner {
+		return errors.New("the league has a different commissioner now")
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	commissioner.IsCommissioner = false
+	err = m.PlayerRepo.UpdatePlayerTransaction(commissioner, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	nominee.IsCommissioner = true
+	err = m.PlayerRepo.UpdatePlayerTransaction(nominee, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = m.CommissionerTransferRepo.AcceptCommissionerTransferTransaction(transfer.ID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
+
+// ForceTransfer makes an active player the league's commissioner without
+// anyone accepting, for super admins taking over abandoned leagues. Whoever
+// was commissioner becomes a player and open nominations are cancelled
+func (m *commissionerTransferService) ForceTransfer(leagueID, playerID int) error {
+	nominee, err := m.activePlayerInLeague(playerID, leagueID)
+	if err != nil {
+		return err
+	}
+
+	players, err := m.PlayerRepo.GetPlayersByLeagueID(leagueID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	for _, p := range players {
+		if !p.IsCommissioner || p.ID == nominee.ID {
+			continue
+		}
+		p.IsCommissioner = false
+		err = m.PlayerRepo.UpdatePlayerTransaction(p, ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	nominee.IsCommissioner = true
+	err = m.PlayerRepo.UpdatePlayerTransaction(nominee, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = m.CommissionerTransferRepo.CancelOpenCommissionerTransfersTransaction(leagueID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
@@ -0,0 +1,178 @@
+package commissionertransferservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var getOpenTransferTests = []struct {
+	name        string
+	leagueID    int
+	expectedID  int
+	expectError bool
+}{
+	{"open transfer", 1, 1, false},
+	{"no open transfer", 4, 0, false},
+	{"repo error", 3, 0, true},
+}
+
+func TestGetOpenTransfer(t *testing.T) {
+	for _, e := range getOpenTransferTests {
+		transfer, err := service.GetOpenTransfer(e.leagueID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if transfer.ID != e.expectedID {
+			t.Errorf("failed %s: expected transfer %d, but got %d", e.name, e.expectedID, transfer.ID)
+		}
+	}
+}
+
+var getTransferByTokenTests = []struct {
+	name        string
+	token       string
+	expectError bool
+}{
+	{"pending", "valid", false},
+	{"expired", "expired", true},
+	{"accepted", "accepted", true},
+	{"cancelled", "cancelled", true},
+	{"not found", "error", true},
+}
+
+func TestGetTransferByToken(t *testing.T) {
+	for _, e := range getTransferByTokenTests {
+		_, err := service.GetTransferByToken(e.token)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var nominateCommissionerTests = []struct {
+	name               string
+	leagueID           int
+	commissionerUserID int
+	nomineePlayerID    int
+	expectError        bool
+}{
+	{"valid", 1, 1, 20, false},
+	{"commissioner not found", 1, 0, 20, true},
+	{"not commissioner", 1, 3, 20, true},
+	{"nominee not found", 1, 1, 9, true},
+	{"nominee inactive", 1, 1, 22, true},
+	{"nominee in another league", 2, 1, 20, true},
+	{"creating fails", 1, 1, 25, true},
+}
+
+func TestNominateCommissioner(t *testing.T) {
+	for _, e := range nominateCommissionerTests {
+		transfer, token, err := service.NominateCommissioner(e.leagueID, e.commissionerUserID, e.nomineePlayerID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if err == nil && (token == "" || transfer.ID == 0) {
+			t.Errorf("failed %s: expected a transfer and token", e.name)
+		}
+	}
+}
+
+var cancelTransferTests = []struct {
+	name        string
+	transfer    models.CommissionerTransfer
+	expectError bool
+}{
+	{"open", models.CommissionerTransfer{ID: 1}, false},
+	{"accepted", models.CommissionerTransfer{ID: 1, AcceptedAt: time.Now()}, true},
+	{"cancelled", models.CommissionerTransfer{ID: 1, CancelledAt: time.Now()}, true},
+	{"update fails", models.CommissionerTransfer{ID: 5}, true},
+}
+
+func TestCancelTransfer(t *testing.T) {
+	for _, e := range cancelTransferTests {
+		err := service.CancelTransfer(e.transfer)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+// pendingTransfer returns the transfer for token as the test repo has it
+func pendingTransfer(t *testing.T, token string) models.CommissionerTransfer {
+	transfer, err := service.GetTransferByToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transfer
+}
+
+var acceptTransferTests = []struct {
+	name        string
+	token       string
+	userID      int
+	expectError bool
+}{
+	{"valid", "valid", 20, false},
+	{"someone else", "valid", 24, true},
+	{"nominee inactive", "inactive", 22, true},
+	{"no longer commissioner", "not commissioner", 24, true},
+	{"updating players fails", "update error", 23, true},
+	{"accepting fails", "accept error", 20, true},
+}
+
+func TestAcceptTransfer(t *testing.T) {
+	for _, e := range acceptTransferTests {
+		err := service.AcceptTransfer(pendingTransfer(t, e.token), e.userID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+
+	expired := pendingTransfer(t, "valid")
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	if err := service.AcceptTransfer(expired, 20); err == nil {
+		t.Error("failed expired: expected error, but didn't get one")
+	}
+}
+
+var forceTransferTests = []struct {
+	name        string
+	leagueID    int
+	playerID    int
+	expectError bool
+}{
+	{"valid", 1, 20, false},
+	{"player not found", 1, 9, true},
+	{"player inactive", 1, 22, true},
+	{"player in another league", 2, 20, true},
+	{"updating player fails", 1, 23, true},
+}
+
+func TestForceTransfer(t *testing.T) {
+	for _, e := range forceTransferTests {
+		err := service.ForceTransfer(e.leagueID, e.playerID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package commissionertransferservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/commissionertransferrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/playerrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.CommissionerTransferService
+
+func TestMain(m *testing.M) {
+	commissionerTransferRepo := commissionertransferrepo.NewTestCommissionerTransferRepo()
+	playerRepo := playerrepo.NewTestPlayerRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewCommissionerTransferService(commissionerTransferRepo, playerRepo, dbManager)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,80 @@
+package commissionertransferservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testCommissionerTransferService struct {
+	CommissionerTransferRepo repository.CommissionerTransferRepo
+}
+
+func NewTestCommissionerTransferService(t repository.CommissionerTransferRepo) services.CommissionerTransferService {
+	return &testCommissionerTransferService{CommissionerTransferRepo: t}
+}
+
+func testTransfer(leagueID int) models.CommissionerTransfer {
+	return models.CommissionerTransfer{
+		ID:           1,
+		LeagueID:     leagueID,
+		FromPlayerID: 21,
+		ToPlayerID:   20,
+		ExpiresAt:    time.Now().Add(models.CommissionerTransferLifetime),
+		League:       models.League{ID: leagueID, Name: "League"},
+		FromUser:     models.User{ID: 21, FirstName: "Old", LastName: "Commissioner", Email: "old@commissioner.com"},
+		ToUser:       models.User{ID: 20, FirstName: "New", LastName: "Commissioner", Email: "new@commissioner.com"},
+	}
+}
+
+func (m *testCommissionerTransferService) GetOpenTransfer(leagueID int) (models.CommissionerTransfer, error) {
+	if leagueID == 5 {
+		return models.CommissionerTransfer{}, errors.New("some error")
+	}
+	if leagueID == 1 || leagueID == 6 {
+		return testTransfer(leagueID), nil
+	}
+	return models.CommissionerTransfer{}, nil
+}
+
+func (m *testCommissionerTransferService) GetTransferByToken(token string) (models.CommissionerTransfer, error) {
+	if token == "expired" {
+		return models.CommissionerTransfer{}, errors.New("this link is no longer valid")
+	}
+	t := testTransfer(1)
+	if token == "accepterror" {
+		t.ID = 7
+	}
+	return t, nil
+}
+
+func (m *testCommissionerTransferService) NominateCommissioner(leagueID, commissionerUserID, nomineePlayerID int) (models.CommissionerTransfer, string, error) {
+	if nomineePlayerID == 9 {
+		return models.CommissionerTransfer{}, "", errors.New("the new commissioner has to be an active player in the league")
+	}
+	return testTransfer(leagueID), "token", nil
+}
+
+func (m *testCommissionerTransferService) CancelTransfer(transfer models.CommissionerTransfer) error {
+	if transfer.LeagueID == 6 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testCommissionerTransferService) AcceptTransfer(transfer models.CommissionerTransfer, userID int) error {
+	if transfer.ID == 7 || userID != transfer.ToPlayerID {
+		return errors.New("accept error")
+	}
+	return nil
+}
+
+func (m *testCommissionerTransferService) ForceTransfer(leagueID, playerID int) error {
+	if playerID == 9 {
+		return errors.New("the new commissioner has to be an active player in the league")
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type CourseService interface {
+	GetCourses() ([]models.Course, error)
+	GetCourse(ID int) (models.Course, error)
+	GetCourseByName(name string) (models.Course, error)
+	CreateCourse(course models.Course) (int, error)
+	GetTeeSet(ID int) (models.TeeSet, error)
+	CreateTeeSet(teeSet models.TeeSet) (int, error)
+}
@@ -0,0 +1,118 @@
+package courseservice
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type courseService struct {
+	CourseRepo repository.CourseRepo
+	DBManager  repository.DBManager
+}
+
+func NewCourseService(c repository.CourseRepo, m repository.DBManager) services.CourseService {
+	return &courseService{
+		CourseRepo: c,
+		DBManager:  m,
+	}
+}
+
+func (m *courseService) GetCourses() ([]models.Course, error) {
+	return m.CourseRepo.GetAllCourses()
+}
+
+// GetCourse returns a course with all of its tee sets and holes
+func (m *courseService) GetCourse(ID int) (models.Course, error) {
+	course, err := m.CourseRepo.GetCourseByID(ID)
+	if err != nil {
+		return course, err
+	}
+
+	course.TeeSets, err = m.CourseRepo.GetTeeSetsByCourseID(course.ID)
+	if err != nil {
+		return course, err
+	}
+
+	return course, nil
+}
+
+func (m *courseService) GetCourseByName(name string) (models.Course, error) {
+	return m.CourseRepo.GetCourseByName(name)
+}
+
+func (m *courseService) CreateCourse(course models.Course) (int, error) {
+	if course.NumberOfHoles != 9 && course.NumberOfHoles != 18 {
+		return 0, errors.New("a course must have 9 or 18 holes")
+	}
+	return m.CourseRepo.CreateCourse(course)
+}
+
+func (m *courseService) GetTeeSet(ID int) (models.TeeSet, error) {
+	return m.CourseRepo.GetTeeSetByID(ID)
+}
+
+// CreateTeeSet creates a tee set and its holes in a single transaction
+func (m *courseService) CreateTeeSet(teeSet models.TeeSet) (int, error) {
+	course, err := m.CourseRepo.GetCourseByID(teeSet.CourseID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = validateHoles(teeSet.Holes, course.NumberOfHoles)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	teeSetID, err := m.CourseRepo.CreateTeeSetTransaction(teeSet, ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, hole := range teeSet.Holes {
+		hole.TeeSetID = teeSetID
+		err = m.CourseRepo.CreateHoleTransaction(hole, ctx, tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = m.DBManager.CommitTransaction(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return teeSetID, nil
+}
+
+// validateHoles checks that there is one hole per number and that the stroke
+// indexes rank every hole exactly once
+func validateHoles(holes []models.Hole, numberOfHoles int) error {
+	if len(holes) != numberOfHoles {
+		return fmt.Errorf("tee set must have %d holes", numberOfHoles)
+	}
+
+	numbers := make(map[int]bool)
+	strokeIndexes := make(map[int]bool)
+	for _, h := range holes {
+		if h.Number < 1 || h.Number > numberOfHoles || numbers[h.Number] {
+			return fmt.Errorf("invalid hole number %d", h.Number)
+		}
+		if h.StrokeIndex < 1 || h.StrokeIndex > numberOfHoles || strokeIndexes[h.StrokeIndex] {
+			return fmt.Errorf("stroke index %d is invalid or used more than once", h.StrokeIndex)
+		}
+		numbers[h.Number] = true
+		strokeIndexes[h.StrokeIndex] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package courseservice
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestGetCourses(t *testing.T) {
+	service.GetCourses()
+}
+
+func TestGetCourseByName(t *testing.T) {
+	service.GetCourseByName("name")
+}
+
+func TestGetTeeSet(t *testing.T) {
+	service.GetTeeSet(1)
+}
+
+func TestGetCourse(t *testing.T) {
+	_, err := service.GetCourse(1)
+	if err != nil {
+		t.Error("failed success: expected no error but got one")
+	}
+	_, err = service.GetCourse(2)
+	if err == nil {
+		t.Error("failed tee set error: expected error but got none")
+	}
+	_, err = service.GetCourse(3)
+	if err == nil {
+		t.Error("failed course error: expected error but got none")
+	}
+}
+
+var createCourseTests = []struct {
+	name        string
+	course      models.Course
+	expectError bool
+}{
+	{
+		"invalid number of holes",
+		models.Course{Name: "course2", NumberOfHoles: 12},
+		true,
+	},
+	{
+		"db error",
+		models.Course{Name: "course1", NumberOfHoles: 18},
+		true,
+	},
+	{
+		"success",
+		models.Course{Name: "course2", NumberOfHoles: 9},
+		false,
+	},
+}
+
+func TestCreateCourse(t *testing.T) {
+	for _, e := range createCourseTests {
+		_, err := service.CreateCourse(e.course)
+		if e.expectError && err == nil {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if !e.expectError && err != nil {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+	}
+}
+
+// nineHoles returns a valid set of holes for the 9 hole test course
+func nineHoles() []models.Hole {
+	var holes []models.Hole
+	for i := 1; i <= 9; i++ {
+		holes = append(holes, models.Hole{Number: i, Par: 4, Yardage: 400, StrokeIndex: 10 - i})
+	}
+	return holes
+}
+
+var createTeeSetTests = []struct {
+	name             string
+	teeSet           func() models.TeeSet
+	expectedTeeSetID int
+	expectError      bool
+}{
+	{
+		"course not found",
+		func() models.TeeSet {
+			return models.TeeSet{CourseID: 3, Holes: nineHoles()}
+		},
+		0,
+		true,
+	},
+	{
+		"wrong number of holes",
+		func() models.TeeSet {
+			return models.TeeSet{CourseID: 1, Holes: nineHoles()[:8]}
+		},
+		0,
+		true,
+	},
+	{
+		"duplicate stroke index",
+		func() models.TeeSet {
+			holes := nineHoles()
+			holes[0].StrokeIndex = holes[1].StrokeIndex
+			return models.TeeSet{CourseID: 1, Holes: holes}
+		},
+		0,
+		true,
+	},
+	{
+		"duplicate hole number",
+		func() models.TeeSet {
+			holes := nineHoles()
+			holes[0].Number = 2
+			return models.TeeSet{CourseID: 1, Holes: holes}
+		},
+		0,
+		true,
+	},
+	{
+		"tee set error",
+		func() models.TeeSet {
+			return models.TeeSet{CourseID: 1, Name: "Tee Set Error", Holes: nineHoles()}
+		},
+		0,
+		true,
+	},
+	{
+		"hole error",
+		func() models.TeeSet {
+			holes := nineHoles()
+			holes[4].Yardage = 999
+			return models.TeeSet{CourseID: 1, Holes: holes}
+		},
+		0,
+		true,
+	},
+	{
+		"success",
+		func() models.TeeSet {
+			return models.TeeSet{CourseID: 1, Name: "Blue", Holes: nineHoles()}
+		},
+		1,
+		false,
+	},
+}
+
+func TestCreateTeeSet(t *testing.T) {
+	for _, e := range createTeeSetTests {
+		teeSetID, err := service.CreateTeeSet(e.teeSet())
+		if teeSetID != e.expectedTeeSetID {
+			t.Errorf("failed %s: expected tee set ID %d, but got %d", e.name, e.expectedTeeSetID, teeSetID)
+		}
+		if e.expectError && err == nil {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if !e.expectError && err != nil {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+	}
+}
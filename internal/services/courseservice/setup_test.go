@@ -0,0 +1,20 @@
+package courseservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/courserepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.CourseService
+
+func TestMain(m *testing.M) {
+	courseRepo := courserepo.NewTestCourseRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewCourseService(courseRepo, dbManager)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,64 @@
+package courseservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testCourseService struct {
+	CourseRepo repository.CourseRepo
+}
+
+func NewTestCourseService(c repository.CourseRepo) services.CourseService {
+	return &testCourseService{CourseRepo: c}
+}
+
+func (m *testCourseService) GetCourses() ([]models.Course, error) {
+	var c []models.Course
+	return c, nil
+}
+
+func (m *testCourseService) GetCourse(ID int) (models.Course, error) {
+	var c models.Course
+	if ID == 3 {
+		return c, errors.New("course doesn't exist")
+	}
+	c.ID = ID
+	c.NumberOfHoles = 9
+	return c, nil
+}
+
+func (m *testCourseService) GetCourseByName(name string) (models.Course, error) {
+	var c models.Course
+	if name == "course0" {
+		return c, nil
+	}
+	return c, errors.New("course name not found in DB")
+}
+
+func (m *testCourseService) CreateCourse(course models.Course) (int, error) {
+	if course.Name == "course1" {
+		return 0, errors.New("error inserting course in DB")
+	}
+	return 1, nil
+}
+
+func (m *testCourseService) GetTeeSet(ID int) (models.TeeSet, error) {
+	var t models.TeeSet
+	if ID == 3 {
+		return t, errors.New("tee set doesn't exist")
+	}
+	t.ID = ID
+	t.CourseID = 1
+	return t, nil
+}
+
+func (m *testCourseService) CreateTeeSet(teeSet models.TeeSet) (int, error) {
+	if teeSet.Name == "Tee Set Error" {
+		return 0, errors.New("error inserting tee set in DB")
+	}
+	return 1, nil
+}
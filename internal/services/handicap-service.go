@@ -0,0 +1,9 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type HandicapService interface {
+	GetHandicapRecord(userID int) (models.HandicapRecord, error)
+	GetHandicapRecords(players []models.Player) (map[int]models.HandicapRecord, error)
+	GetCourseHandicap(userID int, teeSet models.TeeSet) (int, bool, error)
+}
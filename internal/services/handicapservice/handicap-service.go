@@ -0,0 +1,74 @@
+package handicapservice
+
+import (
+	"github.com/jdonahue135/golf-league-app/internal/handicap"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type handicapService struct {
+	ScoreRepo  repository.ScoreRepo
+	CourseRepo repository.CourseRepo
+}
+
+func NewHandicapService(s repository.ScoreRepo, c repository.CourseRepo) services.HandicapService {
+	return &handicapService{
+		ScoreRepo:  s,
+		CourseRepo: c,
+	}
+}
+
+// GetHandicapRecord calculates a user's handicap index from every round they
+// have posted, across all of their leagues
+func (m *handicapService) GetHandicapRecord(userID int) (models.HandicapRecord, error) {
+	var record models.HandicapRecord
+
+	rounds, err := m.ScoreRepo.GetRoundsByUserID(userID)
+	if err != nil {
+		return record, err
+	}
+
+	// rounds only carry the tee set rating and slope, the holes are needed
+	// for par and stroke indexes
+	teeSets := make(map[int]models.TeeSet)
+	for i, round := range rounds {
+		teeSet, ok := teeSets[round.TeeSetID]
+		if !ok {
+			teeSet, err = m.CourseRepo.GetTeeSetByID(round.TeeSetID)
+			if err != nil {
+				return record, err
+			}
+			teeSets[round.TeeSetID] = teeSet
+		}
+		rounds[i].TeeSet.Holes = teeSet.Holes
+	}
+
+	return handicap.Calculate(rounds), nil
+}
+
+// GetHandicapRecords returns the handicap record of each player keyed by player ID
+func (m *handicapService) GetHandicapRecords(players []models.Player) (map[int]models.HandicapRecord, error) {
+	records := make(map[int]models.HandicapRecord)
+
+	for _, p := range players {
+		record, err := m.GetHandicapRecord(p.UserID)
+		if err != nil {
+			return records, err
+		}
+		records[p.ID] = record
+	}
+
+	return records, nil
+}
+
+// GetCourseHandicap returns the strokes a user receives from a tee set and
+// whether the user has a handicap index to calculate them from
+func (m *handicapService) GetCourseHandicap(userID int, teeSet models.TeeSet) (int, bool, error) {
+	record, err := m.GetHandicapRecord(userID)
+	if err != nil || !record.HasIndex {
+		return 0, false, err
+	}
+
+	return handicap.CourseHandicap(record.Index, teeSet), true, nil
+}
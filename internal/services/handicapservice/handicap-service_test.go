@@ -0,0 +1,72 @@
+package handicapservice
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var getHandicapRecordTests = []struct {
+	name                  string
+	userID                int
+	expectedDifferentials int
+	expectError           bool
+}{
+	{
+		"rounds error",
+		0,
+		0,
+		true,
+	},
+	{
+		"nine hole rounds combined",
+		1,
+		1,
+		false,
+	},
+}
+
+func TestGetHandicapRecord(t *testing.T) {
+	for _, e := range getHandicapRecordTests {
+		record, err := service.GetHandicapRecord(e.userID)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if len(record.Differentials) != e.expectedDifferentials {
+			t.Errorf("failed %s: expected %d differentials, but got %d", e.name, e.expectedDifferentials, len(record.Differentials))
+		}
+	}
+}
+
+func TestGetHandicapRecords(t *testing.T) {
+	records, err := service.GetHandicapRecords([]models.Player{{ID: 7, UserID: 1}})
+	if err != nil {
+		t.Error("failed success: expected no error but got one")
+	}
+	if _, ok := records[7]; !ok {
+		t.Error("failed success: expected record keyed by player ID")
+	}
+
+	_, err = service.GetHandicapRecords([]models.Player{{ID: 7, UserID: 0}})
+	if err == nil {
+		t.Error("failed rounds error: expected error but got none")
+	}
+}
+
+func TestGetCourseHandicap(t *testing.T) {
+	_, hasIndex, err := service.GetCourseHandicap(1, models.TeeSet{})
+	if err != nil {
+		t.Error("failed no index: expected no error but got one")
+	}
+	if hasIndex {
+		t.Error("failed no index: expected no index but got one")
+	}
+
+	_, _, err = service.GetCourseHandicap(0, models.TeeSet{})
+	if err == nil {
+		t.Error("failed rounds error: expected error but got none")
+	}
+}
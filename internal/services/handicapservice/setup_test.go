@@ -0,0 +1,20 @@
+package handicapservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/courserepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/scorerepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.HandicapService
+
+func TestMain(m *testing.M) {
+	scoreRepo := scorerepo.NewTestScoreRepo()
+	courseRepo := courserepo.NewTestCourseRepo()
+	service = NewHandicapService(scoreRepo, courseRepo)
+
+	os.Exit(m.Run())
+}
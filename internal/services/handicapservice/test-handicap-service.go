@@ -0,0 +1,54 @@
+package handicapservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testHandicapService struct {
+	ScoreRepo repository.ScoreRepo
+}
+
+func NewTestHandicapService(s repository.ScoreRepo) services.HandicapService {
+	return &testHandicapService{ScoreRepo: s}
+}
+
+func (m *testHandicapService) GetHandicapRecord(userID int) (models.HandicapRecord, error) {
+	var r models.HandicapRecord
+	if userID == 0 {
+		return r, errors.New("handicap error")
+	}
+	r.Index = 12.4
+	r.HasIndex = true
+	r.Differentials = []models.Differential{
+		{RoundID: 1, CourseName: "course0", TeeName: "White", AdjustedGrossScore: 85, CourseRating: 72.0, Slope: 113, ScoreDifferential: 13.0, Counted: true},
+		{RoundID: 2, CourseName: "course0", TeeName: "White", AdjustedGrossScore: 90, CourseRating: 72.0, Slope: 113, ScoreDifferential: 18.0},
+		{RoundID: 3, CourseName: "course0", TeeName: "White", AdjustedGrossScore: 95, CourseRating: 72.0, Slope: 113, ScoreDifferential: 23.0},
+	}
+	return r, nil
+}
+
+func (m *testHandicapService) GetHandicapRecords(players []models.Player) (map[int]models.HandicapRecord, error) {
+	records := make(map[int]models.HandicapRecord)
+	for _, p := range players {
+		record, err := m.GetHandicapRecord(p.UserID)
+		if err != nil {
+			return records, err
+		}
+		records[p.ID] = record
+	}
+	return records, nil
+}
+
+func (m *testHandicapService) GetCourseHandicap(userID int, teeSet models.TeeSet) (int, bool, error) {
+	if userID == 0 {
+		return 0, false, errors.New("handicap error")
+	}
+	if userID == 3 {
+		return 0, false, nil
+	}
+	return 12, true, nil
+}
@@ -0,0 +1,12 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type InvitationService interface {
+	GetInvitation(ID int) (models.Invitation, error)
+	GetInvitationByToken(token string) (models.Invitation, error)
+	GetOpenInvitationsInLeague(leagueID int) ([]models.Invitation, error)
+	ResendInvitation(invitation models.Invitation) (string, error)
+	RevokeInvitation(invitation models.Invitation) error
+	ClaimInvitation(invitation models.Invitation, user models.User, password string) error
+}
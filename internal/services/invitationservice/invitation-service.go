@@ -0,0 +1,124 @@
+package invitationservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+)
+
+type invitationService struct {
+	InvitationRepo repository.InvitationRepo
+	UserRepo       repository.UserRepo
+	DBManager      repository.DBManager
+}
+
+func NewInvitationService(i repository.InvitationRepo, u repository.UserRepo, m repository.DBManager) services.InvitationService {
+	return &invitationService{
+		InvitationRepo: i,
+		UserRepo:       u,
+		DBManager:      m,
+	}
+}
+
+func (m *invitationService) GetInvitation(ID int) (models.Invitation, error) {
+	return m.InvitationRepo.GetInvitationByID(ID)
+}
+
+// GetInvitationByToken returns the invitation an emailed link is for, as long
+// as it can still be claimed
+func (m *invitationService) GetInvitationByToken(token string) (models.Invitation, error) {
+	invitation, err := m.InvitationRepo.GetInvitationByTokenHash(tokens.Hash(token))
+	if err != nil {
+		return invitation, err
+	}
+
+	if !invitation.IsPending(time.Now().UTC()) {
+		return models.Invitation{}, errors.New("this invitation is no longer valid")
+	}
+
+	return invitation, nil
+}
+
+// GetOpenInvitationsInLeague returns the league's invitations that haven't
+// been claimed or revoked, including expired ones that can be resent
+func (m *invitationService) GetOpenInvitationsInLeague(leagueID int) ([]models.Invitation, error) {
+	return m.InvitationRepo.GetOpenInvitationsByLeagueID(leagueID)
+}
+
+// ResendInvitation gives an open invitation a new link that's good for another
+// InvitationLifetime, and returns the link's token. Links in earlier emails
+// stop working
+func (m *invitationService) ResendInvitation(invitation models.Invitation) (string, error) {
+	if !invitation.AcceptedAt.IsZero() {
+		return "", errors.New("this invitation has already been claimed")
+	}
+	if !invitation.RevokedAt.IsZero() {
+		return "", errors.New("this invitation has been revoked")
+	}
+
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	err = m.InvitationRepo.RenewInvitation(invitation.ID, tokenHash, time.Now().UTC().Add(models.InvitationLifetime))
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// RevokeInvitation stops an open invitation from being claimed. The player
+// stays in the league
+func (m *invitationService) RevokeInvitation(invitation models.Invitation) error {
+	if !invitation.AcceptedAt.IsZero() {
+		return errors.New("this invitation has already been claimed")
+	}
+	if !invitation.RevokedAt.IsZero() {
+		return errors.New("this invitation has already been revoked")
+	}
+
+	return m.InvitationRepo.RevokeInvitation(invitation.ID)
+}
+
+// ClaimInvitation sets the name and password of the invited user so they can
+// log in, and closes the invitation. A user who already has a password has to
+// log in instead
+func (m *invitationService) ClaimInvitation(invitation models.Invitation, user models.User, password string) error {
+	if !invitation.IsPending(time.Now().UTC()) {
+		return errors.New("this invitation is no longer valid")
+	}
+
+	existing, err := m.UserRepo.GetUserByID(invitation.UserID)
+	if err != nil {
+		return err
+	}
+	if existing.IsClaimed() {
+		return errors.New("this account has already been claimed")
+	}
+
+	user.ID = invitation.UserID
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	err = m.UserRepo.ActivateUserTransaction(user, password, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = m.InvitationRepo.AcceptInvitationTransaction(invitation.ID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
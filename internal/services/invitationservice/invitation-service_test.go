@@ -0,0 +1,108 @@
+package invitationservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestGetInvitation(t *testing.T) {
+	service.GetInvitation(1)
+}
+
+func TestGetOpenInvitationsInLeague(t *testing.T) {
+	service.GetOpenInvitationsInLeague(1)
+}
+
+var getInvitationByTokenTests = []struct {
+	name        string
+	token       string
+	expectError bool
+}{
+	{"pending", "valid", false},
+	{"expired", "expired", true},
+	{"accepted", "accepted", true},
+	{"revoked", "revoked", true},
+	{"not found", "error", true},
+}
+
+func TestGetInvitationByToken(t *testing.T) {
+	for _, e := range getInvitationByTokenTests {
+		_, err := service.GetInvitationByToken(e.token)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var pending = models.Invitation{ID: 1, UserID: 5, LeagueID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+
+var changeInvitationTests = []struct {
+	name        string
+	invitation  models.Invitation
+	expectError bool
+}{
+	{"pending", pending, false},
+	{"expired", models.Invitation{ID: 1, ExpiresAt: time.Now().Add(-time.Hour)}, false},
+	{"accepted", models.Invitation{ID: 1, AcceptedAt: time.Now()}, true},
+	{"revoked", models.Invitation{ID: 1, RevokedAt: time.Now()}, true},
+	{"update fails", models.Invitation{ID: 5, ExpiresAt: time.Now().Add(time.Hour)}, true},
+}
+
+func TestResendInvitation(t *testing.T) {
+	for _, e := range changeInvitationTests {
+		token, err := service.ResendInvitation(e.invitation)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if !e.expectError && token == "" {
+			t.Errorf("failed %s: expected a new token, but got none", e.name)
+		}
+	}
+}
+
+func TestRevokeInvitation(t *testing.T) {
+	for _, e := range changeInvitationTests {
+		err := service.RevokeInvitation(e.invitation)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var claimInvitationTests = []struct {
+	name        string
+	invitation  models.Invitation
+	password    string
+	expectError bool
+}{
+	{"pending", pending, "password", false},
+	{"expired", models.Invitation{ID: 1, ExpiresAt: time.Now().Add(-time.Hour)}, "password", true},
+	{"revoked", models.Invitation{ID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: time.Now()}, "password", true},
+	{"already claimed", models.Invitation{ID: 1, UserID: 55, ExpiresAt: time.Now().Add(time.Hour)}, "password", true},
+	{"user not found", models.Invitation{ID: 1, ExpiresAt: time.Now().Add(time.Hour)}, "password", true},
+	{"user update fails", pending, "error", true},
+	{"invitation update fails", models.Invitation{ID: 7, UserID: 5, ExpiresAt: time.Now().Add(time.Hour)}, "password", true},
+}
+
+func TestClaimInvitation(t *testing.T) {
+	for _, e := range claimInvitationTests {
+		err := service.ClaimInvitation(e.invitation, models.User{FirstName: "New", LastName: "Player"}, e.password)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package invitationservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/invitationrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.InvitationService
+
+func TestMain(m *testing.M) {
+	invitationRepo := invitationrepo.NewTestInvitationRepo()
+	userRepo := userrepo.NewTestUserRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewInvitationService(invitationRepo, userRepo, dbManager)
+
+	os.Exit(m.Run())
+}
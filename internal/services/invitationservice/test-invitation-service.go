@@ -0,0 +1,76 @@
+package invitationservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testInvitationService struct {
+	InvitationRepo repository.InvitationRepo
+}
+
+func NewTestInvitationService(i repository.InvitationRepo) services.InvitationService {
+	return &testInvitationService{InvitationRepo: i}
+}
+
+func testInvitation(ID int) models.Invitation {
+	return models.Invitation{
+		ID:        ID,
+		UserID:    5,
+		LeagueID:  1,
+		ExpiresAt: time.Now().Add(models.InvitationLifetime),
+		User:      models.User{ID: 5, FirstName: "New", LastName: "Player", Email: "new@player.com"},
+		League:    models.League{ID: 1, Name: "League"},
+	}
+}
+
+func (m *testInvitationService) GetInvitation(ID int) (models.Invitation, error) {
+	if ID == 3 {
+		return models.Invitation{}, errors.New("invitation doesn't exist")
+	}
+	return testInvitation(ID), nil
+}
+
+func (m *testInvitationService) GetInvitationByToken(token string) (models.Invitation, error) {
+	if token == "expired" {
+		return models.Invitation{}, errors.New("this invitation is no longer valid")
+	}
+	invitation := testInvitation(1)
+	if token == "claimed" {
+		invitation.UserID = 55
+	}
+	return invitation, nil
+}
+
+func (m *testInvitationService) GetOpenInvitationsInLeague(leagueID int) ([]models.Invitation, error) {
+	var i []models.Invitation
+	if leagueID == 8 {
+		return i, errors.New("invitations error")
+	}
+	return append(i, testInvitation(1)), nil
+}
+
+func (m *testInvitationService) ResendInvitation(invitation models.Invitation) (string, error) {
+	if invitation.ID == 5 {
+		return "", errors.New("invitation update failed")
+	}
+	return "token", nil
+}
+
+func (m *testInvitationService) RevokeInvitation(invitation models.Invitation) error {
+	if invitation.ID == 5 {
+		return errors.New("invitation update failed")
+	}
+	return nil
+}
+
+func (m *testInvitationService) ClaimInvitation(invitation models.Invitation, user models.User, password string) error {
+	if password == "error" {
+		return errors.New("claim failed")
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type LeagueRoleService interface {
+	GetMembership(userID, leagueID int) (models.LeagueMembership, error)
+	GetLeagueAdmin(ID int) (models.LeagueAdmin, error)
+	GetLeagueAdmins(leagueID int) ([]models.LeagueAdmin, error)
+	GrantLeagueRole(leagueID int, email, role string) error
+	RevokeLeagueRole(admin models.LeagueAdmin) error
+}
@@ -6,7 +6,10 @@ type LeagueService interface {
 	GetLeague(ID int) (models.League, error)
 	GetLeagueByName(name string) (models.League, error)
 	GetLeaguesByUser(userID int) ([]models.League, error)
+	SearchLeagues(search string, page models.Page) ([]models.League, models.Page, error)
 	CreateLeagueWithCommissioner(league models.League, commissioner models.Player) (int, error)
 	AddExistingUserToLeague(userID, leagueID int) error
-	AddNewUserToLeague(user models.User, leagueID int) error
+	AddNewUserToLeague(user models.User, leagueID int) (string, error)
+	ImportRoster(leagueID int, rows []models.RosterRow) (models.RosterImport, error)
+	UpdateLeagueSettings(league models.League) error
 }
@@ -0,0 +1,102 @@
+package leagueroleservice
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type leagueRoleService struct {
+	PlayerRepo      repository.PlayerRepo
+	LeagueAdminRepo repository.LeagueAdminRepo
+	UserRepo        repository.UserRepo
+}
+
+func NewLeagueRoleService(p repository.PlayerRepo, a repository.LeagueAdminRepo, u repository.UserRepo) services.LeagueRoleService {
+	return &leagueRoleService{
+		PlayerRepo:      p,
+		LeagueAdminRepo: a,
+		UserRepo:        u,
+	}
+}
+
+// GetMembership returns the user's role in the league. The commissioner
+// outranks any role they've been given, and a role they've been given
+// outranks being on the roster. Players who have left or been removed from
+// the league aren't on its roster any more, so it's an error if the user has
+// no part in the league at all
+func (m *leagueRoleService) GetMembership(userID, leagueID int) (models.LeagueMembership, error) {
+	membership := models.LeagueMembership{LeagueID: leagueID, UserID: userID}
+
+	player, err := m.PlayerRepo.GetPlayerByUserAndLeagueID(userID, leagueID)
+	onRoster := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return membership, err
+	}
+
+	if onRoster && player.IsCommissioner {
+		membership.Role = models.LeagueRoleCommissioner
+		return membership, nil
+	}
+
+	admin, err := m.LeagueAdminRepo.GetLeagueAdmin(leagueID, userID)
+	if err == nil {
+		membership.Role = admin.Role
+		return membership, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return membership, err
+	}
+
+	if !onRoster || !player.IsActive {
+		return membership, errors.New("user not in league")
+	}
+
+	membership.Role = models.LeagueRolePlayer
+	return membership, nil
+}
+
+func (m *leagueRoleService) GetLeagueAdmin(ID int) (models.LeagueAdmin, error) {
+	return m.LeagueAdminRepo.GetLeagueAdminByID(ID)
+}
+
+func (m *leagueRoleService) GetLeagueAdmins(leagueID int) ([]models.LeagueAdmin, error) {
+	return m.LeagueAdminRepo.GetLeagueAdminsByLeagueID(leagueID)
+}
+
+// GrantLeagueRole gives the user with the email a role in the league,
+// replacing any role they already had. They need an account, but don't need
+// to be on the roster
+func (m *leagueRoleService) GrantLeagueRole(leagueID int, email, role string) error {
+	if !models.IsGrantableLeagueRole(role) {
+		return errors.New("that role can't be given out")
+	}
+
+	user, err := m.UserRepo.GetUserByEmail(email)
+	if err != nil {
+		return errors.New("no one with that email has an account")
+	}
+
+	player, err := m.PlayerRepo.GetPlayerByUserAndLeagueID(user.ID, leagueID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if err == nil && player.IsCommissioner {
+		return errors.New("the commissioner's role can't be changed")
+	}
+
+	return m.LeagueAdminRepo.SaveLeagueAdmin(models.LeagueAdmin{
+		LeagueID: leagueID,
+		UserID:   user.ID,
+		Role:     role,
+	})
+}
+
+// RevokeLeagueRole takes away a role the user was given. If they're on the
+// roster they go back to being a player
+func (m *leagueRoleService) RevokeLeagueRole(admin models.LeagueAdmin) error {
+	return m.LeagueAdminRepo.DeleteLeagueAdmin(admin.ID)
+}
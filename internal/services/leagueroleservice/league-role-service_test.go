@@ -0,0 +1,85 @@
+package leagueroleservice
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestGetLeagueAdmin(t *testing.T) {
+	service.GetLeagueAdmin(1)
+}
+
+func TestGetLeagueAdmins(t *testing.T) {
+	service.GetLeagueAdmins(1)
+}
+
+var getMembershipTests = []struct {
+	name         string
+	userID       int
+	expectedRole string
+	expectError  bool
+}{
+	{"commissioner", 1, models.LeagueRoleCommissioner, false},
+	{"player", 3, models.LeagueRolePlayer, false},
+	{"co-commissioner", 12, models.LeagueRoleCoCommissioner, false},
+	{"scorekeeper", 13, models.LeagueRoleScorekeeper, false},
+	{"read-only member", 14, models.LeagueRoleMember, false},
+	{"not in league", 15, "", true},
+	{"left league", 2, "", true},
+	{"player error", 0, "", true},
+	{"league admin error", 5, "", true},
+}
+
+func TestGetMembership(t *testing.T) {
+	for _, e := range getMembershipTests {
+		membership, err := service.GetMembership(e.userID, 1)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if err == nil && membership.Role != e.expectedRole {
+			t.Errorf("failed %s: expected role %s, but got %s", e.name, e.expectedRole, membership.Role)
+		}
+	}
+}
+
+var grantLeagueRoleTests = []struct {
+	name        string
+	email       string
+	role        string
+	expectError bool
+}{
+	{"valid", "player@here.ca", models.LeagueRoleCoCommissioner, false},
+	{"read-only member", "player@here.ca", models.LeagueRoleMember, false},
+	{"commissioner role", "player@here.ca", models.LeagueRoleCommissioner, true},
+	{"player role", "player@here.ca", models.LeagueRolePlayer, true},
+	{"unknown role", "player@here.ca", "owner", true},
+	{"no account", "me@here.ca", models.LeagueRoleScorekeeper, true},
+	{"commissioner", "commissioner@here.ca", models.LeagueRoleScorekeeper, true},
+	{"player error", "someone@here.ca", models.LeagueRoleScorekeeper, true},
+	{"save fails", "saveerror@here.ca", models.LeagueRoleScorekeeper, true},
+}
+
+func TestGrantLeagueRole(t *testing.T) {
+	for _, e := range grantLeagueRoleTests {
+		err := service.GrantLeagueRole(1, e.email, e.role)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+func TestRevokeLeagueRole(t *testing.T) {
+	if err := service.RevokeLeagueRole(models.LeagueAdmin{ID: 1}); err != nil {
+		t.Errorf("failed revoke: expected no error, but got %s", err.Error())
+	}
+	if err := service.RevokeLeagueRole(models.LeagueAdmin{ID: 2}); err == nil {
+		t.Error("failed revoke: expected error, but didn't get one")
+	}
+}
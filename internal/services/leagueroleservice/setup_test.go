@@ -0,0 +1,22 @@
+package leagueroleservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/leagueadminrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/playerrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.LeagueRoleService
+
+func TestMain(m *testing.M) {
+	playerRepo := playerrepo.NewTestPlayerRepo()
+	leagueAdminRepo := leagueadminrepo.NewTestLeagueAdminRepo()
+	userRepo := userrepo.NewTestUserRepo()
+	service = NewLeagueRoleService(playerRepo, leagueAdminRepo, userRepo)
+
+	os.Exit(m.Run())
+}
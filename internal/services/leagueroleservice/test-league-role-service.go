@@ -0,0 +1,72 @@
+package leagueroleservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testLeagueRoleService struct {
+	LeagueAdminRepo repository.LeagueAdminRepo
+}
+
+func NewTestLeagueRoleService(a repository.LeagueAdminRepo) services.LeagueRoleService {
+	return &testLeagueRoleService{LeagueAdminRepo: a}
+}
+
+func (m *testLeagueRoleService) GetMembership(userID, leagueID int) (models.LeagueMembership, error) {
+	membership := models.LeagueMembership{LeagueID: leagueID, UserID: userID}
+	if userID == 4 && (leagueID == 4 || leagueID == 8) {
+		return membership, errors.New("user not in league")
+	}
+	switch userID {
+	case 3:
+		membership.Role = models.LeagueRolePlayer
+	case 12:
+		membership.Role = models.LeagueRoleCoCommissioner
+	case 13:
+		membership.Role = models.LeagueRoleScorekeeper
+	case 14:
+		membership.Role = models.LeagueRoleMember
+	default:
+		membership.Role = models.LeagueRoleCommissioner
+	}
+	return membership, nil
+}
+
+func (m *testLeagueRoleService) GetLeagueAdmin(ID int) (models.LeagueAdmin, error) {
+	if ID == 3 {
+		return models.LeagueAdmin{}, errors.New("league admin doesn't exist")
+	}
+	return models.LeagueAdmin{
+		ID:       ID,
+		LeagueID: 1,
+		UserID:   12,
+		Role:     models.LeagueRoleCoCommissioner,
+		User:     models.User{ID: 12, FirstName: "Co", LastName: "Commissioner", Email: "co@commissioner.com"},
+	}, nil
+}
+
+func (m *testLeagueRoleService) GetLeagueAdmins(leagueID int) ([]models.LeagueAdmin, error) {
+	if leagueID == 2 {
+		return nil, errors.New("some error")
+	}
+	admin, _ := m.GetLeagueAdmin(1)
+	return []models.LeagueAdmin{admin}, nil
+}
+
+func (m *testLeagueRoleService) GrantLeagueRole(leagueID int, email, role string) error {
+	if email == "error@here.com" || !models.IsGrantableLeagueRole(role) {
+		return errors.New("grant error")
+	}
+	return nil
+}
+
+func (m *testLeagueRoleService) RevokeLeagueRole(admin models.LeagueAdmin) error {
+	if admin.ID == 2 {
+		return errors.New("revoke error")
+	}
+	return nil
+}
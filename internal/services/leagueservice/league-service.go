@@ -1,26 +1,33 @@
 package leagueservice
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/jdonahue135/golf-league-app/internal/models"
 	"github.com/jdonahue135/golf-league-app/internal/repository"
 	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
 )
 
 type leagueService struct {
-	LeagueRepo repository.LeagueRepo
-	PlayerRepo repository.PlayerRepo
-	UserRepo   repository.UserRepo
-	DBManager  repository.DBManager
+	LeagueRepo     repository.LeagueRepo
+	PlayerRepo     repository.PlayerRepo
+	UserRepo       repository.UserRepo
+	InvitationRepo repository.InvitationRepo
+	DBManager      repository.DBManager
 }
 
-func NewLeagueService(l repository.LeagueRepo, p repository.PlayerRepo, u repository.UserRepo, m repository.DBManager) services.LeagueService {
+func NewLeagueService(l repository.LeagueRepo, p repository.PlayerRepo, u repository.UserRepo, i repository.InvitationRepo, m repository.DBManager) services.LeagueService {
 	return &leagueService{
-		LeagueRepo: l,
-		PlayerRepo: p,
-		UserRepo:   u,
-		DBManager:  m,
+		LeagueRepo:     l,
+		PlayerRepo:     p,
+		UserRepo:       u,
+		InvitationRepo: i,
+		DBManager:      m,
 	}
 }
 
@@ -36,6 +43,23 @@ func (m *leagueService) GetLeaguesByUser(userID int) ([]models.League, error) {
 	return m.LeagueRepo.GetLeaguesByUserID(userID)
 }
 
+// SearchLeagues returns the page of leagues whose name contains search, newest
+// first, along with the page filled in with how many leagues matched
+func (m *leagueService) SearchLeagues(search string, page models.Page) ([]models.League, models.Page, error) {
+	total, err := m.LeagueRepo.CountLeagues(search)
+	if err != nil {
+		return nil, page, err
+	}
+	page.Total = total
+
+	leagues, err := m.LeagueRepo.AllLeagues(search, page.Size, page.Offset())
+	if err != nil {
+		return nil, page, err
+	}
+
+	return leagues, page, nil
+}
+
 func (m *leagueService) CreateLeagueWithCommissioner(league models.League, commissioner models.Player) (int, error) {
 	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
 	defer cancel()
@@ -62,14 +86,108 @@ func (m *leagueService) CreateLeagueWithCommissioner(league models.League, commi
 	return leagueID, nil
 }
 
+// AddExistingUserToLeague adds a user with an account to a league, or
+// reactivates them if they left it
 func (m *leagueService) AddExistingUserToLeague(userID, leagueID int) error {
-	player, err := m.PlayerRepo.GetPlayerByUserAndLeagueID(userID, leagueID)
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	err = m.addExistingUserTransaction(models.RosterRow{User: models.User{ID: userID}}, leagueID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
+
+// AddNewUserToLeague adds a player without an account to a league along with
+// an invitation to claim the account, and returns the token for the
+// invitation's link
+func (m *leagueService) AddNewUserToLeague(user models.User, leagueID int) (string, error) {
+	//transaction
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := m.addNewUserTransaction(models.RosterRow{User: user}, leagueID, ctx, tx)
+	if err != nil {
+		return "", err
+	}
+
+	err = m.DBManager.CommitTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// ImportRoster adds every player in a roster to a league in one transaction,
+// so either all of them are added or none are. Players without an account get
+// an invitation, and its token is returned in their row
+func (m *leagueService) ImportRoster(leagueID int, rows []models.RosterRow) (models.RosterImport, error) {
+	var result models.RosterImport
+	if len(rows) == 0 {
+		return result, errors.New("roster has no players")
+	}
+	for _, row := range rows {
+		if !row.IsValid() {
+			return result, fmt.Errorf("roster line %d is invalid", row.Line)
+		}
+	}
+
+	// returning before the commit cancels the transaction's context, which
+	// rolls back the players already added
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return result, err
+	}
+
+	for _, row := range rows {
+		if row.ExistingUser {
+			err = m.addExistingUserTransaction(row, leagueID, ctx, tx)
+			if err != nil {
+				return models.RosterImport{}, fmt.Errorf("roster line %d: %s", row.Line, err)
+			}
+			result.Added++
+		} else {
+			row.InvitationToken, err = m.addNewUserTransaction(row, leagueID, ctx, tx)
+			if err != nil {
+				return models.RosterImport{}, fmt.Errorf("roster line %d: %s", row.Line, err)
+			}
+			result.Invited++
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	err = m.DBManager.CommitTransaction(tx)
+	if err != nil {
+		return models.RosterImport{}, err
+	}
+
+	return result, nil
+}
+
+// addExistingUserTransaction adds the user in a roster row to a league, or
+// reactivates them if they left it. The row's handicap replaces the one they
+// had before
+func (m *leagueService) addExistingUserTransaction(row models.RosterRow, leagueID int, ctx context.Context, tx *sql.Tx) error {
+	player, err := m.PlayerRepo.GetPlayerByUserAndLeagueID(row.User.ID, leagueID)
 	if err == nil {
 		if player.IsActive {
 			return errors.New("this player is already in this league")
 		}
 		player.IsActive = true
-		err = m.PlayerRepo.UpdatePlayer(player)
+		if row.HasHandicap {
+			player.Handicap = row.Handicap
+		}
+		err = m.PlayerRepo.UpdatePlayerTransaction(player, ctx, tx)
 		if err != nil {
 			return errors.New("cannot reactivate player")
 		}
@@ -78,45 +196,65 @@ func (m *leagueService) AddExistingUserToLeague(userID, leagueID int) error {
 
 	player = models.Player{
 		LeagueID:       leagueID,
-		UserID:         userID,
+		UserID:         row.User.ID,
+		Handicap:       row.Handicap,
 		IsActive:       true,
 		IsCommissioner: false,
 	}
-	err = m.PlayerRepo.CreatePlayer(player)
-	return err
+	return m.PlayerRepo.CreatePlayerTransaction(player, ctx, tx)
 }
 
-func (m *leagueService) AddNewUserToLeague(user models.User, leagueID int) error {
-	//transaction
-	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
-	defer cancel()
+// addNewUserTransaction creates an account for the user in a roster row, adds
+// them to a league and invites them to claim the account. It returns the
+// token for the invitation's link
+func (m *leagueService) addNewUserTransaction(row models.RosterRow, leagueID int, ctx context.Context, tx *sql.Tx) (string, error) {
+	token, tokenHash, err := tokens.Generate()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	//create user
-	userID, err := m.UserRepo.CreateInactiveUserTransaction(user, ctx, tx)
+	userID, err := m.UserRepo.CreateInactiveUserTransaction(row.User, ctx, tx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	//create player
 	player := models.Player{
 		UserID:         userID,
 		LeagueID:       leagueID,
+		Handicap:       row.Handicap,
 		IsCommissioner: false,
 		IsActive:       true,
 	}
 	err = m.PlayerRepo.CreatePlayerTransaction(player, ctx, tx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	err = m.DBManager.CommitTransaction(tx)
-
+	//create invitation
+	invitation := models.Invitation{
+		UserID:    userID,
+		LeagueID:  leagueID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().UTC().Add(models.InvitationLifetime),
+	}
+	_, err = m.InvitationRepo.CreateInvitationTransaction(invitation, ctx, tx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return nil
+	return token, nil
+}
+
+// UpdateLeagueSettings saves the league's scoring format, Stableford points
+// and time zone
+func (m *leagueService) UpdateLeagueSettings(league models.League) error {
+	if !models.IsScoringFormat(league.ScoringFormat) {
+		return errors.New("unknown scoring format")
+	}
+	if _, ok := league.Location(); league.TimeZone != "" && !ok {
+		return errors.New("unknown time zone")
+	}
+	return m.LeagueRepo.UpdateLeagueSettings(league)
 }
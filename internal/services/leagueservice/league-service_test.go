@@ -19,6 +19,36 @@ func TestGetLeaguesByUser(t *testing.T) {
 	service.GetLeaguesByUser(1)
 }
 
+var searchLeaguesTests = []struct {
+	name          string
+	search        string
+	page          models.Page
+	expectedTotal int
+	expectError   bool
+}{
+	{"success", "", models.NewPage(1, 25), 1, false},
+	{"count error", "count error", models.NewPage(1, 25), 0, true},
+	{"search error", "error", models.NewPage(2, 25), 1, true},
+}
+
+func TestSearchLeagues(t *testing.T) {
+	for _, e := range searchLeaguesTests {
+		leagues, page, err := service.SearchLeagues(e.search, e.page)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if page.Total != e.expectedTotal {
+			t.Errorf("failed %s: expected total %d, but got %d", e.name, e.expectedTotal, page.Total)
+		}
+		if !e.expectError && len(leagues) == 0 {
+			t.Errorf("failed %s: expected leagues, but got none", e.name)
+		}
+	}
+}
+
 var createLeagueTests = []struct {
 	name             string
 	league           models.League
@@ -84,7 +114,7 @@ var existingUserTests = []struct {
 	},
 	{
 		"error - create player db error",
-		0,
+		15,
 		1,
 		true,
 	},
@@ -133,6 +163,12 @@ var newUserTests = []struct {
 		0,
 		true,
 	},
+	{
+		"error - invitation create error",
+		models.User{FirstName: "invitation create error"},
+		0,
+		true,
+	},
 	{
 		"success",
 		models.User{},
@@ -143,7 +179,119 @@ var newUserTests = []struct {
 
 func TestAddNewUserToLeague(t *testing.T) {
 	for _, e := range newUserTests {
-		err := service.AddNewUserToLeague(e.user, e.LeagueID)
+		token, err := service.AddNewUserToLeague(e.user, e.LeagueID)
+		if e.expectError && err == nil {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if !e.expectError && err != nil {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if !e.expectError && token == "" {
+			t.Errorf("failed %s: expected an invitation token but got none", e.name)
+		}
+	}
+}
+
+var importRosterTests = []struct {
+	name            string
+	rows            []models.RosterRow
+	expectedAdded   int
+	expectedInvited int
+	expectError     bool
+}{
+	{"no rows", nil, 0, 0, true},
+	{
+		"invalid row",
+		[]models.RosterRow{{Line: 1, Errors: []string{"Email: Invalid email address"}}},
+		0,
+		0,
+		true,
+	},
+	{
+		"player already in league",
+		[]models.RosterRow{
+			{Line: 1, User: models.User{FirstName: "New"}},
+			{Line: 2, User: models.User{ID: 3}, ExistingUser: true},
+		},
+		0,
+		0,
+		true,
+	},
+	{
+		"invitation error",
+		[]models.RosterRow{{Line: 1, User: models.User{FirstName: "invitation create error"}}},
+		0,
+		0,
+		true,
+	},
+	{
+		"success",
+		[]models.RosterRow{
+			{Line: 1, User: models.User{FirstName: "New"}, Handicap: 12, HasHandicap: true},
+			{Line: 2, User: models.User{ID: 4}, ExistingUser: true, Handicap: 8, HasHandicap: true},
+			{Line: 3, User: models.User{ID: 5}, ExistingUser: true},
+		},
+		2,
+		1,
+		false,
+	},
+}
+
+func TestImportRoster(t *testing.T) {
+	for _, e := range importRosterTests {
+		result, err := service.ImportRoster(2, e.rows)
+		if e.expectError && err == nil {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if !e.expectError && err != nil {
+			t.Errorf("failed %s: expected no error but got %s", e.name, err.Error())
+		}
+		if result.Added != e.expectedAdded || result.Invited != e.expectedInvited {
+			t.Errorf("failed %s: expected %d added and %d invited, but got %d and %d", e.name, e.expectedAdded, e.expectedInvited, result.Added, result.Invited)
+		}
+		for _, row := range result.Rows {
+			if !row.ExistingUser && row.InvitationToken == "" {
+				t.Errorf("failed %s: expected an invitation token for line %d but got none", e.name, row.Line)
+			}
+		}
+	}
+}
+
+var updateLeagueSettingsTests = []struct {
+	name        string
+	league      models.League
+	expectError bool
+}{
+	{
+		"unknown format",
+		models.League{ID: 1, ScoringFormat: "scramble"},
+		true,
+	},
+	{
+		"error updating league",
+		models.League{ID: 5, ScoringFormat: models.FormatMatchPlay},
+		true,
+	},
+	{
+		"unknown time zone",
+		models.League{ID: 1, ScoringFormat: models.FormatMatchPlay, TimeZone: "Mars/Olympus_Mons"},
+		true,
+	},
+	{
+		"success with time zone",
+		models.League{ID: 1, ScoringFormat: models.FormatMatchPlay, TimeZone: "America/Chicago"},
+		false,
+	},
+	{
+		"success",
+		models.League{ID: 1, ScoringFormat: models.FormatStableford, StablefordTable: models.ModifiedStableford},
+		false,
+	},
+}
+
+func TestUpdateLeagueSettings(t *testing.T) {
+	for _, e := range updateLeagueSettingsTests {
+		err := service.UpdateLeagueSettings(e.league)
 		if e.expectError && err == nil {
 			t.Errorf("failed %s: expected error but got none", e.name)
 		}
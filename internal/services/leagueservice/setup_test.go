@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/invitationrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/leaguerepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/playerrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
@@ -17,8 +18,9 @@ func TestMain(m *testing.M) {
 	leagueRepo := leaguerepo.NewTestLeagueRepo()
 	playerRepo := playerrepo.NewTestPlayerRepo()
 	userRepo := userrepo.NewTestUserRepo()
+	invitationRepo := invitationrepo.NewTestInvitationRepo()
 	dbManager := dbmanager.NewTestDBManager()
-	service = NewLeagueService(leagueRepo, playerRepo, userRepo, dbManager)
+	service = NewLeagueService(leagueRepo, playerRepo, userRepo, invitationRepo, dbManager)
 
 	os.Exit(m.Run())
 }
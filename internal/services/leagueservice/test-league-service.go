@@ -24,6 +24,11 @@ func (m *testLeagueService) GetLeague(ID int) (models.League, error) {
 		return l, errors.New("league doesn't exist")
 	}
 	l.ID = ID
+	l.ScoringFormat = models.FormatStrokePlay
+	l.StablefordTable = models.StandardStableford
+	l.MatchAllowance = 1
+	l.WinPoints = 2
+	l.HalvePoints = 1
 	return l, nil
 }
 
@@ -40,9 +45,21 @@ func (m *testLeagueService) GetLeaguesByUser(userID int) ([]models.League, error
 	if userID == 2 {
 		return l, errors.New("service error")
 	}
+	if userID == 4 {
+		// a league the user has left, which has a schedule that can't be read
+		return append(l, models.League{ID: 8, Name: "Left League"}), nil
+	}
 	return l, nil
 }
 
+func (m *testLeagueService) SearchLeagues(search string, page models.Page) ([]models.League, models.Page, error) {
+	if search == "error" {
+		return nil, page, errors.New("cannot search leagues")
+	}
+	page.Total = 1
+	return []models.League{{ID: 1, Name: "league0", ScoringFormat: models.FormatStrokePlay}}, page, nil
+}
+
 func (m *testLeagueService) CreateLeagueWithCommissioner(league models.League, commissioner models.Player) (int, error) {
 	if league.Name == "league1" {
 		return 0, errors.New("error inserting league in DB")
@@ -57,9 +74,33 @@ func (m *testLeagueService) AddExistingUserToLeague(userID, leagueID int) error
 	return nil
 }
 
-func (m *testLeagueService) AddNewUserToLeague(user models.User, leagueID int) error {
+func (m *testLeagueService) AddNewUserToLeague(user models.User, leagueID int) (string, error) {
 	if leagueID == 2 {
-		return errors.New("error adding user to DB")
+		return "", errors.New("error adding user to DB")
+	}
+	return "token", nil
+}
+
+func (m *testLeagueService) ImportRoster(leagueID int, rows []models.RosterRow) (models.RosterImport, error) {
+	var result models.RosterImport
+	if leagueID == 5 {
+		return result, errors.New("error importing roster")
+	}
+	for _, row := range rows {
+		if row.ExistingUser {
+			result.Added++
+		} else {
+			row.InvitationToken = "token"
+			result.Invited++
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+func (m *testLeagueService) UpdateLeagueSettings(league models.League) error {
+	if league.ID == 5 {
+		return errors.New("error updating league in DB")
 	}
 	return nil
 }
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+type LoginThrottleService interface {
+	StartLogin(email, ip string) (time.Duration, bool, error)
+	ReleaseLogin(email, ip string) error
+	RecordSuccessfulLogin(email, ip string) error
+	GetLockouts() ([]models.LoginAttempt, error)
+	ClearLockout(key string) error
+}
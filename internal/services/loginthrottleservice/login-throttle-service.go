@@ -0,0 +1,74 @@
+package loginthrottleservice
+
+import (
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type loginThrottleService struct {
+	LoginAttemptRepo repository.LoginAttemptRepo
+}
+
+func NewLoginThrottleService(r repository.LoginAttemptRepo) services.LoginThrottleService {
+	return &loginThrottleService{
+		LoginAttemptRepo: r,
+	}
+}
+
+// StartLogin counts a login for email from ip as failed before its password is
+// checked, so logins tried at the same time can't all get in under the limit.
+// If either has failed too often it returns how long to wait instead, and
+// nothing is counted. It reports whether counting the login locked out the
+// account, so its owner can be told if the password turns out to be wrong
+func (m *loginThrottleService) StartLogin(email, ip string) (time.Duration, bool, error) {
+	now := time.Now().UTC()
+	emailKey := models.EmailLoginKey(email)
+
+	wait, locked, err := m.LoginAttemptRepo.ReserveLoginAttempt(emailKey, models.EmailLoginPolicy, now)
+	if err != nil || wait > 0 {
+		return wait, false, err
+	}
+
+	ipWait, _, err := m.LoginAttemptRepo.ReserveLoginAttempt(models.IPLoginKey(ip), models.IPLoginPolicy, now)
+	if err != nil || ipWait > 0 {
+		if releaseErr := m.LoginAttemptRepo.ReleaseLoginAttempt(emailKey, models.EmailLoginPolicy); releaseErr != nil {
+			return 0, false, releaseErr
+		}
+		return ipWait, false, err
+	}
+
+	return 0, locked, nil
+}
+
+// ReleaseLogin takes back the login StartLogin counted for a login that got
+// part of the way, like a right password that still needs a two-factor code.
+// Earlier failures still count, so the rest of the login stays throttled
+func (m *loginThrottleService) ReleaseLogin(email, ip string) error {
+	if err := m.LoginAttemptRepo.ReleaseLoginAttempt(models.EmailLoginKey(email), models.EmailLoginPolicy); err != nil {
+		return err
+	}
+	return m.LoginAttemptRepo.ReleaseLoginAttempt(models.IPLoginKey(ip), models.IPLoginPolicy)
+}
+
+// RecordSuccessfulLogin forgets an account's failed logins and takes back the
+// login StartLogin counted against the client address. The address keeps its
+// other failures, so one good password can't be used to keep guessing at
+// other accounts
+func (m *loginThrottleService) RecordSuccessfulLogin(email, ip string) error {
+	if err := m.LoginAttemptRepo.DeleteLoginAttempt(models.EmailLoginKey(email)); err != nil {
+		return err
+	}
+	return m.LoginAttemptRepo.ReleaseLoginAttempt(models.IPLoginKey(ip), models.IPLoginPolicy)
+}
+
+func (m *loginThrottleService) GetLockouts() ([]models.LoginAttempt, error) {
+	return m.LoginAttemptRepo.GetLockedLoginAttempts(time.Now().UTC())
+}
+
+// ClearLockout lets logins for a locked out key go ahead again
+func (m *loginThrottleService) ClearLockout(key string) error {
+	return m.LoginAttemptRepo.DeleteLoginAttempt(key)
+}
@@ -0,0 +1,210 @@
+package loginthrottleservice
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository/loginattemptrepo"
+)
+
+var startLoginTests = []struct {
+	name         string
+	email        string
+	ip           string
+	expectWait   bool
+	expectLocked bool
+	expectError  bool
+}{
+	{"no failures", "me@here.ca", "10.0.0.1", false, false, false},
+	{"locked out", "locked@here.ca", "10.0.0.1", true, false, false},
+	{"locked out with different case", " Locked@Here.ca", "10.0.0.1", true, false, false},
+	{"login that locks out", "lockout@here.ca", "10.0.0.1", false, true, false},
+	{"address locked out", "me@here.ca", "10.0.0.9", true, false, false},
+	{"store error", "error@here.com", "10.0.0.1", false, false, true},
+	{"release error", "release@error.com", "10.0.0.9", false, false, true},
+}
+
+func TestStartLogin(t *testing.T) {
+	for _, e := range startLoginTests {
+		wait, locked, err := service.StartLogin(e.email, e.ip)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if e.expectWait && wait == 0 {
+			t.Errorf("failed %s: expected to wait, but didn't", e.name)
+		}
+		if !e.expectWait && wait != 0 {
+			t.Errorf("failed %s: expected no wait, but got %s", e.name, wait)
+		}
+		if locked != e.expectLocked {
+			t.Errorf("failed %s: expected locked to be %t, but got %t", e.name, e.expectLocked, locked)
+		}
+	}
+}
+
+func TestBackoffAndLockout(t *testing.T) {
+	r := loginattemptrepo.NewMemoryLoginAttemptRepo()
+	s := NewLoginThrottleService(r)
+	p := models.EmailLoginPolicy
+	key := models.EmailLoginKey("me@here.ca")
+
+	// the repo is given the time, so each login can wait out its delay
+	now := time.Now().UTC()
+	for i := 1; i <= p.LockoutAttempts; i++ {
+		wait, locked, err := r.ReserveLoginAttempt(key, p, now)
+		if err != nil {
+			t.Fatalf("failed login %d: expected no error, but got %s", i, err.Error())
+		}
+		if i <= p.FreeAttempts+1 && wait != 0 {
+			t.Errorf("failed login %d: expected no wait, but got %s", i, wait)
+		}
+		if i > p.FreeAttempts+1 && wait == 0 {
+			t.Errorf("failed login %d: expected to wait, but didn't", i)
+		}
+
+		if wait > 0 {
+			now = now.Add(wait)
+			_, locked, _ = r.ReserveLoginAttempt(key, p, now)
+		}
+		if locked != (i == p.LockoutAttempts) {
+			t.Errorf("failed login %d: expected locked to be %t, but got %t", i, i == p.LockoutAttempts, locked)
+		}
+	}
+
+	if wait, _, _ := r.ReserveLoginAttempt(key, p, now); wait <= p.MaxDelay {
+		t.Errorf("expected a lockout, but only got %s", wait)
+	}
+
+	lockouts, _ := s.GetLockouts()
+	if len(lockouts) != 1 || lockouts[0].Subject() != "me@here.ca" {
+		t.Errorf("expected me@here.ca to be locked out, but got %v", lockouts)
+	}
+
+	if wait, _, _ := s.StartLogin("you@here.ca", "10.0.0.1"); wait != 0 {
+		t.Errorf("expected another account not to wait, but got %s", wait)
+	}
+
+	if err := s.ClearLockout(lockouts[0].Key); err != nil {
+		t.Errorf("expected no error clearing lockout, but got %s", err.Error())
+	}
+	if wait, _, _ := s.StartLogin("me@here.ca", "10.0.0.2"); wait != 0 {
+		t.Errorf("expected no wait after clearing the lockout, but got %s", wait)
+	}
+}
+
+func TestStartLoginAtTheSameTime(t *testing.T) {
+	s := NewLoginThrottleService(loginattemptrepo.NewMemoryLoginAttemptRepo())
+	p := models.EmailLoginPolicy
+
+	var wg sync.WaitGroup
+	started := make(chan bool, p.LockoutAttempts)
+	for i := 0; i < p.LockoutAttempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			wait, _, err := s.StartLogin("me@here.ca", fmt.Sprintf("10.0.0.%d", i))
+			started <- err == nil && wait == 0
+		}(i)
+	}
+	wg.Wait()
+	close(started)
+
+	count := 0
+	for ok := range started {
+		if ok {
+			count++
+		}
+	}
+
+	// each login is counted before the next one looks, so only the free ones
+	// and the first one with a delay get to try a password
+	if count != p.FreeAttempts+1 {
+		t.Errorf("expected %d logins to go ahead, but got %d", p.FreeAttempts+1, count)
+	}
+}
+
+func TestRecordSuccessfulLogin(t *testing.T) {
+	r := loginattemptrepo.NewMemoryLoginAttemptRepo()
+	s := NewLoginThrottleService(r)
+
+	for i := 0; i <= models.EmailLoginPolicy.FreeAttempts; i++ {
+		s.StartLogin("me@here.ca", "10.0.0.1")
+	}
+
+	if err := s.RecordSuccessfulLogin("me@here.ca", "10.0.0.1"); err != nil {
+		t.Errorf("expected no error, but got %s", err.Error())
+	}
+	if wait, _, _ := s.StartLogin("me@here.ca", "10.0.0.2"); wait != 0 {
+		t.Errorf("expected no wait after a successful login, but got %s", wait)
+	}
+
+	// the address only got back the login that succeeded
+	lockouts, _ := r.GetLockedLoginAttempts(time.Now().UTC())
+	if len(lockouts) != 0 {
+		t.Errorf("expected no lockouts, but got %v", lockouts)
+	}
+}
+
+func TestReleaseLogin(t *testing.T) {
+	s := NewLoginThrottleService(loginattemptrepo.NewMemoryLoginAttemptRepo())
+
+	for i := 0; i <= models.EmailLoginPolicy.FreeAttempts; i++ {
+		s.StartLogin("me@here.ca", "10.0.0.1")
+	}
+
+	if err := s.ReleaseLogin("me@here.ca", "10.0.0.1"); err != nil {
+		t.Errorf("expected no error, but got %s", err.Error())
+	}
+	if wait, _, _ := s.StartLogin("me@here.ca", "10.0.0.1"); wait != 0 {
+		t.Errorf("expected no wait after releasing a login, but got %s", wait)
+	}
+	if wait, _, _ := s.StartLogin("me@here.ca", "10.0.0.1"); wait == 0 {
+		t.Error("expected the earlier failures to still count, but they didn't")
+	}
+}
+
+func TestReleaseLiftsLockout(t *testing.T) {
+	p := models.EmailLoginPolicy
+	now := time.Now()
+
+	a := models.LoginAttempt{Failures: p.LockoutAttempts - 1, LastFailedAt: now.Add(-p.MaxDelay)}
+	a, wait, locked := a.Reserve(p, now)
+	if wait != 0 || !locked {
+		t.Fatalf("expected the login to lock out, but got wait %s and locked %t", wait, locked)
+	}
+
+	a = a.Release(p)
+	if a.IsLocked(now) {
+		t.Error("expected releasing the login to lift its lockout, but it didn't")
+	}
+	if a.Failures != p.LockoutAttempts-1 {
+		t.Errorf("expected %d failures, but got %d", p.LockoutAttempts-1, a.Failures)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	p := models.EmailLoginPolicy
+	var delayTests = []struct {
+		failures int
+		expected int64
+	}{
+		{p.FreeAttempts, 0},
+		{p.FreeAttempts + 1, int64(p.BaseDelay)},
+		{p.FreeAttempts + 2, int64(2 * p.BaseDelay)},
+		{p.FreeAttempts + 3, int64(4 * p.BaseDelay)},
+		{p.FreeAttempts + 100, int64(p.MaxDelay)},
+	}
+
+	for _, e := range delayTests {
+		a := models.LoginAttempt{Failures: e.failures}
+		if got := int64(a.Delay(p)); got != e.expected {
+			t.Errorf("failed %d failures: expected delay %d, but got %d", e.failures, e.expected, got)
+		}
+	}
+}
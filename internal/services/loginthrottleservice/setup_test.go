@@ -0,0 +1,18 @@
+package loginthrottleservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/loginattemptrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.LoginThrottleService
+
+func TestMain(m *testing.M) {
+	loginAttemptRepo := loginattemptrepo.NewTestLoginAttemptRepo()
+	service = NewLoginThrottleService(loginAttemptRepo)
+
+	os.Exit(m.Run())
+}
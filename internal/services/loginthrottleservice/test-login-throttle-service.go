@@ -0,0 +1,50 @@
+package loginthrottleservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testLoginThrottleService struct {
+	LoginAttemptRepo repository.LoginAttemptRepo
+}
+
+func NewTestLoginThrottleService(r repository.LoginAttemptRepo) services.LoginThrottleService {
+	return &testLoginThrottleService{LoginAttemptRepo: r}
+}
+
+func (m *testLoginThrottleService) StartLogin(email, ip string) (time.Duration, bool, error) {
+	if email == "error@here.com" {
+		return 0, false, errors.New("some error")
+	}
+	if email == "locked@here.ca" {
+		return models.EmailLoginPolicy.LockoutDuration, false, nil
+	}
+	if email == "lockout@here.ca" {
+		return 0, true, nil
+	}
+	return 0, false, nil
+}
+
+func (m *testLoginThrottleService) ReleaseLogin(email, ip string) error {
+	return nil
+}
+
+func (m *testLoginThrottleService) RecordSuccessfulLogin(email, ip string) error {
+	return nil
+}
+
+func (m *testLoginThrottleService) GetLockouts() ([]models.LoginAttempt, error) {
+	return m.LoginAttemptRepo.GetLockedLoginAttempts(time.Now())
+}
+
+func (m *testLoginThrottleService) ClearLockout(key string) error {
+	if key == "error" {
+		return errors.New("some error")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ type PlayerService interface {
 	GetPlayer(ID int) (models.Player, error)
 	GetPlayersInLeague(leagueID int) ([]models.Player, error)
 	GetPlayerInLeague(userID, leagueID int) (models.Player, error)
+	IsCommissioner(userID int) (bool, error)
 	ActivatePlayer(player models.Player) error
 	RemovePlayer(player models.Player) error
 }
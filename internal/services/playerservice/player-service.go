@@ -28,6 +28,22 @@ func (m *playerService) GetPlayerInLeague(userID, leagueID int) (models.Player,
 	return m.PlayerRepo.GetPlayerByUserAndLeagueID(userID, leagueID)
 }
 
+// IsCommissioner reports whether the user is the active commissioner of any league
+func (m *playerService) IsCommissioner(userID int) (bool, error) {
+	players, err := m.PlayerRepo.GetPlayersByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range players {
+		if p.IsCommissioner && p.IsActive {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (m *playerService) ActivatePlayer(player models.Player) error {
 	player.IsActive = true
 	return m.PlayerRepo.UpdatePlayer(player)
@@ -18,6 +18,21 @@ func TestGetPlayer(t *testing.T) {
 	service.GetPlayer(1)
 }
 
+func TestIsCommissioner(t *testing.T) {
+	isCommissioner, err := service.IsCommissioner(1)
+	if err != nil || !isCommissioner {
+		t.Error("failed commissioner: expected user to be a commissioner")
+	}
+	isCommissioner, err = service.IsCommissioner(2)
+	if err != nil || isCommissioner {
+		t.Error("failed not commissioner: expected user not to be a commissioner")
+	}
+	_, err = service.IsCommissioner(0)
+	if err == nil {
+		t.Error("failed error: expected error but got none")
+	}
+}
+
 func TestActivatePlayer(t *testing.T) {
 	var p models.Player
 	service.ActivatePlayer(p)
@@ -2,6 +2,7 @@ package playerservice
 
 import (
 	"errors"
+	"time"
 
 	"github.com/jdonahue135/golf-league-app/internal/models"
 	"github.com/jdonahue135/golf-league-app/internal/repository"
@@ -21,11 +22,10 @@ func (m *testPlayerService) GetPlayer(ID int) (models.Player, error) {
 	if ID == 9 {
 		return p, errors.New("Player not found")
 	}
-	if ID == 8 {
-		p.IsActive = false
-	}
 	p.ID = ID
-	p.IsActive = true
+	p.LeagueID = 1
+	p.UserID = ID
+	p.IsActive = ID != 8
 	return p, nil
 }
 
@@ -34,6 +34,13 @@ func (m *testPlayerService) GetPlayersInLeague(leagueID int) ([]models.Player, e
 	if leagueID == 2 {
 		return p, errors.New("player error")
 	}
+	if leagueID == 12 {
+		p = append(p,
+			models.Player{ID: 1, IsActive: true, User: models.User{Email: "verified@player.com", VerifiedAt: time.Now()}},
+			models.Player{ID: 2, IsActive: true, User: models.User{Email: "unverified@player.com"}},
+			models.Player{ID: 3, User: models.User{Email: "inactive@player.com", VerifiedAt: time.Now()}},
+		)
+	}
 	return p, nil
 }
 
@@ -47,9 +54,20 @@ func (m *testPlayerService) GetPlayerInLeague(userID, leagueID int) (models.Play
 	} else {
 		p.IsCommissioner = true
 	}
+	p.IsActive = userID != 2
 	return p, nil
 }
 
+func (m *testPlayerService) IsCommissioner(userID int) (bool, error) {
+	if userID == 0 {
+		return false, errors.New("player error")
+	}
+	if userID == 3 {
+		return false, nil
+	}
+	return true, nil
+}
+
 func (m *testPlayerService) ActivatePlayer(player models.Player) error {
 	return nil
 }
@@ -0,0 +1,13 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type ScheduleService interface {
+	GetSchedule(seasonID int) ([]models.ScheduleWeek, error)
+	GetScheduleWeek(ID int) (models.ScheduleWeek, error)
+	GenerateSchedule(season models.Season, players []models.Player, options models.ScheduleOptions) error
+	UpdateScheduleWeek(week models.ScheduleWeek, players []models.Player) error
+	PublishSchedule(seasonID int) error
+	CancelScheduleWeek(ID int) error
+	ReinstateScheduleWeek(ID int) error
+}
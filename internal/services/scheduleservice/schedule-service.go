@@ -0,0 +1,260 @@
+package scheduleservice
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/schedule"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type scheduleService struct {
+	ScheduleRepo repository.ScheduleRepo
+	DBManager    repository.DBManager
+}
+
+func NewScheduleService(s repository.ScheduleRepo, m repository.DBManager) services.ScheduleService {
+	return &scheduleService{
+		ScheduleRepo: s,
+		DBManager:    m,
+	}
+}
+
+func (m *scheduleService) GetSchedule(seasonID int) ([]models.ScheduleWeek, error) {
+	return m.ScheduleRepo.GetScheduleWeeksBySeasonID(seasonID)
+}
+
+func (m *scheduleService) GetScheduleWeek(ID int) (models.ScheduleWeek, error) {
+	return m.ScheduleRepo.GetScheduleWeekByID(ID)
+}
+
+// activePlayerIDs returns the IDs of the players still active in the league
+func activePlayerIDs(players []models.Player) []int {
+	var ids []int
+	for _, p := range players {
+		if p.IsActive {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+// GenerateSchedule replaces a season's schedule with a round-robin of the
+// active players, one week for every seventh day in the options' date range.
+// A published schedule can't be regenerated
+func (m *scheduleService) GenerateSchedule(season models.Season, players []models.Player, options models.ScheduleOptions) error {
+	if season.Status == models.SeasonStatusCompleted {
+		return errors.New("a completed season can't be scheduled")
+	}
+
+	if !season.Includes(options.StartDate) || !season.Includes(options.EndDate) {
+		return errors.New("the schedule must fall within the season")
+	}
+
+	weeks, err := m.ScheduleRepo.GetScheduleWeeksBySeasonID(season.ID)
+	if err != nil {
+		return err
+	}
+	for _, w := range weeks {
+		if w.IsPublished {
+			return errors.New("the schedule has already been published")
+		}
+	}
+
+	if options.TeeTime != "" && !models.IsTeeTime(options.TeeTime) {
+		return errors.New("invalid tee time")
+	}
+
+	playerIDs := activePlayerIDs(players)
+	if len(playerIDs) < 2 {
+		return errors.New("a schedule needs at least two active players")
+	}
+
+	dates := schedule.WeeklyDates(options.StartDate, options.EndDate)
+	if len(dates) == 0 {
+		return errors.New("the schedule must end after it starts")
+	}
+
+	pairings := schedule.RoundRobin(playerIDs, len(dates))
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	err = m.ScheduleRepo.DeleteUnpublishedScheduleWeeksTransaction(season.ID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for i, date := range dates {
+		week := models.ScheduleWeek{
+			SeasonID:   season.ID,
+			WeekNumber: i + 1,
+			PlayDate:   date,
+			CourseID:   options.CourseID,
+			TeeTime:    options.TeeTime,
+		}
+		if options.AlternateNines {
+			week.Nine = models.NineFront
+			if i%2 == 1 {
+				week.Nine = models.NineBack
+			}
+		}
+
+		weekID, err := m.ScheduleRepo.CreateScheduleWeekTransaction(week, ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range pairings[i] {
+			matchup := models.Matchup{
+				ScheduleWeekID: weekID,
+				HomePlayerID:   p.Home,
+				AwayPlayerID:   p.Away,
+			}
+			err = m.ScheduleRepo.CreateMatchupTransaction(matchup, ctx, tx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
+
+// validateMatchups checks every player in the week is an active player who
+// plays only once
+func validateMatchups(matchups []models.Matchup, players []models.Player) error {
+	active := make(map[int]bool)
+	for _, id := range activePlayerIDs(players) {
+		active[id] = true
+	}
+
+	scheduled := make(map[int]bool)
+	for i, matchup := range matchups {
+		ids := []int{matchup.HomePlayerID}
+		if !matchup.IsBye() {
+			ids = append(ids, matchup.AwayPlayerID)
+		}
+
+		for _, id := range ids {
+			if !active[id] {
+				return fmt.Errorf("matchup %d has a player who isn't active in the league", i+1)
+			}
+			if scheduled[id] {
+				return fmt.Errorf("matchup %d has a player who is already playing this week", i+1)
+			}
+			scheduled[id] = true
+		}
+	}
+
+	return nil
+}
+
+// UpdateScheduleWeek replaces the matchups, scoring format, course and tee time
+// of a week that hasn't been published. An empty format plays the week in the
+// league's format
+func (m *scheduleService) UpdateScheduleWeek(week models.ScheduleWeek, players []models.Player) error {
+	existing, err := m.ScheduleRepo.GetScheduleWeekByID(week.ID)
+	if err != nil {
+		return err
+	}
+
+	if existing.IsPublished {
+		return errors.New("a published week can't be changed")
+	}
+
+	if week.Format != "" && !models.IsScoringFormat(week.Format) {
+		return errors.New("invalid scoring format")
+	}
+
+	if week.TeeTime != "" && !models.IsTeeTime(week.TeeTime) {
+		return errors.New("invalid tee time")
+	}
+
+	err = validateMatchups(week.Matchups, players)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	existing.Format = week.Format
+	existing.CourseID = week.CourseID
+	existing.TeeTime = week.TeeTime
+	err = m.ScheduleRepo.UpdateScheduleWeekTransaction(existing, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = m.ScheduleRepo.DeleteMatchupsByScheduleWeekIDTransaction(existing.ID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, matchup := range week.Matchups {
+		matchup.ScheduleWeekID = existing.ID
+		err = m.ScheduleRepo.CreateMatchupTransaction(matchup, ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
+
+// PublishSchedule makes a season's schedule visible to its players and stops
+// it from being regenerated
+func (m *scheduleService) PublishSchedule(seasonID int) error {
+	weeks, err := m.ScheduleRepo.GetScheduleWeeksBySeasonID(seasonID)
+	if err != nil {
+		return err
+	}
+
+	if len(weeks) == 0 {
+		return errors.New("there is no schedule to publish")
+	}
+
+	return m.ScheduleRepo.PublishScheduleWeeks(seasonID)
+}
+
+// CancelScheduleWeek calls off a published week. The week stays on the
+// schedule so players' calendars show it was cancelled
+func (m *scheduleService) CancelScheduleWeek(ID int) error {
+	week, err := m.ScheduleRepo.GetScheduleWeekByID(ID)
+	if err != nil {
+		return err
+	}
+
+	if !week.IsPublished {
+		return errors.New("only a published week can be cancelled")
+	}
+	if week.IsCancelled() {
+		return errors.New("the week is already cancelled")
+	}
+
+	return m.ScheduleRepo.UpdateScheduleWeekCancellation(week.ID, time.Now().UTC())
+}
+
+// ReinstateScheduleWeek puts a cancelled week back on the schedule
+func (m *scheduleService) ReinstateScheduleWeek(ID int) error {
+	week, err := m.ScheduleRepo.GetScheduleWeekByID(ID)
+	if err != nil {
+		return err
+	}
+
+	if !week.IsCancelled() {
+		return errors.New("the week isn't cancelled")
+	}
+
+	return m.ScheduleRepo.UpdateScheduleWeekCancellation(week.ID, time.Time{})
+}
@@ -0,0 +1,298 @@
+package scheduleservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestGetSchedule(t *testing.T) {
+	service.GetSchedule(1)
+}
+
+func TestGetScheduleWeek(t *testing.T) {
+	service.GetScheduleWeek(1)
+}
+
+func date(month, day int) time.Time {
+	return time.Date(2024, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func testSeason(ID int, status string) models.Season {
+	return models.Season{ID: ID, StartDate: date(1, 1), EndDate: date(12, 31), Status: status}
+}
+
+// testPlayers returns active players with the given IDs
+func testPlayers(ids ...int) []models.Player {
+	var players []models.Player
+	for _, id := range ids {
+		players = append(players, models.Player{ID: id, IsActive: true})
+	}
+	return players
+}
+
+var generateScheduleTests = []struct {
+	name        string
+	season      models.Season
+	players     []models.Player
+	options     models.ScheduleOptions
+	expectError bool
+}{
+	{
+		"completed season",
+		testSeason(1, models.SeasonStatusCompleted),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		true,
+	},
+	{
+		"outside the season",
+		testSeason(1, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(4, 3).AddDate(1, 0, 0)},
+		true,
+	},
+	{
+		"ends before it starts",
+		testSeason(1, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(8, 28), EndDate: date(4, 3)},
+		true,
+	},
+	{
+		"schedule error",
+		testSeason(3, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		true,
+	},
+	{
+		"already published",
+		testSeason(2, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		true,
+	},
+	{
+		"not enough active players",
+		testSeason(1, models.SeasonStatusActive),
+		append(testPlayers(1), models.Player{ID: 2}),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		true,
+	},
+	{
+		"error deleting old schedule",
+		testSeason(6, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		true,
+	},
+	{
+		"error inserting week",
+		testSeason(4, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		true,
+	},
+	{
+		"error inserting matchup",
+		testSeason(1, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 99),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		true,
+	},
+	{
+		"invalid tee time",
+		testSeason(1, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28), TeeTime: "5:30pm"},
+		true,
+	},
+	{
+		"odd number of players alternating nines",
+		testSeason(1, models.SeasonStatusDraft),
+		testPlayers(1, 2, 3, 4, 5),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28), AlternateNines: true},
+		false,
+	},
+	{
+		"success",
+		testSeason(1, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28)},
+		false,
+	},
+	{
+		"success with course and tee time",
+		testSeason(1, models.SeasonStatusActive),
+		testPlayers(1, 2, 3, 4),
+		models.ScheduleOptions{StartDate: date(4, 3), EndDate: date(8, 28), CourseID: 1, TeeTime: "17:30"},
+		false,
+	},
+}
+
+func TestGenerateSchedule(t *testing.T) {
+	for _, e := range generateScheduleTests {
+		err := service.GenerateSchedule(e.season, e.players, e.options)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
+
+var updateScheduleWeekTests = []struct {
+	name        string
+	week        models.ScheduleWeek
+	expectError bool
+}{
+	{
+		"week not found",
+		models.ScheduleWeek{ID: 3},
+		true,
+	},
+	{
+		"week published",
+		models.ScheduleWeek{ID: 2},
+		true,
+	},
+	{
+		"invalid format",
+		models.ScheduleWeek{ID: 1, Format: "scramble", Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}}},
+		true,
+	},
+	{
+		"invalid tee time",
+		models.ScheduleWeek{ID: 1, TeeTime: "25:00", Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}}},
+		true,
+	},
+	{
+		"error updating week",
+		models.ScheduleWeek{ID: 6, Format: models.FormatStableford, Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}}},
+		true,
+	},
+	{
+		"player not in league",
+		models.ScheduleWeek{ID: 1, Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 7}}},
+		true,
+	},
+	{
+		"player scheduled twice",
+		models.ScheduleWeek{ID: 1, Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}, {HomePlayerID: 2, AwayPlayerID: 3}}},
+		true,
+	},
+	{
+		"player against themselves",
+		models.ScheduleWeek{ID: 1, Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 1}}},
+		true,
+	},
+	{
+		"error deleting matchups",
+		models.ScheduleWeek{ID: 5, Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}}},
+		true,
+	},
+	{
+		"error inserting matchup",
+		models.ScheduleWeek{ID: 1, Matchups: []models.Matchup{{HomePlayerID: 99, AwayPlayerID: 2}}},
+		true,
+	},
+	{
+		"success",
+		models.ScheduleWeek{ID: 1, Matchups: []models.Matchup{{HomePlayerID: 2, AwayPlayerID: 1}, {HomePlayerID: 3}}},
+		false,
+	},
+	{
+		"success with format",
+		models.ScheduleWeek{ID: 1, Format: models.FormatMatchPlay, Matchups: []models.Matchup{{HomePlayerID: 2, AwayPlayerID: 1}}},
+		false,
+	},
+	{
+		"success with course and tee time",
+		models.ScheduleWeek{ID: 1, CourseID: 1, TeeTime: "08:00", Matchups: []models.Matchup{{HomePlayerID: 2, AwayPlayerID: 1}}},
+		false,
+	},
+}
+
+func TestUpdateScheduleWeek(t *testing.T) {
+	for _, e := range updateScheduleWeekTests {
+		err := service.UpdateScheduleWeek(e.week, testPlayers(1, 2, 3, 99))
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
+
+var publishScheduleTests = []struct {
+	name        string
+	seasonID    int
+	expectError bool
+}{
+	{"schedule error", 3, true},
+	{"no schedule", 8, true},
+	{"error publishing", 5, true},
+	{"success", 1, false},
+}
+
+func TestPublishSchedule(t *testing.T) {
+	for _, e := range publishScheduleTests {
+		err := service.PublishSchedule(e.seasonID)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
+
+var cancelScheduleWeekTests = []struct {
+	name        string
+	weekID      int
+	expectError bool
+}{
+	{"week not found", 3, true},
+	{"week not published", 1, true},
+	{"already cancelled", 8, true},
+	{"error cancelling", 7, true},
+	{"success", 2, false},
+}
+
+func TestCancelScheduleWeek(t *testing.T) {
+	for _, e := range cancelScheduleWeekTests {
+		err := service.CancelScheduleWeek(e.weekID)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
+
+var reinstateScheduleWeekTests = []struct {
+	name        string
+	weekID      int
+	expectError bool
+}{
+	{"week not found", 3, true},
+	{"week not cancelled", 2, true},
+	{"success", 8, false},
+}
+
+func TestReinstateScheduleWeek(t *testing.T) {
+	for _, e := range reinstateScheduleWeekTests {
+		err := service.ReinstateScheduleWeek(e.weekID)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package scheduleservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/schedulerepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.ScheduleService
+
+func TestMain(m *testing.M) {
+	scheduleRepo := schedulerepo.NewTestScheduleRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewScheduleService(scheduleRepo, dbManager)
+
+	os.Exit(m.Run())
+}
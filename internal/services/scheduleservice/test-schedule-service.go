@@ -0,0 +1,98 @@
+package scheduleservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testScheduleService struct {
+	ScheduleRepo repository.ScheduleRepo
+}
+
+func NewTestScheduleService(s repository.ScheduleRepo) services.ScheduleService {
+	return &testScheduleService{ScheduleRepo: s}
+}
+
+func testScheduleWeek(ID, seasonID int, isPublished bool) models.ScheduleWeek {
+	return models.ScheduleWeek{
+		ID:          ID,
+		SeasonID:    seasonID,
+		WeekNumber:  1,
+		PlayDate:    time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC),
+		IsPublished: isPublished,
+		Matchups: []models.Matchup{
+			{
+				ID:             1,
+				ScheduleWeekID: ID,
+				HomePlayerID:   1,
+				AwayPlayerID:   2,
+				HomePlayer:     models.Player{ID: 1, User: models.User{FirstName: "Home", LastName: "Player"}},
+				AwayPlayer:     models.Player{ID: 2, User: models.User{FirstName: "Away", LastName: "Player"}},
+			},
+			{
+				ID:             2,
+				ScheduleWeekID: ID,
+				HomePlayerID:   3,
+				HomePlayer:     models.Player{ID: 3, User: models.User{FirstName: "Bye", LastName: "Player"}},
+			},
+		},
+	}
+}
+
+func (m *testScheduleService) GetSchedule(seasonID int) ([]models.ScheduleWeek, error) {
+	var w []models.ScheduleWeek
+	if seasonID == 8 {
+		return w, errors.New("schedule error")
+	}
+	return append(w, testScheduleWeek(1, seasonID, seasonID == 2)), nil
+}
+
+func (m *testScheduleService) GetScheduleWeek(ID int) (models.ScheduleWeek, error) {
+	if ID == 3 {
+		return models.ScheduleWeek{}, errors.New("week doesn't exist")
+	}
+	week := testScheduleWeek(ID, 1, ID == 2 || ID == 9)
+	if ID == 9 {
+		week.Format = models.FormatMatchPlay
+	}
+	return week, nil
+}
+
+func (m *testScheduleService) GenerateSchedule(season models.Season, players []models.Player, options models.ScheduleOptions) error {
+	if season.ID == 5 {
+		return errors.New("the schedule has already been published")
+	}
+	return nil
+}
+
+func (m *testScheduleService) UpdateScheduleWeek(week models.ScheduleWeek, players []models.Player) error {
+	if week.ID == 5 {
+		return errors.New("matchup 1 has a player who is already playing this week")
+	}
+	return nil
+}
+
+func (m *testScheduleService) PublishSchedule(seasonID int) error {
+	if seasonID == 5 {
+		return errors.New("there is no schedule to publish")
+	}
+	return nil
+}
+
+func (m *testScheduleService) CancelScheduleWeek(ID int) error {
+	if ID == 5 {
+		return errors.New("only a published week can be cancelled")
+	}
+	return nil
+}
+
+func (m *testScheduleService) ReinstateScheduleWeek(ID int) error {
+	if ID == 5 {
+		return errors.New("the week isn't cancelled")
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type ScoreService interface {
+	GetRound(ID int) (models.Round, error)
+	GetRoundsInLeague(leagueID int) ([]models.Round, error)
+	GetRoundsInSeason(seasonID int) ([]models.Round, error)
+	PostRound(round models.Round) (int, error)
+}
@@ -0,0 +1,108 @@
+package scoreservice
+
+import (
+	"fmt"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+const minStrokes = 1
+
+const maxStrokes = 15
+
+type scoreService struct {
+	ScoreRepo  repository.ScoreRepo
+	CourseRepo repository.CourseRepo
+	DBManager  repository.DBManager
+}
+
+func NewScoreService(s repository.ScoreRepo, c repository.CourseRepo, m repository.DBManager) services.ScoreService {
+	return &scoreService{
+		ScoreRepo:  s,
+		CourseRepo: c,
+		DBManager:  m,
+	}
+}
+
+func (m *scoreService) GetRound(ID int) (models.Round, error) {
+	return m.ScoreRepo.GetRoundByID(ID)
+}
+
+func (m *scoreService) GetRoundsInLeague(leagueID int) ([]models.Round, error) {
+	return m.ScoreRepo.GetRoundsByLeagueID(leagueID)
+}
+
+func (m *scoreService) GetRoundsInSeason(seasonID int) ([]models.Round, error) {
+	return m.ScoreRepo.GetRoundsBySeasonID(seasonID)
+}
+
+// PostRound validates a round against its tee set and stores it with its hole scores
+func (m *scoreService) PostRound(round models.Round) (int, error) {
+	teeSet, err := m.CourseRepo.GetTeeSetByID(round.TeeSetID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = validateHoleScores(round.HoleScores, teeSet)
+	if err != nil {
+		return 0, err
+	}
+
+	round.GrossScore = 0
+	for _, h := range round.HoleScores {
+		round.GrossScore += h.Strokes
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	roundID, err := m.ScoreRepo.CreateRoundTransaction(round, ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, h := range round.HoleScores {
+		h.RoundID = roundID
+		err = m.ScoreRepo.CreateHoleScoreTransaction(h, ctx, tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = m.DBManager.CommitTransaction(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return roundID, nil
+}
+
+// validateHoleScores checks that every hole of the tee set has exactly one score
+func validateHoleScores(holeScores []models.HoleScore, teeSet models.TeeSet) error {
+	if len(holeScores) != len(teeSet.Holes) {
+		return fmt.Errorf("a score is required for all %d holes", len(teeSet.Holes))
+	}
+
+	holes := make(map[int]bool)
+	for _, h := range teeSet.Holes {
+		holes[h.Number] = true
+	}
+
+	scored := make(map[int]bool)
+	for _, h := range holeScores {
+		if !holes[h.HoleNumber] || scored[h.HoleNumber] {
+			return fmt.Errorf("invalid score for hole %d", h.HoleNumber)
+		}
+		if h.Strokes < minStrokes || h.Strokes > maxStrokes {
+			return fmt.Errorf("score for hole %d must be from %d to %d", h.HoleNumber, minStrokes, maxStrokes)
+		}
+		scored[h.HoleNumber] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package scoreservice
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestGetRound(t *testing.T) {
+	service.GetRound(1)
+}
+
+func TestGetRoundsInLeague(t *testing.T) {
+	service.GetRoundsInLeague(1)
+}
+
+func TestGetRoundsInSeason(t *testing.T) {
+	service.GetRoundsInSeason(1)
+}
+
+// nineHoleScores returns a score of strokes on each hole of the 9 hole test tee set
+func nineHoleScores(strokes int) []models.HoleScore {
+	var holeScores []models.HoleScore
+	for i := 1; i <= 9; i++ {
+		holeScores = append(holeScores, models.HoleScore{HoleNumber: i, Strokes: strokes})
+	}
+	return holeScores
+}
+
+var postRoundTests = []struct {
+	name            string
+	round           func() models.Round
+	expectedRoundID int
+	expectError     bool
+}{
+	{
+		"tee set not found",
+		func() models.Round {
+			return models.Round{TeeSetID: 3, HoleScores: nineHoleScores(5)}
+		},
+		0,
+		true,
+	},
+	{
+		"missing hole",
+		func() models.Round {
+			return models.Round{TeeSetID: 1, HoleScores: nineHoleScores(5)[:8]}
+		},
+		0,
+		true,
+	},
+	{
+		"hole not on tee set",
+		func() models.Round {
+			holeScores := nineHoleScores(5)
+			holeScores[8].HoleNumber = 10
+			return models.Round{TeeSetID: 1, HoleScores: holeScores}
+		},
+		0,
+		true,
+	},
+	{
+		"hole scored twice",
+		func() models.Round {
+			holeScores := nineHoleScores(5)
+			holeScores[8].HoleNumber = 1
+			return models.Round{TeeSetID: 1, HoleScores: holeScores}
+		},
+		0,
+		true,
+	},
+	{
+		"strokes out of range",
+		func() models.Round {
+			return models.Round{TeeSetID: 1, HoleScores: nineHoleScores(16)}
+		},
+		0,
+		true,
+	},
+	{
+		"round error",
+		func() models.Round {
+			return models.Round{TeeSetID: 1, PlayerID: 2, HoleScores: nineHoleScores(5)}
+		},
+		0,
+		true,
+	},
+	{
+		"hole score error",
+		func() models.Round {
+			return models.Round{TeeSetID: 1, HoleScores: nineHoleScores(14)}
+		},
+		0,
+		true,
+	},
+	{
+		"success",
+		func() models.Round {
+			return models.Round{TeeSetID: 1, HoleScores: nineHoleScores(5)}
+		},
+		1,
+		false,
+	},
+}
+
+func TestPostRound(t *testing.T) {
+	for _, e := range postRoundTests {
+		roundID, err := service.PostRound(e.round())
+		if roundID != e.expectedRoundID {
+			t.Errorf("failed %s: expected round ID %d, but got %d", e.name, e.expectedRoundID, roundID)
+		}
+		if e.expectError && err == nil {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if !e.expectError && err != nil {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+	}
+}
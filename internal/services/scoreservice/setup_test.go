@@ -0,0 +1,22 @@
+package scoreservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/courserepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/scorerepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.ScoreService
+
+func TestMain(m *testing.M) {
+	scoreRepo := scorerepo.NewTestScoreRepo()
+	courseRepo := courserepo.NewTestCourseRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewScoreService(scoreRepo, courseRepo, dbManager)
+
+	os.Exit(m.Run())
+}
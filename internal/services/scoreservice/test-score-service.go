@@ -0,0 +1,54 @@
+package scoreservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testScoreService struct {
+	ScoreRepo repository.ScoreRepo
+}
+
+func NewTestScoreService(s repository.ScoreRepo) services.ScoreService {
+	return &testScoreService{ScoreRepo: s}
+}
+
+func (m *testScoreService) GetRound(ID int) (models.Round, error) {
+	var r models.Round
+	if ID == 3 {
+		return r, errors.New("round doesn't exist")
+	}
+	r.ID = ID
+	r.LeagueID = 1
+	if ID == 4 {
+		r.LeagueID = 2
+	}
+	r.HoleScores = []models.HoleScore{{HoleNumber: 1, Strokes: 4}}
+	return r, nil
+}
+
+func (m *testScoreService) GetRoundsInLeague(leagueID int) ([]models.Round, error) {
+	var r []models.Round
+	if leagueID == 5 {
+		return r, errors.New("round error")
+	}
+	return r, nil
+}
+
+func (m *testScoreService) GetRoundsInSeason(seasonID int) ([]models.Round, error) {
+	var r []models.Round
+	if seasonID == 5 {
+		return r, errors.New("round error")
+	}
+	return r, nil
+}
+
+func (m *testScoreService) PostRound(round models.Round) (int, error) {
+	if round.TeeSetID == 5 {
+		return 0, errors.New("error inserting round in DB")
+	}
+	return 1, nil
+}
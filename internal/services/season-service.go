@@ -0,0 +1,12 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type SeasonService interface {
+	GetSeason(ID int) (models.Season, error)
+	GetSeasonsInLeague(leagueID int) ([]models.Season, error)
+	GetActiveSeason(leagueID int) (models.Season, error)
+	CreateSeason(season models.Season) (int, error)
+	OpenSeason(ID int) error
+	CloseSeason(ID int) error
+}
@@ -0,0 +1,75 @@
+package seasonservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type seasonService struct {
+	SeasonRepo repository.SeasonRepo
+}
+
+func NewSeasonService(s repository.SeasonRepo) services.SeasonService {
+	return &seasonService{
+		SeasonRepo: s,
+	}
+}
+
+func (m *seasonService) GetSeason(ID int) (models.Season, error) {
+	return m.SeasonRepo.GetSeasonByID(ID)
+}
+
+func (m *seasonService) GetSeasonsInLeague(leagueID int) ([]models.Season, error) {
+	return m.SeasonRepo.GetSeasonsByLeagueID(leagueID)
+}
+
+func (m *seasonService) GetActiveSeason(leagueID int) (models.Season, error) {
+	return m.SeasonRepo.GetActiveSeasonByLeagueID(leagueID)
+}
+
+// CreateSeason adds a draft season to a league
+func (m *seasonService) CreateSeason(season models.Season) (int, error) {
+	if !season.EndDate.After(season.StartDate) {
+		return 0, errors.New("a season must end after it starts")
+	}
+
+	season.Status = models.SeasonStatusDraft
+
+	return m.SeasonRepo.CreateSeason(season)
+}
+
+// OpenSeason makes a draft season the league's active season. A league can
+// only have one active season, so the current one must be closed first
+func (m *seasonService) OpenSeason(ID int) error {
+	season, err := m.SeasonRepo.GetSeasonByID(ID)
+	if err != nil {
+		return err
+	}
+
+	if season.Status != models.SeasonStatusDraft {
+		return errors.New("only a draft season can be opened")
+	}
+
+	if _, err = m.SeasonRepo.GetActiveSeasonByLeagueID(season.LeagueID); err == nil {
+		return errors.New("this league already has an active season")
+	}
+
+	return m.SeasonRepo.UpdateSeasonStatus(season.ID, models.SeasonStatusActive)
+}
+
+// CloseSeason completes an active season
+func (m *seasonService) CloseSeason(ID int) error {
+	season, err := m.SeasonRepo.GetSeasonByID(ID)
+	if err != nil {
+		return err
+	}
+
+	if !season.IsActive() {
+		return errors.New("only an active season can be closed")
+	}
+
+	return m.SeasonRepo.UpdateSeasonStatus(season.ID, models.SeasonStatusCompleted)
+}
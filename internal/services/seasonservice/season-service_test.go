@@ -0,0 +1,112 @@
+package seasonservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestGetSeason(t *testing.T) {
+	service.GetSeason(1)
+}
+
+func TestGetSeasonsInLeague(t *testing.T) {
+	service.GetSeasonsInLeague(1)
+}
+
+func TestGetActiveSeason(t *testing.T) {
+	service.GetActiveSeason(1)
+}
+
+var createSeasonTests = []struct {
+	name        string
+	season      models.Season
+	expectError bool
+}{
+	{
+		"ends before it starts",
+		models.Season{
+			Name:      "2024",
+			StartDate: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		true,
+	},
+	{
+		"error inserting season",
+		models.Season{
+			Name:      "Season Error",
+			StartDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		},
+		true,
+	},
+	{
+		"success",
+		models.Season{
+			Name:      "2024",
+			StartDate: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		},
+		false,
+	},
+}
+
+func TestCreateSeason(t *testing.T) {
+	for _, e := range createSeasonTests {
+		_, err := service.CreateSeason(e.season)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
+
+var openSeasonTests = []struct {
+	name        string
+	seasonID    int
+	expectError bool
+}{
+	{"season not found", 3, true},
+	{"season already active", 1, true},
+	{"league has an active season", 2, true},
+	{"error updating season", 5, true},
+	{"success", 7, false},
+}
+
+func TestOpenSeason(t *testing.T) {
+	for _, e := range openSeasonTests {
+		err := service.OpenSeason(e.seasonID)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
+
+var closeSeasonTests = []struct {
+	name        string
+	seasonID    int
+	expectError bool
+}{
+	{"season not found", 3, true},
+	{"season not active", 4, true},
+	{"success", 1, false},
+}
+
+func TestCloseSeason(t *testing.T) {
+	for _, e := range closeSeasonTests {
+		err := service.CloseSeason(e.seasonID)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package seasonservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/seasonrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.SeasonService
+
+func TestMain(m *testing.M) {
+	seasonRepo := seasonrepo.NewTestSeasonRepo()
+	service = NewSeasonService(seasonRepo)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,75 @@
+package seasonservice
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testSeasonService struct {
+	SeasonRepo repository.SeasonRepo
+}
+
+func NewTestSeasonService(s repository.SeasonRepo) services.SeasonService {
+	return &testSeasonService{SeasonRepo: s}
+}
+
+func testSeason(ID, leagueID int) models.Season {
+	return models.Season{
+		ID:        ID,
+		LeagueID:  leagueID,
+		Name:      "2024",
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		Status:    models.SeasonStatusActive,
+	}
+}
+
+func (m *testSeasonService) GetSeason(ID int) (models.Season, error) {
+	if ID == 3 {
+		return models.Season{}, errors.New("season doesn't exist")
+	}
+	return testSeason(ID, 1), nil
+}
+
+func (m *testSeasonService) GetSeasonsInLeague(leagueID int) ([]models.Season, error) {
+	var s []models.Season
+	if leagueID == 7 {
+		return s, errors.New("season error")
+	}
+	if leagueID == 6 {
+		return s, nil
+	}
+	return append(s, testSeason(leagueID, leagueID)), nil
+}
+
+func (m *testSeasonService) GetActiveSeason(leagueID int) (models.Season, error) {
+	if leagueID == 6 {
+		return models.Season{}, errors.New("no active season")
+	}
+	return testSeason(leagueID, leagueID), nil
+}
+
+func (m *testSeasonService) CreateSeason(season models.Season) (int, error) {
+	if season.Name == "Season Error" {
+		return 0, errors.New("error inserting season in DB")
+	}
+	return 1, nil
+}
+
+func (m *testSeasonService) OpenSeason(ID int) error {
+	if ID == 5 {
+		return errors.New("league already has an active season")
+	}
+	return nil
+}
+
+func (m *testSeasonService) CloseSeason(ID int) error {
+	if ID == 5 {
+		return errors.New("season not active")
+	}
+	return nil
+}
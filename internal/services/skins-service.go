@@ -0,0 +1,10 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type SkinsService interface {
+	GetSkinsGame(weekID int) (models.SkinsGame, error)
+	SaveSkinsGame(week models.ScheduleWeek, game models.SkinsGame, players []models.Player) error
+	GetWeekRounds(week models.ScheduleWeek) ([]models.Round, error)
+	GetSkinsResult(game models.SkinsGame, rounds []models.Round) models.SkinsResult
+}
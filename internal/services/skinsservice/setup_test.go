@@ -0,0 +1,24 @@
+package skinsservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/courserepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/scorerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/skinsrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.SkinsService
+
+func TestMain(m *testing.M) {
+	skinsRepo := skinsrepo.NewTestSkinsRepo()
+	scoreRepo := scorerepo.NewTestScoreRepo()
+	courseRepo := courserepo.NewTestCourseRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewSkinsService(skinsRepo, scoreRepo, courseRepo, dbManager)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,163 @@
+package skinsservice
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/skins"
+)
+
+// minimumEntries is the fewest players a skins game can be played with
+const minimumEntries = 2
+
+type skinsService struct {
+	SkinsRepo  repository.SkinsRepo
+	ScoreRepo  repository.ScoreRepo
+	CourseRepo repository.CourseRepo
+	DBManager  repository.DBManager
+}
+
+func NewSkinsService(sk repository.SkinsRepo, s repository.ScoreRepo, c repository.CourseRepo, m repository.DBManager) services.SkinsService {
+	return &skinsService{
+		SkinsRepo:  sk,
+		ScoreRepo:  s,
+		CourseRepo: c,
+		DBManager:  m,
+	}
+}
+
+// GetSkinsGame returns a week's skins game. A week without one gets an empty
+// game with nobody entered
+func (m *skinsService) GetSkinsGame(weekID int) (models.SkinsGame, error) {
+	game, err := m.SkinsRepo.GetSkinsGameByWeekID(weekID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.SkinsGame{ScheduleWeekID: weekID, Carryovers: true}, nil
+	}
+	return game, err
+}
+
+// SaveSkinsGame sets up a week's skins game, replacing the pot, rules and
+// entries of any game the week already has. Only the league's active players
+// can be entered
+func (m *skinsService) SaveSkinsGame(week models.ScheduleWeek, game models.SkinsGame, players []models.Player) error {
+	if game.Pot < 0 {
+		return errors.New("the pot can't be negative")
+	}
+
+	if !models.IsSkinsValidation(game.Validation) {
+		return errors.New("unknown skins validation rule")
+	}
+
+	if len(game.PlayerIDs) < minimumEntries {
+		return errors.New("a skins game needs at least two players")
+	}
+
+	active := make(map[int]bool)
+	for _, p := range players {
+		if p.IsActive {
+			active[p.ID] = true
+		}
+	}
+
+	entered := make(map[int]bool)
+	for _, id := range game.PlayerIDs {
+		if !active[id] {
+			return errors.New("every player in a skins game must be active in the league")
+		}
+		if entered[id] {
+			return errors.New("a player can only be entered in a skins game once")
+		}
+		entered[id] = true
+	}
+
+	game.ScheduleWeekID = week.ID
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	gameID, err := m.SkinsRepo.SaveSkinsGameTransaction(game, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = m.SkinsRepo.DeleteSkinsEntriesTransaction(gameID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range game.PlayerIDs {
+		err = m.SkinsRepo.AddSkinsEntryTransaction(gameID, id, ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
+
+// GetWeekRounds returns the first round each player posted during a week, with
+// its hole scores and tee set's holes, ordered by player name
+func (m *skinsService) GetWeekRounds(week models.ScheduleWeek) ([]models.Round, error) {
+	rounds, err := m.ScoreRepo.GetRoundsBySeasonID(week.SeasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	first := make(map[int]models.Round)
+	for _, r := range rounds {
+		if !week.Includes(r.PlayedOn) {
+			continue
+		}
+		if found, ok := first[r.PlayerID]; !ok || r.PlayedOn.Before(found.PlayedOn) {
+			first[r.PlayerID] = r
+		}
+	}
+
+	teeSets := make(map[int]models.TeeSet)
+	var played []models.Round
+	for _, r := range first {
+		teeSet, ok := teeSets[r.TeeSetID]
+		if !ok {
+			teeSet, err = m.CourseRepo.GetTeeSetByID(r.TeeSetID)
+			if err != nil {
+				return nil, err
+			}
+			teeSets[r.TeeSetID] = teeSet
+		}
+		r.TeeSet.Holes = teeSet.Holes
+		played = append(played, r)
+	}
+
+	sort.Slice(played, func(i, j int) bool {
+		a, b := played[i].Player.User, played[j].Player.User
+		if !strings.EqualFold(a.LastName, b.LastName) {
+			return strings.ToLower(a.LastName) < strings.ToLower(b.LastName)
+		}
+		if !strings.EqualFold(a.FirstName, b.FirstName) {
+			return strings.ToLower(a.FirstName) < strings.ToLower(b.FirstName)
+		}
+		return played[i].PlayerID < played[j].PlayerID
+	})
+
+	return played, nil
+}
+
+// GetSkinsResult settles a skins game from the week's rounds of the players
+// entered in it
+func (m *skinsService) GetSkinsResult(game models.SkinsGame, rounds []models.Round) models.SkinsResult {
+	var entered []models.Round
+	for _, r := range rounds {
+		if game.HasPlayer(r.PlayerID) {
+			entered = append(entered, r)
+		}
+	}
+	return skins.Calculate(game, entered)
+}
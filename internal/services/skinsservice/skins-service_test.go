@@ -0,0 +1,120 @@
+package skinsservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var getSkinsGameTests = []struct {
+	name            string
+	weekID          int
+	expectedEntries int
+	expectError     bool
+}{
+	{"game", 1, 2, false},
+	{"week without a game", 4, 0, false},
+	{"error", 7, 0, true},
+}
+
+func TestGetSkinsGame(t *testing.T) {
+	for _, e := range getSkinsGameTests {
+		game, err := service.GetSkinsGame(e.weekID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if !e.expectError && game.ScheduleWeekID != e.weekID {
+			t.Errorf("failed %s: expected game for week %d, but got week %d", e.name, e.weekID, game.ScheduleWeekID)
+		}
+		if len(game.PlayerIDs) != e.expectedEntries {
+			t.Errorf("failed %s: expected %d entries, but got %d", e.name, e.expectedEntries, len(game.PlayerIDs))
+		}
+	}
+}
+
+// players are active in the league apart from player 5
+var players = []models.Player{
+	{ID: 1, IsActive: true},
+	{ID: 2, IsActive: true},
+	{ID: 3, IsActive: true},
+	{ID: 5, IsActive: false},
+	{ID: 99, IsActive: true},
+}
+
+var saveSkinsGameTests = []struct {
+	name        string
+	week        models.ScheduleWeek
+	game        models.SkinsGame
+	expectError bool
+}{
+	{"valid", models.ScheduleWeek{ID: 1}, models.SkinsGame{Pot: 2000, PlayerIDs: []int{1, 2, 3}}, false},
+	{"validated", models.ScheduleWeek{ID: 1}, models.SkinsGame{Pot: 2000, Validation: models.SkinsValidationNextHole, PlayerIDs: []int{1, 2}}, false},
+	{"negative pot", models.ScheduleWeek{ID: 1}, models.SkinsGame{Pot: -100, PlayerIDs: []int{1, 2}}, true},
+	{"unknown validation", models.ScheduleWeek{ID: 1}, models.SkinsGame{Validation: "birdies", PlayerIDs: []int{1, 2}}, true},
+	{"one player", models.ScheduleWeek{ID: 1}, models.SkinsGame{PlayerIDs: []int{1}}, true},
+	{"player not in league", models.ScheduleWeek{ID: 1}, models.SkinsGame{PlayerIDs: []int{1, 7}}, true},
+	{"inactive player", models.ScheduleWeek{ID: 1}, models.SkinsGame{PlayerIDs: []int{1, 5}}, true},
+	{"player entered twice", models.ScheduleWeek{ID: 1}, models.SkinsGame{PlayerIDs: []int{1, 1}}, true},
+	{"game save fails", models.ScheduleWeek{ID: 5}, models.SkinsGame{PlayerIDs: []int{1, 2}}, true},
+	{"entry fails", models.ScheduleWeek{ID: 1}, models.SkinsGame{PlayerIDs: []int{1, 99}}, true},
+}
+
+func TestSaveSkinsGame(t *testing.T) {
+	for _, e := range saveSkinsGameTests {
+		err := service.SaveSkinsGame(e.week, e.game, players)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+// the test score repo's season 9 round was played on the zero date on a tee set
+// the test course repo can't load
+var getWeekRoundsTests = []struct {
+	name           string
+	week           models.ScheduleWeek
+	expectedRounds int
+	expectError    bool
+}{
+	{"no rounds", models.ScheduleWeek{ID: 1, SeasonID: 1}, 0, false},
+	{"round in the week", models.ScheduleWeek{ID: 1, SeasonID: 9}, 0, true},
+	{"round outside the week", models.ScheduleWeek{ID: 1, SeasonID: 9, PlayDate: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}, 0, false},
+	{"rounds error", models.ScheduleWeek{ID: 1, SeasonID: 5}, 0, true},
+}
+
+func TestGetWeekRounds(t *testing.T) {
+	for _, e := range getWeekRoundsTests {
+		rounds, err := service.GetWeekRounds(e.week)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if len(rounds) != e.expectedRounds {
+			t.Errorf("failed %s: expected %d rounds, but got %d", e.name, e.expectedRounds, len(rounds))
+		}
+	}
+}
+
+func TestGetSkinsResult(t *testing.T) {
+	holes := []models.Hole{{Number: 1, Par: 4, StrokeIndex: 1}}
+	rounds := []models.Round{
+		{PlayerID: 1, Player: models.Player{ID: 1}, TeeSet: models.TeeSet{Holes: holes}, HoleScores: []models.HoleScore{{HoleNumber: 1, Strokes: 4}}},
+		{PlayerID: 2, Player: models.Player{ID: 2}, TeeSet: models.TeeSet{Holes: holes}, HoleScores: []models.HoleScore{{HoleNumber: 1, Strokes: 5}}},
+		{PlayerID: 3, Player: models.Player{ID: 3}, TeeSet: models.TeeSet{Holes: holes}, HoleScores: []models.HoleScore{{HoleNumber: 1, Strokes: 3}}},
+	}
+
+	// player 3 had the best score but didn't enter
+	result := service.GetSkinsResult(models.SkinsGame{Pot: 1000, PlayerIDs: []int{1, 2}}, rounds)
+	if len(result.Winners) != 1 || result.Winners[0].Player.ID != 1 {
+		t.Errorf("expected player 1 to win the only skin, but got %v", result.Winners)
+	}
+}
@@ -0,0 +1,58 @@
+package skinsservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/skins"
+)
+
+type testSkinsService struct {
+	SkinsRepo repository.SkinsRepo
+}
+
+func NewTestSkinsService(sk repository.SkinsRepo) services.SkinsService {
+	return &testSkinsService{SkinsRepo: sk}
+}
+
+func (m *testSkinsService) GetSkinsGame(weekID int) (models.SkinsGame, error) {
+	if weekID == 7 {
+		return models.SkinsGame{}, errors.New("skins error")
+	}
+	return models.SkinsGame{ID: 1, ScheduleWeekID: weekID, Pot: 2000, Carryovers: true, PlayerIDs: []int{1, 2}}, nil
+}
+
+func (m *testSkinsService) SaveSkinsGame(week models.ScheduleWeek, game models.SkinsGame, players []models.Player) error {
+	if game.Pot == 99900 {
+		return errors.New("skins game creation failed")
+	}
+	return nil
+}
+
+func (m *testSkinsService) GetWeekRounds(week models.ScheduleWeek) ([]models.Round, error) {
+	if week.ID == 8 {
+		return nil, errors.New("rounds error")
+	}
+	var rounds []models.Round
+	for id := 1; id <= 2; id++ {
+		round := models.Round{
+			PlayerID:   id,
+			PlayedOn:   week.PlayDate,
+			GrossScore: 36 + id,
+			Player:     models.Player{ID: id, User: models.User{FirstName: "Test", LastName: "Player"}},
+		}
+		for number := 1; number <= 9; number++ {
+			round.TeeSet.Holes = append(round.TeeSet.Holes, models.Hole{Number: number, Par: 4, StrokeIndex: number})
+			round.HoleScores = append(round.HoleScores, models.HoleScore{HoleNumber: number, Strokes: 4})
+		}
+		round.HoleScores[id-1].Strokes = 3
+		rounds = append(rounds, round)
+	}
+	return rounds, nil
+}
+
+func (m *testSkinsService) GetSkinsResult(game models.SkinsGame, rounds []models.Round) models.SkinsResult {
+	return skins.Calculate(game, rounds)
+}
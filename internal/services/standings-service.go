@@ -0,0 +1,8 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type StandingsService interface {
+	GetStandings(league models.League, season models.Season, players []models.Player, sortBy string) (models.Standings, error)
+	GetTeamStandings(season models.Season, teams []models.Team, format string) (models.TeamStandings, error)
+}
@@ -0,0 +1,22 @@
+package standingsservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/courserepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/schedulerepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/scorerepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.StandingsService
+
+func TestMain(m *testing.M) {
+	scoreRepo := scorerepo.NewTestScoreRepo()
+	scheduleRepo := schedulerepo.NewTestScheduleRepo()
+	courseRepo := courserepo.NewTestCourseRepo()
+	service = NewStandingsService(scoreRepo, scheduleRepo, courseRepo)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,92 @@
+package standingsservice
+
+import (
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/standings"
+)
+
+type standingsService struct {
+	ScoreRepo    repository.ScoreRepo
+	ScheduleRepo repository.ScheduleRepo
+	CourseRepo   repository.CourseRepo
+}
+
+func NewStandingsService(s repository.ScoreRepo, sch repository.ScheduleRepo, c repository.CourseRepo) services.StandingsService {
+	return &standingsService{
+		ScoreRepo:    s,
+		ScheduleRepo: sch,
+		CourseRepo:   c,
+	}
+}
+
+// GetStandings ranks the season's active players from the rounds posted in the
+// season and the matchups of its published schedule, scored in the league's
+// formats
+func (m *standingsService) GetStandings(league models.League, season models.Season, players []models.Player, sortBy string) (models.Standings, error) {
+	rounds, weeks, err := m.seasonResults(season.ID)
+	if err != nil {
+		return models.Standings{}, err
+	}
+
+	var active []models.Player
+	for _, p := range players {
+		if p.IsActive {
+			active = append(active, p)
+		}
+	}
+
+	return standings.Calculate(league, active, rounds, weeks, sortBy), nil
+}
+
+// GetTeamStandings ranks the season's teams in a team format from the rounds
+// their players posted in each week of the published schedule
+func (m *standingsService) GetTeamStandings(season models.Season, teams []models.Team, format string) (models.TeamStandings, error) {
+	rounds, weeks, err := m.seasonResults(season.ID)
+	if err != nil {
+		return models.TeamStandings{}, err
+	}
+
+	return standings.CalculateTeams(format, teams, rounds, weeks), nil
+}
+
+// seasonResults returns the rounds posted in a season, with their tee set's
+// holes, and the weeks of its published schedule
+func (m *standingsService) seasonResults(seasonID int) ([]models.Round, []models.ScheduleWeek, error) {
+	rounds, err := m.ScoreRepo.GetRoundsBySeasonID(seasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// match play, Stableford and best ball are scored hole by hole, so rounds
+	// need the par and stroke index of their tee set's holes
+	teeSets := make(map[int]models.TeeSet)
+	for i, round := range rounds {
+		teeSet, ok := teeSets[round.TeeSetID]
+		if !ok {
+			teeSet, err = m.CourseRepo.GetTeeSetByID(round.TeeSetID)
+			if err != nil {
+				return nil, nil, err
+			}
+			teeSets[round.TeeSetID] = teeSet
+		}
+		rounds[i].TeeSet.Holes = teeSet.Holes
+	}
+
+	weeks, err := m.ScheduleRepo.GetScheduleWeeksBySeasonID(seasonID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// players only see a schedule once it's published, so draft matchups
+	// don't count
+	var published []models.ScheduleWeek
+	for _, w := range weeks {
+		if w.IsPublished {
+			published = append(published, w)
+		}
+	}
+
+	return rounds, published, nil
+}
@@ -0,0 +1,69 @@
+package standingsservice
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var players = []models.Player{
+	{ID: 1, IsActive: true},
+	{ID: 2, IsActive: true},
+	{ID: 3, IsActive: false},
+}
+
+var getStandingsTests = []struct {
+	name            string
+	seasonID        int
+	expectedPlayers int
+	expectError     bool
+}{
+	{"rounds error", 5, 0, true},
+	{"schedule error", 3, 0, true},
+	{"tee set error", 9, 0, true},
+	{"success", 1, 2, false},
+}
+
+func TestGetStandings(t *testing.T) {
+	for _, e := range getStandingsTests {
+		standings, err := service.GetStandings(models.League{ScoringFormat: models.FormatStrokePlay}, models.Season{ID: e.seasonID}, players, models.StandingsByPoints)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if len(standings.Players) != e.expectedPlayers {
+			t.Errorf("failed %s: expected %d players in the standings, but got %d", e.name, e.expectedPlayers, len(standings.Players))
+		}
+	}
+}
+
+var getTeamStandingsTests = []struct {
+	name          string
+	seasonID      int
+	expectedTeams int
+	expectError   bool
+}{
+	{"rounds error", 5, 0, true},
+	{"schedule error", 3, 0, true},
+	{"tee set error", 9, 0, true},
+	{"success", 1, 1, false},
+}
+
+func TestGetTeamStandings(t *testing.T) {
+	teams := []models.Team{{ID: 1, Players: players[:2]}}
+
+	for _, e := range getTeamStandingsTests {
+		standings, err := service.GetTeamStandings(models.Season{ID: e.seasonID}, teams, models.TeamFormatBestBallNet)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+		if len(standings.Teams) != e.expectedTeams {
+			t.Errorf("failed %s: expected %d teams in the standings, but got %d", e.name, e.expectedTeams, len(standings.Teams))
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package standingsservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/standings"
+)
+
+type testStandingsService struct {
+	ScoreRepo repository.ScoreRepo
+}
+
+func NewTestStandingsService(s repository.ScoreRepo) services.StandingsService {
+	return &testStandingsService{ScoreRepo: s}
+}
+
+func (m *testStandingsService) GetStandings(league models.League, season models.Season, players []models.Player, sortBy string) (models.Standings, error) {
+	if season.ID == 5 {
+		return models.Standings{}, errors.New("standings error")
+	}
+	return standings.Calculate(league, players, nil, nil, sortBy), nil
+}
+
+func (m *testStandingsService) GetTeamStandings(season models.Season, teams []models.Team, format string) (models.TeamStandings, error) {
+	if season.ID == 5 {
+		return models.TeamStandings{}, errors.New("standings error")
+	}
+	return standings.CalculateTeams(format, teams, nil, nil), nil
+}
@@ -0,0 +1,10 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type TeamService interface {
+	GetTeam(ID int) (models.Team, error)
+	GetTeamsInSeason(seasonID int) ([]models.Team, error)
+	CreateTeam(season models.Season, team models.Team, players []models.Player) (int, error)
+	DeleteTeam(ID int) error
+}
@@ -0,0 +1,20 @@
+package teamservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/teamrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.TeamService
+
+func TestMain(m *testing.M) {
+	teamRepo := teamrepo.NewTestTeamRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewTeamService(teamRepo, dbManager)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,112 @@
+package teamservice
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+// minimumPlayers is the fewest players a team can have
+const minimumPlayers = 2
+
+type teamService struct {
+	TeamRepo  repository.TeamRepo
+	DBManager repository.DBManager
+}
+
+func NewTeamService(t repository.TeamRepo, m repository.DBManager) services.TeamService {
+	return &teamService{
+		TeamRepo:  t,
+		DBManager: m,
+	}
+}
+
+func (m *teamService) GetTeam(ID int) (models.Team, error) {
+	return m.TeamRepo.GetTeamByID(ID)
+}
+
+func (m *teamService) GetTeamsInSeason(seasonID int) ([]models.Team, error) {
+	return m.TeamRepo.GetTeamsBySeasonID(seasonID)
+}
+
+// CreateTeam adds a team of the league's active players to a season. A player
+// can only be on one team a season
+func (m *teamService) CreateTeam(season models.Season, team models.Team, players []models.Player) (int, error) {
+	if season.Status == models.SeasonStatusCompleted {
+		return 0, errors.New("teams can't be added to a completed season")
+	}
+
+	team.Name = strings.TrimSpace(team.Name)
+	if team.Name == "" {
+		return 0, errors.New("a team needs a name")
+	}
+
+	if len(team.Players) < minimumPlayers {
+		return 0, errors.New("a team needs at least two players")
+	}
+
+	active := make(map[int]bool)
+	for _, p := range players {
+		if p.IsActive {
+			active[p.ID] = true
+		}
+	}
+
+	existing, err := m.TeamRepo.GetTeamsBySeasonID(season.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, t := range existing {
+		if strings.EqualFold(t.Name, team.Name) {
+			return 0, errors.New("there is already a team with that name this season")
+		}
+	}
+
+	added := make(map[int]bool)
+	for _, p := range team.Players {
+		if !active[p.ID] {
+			return 0, errors.New("every player on a team must be active in the league")
+		}
+		if added[p.ID] {
+			return 0, errors.New("a player can only be added to a team once")
+		}
+		added[p.ID] = true
+
+		for _, t := range existing {
+			if t.HasPlayer(p.ID) {
+				return 0, errors.New("a player is already on a team this season")
+			}
+		}
+	}
+
+	team.LeagueID = season.LeagueID
+	team.SeasonID = season.ID
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	teamID, err := m.TeamRepo.CreateTeamTransaction(team, ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, p := range team.Players {
+		err = m.TeamRepo.AddPlayerToTeamTransaction(teamID, p.ID, ctx, tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return teamID, m.DBManager.CommitTransaction(tx)
+}
+
+func (m *teamService) DeleteTeam(ID int) error {
+	return m.TeamRepo.DeleteTeam(ID)
+}
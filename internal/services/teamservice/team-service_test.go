@@ -0,0 +1,124 @@
+package teamservice
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func TestGetTeam(t *testing.T) {
+	service.GetTeam(1)
+}
+
+func TestGetTeamsInSeason(t *testing.T) {
+	service.GetTeamsInSeason(1)
+}
+
+func TestDeleteTeam(t *testing.T) {
+	service.DeleteTeam(1)
+}
+
+// testPlayers returns active players with the given IDs
+func testPlayers(ids ...int) []models.Player {
+	var players []models.Player
+	for _, id := range ids {
+		players = append(players, models.Player{ID: id, IsActive: true})
+	}
+	return players
+}
+
+var activeSeason = models.Season{ID: 2, LeagueID: 1, Status: models.SeasonStatusActive}
+
+var createTeamTests = []struct {
+	name        string
+	season      models.Season
+	team        models.Team
+	expectError bool
+}{
+	{
+		"completed season",
+		models.Season{ID: 2, LeagueID: 1, Status: models.SeasonStatusCompleted},
+		models.Team{Name: "New Team", Players: testPlayers(3, 4)},
+		true,
+	},
+	{
+		"missing name",
+		activeSeason,
+		models.Team{Name: " ", Players: testPlayers(3, 4)},
+		true,
+	},
+	{
+		"one player",
+		activeSeason,
+		models.Team{Name: "New Team", Players: testPlayers(3)},
+		true,
+	},
+	{
+		"player not in league",
+		activeSeason,
+		models.Team{Name: "New Team", Players: testPlayers(3, 7)},
+		true,
+	},
+	{
+		"inactive player",
+		activeSeason,
+		models.Team{Name: "New Team", Players: testPlayers(3, 5)},
+		true,
+	},
+	{
+		"player added twice",
+		activeSeason,
+		models.Team{Name: "New Team", Players: testPlayers(3, 3)},
+		true,
+	},
+	{
+		"player already on a team",
+		activeSeason,
+		models.Team{Name: "New Team", Players: testPlayers(1, 3)},
+		true,
+	},
+	{
+		"name taken",
+		activeSeason,
+		models.Team{Name: "team", Players: testPlayers(3, 4)},
+		true,
+	},
+	{
+		"teams error",
+		models.Season{ID: 3, LeagueID: 1, Status: models.SeasonStatusActive},
+		models.Team{Name: "New Team", Players: testPlayers(3, 4)},
+		true,
+	},
+	{
+		"error inserting team",
+		activeSeason,
+		models.Team{Name: "Team Error", Players: testPlayers(3, 4)},
+		true,
+	},
+	{
+		"error inserting team player",
+		activeSeason,
+		models.Team{Name: "New Team", Players: testPlayers(3, 99)},
+		true,
+	},
+	{
+		"success",
+		activeSeason,
+		models.Team{Name: "New Team", Players: testPlayers(3, 4, 6)},
+		false,
+	},
+}
+
+func TestCreateTeam(t *testing.T) {
+	players := append(testPlayers(1, 2, 3, 4, 6, 99), models.Player{ID: 5})
+
+	for _, e := range createTeamTests {
+		_, err := service.CreateTeam(e.season, e.team, players)
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error but got one", e.name)
+		}
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error but got none", e.name)
+		}
+	}
+}
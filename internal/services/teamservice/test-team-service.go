@@ -0,0 +1,59 @@
+package teamservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testTeamService struct {
+	TeamRepo repository.TeamRepo
+}
+
+func NewTestTeamService(t repository.TeamRepo) services.TeamService {
+	return &testTeamService{TeamRepo: t}
+}
+
+func testTeam(ID, seasonID int) models.Team {
+	return models.Team{
+		ID:       ID,
+		LeagueID: 1,
+		SeasonID: seasonID,
+		Name:     "Team",
+		Players: []models.Player{
+			{ID: 1, LeagueID: 1, IsActive: true, User: models.User{FirstName: "First", LastName: "Player"}},
+			{ID: 2, LeagueID: 1, IsActive: true, User: models.User{FirstName: "Second", LastName: "Player"}},
+		},
+	}
+}
+
+func (m *testTeamService) GetTeam(ID int) (models.Team, error) {
+	if ID == 3 {
+		return models.Team{}, errors.New("team doesn't exist")
+	}
+	return testTeam(ID, 1), nil
+}
+
+func (m *testTeamService) GetTeamsInSeason(seasonID int) ([]models.Team, error) {
+	var t []models.Team
+	if seasonID == 8 {
+		return t, errors.New("teams error")
+	}
+	return append(t, testTeam(1, seasonID)), nil
+}
+
+func (m *testTeamService) CreateTeam(season models.Season, team models.Team, players []models.Player) (int, error) {
+	if team.Name == "Team Error" {
+		return 0, errors.New("a player is already on a team this season")
+	}
+	return 1, nil
+}
+
+func (m *testTeamService) DeleteTeam(ID int) error {
+	if ID == 5 {
+		return errors.New("team deletion failed")
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type TwoFactorService interface {
+	NewSecret(user models.User) (string, string, error)
+	EnableTwoFactor(userID int, secret, code string) ([]string, error)
+	DisableTwoFactor(user models.User, code string) error
+	VerifyCode(user models.User, code string) error
+	RecoveryCodesLeft(userID int) (int, error)
+	IsRequired(userID int) (bool, error)
+	RequiredForCommissioners() (bool, error)
+	SetRequiredForCommissioners(required bool) error
+}
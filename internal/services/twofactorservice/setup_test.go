@@ -0,0 +1,26 @@
+package twofactorservice
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/playerrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/recoverycoderepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/settingrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+var service services.TwoFactorService
+
+func TestMain(m *testing.M) {
+	userRepo := userrepo.NewTestUserRepo()
+	recoveryCodeRepo := recoverycoderepo.NewTestRecoveryCodeRepo()
+	settingRepo := settingrepo.NewTestSettingRepo()
+	playerRepo := playerrepo.NewTestPlayerRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewTwoFactorService(userRepo, recoveryCodeRepo, settingRepo, playerRepo, dbManager)
+
+	os.Exit(m.Run())
+}
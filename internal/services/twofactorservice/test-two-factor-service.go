@@ -0,0 +1,63 @@
+package twofactorservice
+
+import (
+	"errors"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/totp"
+)
+
+type testTwoFactorService struct {
+	UserRepo repository.UserRepo
+}
+
+func NewTestTwoFactorService(u repository.UserRepo) services.TwoFactorService {
+	return &testTwoFactorService{UserRepo: u}
+}
+
+func (m *testTwoFactorService) NewSecret(user models.User) (string, string, error) {
+	secret := "JBSWY3DPEHPK3PXP"
+	return secret, totp.URI(models.TwoFactorIssuer, user.Email, secret), nil
+}
+
+func (m *testTwoFactorService) EnableTwoFactor(userID int, secret, code string) ([]string, error) {
+	if code != "123456" {
+		return nil, errors.New("code is incorrect")
+	}
+	return []string{"abcd-2345", "efgh-6789"}, nil
+}
+
+func (m *testTwoFactorService) DisableTwoFactor(user models.User, code string) error {
+	if user.ID == 11 {
+		return errors.New("two-factor authentication is required for commissioners")
+	}
+	if code != "123456" {
+		return errors.New("code is incorrect")
+	}
+	return nil
+}
+
+func (m *testTwoFactorService) VerifyCode(user models.User, code string) error {
+	if code != "123456" {
+		return errors.New("code is incorrect")
+	}
+	return nil
+}
+
+func (m *testTwoFactorService) RecoveryCodesLeft(userID int) (int, error) {
+	return models.RecoveryCodeCount, nil
+}
+
+func (m *testTwoFactorService) IsRequired(userID int) (bool, error) {
+	return userID == 11, nil
+}
+
+func (m *testTwoFactorService) RequiredForCommissioners() (bool, error) {
+	return false, nil
+}
+
+func (m *testTwoFactorService) SetRequiredForCommissioners(required bool) error {
+	return nil
+}
@@ -0,0 +1,221 @@
+package twofactorservice
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+	"github.com/jdonahue135/golf-league-app/internal/totp"
+)
+
+// recoveryCodeAlphabet leaves out letters and digits that look alike
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// recoveryCodeLength is how many characters are in a recovery code, not
+// counting the dash in the middle
+const recoveryCodeLength = 8
+
+type twoFactorService struct {
+	UserRepo         repository.UserRepo
+	RecoveryCodeRepo repository.RecoveryCodeRepo
+	SettingRepo      repository.SettingRepo
+	PlayerRepo       repository.PlayerRepo
+	DBManager        repository.DBManager
+}
+
+func NewTwoFactorService(u repository.UserRepo, r repository.RecoveryCodeRepo, s repository.SettingRepo, p repository.PlayerRepo, m repository.DBManager) services.TwoFactorService {
+	return &twoFactorService{
+		UserRepo:         u,
+		RecoveryCodeRepo: r,
+		SettingRepo:      s,
+		PlayerRepo:       p,
+		DBManager:        m,
+	}
+}
+
+// NewSecret returns a secret for the user to set up their authenticator app
+// with, and the otpauth URI the app can read it from. Nothing is saved until
+// the user proves the app works with EnableTwoFactor
+func (m *twoFactorService) NewSecret(user models.User) (string, string, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	return secret, totp.URI(models.TwoFactorIssuer, user.Email, secret), nil
+}
+
+// EnableTwoFactor turns on two-factor authentication with secret once code
+// shows the user's app is set up with it. It returns the user's recovery
+// codes, which can't be looked up again
+func (m *twoFactorService) EnableTwoFactor(userID int, secret, code string) ([]string, error) {
+	if _, ok := totp.Validate(secret, code, time.Now()); !ok {
+		return nil, errors.New("code is incorrect, check your authenticator app and try again")
+	}
+
+	codes, codeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.UserRepo.EnableTwoFactorTransaction(userID, secret, ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.RecoveryCodeRepo.DeleteRecoveryCodesTransaction(userID, ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.RecoveryCodeRepo.CreateRecoveryCodesTransaction(userID, codeHashes, ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.DBManager.CommitTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// DisableTwoFactor turns off two-factor authentication for a user, who has
+// to enter a code to prove it's them. Commissioners can't turn it off while
+// super admins require it
+func (m *twoFactorService) DisableTwoFactor(user models.User, code string) error {
+	required, err := m.IsRequired(user.ID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.New("two-factor authentication is required for commissioners")
+	}
+
+	if err = m.VerifyCode(user, code); err != nil {
+		return err
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	err = m.UserRepo.DisableTwoFactorTransaction(user.ID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = m.RecoveryCodeRepo.DeleteRecoveryCodesTransaction(user.ID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
+
+// VerifyCode checks a code from the user's authenticator app, or one of their
+// recovery codes. Either only works once
+func (m *twoFactorService) VerifyCode(user models.User, code string) error {
+	if !user.HasTwoFactor() {
+		return errors.New("two-factor authentication isn't turned on")
+	}
+
+	if step, ok := totp.Validate(user.TOTPSecret, code, time.Now()); ok {
+		return m.UserRepo.UseTOTPStep(user.ID, step)
+	}
+
+	err := m.RecoveryCodeRepo.UseRecoveryCode(user.ID, tokens.Hash(normalizeRecoveryCode(code)))
+	if err != nil {
+		return errors.New("code is incorrect")
+	}
+
+	return nil
+}
+
+func (m *twoFactorService) RecoveryCodesLeft(userID int) (int, error) {
+	return m.RecoveryCodeRepo.CountUnusedRecoveryCodes(userID)
+}
+
+// IsRequired reports whether the user has to have two-factor authentication
+// turned on, because they're a commissioner and super admins require it
+func (m *twoFactorService) IsRequired(userID int) (bool, error) {
+	required, err := m.RequiredForCommissioners()
+	if err != nil || !required {
+		return false, err
+	}
+
+	players, err := m.PlayerRepo.GetPlayersByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range players {
+		if p.IsCommissioner && p.IsActive {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (m *twoFactorService) RequiredForCommissioners() (bool, error) {
+	value, err := m.SettingRepo.GetSetting(models.SettingRequireCommissionerTwoFactor)
+	if err != nil {
+		return false, err
+	}
+	return value == "true", nil
+}
+
+func (m *twoFactorService) SetRequiredForCommissioners(required bool) error {
+	value := "false"
+	if required {
+		value = "true"
+	}
+	return m.SettingRepo.SaveSetting(models.SettingRequireCommissionerTwoFactor, value)
+}
+
+// generateRecoveryCodes returns RecoveryCodeCount new codes to show the user,
+// along with the hashes to store for them
+func generateRecoveryCodes() ([]string, []string, error) {
+	var codes, codeHashes []string
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	for i := 0; i < models.RecoveryCodeCount; i++ {
+		b := make([]byte, recoveryCodeLength)
+		for j := range b {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return nil, nil, err
+			}
+			b[j] = recoveryCodeAlphabet[n.Int64()]
+		}
+
+		code := string(b)
+		codes = append(codes, code[:recoveryCodeLength/2]+"-"+code[recoveryCodeLength/2:])
+		codeHashes = append(codeHashes, tokens.Hash(code))
+	}
+
+	return codes, codeHashes, nil
+}
+
+// normalizeRecoveryCode lets a recovery code be typed with or without its
+// dash, spaces or capitals
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.Replace(code, "-", "", -1)
+	return strings.Replace(code, " ", "", -1)
+}
@@ -0,0 +1,162 @@
+package twofactorservice
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/totp"
+)
+
+const testSecret = "JBSWY3DPEHPK3PXP"
+
+func currentCode() string {
+	code, _ := totp.Code(testSecret, time.Now())
+	return code
+}
+
+func TestNewSecret(t *testing.T) {
+	secret, uri, err := service.NewSecret(models.User{Email: "me@here.ca"})
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err.Error())
+	}
+	if secret == "" || !strings.Contains(uri, secret) {
+		t.Errorf("expected a secret in the uri, but got secret %q and uri %q", secret, uri)
+	}
+}
+
+var enableTwoFactorTests = []struct {
+	name        string
+	userID      int
+	code        string
+	expectError bool
+}{
+	{"success", 1, currentCode(), false},
+	{"wrong code", 1, "000000", true},
+	{"enable error", 3, currentCode(), true},
+	{"create codes error", 4, currentCode(), true},
+	{"delete codes error", 5, currentCode(), true},
+}
+
+func TestEnableTwoFactor(t *testing.T) {
+	for _, e := range enableTwoFactorTests {
+		codes, err := service.EnableTwoFactor(e.userID, testSecret, e.code)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if !e.expectError && len(codes) != models.RecoveryCodeCount {
+			t.Errorf("failed %s: expected %d recovery codes, but got %d", e.name, models.RecoveryCodeCount, len(codes))
+		}
+	}
+}
+
+func TestRecoveryCodesAreUnique(t *testing.T) {
+	codes, _, err := generateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err.Error())
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != recoveryCodeLength+1 || code[recoveryCodeLength/2] != '-' {
+			t.Errorf("expected a code like abcd-2345, but got %s", code)
+		}
+		if seen[code] {
+			t.Errorf("expected unique codes, but got %s twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func twoFactorUser(ID int) models.User {
+	return models.User{ID: ID, TOTPSecret: testSecret, TOTPEnabledAt: time.Now()}
+}
+
+var verifyCodeTests = []struct {
+	name        string
+	user        models.User
+	code        string
+	expectError bool
+}{
+	{"authenticator code", twoFactorUser(1), currentCode(), false},
+	{"recovery code", twoFactorUser(1), "abcd-2345", false},
+	{"recovery code typed differently", twoFactorUser(1), "ABCD 2345", false},
+	{"wrong code", twoFactorUser(1), "000000", true},
+	{"used authenticator code", twoFactorUser(3), currentCode(), true},
+	{"two-factor not turned on", models.User{ID: 1}, currentCode(), true},
+}
+
+func TestVerifyCode(t *testing.T) {
+	for _, e := range verifyCodeTests {
+		err := service.VerifyCode(e.user, e.code)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+func TestRequiredForCommissioners(t *testing.T) {
+	defer service.SetRequiredForCommissioners(false)
+
+	if required, _ := service.IsRequired(1); required {
+		t.Error("expected two-factor not to be required before it's turned on")
+	}
+
+	if err := service.SetRequiredForCommissioners(true); err != nil {
+		t.Fatalf("expected no error, but got %s", err.Error())
+	}
+
+	var isRequiredTests = []struct {
+		name     string
+		userID   int
+		expected bool
+	}{
+		{"commissioner", 1, true},
+		{"player", 3, false},
+	}
+
+	for _, e := range isRequiredTests {
+		required, err := service.IsRequired(e.userID)
+		if err != nil {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if required != e.expected {
+			t.Errorf("failed %s: expected required to be %t, but got %t", e.name, e.expected, required)
+		}
+	}
+
+	if err := service.DisableTwoFactor(twoFactorUser(1), currentCode()); err == nil {
+		t.Error("expected a commissioner not to be able to turn off two-factor, but they could")
+	}
+}
+
+var disableTwoFactorTests = []struct {
+	name        string
+	user        models.User
+	code        string
+	expectError bool
+}{
+	{"success", twoFactorUser(1), currentCode(), false},
+	{"wrong code", twoFactorUser(1), "000000", true},
+	{"disable error", twoFactorUser(3), "abcd-2345", true},
+	{"delete codes error", twoFactorUser(5), "abcd-2345", true},
+}
+
+func TestDisableTwoFactor(t *testing.T) {
+	for _, e := range disableTwoFactorTests {
+		err := service.DisableTwoFactor(e.user, e.code)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
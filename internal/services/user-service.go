@@ -6,5 +6,18 @@ type UserService interface {
 	GetUser(userID int) (models.User, error)
 	GetUserByEmail(email string) (models.User, error)
 	CreateUser(user models.User, password string) (int, error)
+	UpdateUser(user models.User) error
+	ChangePassword(userID int, currentPassword, newPassword string) error
+	LogOutEverywhere(userID int) error
 	Authenticate(email, password string) (int, int, error)
+	RequestPasswordReset(user models.User) (string, error)
+	GetPasswordReset(token string) (models.PasswordReset, error)
+	ResetPassword(token, password string) error
+	RequestEmailVerification(user models.User) (string, error)
+	VerifyEmail(token string) (int, error)
+	SearchUsers(search string, page models.Page) ([]models.User, models.Page, error)
+	SetAccessLevel(userID, accessLevel int) error
+	DeactivateUser(userID int) error
+	ReactivateUser(userID int) error
+	FixUnclaimedEmail(userID int, email string) error
 }
@@ -4,6 +4,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/jdonahue135/golf-league-app/internal/repository/dbmanager"
+	"github.com/jdonahue135/golf-league-app/internal/repository/emailverificationrepo"
+	"github.com/jdonahue135/golf-league-app/internal/repository/passwordresetrepo"
 	"github.com/jdonahue135/golf-league-app/internal/repository/userrepo"
 	"github.com/jdonahue135/golf-league-app/internal/services"
 )
@@ -12,7 +15,10 @@ var service services.UserService
 
 func TestMain(m *testing.M) {
 	userRepo := userrepo.NewTestUserRepo()
-	service = NewUserService(userRepo)
+	passwordResetRepo := passwordresetrepo.NewTestPasswordResetRepo()
+	emailVerificationRepo := emailverificationrepo.NewTestEmailVerificationRepo()
+	dbManager := dbmanager.NewTestDBManager()
+	service = NewUserService(userRepo, passwordResetRepo, emailVerificationRepo, dbManager)
 
 	os.Exit(m.Run())
 }
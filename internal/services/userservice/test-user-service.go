@@ -2,6 +2,7 @@ package userservice
 
 import (
 	"errors"
+	"time"
 
 	"github.com/jdonahue135/golf-league-app/internal/models"
 	"github.com/jdonahue135/golf-league-app/internal/repository"
@@ -22,6 +23,23 @@ func (m *testUserService) GetUser(userID int) (models.User, error) {
 		return u, errors.New("user not found")
 	}
 	u.ID = userID
+	u.AccessLevel = models.AccessLevelPlayer
+	if userID != 7 && userID != 9 {
+		u.VerifiedAt = time.Now()
+	}
+	if userID == 6 {
+		u.SessionsRevokedAt = time.Now()
+	}
+	if userID == 55 {
+		u.Password = "hashed password"
+	}
+	if userID == 11 {
+		u.DeactivatedAt = time.Now()
+	}
+	if userID == 10 {
+		u.TOTPSecret = "JBSWY3DPEHPK3PXP"
+		u.TOTPEnabledAt = time.Now()
+	}
 
 	return u, nil
 }
@@ -31,6 +49,9 @@ func (m *testUserService) GetUserByEmail(email string) (models.User, error) {
 	if email == "me@here.ca" {
 		return u, errors.New("user not found")
 	}
+	if email == "reset@error.com" {
+		u.ID = 3
+	}
 
 	return u, nil
 }
@@ -42,9 +63,110 @@ func (m *testUserService) CreateUser(user models.User, password string) (int, er
 	return 1, nil
 }
 
+func (m *testUserService) UpdateUser(user models.User) error {
+	if user.FirstName == "error" {
+		return errors.New("update error")
+	}
+	return nil
+}
+
+func (m *testUserService) ChangePassword(userID int, currentPassword, newPassword string) error {
+	if currentPassword == "wrong" {
+		return errors.New("current password is incorrect")
+	}
+	if newPassword == "error" {
+		return errors.New("password update error")
+	}
+	return nil
+}
+
+func (m *testUserService) LogOutEverywhere(userID int) error {
+	if userID == 3 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
 func (m *testUserService) Authenticate(email, password string) (int, int, error) {
-	if email == "jack@nimble.com" {
+	if email == "jack@nimble.com" || email == "lockout@here.ca" {
 		return 0, 0, errors.New("Invalid credentials")
 	}
+	if email == "twofactor@here.ca" {
+		return 10, 1, nil
+	}
+	if email == "required@here.ca" {
+		return 11, 1, nil
+	}
 	return 1, 1, nil
 }
+
+func (m *testUserService) RequestPasswordReset(user models.User) (string, error) {
+	if user.ID == 3 {
+		return "", errors.New("password reset error")
+	}
+	return "token", nil
+}
+
+func (m *testUserService) GetPasswordReset(token string) (models.PasswordReset, error) {
+	if token == "expired" {
+		return models.PasswordReset{}, errors.New("this reset link is no longer valid")
+	}
+	return models.PasswordReset{ID: 1, UserID: 1}, nil
+}
+
+func (m *testUserService) ResetPassword(token, password string) error {
+	if token == "expired" || password == "error" {
+		return errors.New("password reset error")
+	}
+	return nil
+}
+
+func (m *testUserService) RequestEmailVerification(user models.User) (string, error) {
+	if user.ID == 9 {
+		return "", errors.New("email verification error")
+	}
+	return "token", nil
+}
+
+func (m *testUserService) VerifyEmail(token string) (int, error) {
+	if token == "expired" {
+		return 0, errors.New("this verification link is no longer valid")
+	}
+	return 1, nil
+}
+
+func (m *testUserService) SearchUsers(search string, page models.Page) ([]models.User, models.Page, error) {
+	if search == "error" {
+		return nil, page, errors.New("cannot search users")
+	}
+	page.Total = 1
+	return []models.User{{ID: 1, FirstName: "Jack", Email: "jack@nimble.com", AccessLevel: models.AccessLevelPlayer}}, page, nil
+}
+
+func (m *testUserService) SetAccessLevel(userID, accessLevel int) error {
+	if userID == 3 || !models.IsAccessLevel(accessLevel) {
+		return errors.New("cannot change access level")
+	}
+	return nil
+}
+
+func (m *testUserService) DeactivateUser(userID int) error {
+	if userID == 3 {
+		return errors.New("cannot deactivate user")
+	}
+	return nil
+}
+
+func (m *testUserService) ReactivateUser(userID int) error {
+	if userID == 3 {
+		return errors.New("cannot reactivate user")
+	}
+	return nil
+}
+
+func (m *testUserService) FixUnclaimedEmail(userID int, email string) error {
+	if email == "error@here.com" {
+		return errors.New("account already exists with that email address")
+	}
+	return nil
+}
@@ -1,17 +1,29 @@
 package userservice
 
 import (
+	"errors"
+	"time"
+
 	"github.com/jdonahue135/golf-league-app/internal/models"
 	"github.com/jdonahue135/golf-league-app/internal/repository"
 	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
 )
 
 type userService struct {
-	UserRepo repository.UserRepo
+	UserRepo              repository.UserRepo
+	PasswordResetRepo     repository.PasswordResetRepo
+	EmailVerificationRepo repository.EmailVerificationRepo
+	DBManager             repository.DBManager
 }
 
-func NewUserService(r repository.UserRepo) services.UserService {
-	return &userService{UserRepo: r}
+func NewUserService(r repository.UserRepo, p repository.PasswordResetRepo, e repository.EmailVerificationRepo, m repository.DBManager) services.UserService {
+	return &userService{
+		UserRepo:              r,
+		PasswordResetRepo:     p,
+		EmailVerificationRepo: e,
+		DBManager:             m,
+	}
 }
 
 func (m *userService) GetUser(userID int) (models.User, error) {
@@ -26,6 +38,254 @@ func (m *userService) CreateUser(user models.User, password string) (int, error)
 	return m.UserRepo.CreateUser(user, password)
 }
 
+// UpdateUser saves a user's name and email address. Nothing else about the
+// user can be changed this way, and the email can't belong to another user
+func (m *userService) UpdateUser(user models.User) error {
+	existing, err := m.UserRepo.GetUserByID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	other, err := m.UserRepo.GetUserByEmail(user.Email)
+	if err == nil && other.ID != existing.ID {
+		return errors.New("account already exists with that email address")
+	}
+
+	existing.FirstName = user.FirstName
+	existing.LastName = user.LastName
+	existing.Email = user.Email
+
+	return m.UserRepo.UpdateUser(existing)
+}
+
+// ChangePassword sets a new password for the user if the current password is
+// right. The user is logged out everywhere else
+func (m *userService) ChangePassword(userID int, currentPassword, newPassword string) error {
+	user, err := m.UserRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = m.UserRepo.Authenticate(user.Email, currentPassword)
+	if err != nil {
+		return errors.New("current password is incorrect")
+	}
+
+	return m.UserRepo.UpdatePassword(user.ID, newPassword)
+}
+
+// LogOutEverywhere ends all of the user's sessions, including the one they
+// asked from
+func (m *userService) LogOutEverywhere(userID int) error {
+	return m.UserRepo.RevokeSessions(userID)
+}
+
 func (m *userService) Authenticate(email, password string) (int, int, error) {
 	return m.UserRepo.Authenticate(email, password)
 }
+
+// RequestPasswordReset starts a password reset for the user that's good for
+// PasswordResetLifetime, and returns the token for the emailed link
+func (m *userService) RequestPasswordReset(user models.User) (string, error) {
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	reset := models.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().UTC().Add(models.PasswordResetLifetime),
+	}
+
+	_, err = m.PasswordResetRepo.CreatePasswordReset(reset)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// GetPasswordReset returns the password reset an emailed link is for, as long
+// as the link can still be used
+func (m *userService) GetPasswordReset(token string) (models.PasswordReset, error) {
+	reset, err := m.PasswordResetRepo.GetPasswordResetByTokenHash(tokens.Hash(token))
+	if err != nil {
+		return reset, err
+	}
+
+	if !reset.IsValid(time.Now().UTC()) {
+		return models.PasswordReset{}, errors.New("this reset link is no longer valid")
+	}
+
+	return reset, nil
+}
+
+// ResetPassword sets a new password for the user the reset link was sent to.
+// The link can't be used again, and the user is logged out everywhere
+func (m *userService) ResetPassword(token, password string) error {
+	reset, err := m.GetPasswordReset(token)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return err
+	}
+
+	err = m.PasswordResetRepo.UsePasswordResetTransaction(reset.ID, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	err = m.UserRepo.ResetPasswordTransaction(reset.UserID, password, ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return m.DBManager.CommitTransaction(tx)
+}
+
+// RequestEmailVerification starts verifying the user's email address, and
+// returns the token for the emailed link
+func (m *userService) RequestEmailVerification(user models.User) (string, error) {
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	verification := models.EmailVerification{
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().UTC().Add(models.EmailVerificationLifetime),
+	}
+
+	_, err = m.EmailVerificationRepo.CreateEmailVerification(verification)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// VerifyEmail marks the address an emailed link was sent to as verified, and
+// returns the ID of the user it belongs to. The link can't be used again
+func (m *userService) VerifyEmail(token string) (int, error) {
+	verification, err := m.EmailVerificationRepo.GetEmailVerificationByTokenHash(tokens.Hash(token))
+	if err != nil {
+		return 0, err
+	}
+
+	if !verification.IsValid(time.Now().UTC()) {
+		return 0, errors.New("this verification link is no longer valid")
+	}
+
+	ctx, cancel, tx, err := m.DBManager.BeginTransaction()
+	defer cancel()
+	if err != nil {
+		return 0, err
+	}
+
+	err = m.EmailVerificationRepo.UseEmailVerificationTransaction(verification.ID, ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = m.UserRepo.VerifyEmailTransaction(verification.UserID, verification.Email, ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = m.DBManager.CommitTransaction(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	return verification.UserID, nil
+}
+
+// SearchUsers returns the page of users whose name or email contains search,
+// newest first, along with the page filled in with how many users matched
+func (m *userService) SearchUsers(search string, page models.Page) ([]models.User, models.Page, error) {
+	total, err := m.UserRepo.CountUsers(search)
+	if err != nil {
+		return nil, page, err
+	}
+	page.Total = total
+
+	users, err := m.UserRepo.AllUsers(search, page.Size, page.Offset())
+	if err != nil {
+		return nil, page, err
+	}
+
+	return users, page, nil
+}
+
+// SetAccessLevel changes what a user is allowed to do on the site
+func (m *userService) SetAccessLevel(userID, accessLevel int) error {
+	if !models.IsAccessLevel(accessLevel) {
+		return errors.New("choose one of the access levels")
+	}
+
+	user, err := m.UserRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	return m.UserRepo.SetAccessLevel(user.ID, accessLevel)
+}
+
+// DeactivateUser shuts a user's account off so they can't log in, and logs
+// them out everywhere
+func (m *userService) DeactivateUser(userID int) error {
+	user, err := m.UserRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsDeactivated() {
+		return errors.New("this account is already deactivated")
+	}
+
+	return m.UserRepo.DeactivateUser(user.ID)
+}
+
+// ReactivateUser lets a deactivated user log in again
+func (m *userService) ReactivateUser(userID int) error {
+	user, err := m.UserRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.IsDeactivated() {
+		return errors.New("this account isn't deactivated")
+	}
+
+	return m.UserRepo.ReactivateUser(user.ID)
+}
+
+// FixUnclaimedEmail corrects the email address of a user who was added to a
+// league but hasn't claimed their account yet. Users who have claimed their
+// account change their own email on their profile
+func (m *userService) FixUnclaimedEmail(userID int, email string) error {
+	user, err := m.UserRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.IsClaimed() {
+		return errors.New("this account has been claimed, so only its owner can change the email")
+	}
+
+	other, err := m.UserRepo.GetUserByEmail(email)
+	if err == nil && other.ID != user.ID {
+		return errors.New("account already exists with that email address")
+	}
+
+	user.Email = email
+
+	return m.UserRepo.UpdateUser(user)
+}
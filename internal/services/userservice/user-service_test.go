@@ -22,3 +22,289 @@ func TestCreateUser(t *testing.T) {
 func TestAuthenticate(t *testing.T) {
 	service.Authenticate("test@email.com", "password")
 }
+
+var requestPasswordResetTests = []struct {
+	name        string
+	user        models.User
+	expectError bool
+}{
+	{"success", models.User{ID: 1}, false},
+	{"create error", models.User{ID: 3}, true},
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	for _, e := range requestPasswordResetTests {
+		token, err := service.RequestPasswordReset(e.user)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if !e.expectError && token == "" {
+			t.Errorf("failed %s: expected a token, but got none", e.name)
+		}
+	}
+}
+
+var resetPasswordTests = []struct {
+	name        string
+	token       string
+	password    string
+	expectError bool
+}{
+	{"success", "valid", "password", false},
+	{"not found", "error", "password", true},
+	{"expired", "expired", "password", true},
+	{"already used", "used", "password", true},
+	{"used at the same time", "use error", "password", true},
+	{"password update error", "valid", "error", true},
+}
+
+func TestResetPassword(t *testing.T) {
+	for _, e := range resetPasswordTests {
+		err := service.ResetPassword(e.token, e.password)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+func TestRequestEmailVerification(t *testing.T) {
+	for _, e := range requestPasswordResetTests {
+		token, err := service.RequestEmailVerification(e.user)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if !e.expectError && token == "" {
+			t.Errorf("failed %s: expected a token, but got none", e.name)
+		}
+	}
+}
+
+var verifyEmailTests = []struct {
+	name        string
+	token       string
+	expectError bool
+}{
+	{"success", "valid", false},
+	{"not found", "error", true},
+	{"expired", "expired", true},
+	{"already used", "used", true},
+	{"used at the same time", "use error", true},
+	{"email changed since", "email changed", true},
+}
+
+func TestVerifyEmail(t *testing.T) {
+	for _, e := range verifyEmailTests {
+		userID, err := service.VerifyEmail(e.token)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if !e.expectError && userID == 0 {
+			t.Errorf("failed %s: expected a user ID, but got none", e.name)
+		}
+	}
+}
+
+var updateUserTests = []struct {
+	name        string
+	user        models.User
+	expectError bool
+}{
+	{"success", models.User{ID: 1, FirstName: "First", LastName: "Last", Email: "me@here.ca"}, false},
+	{"user not found", models.User{ID: 0, FirstName: "First", LastName: "Last", Email: "me@here.ca"}, true},
+	{"email taken", models.User{ID: 1, FirstName: "First", LastName: "Last", Email: "taken@here.ca"}, true},
+	{"update error", models.User{ID: 1, FirstName: "error", LastName: "Last", Email: "me@here.ca"}, true},
+}
+
+func TestUpdateUser(t *testing.T) {
+	for _, e := range updateUserTests {
+		err := service.UpdateUser(e.user)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var changePasswordTests = []struct {
+	name            string
+	userID          int
+	currentPassword string
+	newPassword     string
+	expectError     bool
+}{
+	{"success", 1, "password", "new password", false},
+	{"user not found", 0, "password", "new password", true},
+	{"wrong current password", 1, "wrong", "new password", true},
+	{"update error", 1, "password", "error", true},
+}
+
+func TestChangePassword(t *testing.T) {
+	for _, e := range changePasswordTests {
+		err := service.ChangePassword(e.userID, e.currentPassword, e.newPassword)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var logOutEverywhereTests = []struct {
+	name        string
+	userID      int
+	expectError bool
+}{
+	{"success", 1, false},
+	{"revoke error", 3, true},
+}
+
+func TestLogOutEverywhere(t *testing.T) {
+	for _, e := range logOutEverywhereTests {
+		err := service.LogOutEverywhere(e.userID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var searchUsersTests = []struct {
+	name          string
+	search        string
+	page          models.Page
+	expectedTotal int
+	expectError   bool
+}{
+	{"success", "", models.NewPage(1, 25), 1, false},
+	{"count error", "count error", models.NewPage(1, 25), 0, true},
+	{"search error", "error", models.NewPage(2, 25), 1, true},
+}
+
+func TestSearchUsers(t *testing.T) {
+	for _, e := range searchUsersTests {
+		users, page, err := service.SearchUsers(e.search, e.page)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if page.Total != e.expectedTotal {
+			t.Errorf("failed %s: expected total %d, but got %d", e.name, e.expectedTotal, page.Total)
+		}
+		if !e.expectError && len(users) == 0 {
+			t.Errorf("failed %s: expected users, but got none", e.name)
+		}
+	}
+}
+
+var setAccessLevelTests = []struct {
+	name        string
+	userID      int
+	accessLevel int
+	expectError bool
+}{
+	{"success", 1, models.AccessLevelAdmin, false},
+	{"not an access level", 1, 4, true},
+	{"user not found", 0, models.AccessLevelAdmin, true},
+	{"update error", 3, models.AccessLevelAdmin, true},
+}
+
+func TestSetAccessLevel(t *testing.T) {
+	for _, e := range setAccessLevelTests {
+		err := service.SetAccessLevel(e.userID, e.accessLevel)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var deactivateUserTests = []struct {
+	name        string
+	userID      int
+	expectError bool
+}{
+	{"success", 1, false},
+	{"user not found", 0, true},
+	{"already deactivated", 11, true},
+	{"update error", 3, true},
+}
+
+func TestDeactivateUser(t *testing.T) {
+	for _, e := range deactivateUserTests {
+		err := service.DeactivateUser(e.userID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var reactivateUserTests = []struct {
+	name        string
+	userID      int
+	expectError bool
+}{
+	{"success", 11, false},
+	{"user not found", 0, true},
+	{"not deactivated", 1, true},
+}
+
+func TestReactivateUser(t *testing.T) {
+	for _, e := range reactivateUserTests {
+		err := service.ReactivateUser(e.userID)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+var fixUnclaimedEmailTests = []struct {
+	name        string
+	userID      int
+	email       string
+	expectError bool
+}{
+	{"success", 2, "me@here.ca", false},
+	{"user not found", 0, "me@here.ca", true},
+	{"claimed account", 8, "me@here.ca", true},
+	{"email taken", 2, "commissioner@here.ca", true},
+	{"same user", 1, "commissioner@here.ca", false},
+}
+
+func TestFixUnclaimedEmail(t *testing.T) {
+	for _, e := range fixUnclaimedEmailTests {
+		err := service.FixUnclaimedEmail(e.userID, e.email)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package services
+
+import "github.com/jdonahue135/golf-league-app/internal/models"
+
+type WebhookService interface {
+	GetWebhook(ID int) (models.Webhook, error)
+	GetWebhooks(leagueID int) ([]models.Webhook, error)
+	CreateWebhook(leagueID int, url string) (models.Webhook, error)
+	DeleteWebhook(webhook models.Webhook) error
+	GetDeliveries(webhookID int) ([]models.WebhookDelivery, error)
+	Publish(leagueID int, event string, data interface{}) error
+	SendTestEvent(webhook models.Webhook) error
+	DeliverDue() ([]models.WebhookDelivery, error)
+}
@@ -0,0 +1,44 @@
+package webhookservice
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/repository/webhookrepo"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/webhooks"
+)
+
+var service services.WebhookService
+
+// received is the signatures the stand-in receiver got, by path
+var received = make(map[string][]string)
+
+func TestMain(m *testing.M) {
+	// the stand-in receiver accepts deliveries to /ok and fails the rest
+	standIn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received[r.URL.Path] = append(received[r.URL.Path], r.Header.Get(webhooks.SignatureHeader))
+		if r.URL.Path != "/ok" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	// send every delivery to the stand-in, whatever host its URL has
+	client := webhooks.NewClient()
+	client.Transport = &http.Transport{
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, standIn.Listener.Addr().String())
+		},
+	}
+
+	webhookRepo := webhookrepo.NewTestWebhookRepo()
+	service = NewWebhookService(webhookRepo, client)
+
+	code := m.Run()
+	standIn.Close()
+	os.Exit(code)
+}
@@ -0,0 +1,84 @@
+package webhookservice
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+)
+
+type testWebhookService struct {
+	WebhookRepo repository.WebhookRepo
+}
+
+func NewTestWebhookService(w repository.WebhookRepo) services.WebhookService {
+	return &testWebhookService{WebhookRepo: w}
+}
+
+func testWebhook(ID, leagueID int) models.Webhook {
+	return models.Webhook{ID: ID, LeagueID: leagueID, URL: "https://hooks.example.com/ok", Secret: "secret"}
+}
+
+func (m *testWebhookService) GetWebhook(ID int) (models.Webhook, error) {
+	switch ID {
+	case 3:
+		return models.Webhook{}, errors.New("webhook not found")
+	case 4:
+		return testWebhook(ID, 2), nil
+	}
+	return testWebhook(ID, 1), nil
+}
+
+func (m *testWebhookService) GetWebhooks(leagueID int) ([]models.Webhook, error) {
+	if leagueID == 5 {
+		return nil, errors.New("some error")
+	}
+	return []models.Webhook{testWebhook(1, leagueID)}, nil
+}
+
+func (m *testWebhookService) CreateWebhook(leagueID int, url string) (models.Webhook, error) {
+	if strings.Contains(url, "error") {
+		return models.Webhook{}, errors.New("enter the full URL, starting with https://")
+	}
+	webhook := testWebhook(1, leagueID)
+	webhook.URL = url
+	return webhook, nil
+}
+
+func (m *testWebhookService) DeleteWebhook(webhook models.Webhook) error {
+	if webhook.ID == 5 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testWebhookService) GetDeliveries(webhookID int) ([]models.WebhookDelivery, error) {
+	if webhookID == 6 {
+		return nil, errors.New("some error")
+	}
+	return []models.WebhookDelivery{
+		{ID: 1, WebhookID: webhookID, Event: models.WebhookEventTest, Attempts: 1, StatusCode: 200, DeliveredAt: time.Now()},
+		{ID: 2, WebhookID: webhookID, Event: models.WebhookEventPlayerAdded, Attempts: 2, StatusCode: 500, LastError: "webhook responded with 500 Internal Server Error", NextAttemptAt: time.Now().Add(time.Minute)},
+	}, nil
+}
+
+func (m *testWebhookService) Publish(leagueID int, event string, data interface{}) error {
+	if leagueID == 5 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testWebhookService) SendTestEvent(webhook models.Webhook) error {
+	if webhook.ID == 7 {
+		return errors.New("some error")
+	}
+	return nil
+}
+
+func (m *testWebhookService) DeliverDue() ([]models.WebhookDelivery, error) {
+	return nil, nil
+}
@@ -0,0 +1,200 @@
+package webhookservice
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/repository"
+	"github.com/jdonahue135/golf-league-app/internal/services"
+	"github.com/jdonahue135/golf-league-app/internal/tokens"
+	"github.com/jdonahue135/golf-league-app/internal/webhooks"
+)
+
+// deliveryLogSize is how many of a webhook's latest deliveries are shown
+const deliveryLogSize = 50
+
+// deliveryBatchSize is how many due deliveries are sent at a time
+const deliveryBatchSize = 20
+
+// deliveryClaim is how long due deliveries are set aside for while they're
+// sent. It's long enough for every delivery in a batch to time out
+const deliveryClaim = deliveryBatchSize * webhooks.Timeout * 3 / 2
+
+// testEventMessage is what the test event a commissioner sends says
+const testEventMessage = "This is a test event from your golf league"
+
+// payload is the JSON body of every delivery
+type payload struct {
+	Event      string      `json:"event"`
+	LeagueID   int         `json:"league_id"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+type webhookService struct {
+	WebhookRepo repository.WebhookRepo
+	Client      *http.Client
+}
+
+func NewWebhookService(w repository.WebhookRepo, c *http.Client) services.WebhookService {
+	return &webhookService{
+		WebhookRepo: w,
+		Client:      c,
+	}
+}
+
+func (m *webhookService) GetWebhook(ID int) (models.Webhook, error) {
+	return m.WebhookRepo.GetWebhookByID(ID)
+}
+
+func (m *webhookService) GetWebhooks(leagueID int) ([]models.Webhook, error) {
+	return m.WebhookRepo.GetWebhooksByLeagueID(leagueID)
+}
+
+// CreateWebhook subscribes a URL to the league's events, with a new secret to
+// sign them with. URLs on private or local addresses are rejected
+func (m *webhookService) CreateWebhook(leagueID int, webhookURL string) (models.Webhook, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return models.Webhook{}, errors.New("enter the full URL, starting with https://")
+	}
+	if err := webhooks.CheckHost(u.Hostname()); err != nil {
+		return models.Webhook{}, err
+	}
+
+	secret, _, err := tokens.Generate()
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	webhook := models.Webhook{
+		LeagueID: leagueID,
+		URL:      u.String(),
+		Secret:   secret,
+	}
+
+	webhook.ID, err = m.WebhookRepo.CreateWebhook(webhook)
+	if err != nil {
+		return models.Webhook{}, err
+	}
+
+	return webhook, nil
+}
+
+// DeleteWebhook unsubscribes a webhook, dropping deliveries still waiting to
+// be sent to it
+func (m *webhookService) DeleteWebhook(webhook models.Webhook) error {
+	return m.WebhookRepo.DeleteWebhook(webhook.ID)
+}
+
+// GetDeliveries returns a webhook's latest deliveries, newest first
+func (m *webhookService) GetDeliveries(webhookID int) ([]models.WebhookDelivery, error) {
+	return m.WebhookRepo.GetWebhookDeliveriesByWebhookID(webhookID, deliveryLogSize)
+}
+
+// Publish queues an event for every webhook subscribed to the league. data is
+// sent as the payload's data, so it should be one of the API's JSON types
+func (m *webhookService) Publish(leagueID int, event string, data interface{}) error {
+	subscribed, err := m.WebhookRepo.GetWebhooksByLeagueID(leagueID)
+	if err != nil || len(subscribed) == 0 {
+		return err
+	}
+
+	body, err := newPayload(leagueID, event, data)
+	if err != nil {
+		return err
+	}
+
+	// queue the event for the rest of the webhooks even if one fails
+	var firstErr error
+	for _, webhook := range subscribed {
+		if err := m.queue(webhook, event, body); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// SendTestEvent queues a test event for one webhook, so the commissioner can
+// check the receiver works
+func (m *webhookService) SendTestEvent(webhook models.Webhook) error {
+	body, err := newPayload(webhook.LeagueID, models.WebhookEventTest, map[string]string{"message": testEventMessage})
+	if err != nil {
+		return err
+	}
+
+	return m.queue(webhook, models.WebhookEventTest, body)
+}
+
+func newPayload(leagueID int, event string, data interface{}) (string, error) {
+	body, err := json.Marshal(payload{
+		Event:      event,
+		LeagueID:   leagueID,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	})
+	return string(body), err
+}
+
+// queue saves a delivery that's due to be sent straight away
+func (m *webhookService) queue(webhook models.Webhook, event, body string) error {
+	_, err := m.WebhookRepo.CreateWebhookDelivery(models.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		Event:         event,
+		Payload:       body,
+		NextAttemptAt: time.Now().UTC(),
+	})
+	return err
+}
+
+// DeliverDue sends the deliveries that are due and saves how each went. A
+// failed delivery is tried again after a wait that doubles every time, until
+// it has been tried WebhookMaxAttempts times. It returns the deliveries it
+// tried
+func (m *webhookService) DeliverDue() ([]models.WebhookDelivery, error) {
+	now := time.Now().UTC()
+	deliveries, err := m.WebhookRepo.ClaimDueWebhookDeliveries(now, now.Add(deliveryClaim), deliveryBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// save the rest of the attempts even if saving one fails
+	var firstErr error
+	for i := range deliveries {
+		d := &deliveries[i]
+		m.attempt(d)
+
+		if err := m.WebhookRepo.UpdateWebhookDeliveryAttempt(*d); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return deliveries, firstErr
+}
+
+// attempt sends a delivery and schedules its next attempt if it failed
+func (m *webhookService) attempt(d *models.WebhookDelivery) {
+	var err error
+	d.Attempts++
+	d.StatusCode, err = webhooks.Send(m.Client, d.Webhook.URL, d.Webhook.Secret, d.Event, d.ID, []byte(d.Payload))
+
+	now := time.Now().UTC()
+	if err == nil {
+		d.LastError = ""
+		d.DeliveredAt = now
+		d.NextAttemptAt = time.Time{}
+		return
+	}
+
+	d.LastError = err.Error()
+	if d.Attempts >= models.WebhookMaxAttempts {
+		d.NextAttemptAt = time.Time{}
+		return
+	}
+	d.NextAttemptAt = now.Add(d.RetryDelay())
+}
@@ -0,0 +1,149 @@
+package webhookservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/webhooks"
+)
+
+var createWebhookTests = []struct {
+	name        string
+	leagueID    int
+	url         string
+	expectError bool
+}{
+	{"https", 1, "https://example.com/hooks", false},
+	{"http", 1, "http://example.com/hooks", false},
+	{"localhost", 1, "http://localhost:8081/hooks", true},
+	{"loopback address", 1, "http://127.0.0.1/hooks", true},
+	{"private address", 1, "https://192.168.1.10/hooks", true},
+	{"link-local address", 1, "http://169.254.169.254/latest/meta-data", true},
+	{"unspecified address", 1, "http://[::]/hooks", true},
+	{"no scheme", 1, "example.com/hooks", true},
+	{"other scheme", 1, "ftp://example.com/hooks", true},
+	{"no host", 1, "https://", true},
+	{"repo error", 3, "https://example.com/hooks", true},
+}
+
+func TestCreateWebhook(t *testing.T) {
+	for _, e := range createWebhookTests {
+		webhook, err := service.CreateWebhook(e.leagueID, e.url)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if !e.expectError && (webhook.ID == 0 || webhook.Secret == "" || webhook.URL != e.url) {
+			t.Errorf("failed %s: expected a saved webhook with a secret, but got %v", e.name, webhook)
+		}
+	}
+}
+
+var publishTests = []struct {
+	name        string
+	leagueID    int
+	expectError bool
+}{
+	{"valid", 1, false},
+	{"no webhooks", 4, false},
+	{"getting webhooks fails", 3, true},
+	{"queueing one fails", 5, true},
+	{"data can't be sent", 1, true},
+}
+
+func TestPublish(t *testing.T) {
+	for _, e := range publishTests {
+		var data interface{} = map[string]int{"player_id": 1}
+		if e.name == "data can't be sent" {
+			data = make(chan int)
+		}
+
+		err := service.Publish(e.leagueID, models.WebhookEventPlayerAdded, data)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
+
+func TestSendTestEvent(t *testing.T) {
+	if err := service.SendTestEvent(models.Webhook{ID: 1, LeagueID: 1}); err != nil {
+		t.Errorf("failed valid: expected no error, but got %s", err.Error())
+	}
+	if err := service.SendTestEvent(models.Webhook{ID: 5, LeagueID: 1}); err == nil {
+		t.Error("failed repo error: expected error, but didn't get one")
+	}
+}
+
+func TestGetDeliveries(t *testing.T) {
+	deliveries, err := service.GetDeliveries(1)
+	if err != nil || len(deliveries) != 1 {
+		t.Errorf("failed valid: expected one delivery, but got %d and %v", len(deliveries), err)
+	}
+	if _, err := service.GetDeliveries(3); err == nil {
+		t.Error("failed repo error: expected error, but didn't get one")
+	}
+}
+
+func TestDeliverDue(t *testing.T) {
+	before := time.Now()
+	deliveries, err := service.DeliverDue()
+	if err == nil {
+		t.Error("expected the error saving delivery 5, but didn't get one")
+	}
+	if len(deliveries) != 5 {
+		t.Fatalf("expected 5 deliveries, but got %d", len(deliveries))
+	}
+
+	byID := make(map[int]models.WebhookDelivery)
+	for _, d := range deliveries {
+		byID[d.ID] = d
+	}
+
+	delivered := byID[1]
+	if !delivered.IsDelivered() || delivered.IsPending() || delivered.StatusCode != 200 || delivered.Attempts != 1 || delivered.LastError != "" {
+		t.Errorf("failed delivered: expected it delivered on the first attempt, but got %v", delivered)
+	}
+
+	failed := byID[2]
+	if failed.IsDelivered() || failed.StatusCode != 500 || failed.Attempts != 1 || failed.LastError == "" {
+		t.Errorf("failed first failure: expected a failed attempt, but got %v", failed)
+	}
+	if failed.NextAttemptAt.Before(before.Add(models.WebhookRetryDelay)) {
+		t.Errorf("failed first failure: expected a retry in %s, but got %s", models.WebhookRetryDelay, failed.NextAttemptAt)
+	}
+
+	backedOff := byID[3]
+	if backedOff.Attempts != 3 || backedOff.NextAttemptAt.Before(before.Add(4*models.WebhookRetryDelay)) {
+		t.Errorf("failed third failure: expected a retry in %s, but got %s", 4*models.WebhookRetryDelay, backedOff.NextAttemptAt)
+	}
+
+	givenUp := byID[4]
+	if givenUp.Attempts != models.WebhookMaxAttempts || givenUp.IsPending() || givenUp.IsDelivered() {
+		t.Errorf("failed last failure: expected no more retries, but got %v", givenUp)
+	}
+
+	for _, signature := range received["/ok"] {
+		if !webhooks.Verify("secret", []byte(`{"event":"webhook.test"}`), signature) {
+			t.Errorf("expected the stand-in to get a valid signature, but got %s", signature)
+		}
+	}
+	if len(received["/ok"]) != 2 || len(received["/fail"]) != 3 {
+		t.Errorf("expected the stand-in to get 2 good and 3 failing deliveries, but got %d and %d", len(received["/ok"]), len(received["/fail"]))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	expected := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute}
+	for i, delay := range expected {
+		d := models.WebhookDelivery{Attempts: i + 1}
+		if d.RetryDelay() != delay {
+			t.Errorf("failed attempt %d: expected %s, but got %s", d.Attempts, delay, d.RetryDelay())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package sessionstore
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// sessionFileExt is the extension of the files sessions are kept in
+const sessionFileExt = ".session"
+
+// FileStore keeps each session in a file in a directory. It's meant for
+// development, where sessions should outlast restarts without a database
+// table. Each file holds the session's expiry followed by its data
+type FileStore struct {
+	Dir         string
+	mu          sync.RWMutex
+	stopCleanup chan bool
+}
+
+// NewFileStore returns a store keeping sessions in dir, creating it if
+// needed, that deletes expired sessions every cleanupInterval. Pass zero to
+// never delete them
+func NewFileStore(dir string, cleanupInterval time.Duration) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	f := &FileStore{Dir: dir}
+	if cleanupInterval > 0 {
+		f.stopCleanup = make(chan bool)
+		go f.startCleanup(cleanupInterval)
+	}
+	return f, nil
+}
+
+// path returns the file for a session token. The token is hashed so it's
+// always a safe file name
+func (f *FileStore) path(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return filepath.Join(f.Dir, hex.EncodeToString(sum[:])+sessionFileExt)
+}
+
+// Find returns the data for a session token, if it exists and hasn't expired
+func (f *FileStore) Find(token string) ([]byte, bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	expiry, b, err := readSessionFile(f.path(token))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !time.Now().Before(expiry) {
+		return nil, false, nil
+	}
+
+	return b, true, nil
+}
+
+// Commit saves the data for a session token, replacing any already there
+func (f *FileStore) Commit(token string, b []byte, expiry time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	contents := make([]byte, 8+len(b))
+	binary.BigEndian.PutUint64(contents, uint64(expiry.UnixNano()))
+	copy(contents[8:], b)
+
+	return ioutil.WriteFile(f.path(token), contents, 0600)
+}
+
+// Delete removes a session token, if it exists
+func (f *FileStore) Delete(token string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := os.Remove(f.path(token))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (f *FileStore) startCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	for {
+		select {
+		case <-ticker.C:
+			if err := f.deleteExpired(); err != nil {
+				log.Println(err)
+			}
+		case <-f.stopCleanup:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// StopCleanup stops the goroutine deleting expired sessions
+func (f *FileStore) StopCleanup() {
+	if f.stopCleanup != nil {
+		f.stopCleanup <- true
+	}
+}
+
+func (f *FileStore) deleteExpired() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(f.Dir, "*"+sessionFileExt))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, path := range paths {
+		expiry, _, err := readSessionFile(path)
+		if err != nil || !now.Before(expiry) {
+			os.Remove(path)
+		}
+	}
+
+	return nil
+}
+
+// readSessionFile returns the expiry and data kept in a session file
+func readSessionFile(path string) (time.Time, []byte, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	// a file too short to hold an expiry is treated as already expired
+	if len(contents) < 8 {
+		return time.Time{}, nil, nil
+	}
+
+	expiry := time.Unix(0, int64(binary.BigEndian.Uint64(contents)))
+	return expiry, contents[8:], nil
+}
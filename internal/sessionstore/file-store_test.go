@@ -0,0 +1,152 @@
+package sessionstore
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestFileStore(t *testing.T) *FileStore {
+	dir, err := ioutil.TempDir("", "sessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	f, err := NewFileStore(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestFileStoreCommitAndFind(t *testing.T) {
+	f := newTestFileStore(t)
+
+	err := f.Commit("token", []byte("data"), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err.Error())
+	}
+
+	b, found, err := f.Find("token")
+	if err != nil || !found {
+		t.Fatalf("expected to find the session, but got found %t and error %v", found, err)
+	}
+	if !bytes.Equal(b, []byte("data")) {
+		t.Errorf("expected data %q, but got %q", "data", b)
+	}
+
+	err = f.Commit("token", []byte("new data"), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err.Error())
+	}
+	b, _, _ = f.Find("token")
+	if !bytes.Equal(b, []byte("new data")) {
+		t.Errorf("expected data %q, but got %q", "new data", b)
+	}
+}
+
+func TestFileStoreFindMissingOrExpired(t *testing.T) {
+	f := newTestFileStore(t)
+
+	if _, found, err := f.Find("missing"); found || err != nil {
+		t.Errorf("expected a missing session not to be found, but got found %t and error %v", found, err)
+	}
+
+	f.Commit("expired", []byte("data"), time.Now().Add(-time.Minute))
+	if _, found, err := f.Find("expired"); found || err != nil {
+		t.Errorf("expected an expired session not to be found, but got found %t and error %v", found, err)
+	}
+}
+
+func TestFileStoreDelete(t *testing.T) {
+	f := newTestFileStore(t)
+
+	f.Commit("token", []byte("data"), time.Now().Add(time.Hour))
+	if err := f.Delete("token"); err != nil {
+		t.Errorf("expected no error, but got %s", err.Error())
+	}
+	if _, found, _ := f.Find("token"); found {
+		t.Error("expected a deleted session not to be found")
+	}
+	if err := f.Delete("token"); err != nil {
+		t.Errorf("expected deleting a missing session to be a no-op, but got %s", err.Error())
+	}
+}
+
+func TestFileStoreTokenIsHashed(t *testing.T) {
+	f := newTestFileStore(t)
+
+	f.Commit("../escape", []byte("data"), time.Now().Add(time.Hour))
+
+	paths, _ := filepath.Glob(filepath.Join(f.Dir, "*"+sessionFileExt))
+	if len(paths) != 1 {
+		t.Fatalf("expected one session file in the store's directory, but got %d", len(paths))
+	}
+}
+
+func TestFileStoreDeleteExpired(t *testing.T) {
+	f := newTestFileStore(t)
+
+	f.Commit("current", []byte("data"), time.Now().Add(time.Hour))
+	f.Commit("expired", []byte("data"), time.Now().Add(-time.Minute))
+
+	if err := f.deleteExpired(); err != nil {
+		t.Fatalf("expected no error, but got %s", err.Error())
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(f.Dir, "*"+sessionFileExt))
+	if len(paths) != 1 {
+		t.Errorf("expected one session file left, but got %d", len(paths))
+	}
+	if _, found, _ := f.Find("current"); !found {
+		t.Error("expected the current session to be kept")
+	}
+}
+
+func TestFileStoreCleanup(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "sessions")
+	defer os.RemoveAll(dir)
+
+	f, err := NewFileStore(dir, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.StopCleanup()
+
+	f.Commit("expired", []byte("data"), time.Now().Add(-time.Minute))
+	time.Sleep(50 * time.Millisecond)
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*"+sessionFileExt))
+	if len(paths) != 0 {
+		t.Errorf("expected the cleanup to delete the expired session, but %d are left", len(paths))
+	}
+}
+
+func TestNew(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "sessions")
+	defer os.RemoveAll(dir)
+
+	var newTests = []struct {
+		name        string
+		kind        string
+		expectError bool
+	}{
+		{"memory", Memory, false},
+		{"file", File, false},
+		{"unknown", "redis", true},
+	}
+
+	for _, e := range newTests {
+		_, err := New(e.kind, nil, dir)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+	}
+}
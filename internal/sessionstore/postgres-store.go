@@ -0,0 +1,106 @@
+package sessionstore
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+// PostgresStore keeps sessions in the sessions table
+type PostgresStore struct {
+	DB          *sql.DB
+	stopCleanup chan bool
+}
+
+// NewPostgresStore returns a store that deletes expired sessions every
+// cleanupInterval. Pass zero to never delete them
+func NewPostgresStore(db *sql.DB, cleanupInterval time.Duration) *PostgresStore {
+	p := &PostgresStore{DB: db}
+	if cleanupInterval > 0 {
+		p.stopCleanup = make(chan bool)
+		go p.startCleanup(cleanupInterval)
+	}
+	return p
+}
+
+// Find returns the data for a session token, if it exists and hasn't expired
+func (p *PostgresStore) Find(token string) ([]byte, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	query := `select data from sessions where token = $1 and expiry > $2`
+
+	var b []byte
+	err := p.DB.QueryRowContext(ctx, query, token, time.Now().UTC()).Scan(&b)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return b, true, nil
+}
+
+// Commit saves the data for a session token, replacing any already there
+func (p *PostgresStore) Commit(token string, b []byte, expiry time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `
+		insert into sessions (token, data, expiry) 
+		values ($1, $2, $3) 
+		on conflict (token) do update set 
+			data = excluded.data, 
+			expiry = excluded.expiry`
+
+	_, err := p.DB.ExecContext(ctx, stmt, token, b, expiry.UTC())
+
+	return err
+}
+
+// Delete removes a session token, if it exists
+func (p *PostgresStore) Delete(token string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `delete from sessions where token = $1`
+
+	_, err := p.DB.ExecContext(ctx, stmt, token)
+
+	return err
+}
+
+func (p *PostgresStore) startCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	for {
+		select {
+		case <-ticker.C:
+			if err := p.deleteExpired(); err != nil {
+				log.Println(err)
+			}
+		case <-p.stopCleanup:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+// StopCleanup stops the goroutine deleting expired sessions
+func (p *PostgresStore) StopCleanup() {
+	if p.stopCleanup != nil {
+		p.stopCleanup <- true
+	}
+}
+
+func (p *PostgresStore) deleteExpired() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	stmt := `delete from sessions where expiry < $1`
+
+	_, err := p.DB.ExecContext(ctx, stmt, time.Now().UTC())
+
+	return err
+}
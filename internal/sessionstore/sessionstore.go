@@ -0,0 +1,39 @@
+// Package sessionstore has the places sessions can be kept, so logins can
+// outlast a restart and be shared between app servers. Every store deletes
+// expired sessions in the background
+package sessionstore
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/alexedwards/scs/v2"
+	"github.com/alexedwards/scs/v2/memstore"
+)
+
+const (
+	// Memory keeps sessions in memory, so they're lost on restart
+	Memory = "memory"
+	// Postgres keeps sessions in the sessions table
+	Postgres = "postgres"
+	// File keeps sessions in files in a directory, for development
+	File = "file"
+)
+
+// CleanupInterval is how often expired sessions are deleted
+const CleanupInterval = 5 * time.Minute
+
+// New returns the kind of store named, for scs to keep sessions in
+func New(kind string, db *sql.DB, dir string) (scs.Store, error) {
+	switch kind {
+	case Memory:
+		return memstore.NewWithCleanupInterval(CleanupInterval), nil
+	case Postgres:
+		return NewPostgresStore(db, CleanupInterval), nil
+	case File:
+		return NewFileStore(dir, CleanupInterval)
+	default:
+		return nil, fmt.Errorf("unknown session store %q, use %s, %s or %s", kind, Memory, Postgres, File)
+	}
+}
@@ -0,0 +1,186 @@
+// Package skins settles a week's skins game, where each hole is worth a skin
+// to the one player who beats everyone else entered on it
+package skins
+
+import (
+	"sort"
+
+	"github.com/jdonahue135/golf-league-app/internal/handicap"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// card is one entrant's scores for the week, keyed by hole number
+type card struct {
+	round models.Round
+	gross map[int]int
+	net   map[int]int
+	par   map[int]int
+}
+
+func newCard(r models.Round) card {
+	c := card{
+		round: r,
+		gross: make(map[int]int),
+		net:   make(map[int]int),
+		par:   make(map[int]int),
+	}
+
+	received := handicap.StrokesByHole(r.CourseHandicap, r.TeeSet.Holes)
+	for _, h := range r.TeeSet.Holes {
+		c.par[h.Number] = h.Par
+	}
+	for _, s := range r.HoleScores {
+		if s.Strokes <= 0 {
+			continue
+		}
+		c.gross[s.HoleNumber] = s.Strokes
+		c.net[s.HoleNumber] = s.Strokes - received[s.HoleNumber]
+	}
+	return c
+}
+
+// score returns the player's score on a hole in the game's format and
+// whether they scored the hole
+func (c card) score(game models.SkinsGame, hole int) (int, bool) {
+	if game.Net {
+		score, ok := c.net[hole]
+		return score, ok
+	}
+	score, ok := c.gross[hole]
+	return score, ok
+}
+
+// Calculate settles a skins game from the rounds its entrants played that
+// week, one round each. On each hole the lowest score wins the skins at stake
+// when nobody else matched it. A tied hole's skin carries over to the next
+// hole when the game plays carryovers and is lost otherwise, as is the skin
+// of a winning score that fails the game's validation rule. The pot is split
+// evenly between the skins won, rounded down to the cent
+func Calculate(game models.SkinsGame, rounds []models.Round) models.SkinsResult {
+	result := models.SkinsResult{Game: game}
+
+	cards := make([]card, len(rounds))
+	for i, r := range rounds {
+		cards[i] = newCard(r)
+	}
+
+	holes := holeNumbers(cards)
+	won := make(map[int]*models.SkinsWinner)
+	carried := 0
+
+	for i, number := range holes {
+		hole := models.SkinsHole{Number: number, Skins: 1 + carried}
+
+		winner, score, ok := lowest(game, cards, number)
+		switch {
+		case !ok:
+			hole.Outcome = models.SkinsHoleTied
+		case !validated(game, winner, holes, i):
+			hole.Outcome = models.SkinsHoleNotValidated
+			hole.Winner = winner.round.Player
+			hole.Score = score
+		default:
+			hole.Outcome = models.SkinsHoleWon
+			hole.Winner = winner.round.Player
+			hole.Score = score
+		}
+
+		if hole.Outcome == models.SkinsHoleWon {
+			w, ok := won[winner.round.PlayerID]
+			if !ok {
+				w = &models.SkinsWinner{Player: winner.round.Player}
+				won[winner.round.PlayerID] = w
+			}
+			w.Holes = append(w.Holes, number)
+			w.Skins += hole.Skins
+			result.Skins += hole.Skins
+			carried = 0
+		} else if game.Carryovers {
+			if hole.Outcome == models.SkinsHoleTied {
+				hole.Outcome = models.SkinsHoleCarried
+			}
+			carried = hole.Skins
+		} else {
+			carried = 0
+		}
+
+		result.Holes = append(result.Holes, hole)
+	}
+	result.Unclaimed = carried
+
+	if result.Skins > 0 {
+		result.Value = game.Pot / result.Skins
+	}
+
+	for _, w := range won {
+		w.Payout = w.Skins * result.Value
+		result.Winners = append(result.Winners, *w)
+	}
+	sort.Slice(result.Winners, func(i, j int) bool {
+		if result.Winners[i].Skins != result.Winners[j].Skins {
+			return result.Winners[i].Skins > result.Winners[j].Skins
+		}
+		return result.Winners[i].Holes[0] < result.Winners[j].Holes[0]
+	})
+
+	return result
+}
+
+// holeNumbers returns the holes any entrant scored, in order
+func holeNumbers(cards []card) []int {
+	seen := make(map[int]bool)
+	var holes []int
+	for _, c := range cards {
+		for number := range c.gross {
+			if !seen[number] {
+				seen[number] = true
+				holes = append(holes, number)
+			}
+		}
+	}
+	sort.Ints(holes)
+	return holes
+}
+
+// lowest returns the entrant with the lowest score on the hole, and false
+// when nobody scored it or the lowest score was tied
+func lowest(game models.SkinsGame, cards []card, hole int) (card, int, bool) {
+	var best card
+	bestScore := 0
+	found, tied := false, false
+
+	for _, c := range cards {
+		score, ok := c.score(game, hole)
+		if !ok {
+			continue
+		}
+		switch {
+		case !found || score < bestScore:
+			best, bestScore, found, tied = c, score, true, false
+		case score == bestScore:
+			tied = true
+		}
+	}
+
+	return best, bestScore, found && !tied
+}
+
+// validated reports whether the winner of the i-th hole keeps the skin under
+// the game's validation rule. The next hole rule has the winner make par or
+// better on the following hole, and the last hole needs no validating
+func validated(game models.SkinsGame, winner card, holes []int, i int) bool {
+	switch game.Validation {
+	case models.SkinsValidationGrossPar:
+		par, ok := winner.par[holes[i]]
+		return ok && winner.gross[holes[i]] <= par
+	case models.SkinsValidationNextHole:
+		if i == len(holes)-1 {
+			return true
+		}
+		next := holes[i+1]
+		score, scored := winner.score(game, next)
+		par, ok := winner.par[next]
+		return scored && ok && score <= par
+	}
+	return true
+}
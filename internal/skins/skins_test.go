@@ -0,0 +1,159 @@
+package skins
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// holes are three par 4s with stroke indexes matching their numbers
+var holes = []models.Hole{
+	{Number: 1, Par: 4, StrokeIndex: 1},
+	{Number: 2, Par: 4, StrokeIndex: 2},
+	{Number: 3, Par: 4, StrokeIndex: 3},
+}
+
+func round(playerID, courseHandicap int, strokes ...int) models.Round {
+	r := models.Round{
+		PlayerID:       playerID,
+		Player:         models.Player{ID: playerID},
+		CourseHandicap: courseHandicap,
+		TeeSet:         models.TeeSet{Holes: holes},
+	}
+	for i, s := range strokes {
+		r.GrossScore += s
+		if s > 0 {
+			r.HoleScores = append(r.HoleScores, models.HoleScore{HoleNumber: i + 1, Strokes: s})
+		}
+	}
+	return r
+}
+
+// players 1 and 2 tie the first hole, player 1 wins the second with a par
+// before bogeying the last, and player 3 wins the last. Player 2 gets a
+// stroke a hole, so in net games player 2 wins the first and ties the others
+var week = []models.Round{
+	round(1, 0, 4, 4, 5),
+	round(2, 3, 4, 5, 4),
+	round(3, 0, 5, 5, 3),
+}
+
+type payout struct {
+	playerID int
+	skins    int
+	payout   int
+}
+
+var calculateTests = []struct {
+	name              string
+	game              models.SkinsGame
+	rounds            []models.Round
+	expectedOutcomes  []string
+	expectedSkins     int
+	expectedValue     int
+	expectedUnclaimed int
+	expectedWinners   []payout
+}{
+	{
+		"gross with carryovers",
+		models.SkinsGame{Pot: 3000, Carryovers: true},
+		week,
+		[]string{models.SkinsHoleCarried, models.SkinsHoleWon, models.SkinsHoleWon},
+		3, 1000, 0,
+		[]payout{{1, 2, 2000}, {3, 1, 1000}},
+	},
+	{
+		"gross without carryovers",
+		models.SkinsGame{Pot: 3000},
+		week,
+		[]string{models.SkinsHoleTied, models.SkinsHoleWon, models.SkinsHoleWon},
+		2, 1500, 0,
+		[]payout{{1, 1, 1500}, {3, 1, 1500}},
+	},
+	{
+		"net leaves carried skins unclaimed",
+		models.SkinsGame{Pot: 3000, Net: true, Carryovers: true},
+		week,
+		[]string{models.SkinsHoleWon, models.SkinsHoleCarried, models.SkinsHoleCarried},
+		1, 3000, 2,
+		[]payout{{2, 1, 3000}},
+	},
+	{
+		"skin not validated on the next hole carries over",
+		models.SkinsGame{Pot: 3000, Carryovers: true, Validation: models.SkinsValidationNextHole},
+		week,
+		[]string{models.SkinsHoleCarried, models.SkinsHoleNotValidated, models.SkinsHoleWon},
+		3, 1000, 0,
+		[]payout{{3, 3, 3000}},
+	},
+	{
+		"net skin without a gross par is lost",
+		models.SkinsGame{Pot: 1000, Net: true, Validation: models.SkinsValidationGrossPar},
+		[]models.Round{round(1, 0, 5, 4, 4), round(2, 1, 5, 5, 5)},
+		[]string{models.SkinsHoleNotValidated, models.SkinsHoleWon, models.SkinsHoleWon},
+		2, 500, 0,
+		[]payout{{1, 2, 1000}},
+	},
+	{
+		"pot split rounds down to the cent",
+		models.SkinsGame{Pot: 1000},
+		[]models.Round{round(1, 0, 3, 5, 4), round(2, 0, 4, 4, 5)},
+		[]string{models.SkinsHoleWon, models.SkinsHoleWon, models.SkinsHoleWon},
+		3, 333, 0,
+		[]payout{{1, 2, 666}, {2, 1, 333}},
+	},
+	{
+		"holes only one player scored",
+		models.SkinsGame{Pot: 1000},
+		[]models.Round{round(1, 0, 5, 0, 0), round(2, 0, 5, 6, 0)},
+		[]string{models.SkinsHoleTied, models.SkinsHoleWon},
+		1, 1000, 0,
+		[]payout{{2, 1, 1000}},
+	},
+	{
+		"no rounds",
+		models.SkinsGame{Pot: 1000, Carryovers: true},
+		nil,
+		nil,
+		0, 0, 0,
+		nil,
+	},
+}
+
+func TestCalculate(t *testing.T) {
+	for _, e := range calculateTests {
+		result := Calculate(e.game, e.rounds)
+
+		if len(result.Holes) != len(e.expectedOutcomes) {
+			t.Errorf("failed %s: expected %d holes, but got %d", e.name, len(e.expectedOutcomes), len(result.Holes))
+		} else {
+			for i, outcome := range e.expectedOutcomes {
+				if result.Holes[i].Outcome != outcome {
+					t.Errorf("failed %s: expected hole %d %s, but got %s", e.name, result.Holes[i].Number, outcome, result.Holes[i].Outcome)
+				}
+			}
+		}
+
+		if result.Skins != e.expectedSkins {
+			t.Errorf("failed %s: expected %d skins, but got %d", e.name, e.expectedSkins, result.Skins)
+		}
+		if result.Value != e.expectedValue {
+			t.Errorf("failed %s: expected skins worth %d, but got %d", e.name, e.expectedValue, result.Value)
+		}
+		if result.Unclaimed != e.expectedUnclaimed {
+			t.Errorf("failed %s: expected %d unclaimed skins, but got %d", e.name, e.expectedUnclaimed, result.Unclaimed)
+		}
+
+		if len(result.Winners) != len(e.expectedWinners) {
+			t.Errorf("failed %s: expected %d winners, but got %d", e.name, len(e.expectedWinners), len(result.Winners))
+			continue
+		}
+		for i, expected := range e.expectedWinners {
+			w := result.Winners[i]
+			if w.Player.ID != expected.playerID || w.Skins != expected.skins || w.Payout != expected.payout {
+				t.Errorf("failed %s: expected player %d to win %d skins for %d, but got player %d with %d skins for %d",
+					e.name, expected.playerID, expected.skins, expected.payout, w.Player.ID, w.Skins, w.Payout)
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Package stableford scores rounds in Stableford points from the net score on
+// each hole
+package stableford
+
+import (
+	"github.com/jdonahue135/golf-league-app/internal/handicap"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// HolePoints returns the points scored on each hole, keyed by hole number. A
+// hole without a score was picked up and scores the points for double bogey
+// or worse
+func HolePoints(holes []models.Hole, holeScores []models.HoleScore, courseHandicap int, table models.StablefordTable) map[int]int {
+	strokes := make(map[int]int)
+	for _, s := range holeScores {
+		strokes[s.HoleNumber] = s.Strokes
+	}
+
+	received := handicap.StrokesByHole(courseHandicap, holes)
+
+	points := make(map[int]int)
+	for _, h := range holes {
+		if strokes[h.Number] <= 0 {
+			points[h.Number] = table.DoubleBogeyOrWorse
+			continue
+		}
+		net := strokes[h.Number] - received[h.Number]
+		points[h.Number] = table.Points(net - h.Par)
+	}
+	return points
+}
+
+// Points returns the total points for a round
+func Points(holes []models.Hole, holeScores []models.HoleScore, courseHandicap int, table models.StablefordTable) int {
+	total := 0
+	for _, p := range HolePoints(holes, holeScores, courseHandicap, table) {
+		total += p
+	}
+	return total
+}
+
+// RoundPoints returns the total points for a posted round from its tee set's
+// holes and the course handicap it was played off
+func RoundPoints(round models.Round, table models.StablefordTable) int {
+	return Points(round.TeeSet.Holes, round.HoleScores, round.CourseHandicap, table)
+}
@@ -0,0 +1,121 @@
+package stableford
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// holes are a par 36 nine with stroke indexes from hole one to nine
+var holes = []models.Hole{
+	{Number: 1, Par: 4, StrokeIndex: 3},
+	{Number: 2, Par: 5, StrokeIndex: 1},
+	{Number: 3, Par: 3, StrokeIndex: 9},
+	{Number: 4, Par: 4, StrokeIndex: 2},
+	{Number: 5, Par: 4, StrokeIndex: 5},
+	{Number: 6, Par: 3, StrokeIndex: 8},
+	{Number: 7, Par: 5, StrokeIndex: 4},
+	{Number: 8, Par: 4, StrokeIndex: 6},
+	{Number: 9, Par: 4, StrokeIndex: 7},
+}
+
+func scores(strokes ...int) []models.HoleScore {
+	var s []models.HoleScore
+	for i, strokes := range strokes {
+		s = append(s, models.HoleScore{HoleNumber: i + 1, Strokes: strokes})
+	}
+	return s
+}
+
+var pointsTableTests = []struct {
+	name     string
+	overPar  int
+	standard int
+	modified int
+}{
+	{"triple bogey", 3, 0, -3},
+	{"double bogey", 2, 0, -3},
+	{"bogey", 1, 1, -1},
+	{"par", 0, 2, 0},
+	{"birdie", -1, 3, 2},
+	{"eagle", -2, 4, 5},
+	{"albatross", -3, 5, 8},
+	{"condor", -4, 5, 8},
+}
+
+func TestStablefordTablePoints(t *testing.T) {
+	for _, e := range pointsTableTests {
+		if actual := models.StandardStableford.Points(e.overPar); actual != e.standard {
+			t.Errorf("failed standard %s: expected %d, but got %d", e.name, e.standard, actual)
+		}
+		if actual := models.ModifiedStableford.Points(e.overPar); actual != e.modified {
+			t.Errorf("failed modified %s: expected %d, but got %d", e.name, e.modified, actual)
+		}
+	}
+}
+
+var pointsTests = []struct {
+	name           string
+	scores         []models.HoleScore
+	courseHandicap int
+	table          models.StablefordTable
+	expected       int
+}{
+	{"all pars scratch", scores(4, 5, 3, 4, 4, 3, 5, 4, 4), 0, models.StandardStableford, 18},
+	{"all bogeys scratch", scores(5, 6, 4, 5, 5, 4, 6, 5, 5), 0, models.StandardStableford, 9},
+	{"all bogeys with a stroke a hole", scores(5, 6, 4, 5, 5, 4, 6, 5, 5), 9, models.StandardStableford, 18},
+	// strokes on stroke indexes 1 to 3: holes 2, 4 and 1 become net pars
+	{"strokes on the hardest holes", scores(5, 6, 4, 5, 5, 4, 6, 5, 5), 3, models.StandardStableford, 12},
+	{"birdie and eagle", scores(3, 3, 3, 4, 4, 3, 5, 4, 4), 0, models.StandardStableford, 21},
+	{"blow up hole scores nothing", scores(9, 5, 3, 4, 4, 3, 5, 4, 4), 0, models.StandardStableford, 16},
+	{"picked up hole", scores(4, 5, 3, 4, 4, 3, 5, 4), 0, models.StandardStableford, 16},
+	{"plus handicap gives a stroke back", scores(4, 5, 3, 4, 4, 3, 5, 4, 4), -1, models.StandardStableford, 17},
+	{"modified pars", scores(4, 5, 3, 4, 4, 3, 5, 4, 4), 0, models.ModifiedStableford, 0},
+	{"modified birdie and double", scores(3, 7, 3, 4, 4, 3, 5, 4, 4), 0, models.ModifiedStableford, -1},
+	{"custom table", scores(3, 5, 3, 4, 4, 3, 5, 4, 4), 0, models.StablefordTable{DoubleBogeyOrWorse: -1, Bogey: 0, Par: 1, Birdie: 3, Eagle: 6, AlbatrossOrBetter: 10}, 11},
+}
+
+func TestPoints(t *testing.T) {
+	for _, e := range pointsTests {
+		if actual := Points(holes, e.scores, e.courseHandicap, e.table); actual != e.expected {
+			t.Errorf("failed %s: expected %d points, but got %d", e.name, e.expected, actual)
+		}
+	}
+}
+
+func TestHolePoints(t *testing.T) {
+	points := HolePoints(holes, scores(5, 6, 4, 5, 5, 4, 6, 5, 5), 3, models.StandardStableford)
+
+	expected := map[int]int{1: 2, 2: 2, 3: 1, 4: 2, 5: 1, 6: 1, 7: 1, 8: 1, 9: 1}
+	for number, e := range expected {
+		if points[number] != e {
+			t.Errorf("hole %d: expected %d points, but got %d", number, e, points[number])
+		}
+	}
+}
+
+func TestRoundPoints(t *testing.T) {
+	round := models.Round{
+		CourseHandicap: 9,
+		HoleScores:     scores(5, 6, 4, 5, 5, 4, 6, 5, 5),
+		TeeSet:         models.TeeSet{Holes: holes},
+	}
+
+	if actual := RoundPoints(round, models.StandardStableford); actual != 18 {
+		t.Errorf("expected 18 points, but got %d", actual)
+	}
+}
+
+func TestParseStablefordTable(t *testing.T) {
+	table, err := models.ParseStablefordTable(models.ModifiedStableford.String())
+	if err != nil || table != models.ModifiedStableford {
+		t.Errorf("expected to read back the modified table, but got %v and %v", table, err)
+	}
+
+	if _, err := models.ParseStablefordTable("0,1,2"); err == nil {
+		t.Error("expected an error for a short table but got none")
+	}
+	if _, err := models.ParseStablefordTable("0,1,2,3,4,x"); err == nil {
+		t.Error("expected an error for a table that isn't numbers but got none")
+	}
+}
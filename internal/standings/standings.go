@@ -0,0 +1,355 @@
+// Package standings ranks a season's players from their posted rounds and the
+// results of their scheduled matchups
+package standings
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/matchplay"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/stableford"
+)
+
+// criterion orders two standings, returning a negative number when a ranks
+// ahead of b, a positive number when b ranks ahead and 0 when they're tied
+type criterion func(a, b models.Standing) int
+
+// criteria are the ways standings can be ranked, each with its tie-breaks
+var criteria = map[string]criterion{
+	models.StandingsByPoints: func(a, b models.Standing) int {
+		return first(
+			compareFloat(b.Points, a.Points),
+			b.Wins-a.Wins,
+			compareAverage(a, b, models.Standing.NetAverage),
+			compareLow(a.LowNet, b.LowNet),
+		)
+	},
+	models.StandingsByNetAverage: func(a, b models.Standing) int {
+		return first(
+			compareAverage(a, b, models.Standing.NetAverage),
+			compareLow(a.LowNet, b.LowNet),
+			compareFloat(b.Points, a.Points),
+		)
+	},
+	models.StandingsByRecord: func(a, b models.Standing) int {
+		return first(
+			compareFloat(b.WinPercentage(), a.WinPercentage()),
+			b.Wins-a.Wins,
+			compareFloat(b.Points, a.Points),
+			compareAverage(a, b, models.Standing.NetAverage),
+		)
+	},
+}
+
+// grossLeaderboard ranks players by their gross scoring
+func grossLeaderboard(a, b models.Standing) int {
+	return first(
+		compareAverage(a, b, models.Standing.GrossAverage),
+		compareLow(a.LowGross, b.LowGross),
+	)
+}
+
+// netLeaderboard ranks players by their net scoring
+func netLeaderboard(a, b models.Standing) int {
+	return first(
+		compareAverage(a, b, models.Standing.NetAverage),
+		compareLow(a.LowNet, b.LowNet),
+	)
+}
+
+// IsValidSortBy reports whether standings can be ranked by sortBy
+func IsValidSortBy(sortBy string) bool {
+	_, ok := criteria[sortBy]
+	return ok
+}
+
+// Calculate returns the players' standings ranked by sortBy, which falls back
+// to points when it isn't a known criterion. The standings are as of the most
+// recent round, and each player's previous rank is their rank a week earlier.
+// Matchups are decided in the scoring format of their week, which defaults to
+// the league's
+func Calculate(league models.League, players []models.Player, rounds []models.Round, weeks []models.ScheduleWeek, sortBy string) models.Standings {
+	if !IsValidSortBy(sortBy) {
+		sortBy = models.StandingsByPoints
+	}
+
+	var asOf time.Time
+	for _, r := range rounds {
+		if r.PlayedOn.After(asOf) {
+			asOf = r.PlayedOn
+		}
+	}
+
+	current := tally(league, players, rounds, weeks, asOf)
+	previous := tally(league, players, rounds, weeks, asOf.Add(-models.WeekLength))
+
+	hasStableford := false
+	for _, s := range current {
+		if s.StablefordRounds > 0 {
+			hasStableford = true
+			break
+		}
+	}
+
+	return models.Standings{
+		SortBy:        sortBy,
+		AsOf:          asOf,
+		Players:       rankWithMovement(current, previous, criteria[sortBy]),
+		Gross:         rankWithMovement(current, previous, grossLeaderboard),
+		Net:           rankWithMovement(current, previous, netLeaderboard),
+		HasStableford: hasStableford,
+	}
+}
+
+// tally totals each player's rounds and matchup results up to and including
+// the given date, in the same order as players
+func tally(league models.League, players []models.Player, rounds []models.Round, weeks []models.ScheduleWeek, asOf time.Time) []models.Standing {
+	standings := make([]models.Standing, len(players))
+	index := make(map[int]int)
+	for i, p := range players {
+		standings[i].Player = p
+		index[p.ID] = i
+	}
+
+	var counted []models.Round
+	for _, r := range rounds {
+		i, ok := index[r.PlayerID]
+		if !ok || r.PlayedOn.After(asOf) {
+			continue
+		}
+		counted = append(counted, r)
+
+		gross := r.GrossScore
+		net := r.GrossScore - r.CourseHandicap
+		s := &standings[i]
+		s.Rounds++
+		s.GrossTotal += gross
+		s.NetTotal += net
+		if s.LowGross == 0 || gross < s.LowGross {
+			s.LowGross = gross
+		}
+		if s.LowNet == 0 || net < s.LowNet {
+			s.LowNet = net
+		}
+
+		if roundFormat(league, weeks, r) == models.FormatStableford {
+			s.StablefordRounds++
+			s.StablefordPoints += stableford.RoundPoints(r, league.StablefordTable)
+		}
+	}
+
+	for _, w := range weeks {
+		if w.PlayDate.After(asOf) {
+			continue
+		}
+		for _, m := range w.Matchups {
+			if m.IsBye() {
+				continue
+			}
+			home, homeOK := index[m.HomePlayerID]
+			away, awayOK := index[m.AwayPlayerID]
+			if !homeOK || !awayOK {
+				continue
+			}
+
+			homeRound, homePlayed := weekRound(counted, m.HomePlayerID, w)
+			awayRound, awayPlayed := weekRound(counted, m.AwayPlayerID, w)
+			if !homePlayed && !awayPlayed {
+				continue
+			}
+
+			// a player who posts a round beats one who doesn't
+			var result int
+			var homeHoles, awayHoles float64
+			switch {
+			case !awayPlayed:
+				result = -1
+			case !homePlayed:
+				result = 1
+			default:
+				result, homeHoles, awayHoles = compare(w.ScoringFormat(league), league, homeRound, awayRound)
+			}
+
+			award(league, &standings[home], &standings[away], result)
+			standings[home].Points += homeHoles
+			standings[away].Points += awayHoles
+		}
+	}
+
+	return standings
+}
+
+// award records a matchup result, worth the league's win and halve points,
+// where a negative result is a win for the home player, a positive result a
+// win for the away player and 0 a tie
+func award(league models.League, home, away *models.Standing, result int) {
+	switch {
+	case result < 0:
+		home.Wins++
+		home.Points += league.WinPoints
+		away.Losses++
+	case result > 0:
+		away.Wins++
+		away.Points += league.WinPoints
+		home.Losses++
+	default:
+		home.Ties++
+		home.Points += league.HalvePoints
+		away.Ties++
+		away.Points += league.HalvePoints
+	}
+}
+
+// weekRound returns the player's first round played during the week
+func weekRound(rounds []models.Round, playerID int, week models.ScheduleWeek) (models.Round, bool) {
+	var found models.Round
+	ok := false
+	for _, r := range rounds {
+		if r.PlayerID != playerID || !week.Includes(r.PlayedOn) {
+			continue
+		}
+		if !ok || r.PlayedOn.Before(found.PlayedOn) {
+			found = r
+			ok = true
+		}
+	}
+	return found, ok
+}
+
+// roundFormat returns the scoring format of the week a round was played in,
+// or the league's format when it wasn't played in a scheduled week
+func roundFormat(league models.League, weeks []models.ScheduleWeek, r models.Round) string {
+	for _, w := range weeks {
+		if w.Includes(r.PlayedOn) {
+			return w.ScoringFormat(league)
+		}
+	}
+	return league.ScoringFormat
+}
+
+// compare decides a matchup between two posted rounds in the given format,
+// returning a negative number when the home player wins, a positive number
+// when the away player wins and 0 for a tie, along with the points each
+// player earned for holes won in match play
+func compare(format string, league models.League, home, away models.Round) (int, float64, float64) {
+	switch format {
+	case models.FormatMatchPlay:
+		match := matchplay.Play(home.TeeSet.Holes, home.HoleScores, away.HoleScores, home.CourseHandicap, away.CourseHandicap, matchplay.LeagueOptions(league))
+		homeHoles, awayHoles := matchplay.HolePoints(match, league.HolePoints)
+		return -match.HomeUp, homeHoles, awayHoles
+	case models.FormatStableford:
+		return stableford.RoundPoints(away, league.StablefordTable) - stableford.RoundPoints(home, league.StablefordTable), 0, 0
+	}
+	return netScore(home) - netScore(away), 0, 0
+}
+
+func netScore(r models.Round) int {
+	return r.GrossScore - r.CourseHandicap
+}
+
+// rankWithMovement ranks the current standings and sets each player's rank in
+// the previous standings. Previous ranks are left at 0 when nobody had played
+func rankWithMovement(current, previous []models.Standing, c criterion) []models.Standing {
+	ranked := rank(current, c)
+
+	played := false
+	for _, s := range previous {
+		if s.Rounds > 0 {
+			played = true
+			break
+		}
+	}
+	if !played {
+		return ranked
+	}
+
+	previousRanks := make(map[int]int)
+	for _, s := range rank(previous, c) {
+		previousRanks[s.Player.ID] = s.Rank
+	}
+	for i := range ranked {
+		ranked[i].PreviousRank = previousRanks[ranked[i].Player.ID]
+	}
+
+	return ranked
+}
+
+// rank returns a sorted copy of the standings with ranks set. Players tied on
+// every criterion share a rank and are listed by name
+func rank(standings []models.Standing, c criterion) []models.Standing {
+	ranked := append([]models.Standing(nil), standings...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if result := c(ranked[i], ranked[j]); result != 0 {
+			return result < 0
+		}
+		return byName(ranked[i].Player, ranked[j].Player)
+	})
+
+	for i := range ranked {
+		if i > 0 && c(ranked[i-1], ranked[i]) == 0 {
+			ranked[i].Rank = ranked[i-1].Rank
+		} else {
+			ranked[i].Rank = i + 1
+		}
+	}
+
+	return ranked
+}
+
+func byName(a, b models.Player) bool {
+	aName := strings.ToLower(a.User.LastName + " " + a.User.FirstName)
+	bName := strings.ToLower(b.User.LastName + " " + b.User.FirstName)
+	if aName != bName {
+		return aName < bName
+	}
+	return a.ID < b.ID
+}
+
+// first returns the first comparison that isn't a tie
+func first(comparisons ...int) int {
+	for _, c := range comparisons {
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareAverage orders players by a scoring average, lowest first, with
+// players who haven't posted a round last
+func compareAverage(a, b models.Standing, average func(models.Standing) float64) int {
+	switch {
+	case a.Rounds == 0 && b.Rounds == 0:
+		return 0
+	case a.Rounds == 0:
+		return 1
+	case b.Rounds == 0:
+		return -1
+	}
+	return compareFloat(average(a), average(b))
+}
+
+// compareLow orders low scores, lowest first, with players who don't have one last
+func compareLow(a, b int) int {
+	switch {
+	case a == b:
+		return 0
+	case a == 0:
+		return 1
+	case b == 0:
+		return -1
+	}
+	return a - b
+}
@@ -0,0 +1,319 @@
+package standings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+func date(day int) time.Time {
+	return time.Date(2024, 4, day, 0, 0, 0, 0, time.UTC)
+}
+
+func player(ID int, first, last string) models.Player {
+	return models.Player{ID: ID, IsActive: true, User: models.User{FirstName: first, LastName: last}}
+}
+
+var league = models.League{
+	ScoringFormat:   models.FormatStrokePlay,
+	StablefordTable: models.StandardStableford,
+	MatchAllowance:  1,
+	WinPoints:       2,
+	HalvePoints:     1,
+}
+
+var players = []models.Player{
+	player(1, "Alice", "Adams"),
+	player(2, "Bob", "Baker"),
+	player(3, "Carl", "Clark"),
+	player(4, "Dan", "Davis"),
+	player(5, "Eve", "Evans"),
+}
+
+func round(playerID, day, gross, courseHandicap int) models.Round {
+	return models.Round{PlayerID: playerID, PlayedOn: date(day), GrossScore: gross, CourseHandicap: courseHandicap}
+}
+
+var rounds = []models.Round{
+	// week one: Adams beats Baker, Clark and Davis halve
+	round(1, 3, 80, 10),
+	round(2, 3, 78, 5),
+	round(3, 3, 85, 12),
+	round(4, 4, 90, 17),
+	// week two: Clark beats Adams, Baker wins as Davis doesn't post
+	round(1, 10, 82, 10),
+	round(3, 10, 80, 12),
+	round(2, 11, 76, 5),
+	// a player who isn't in the league
+	round(9, 10, 60, 0),
+}
+
+var weeks = []models.ScheduleWeek{
+	{
+		ID:       1,
+		PlayDate: date(3),
+		Matchups: []models.Matchup{
+			{HomePlayerID: 1, AwayPlayerID: 2},
+			{HomePlayerID: 3, AwayPlayerID: 4},
+			{HomePlayerID: 5},
+		},
+	},
+	{
+		ID:       2,
+		PlayDate: date(10),
+		Matchups: []models.Matchup{
+			{HomePlayerID: 1, AwayPlayerID: 3},
+			{HomePlayerID: 2, AwayPlayerID: 4},
+			{HomePlayerID: 5},
+		},
+	},
+	{
+		ID:       3,
+		PlayDate: date(17),
+		Matchups: []models.Matchup{
+			{HomePlayerID: 1, AwayPlayerID: 4},
+		},
+	},
+}
+
+// order returns the player IDs of the standings in ranked order
+func order(standings []models.Standing) []int {
+	var ids []int
+	for _, s := range standings {
+		ids = append(ids, s.Player.ID)
+	}
+	return ids
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var calculateTests = []struct {
+	name           string
+	sortBy         string
+	expectedSortBy string
+	expectedOrder  []int
+}{
+	{"points", models.StandingsByPoints, models.StandingsByPoints, []int{3, 1, 2, 4, 5}},
+	{"net average", models.StandingsByNetAverage, models.StandingsByNetAverage, []int{3, 1, 2, 4, 5}},
+	{"record", models.StandingsByRecord, models.StandingsByRecord, []int{3, 1, 2, 4, 5}},
+	{"unknown criterion", "handicap", models.StandingsByPoints, []int{3, 1, 2, 4, 5}},
+}
+
+func TestCalculate(t *testing.T) {
+	for _, e := range calculateTests {
+		standings := Calculate(league, players, rounds, weeks, e.sortBy)
+		if standings.SortBy != e.expectedSortBy {
+			t.Errorf("failed %s: expected sort by %s, but got %s", e.name, e.expectedSortBy, standings.SortBy)
+		}
+		if !standings.AsOf.Equal(date(11)) {
+			t.Errorf("failed %s: expected standings as of the last round, but got %s", e.name, standings.AsOf)
+		}
+		if actual := order(standings.Players); !equal(actual, e.expectedOrder) {
+			t.Errorf("failed %s: expected order %v, but got %v", e.name, e.expectedOrder, actual)
+		}
+	}
+}
+
+func TestCalculateTotals(t *testing.T) {
+	standings := Calculate(league, players, rounds, weeks, models.StandingsByPoints)
+
+	expected := map[int]models.Standing{
+		1: {Points: 2, Wins: 1, Losses: 1, Rounds: 2, GrossTotal: 162, NetTotal: 142, LowGross: 80, LowNet: 70},
+		2: {Points: 2, Wins: 1, Losses: 1, Rounds: 2, GrossTotal: 154, NetTotal: 144, LowGross: 76, LowNet: 71},
+		3: {Points: 3, Wins: 1, Ties: 1, Rounds: 2, GrossTotal: 165, NetTotal: 141, LowGross: 80, LowNet: 68},
+		4: {Points: 1, Ties: 1, Losses: 1, Rounds: 1, GrossTotal: 90, NetTotal: 73, LowGross: 90, LowNet: 73},
+		5: {},
+	}
+
+	for _, s := range standings.Players {
+		e := expected[s.Player.ID]
+		if s.Points != e.Points || s.Wins != e.Wins || s.Losses != e.Losses || s.Ties != e.Ties {
+			t.Errorf("player %d: expected %v points and %d-%d-%d, but got %v points and %d-%d-%d", s.Player.ID, e.Points, e.Wins, e.Losses, e.Ties, s.Points, s.Wins, s.Losses, s.Ties)
+		}
+		if s.Rounds != e.Rounds || s.GrossTotal != e.GrossTotal || s.NetTotal != e.NetTotal || s.LowGross != e.LowGross || s.LowNet != e.LowNet {
+			t.Errorf("player %d: expected scoring %+v, but got %+v", s.Player.ID, e, s)
+		}
+	}
+}
+
+func TestCalculateMovement(t *testing.T) {
+	standings := Calculate(league, players, rounds, weeks, models.StandingsByPoints)
+
+	// a week earlier Adams led and Clark and Davis were tied for second
+	expected := map[int]struct {
+		rank         int
+		previousRank int
+		movement     int
+	}{
+		3: {1, 2, 1},
+		1: {2, 1, -1},
+		2: {3, 4, 1},
+		4: {4, 2, -2},
+		5: {5, 5, 0},
+	}
+
+	for _, s := range standings.Players {
+		e := expected[s.Player.ID]
+		if s.Rank != e.rank || s.PreviousRank != e.previousRank || s.Movement() != e.movement {
+			t.Errorf("player %d: expected rank %d from %d (%d), but got rank %d from %d (%d)", s.Player.ID, e.rank, e.previousRank, e.movement, s.Rank, s.PreviousRank, s.Movement())
+		}
+	}
+}
+
+func TestCalculateLeaderboards(t *testing.T) {
+	standings := Calculate(league, players, rounds, weeks, models.StandingsByPoints)
+
+	if actual := order(standings.Gross); !equal(actual, []int{2, 1, 3, 4, 5}) {
+		t.Errorf("expected gross leaderboard [2 1 3 4 5], but got %v", actual)
+	}
+	if actual := order(standings.Net); !equal(actual, []int{3, 1, 2, 4, 5}) {
+		t.Errorf("expected net leaderboard [3 1 2 4 5], but got %v", actual)
+	}
+}
+
+func TestCalculateTies(t *testing.T) {
+	tied := []models.Round{
+		round(2, 3, 80, 10),
+		round(1, 3, 80, 10),
+	}
+	tiedWeeks := []models.ScheduleWeek{
+		{PlayDate: date(3), Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}}},
+	}
+
+	standings := Calculate(league, players[:3], tied, tiedWeeks, models.StandingsByPoints)
+
+	if actual := order(standings.Players); !equal(actual, []int{1, 2, 3}) {
+		t.Errorf("expected tied players listed by name, but got %v", actual)
+	}
+	if standings.Players[0].Rank != 1 || standings.Players[1].Rank != 1 || standings.Players[2].Rank != 3 {
+		t.Errorf("expected ranks 1, 1 and 3, but got %d, %d and %d", standings.Players[0].Rank, standings.Players[1].Rank, standings.Players[2].Rank)
+	}
+	if standings.Players[0].Ties != 1 || standings.Players[0].Points != league.HalvePoints {
+		t.Errorf("expected a halved matchup, but got %+v", standings.Players[0])
+	}
+	if standings.Players[0].PreviousRank != 0 {
+		t.Errorf("expected no previous rank in the first week, but got %d", standings.Players[0].PreviousRank)
+	}
+}
+
+func TestCalculateNoRounds(t *testing.T) {
+	standings := Calculate(league, players, nil, weeks, models.StandingsByRecord)
+
+	for _, s := range standings.Players {
+		if s.Rank != 1 || s.Matches() != 0 {
+			t.Errorf("player %d: expected everyone tied without a result, but got rank %d with %d matches", s.Player.ID, s.Rank, s.Matches())
+		}
+	}
+}
+
+// holes are nine par 4s with stroke indexes matching their numbers
+func holes() []models.Hole {
+	var h []models.Hole
+	for i := 1; i <= 9; i++ {
+		h = append(h, models.Hole{Number: i, Par: 4, StrokeIndex: i})
+	}
+	return h
+}
+
+func holeRound(playerID, day, courseHandicap int, strokes ...int) models.Round {
+	r := round(playerID, day, 0, courseHandicap)
+	r.TeeSet.Holes = holes()
+	for i, s := range strokes {
+		r.GrossScore += s
+		r.HoleScores = append(r.HoleScores, models.HoleScore{HoleNumber: i + 1, Strokes: s})
+	}
+	return r
+}
+
+func TestCalculateFormats(t *testing.T) {
+	// Adams pars every hole for the lower score, but Baker's three birdies
+	// win the match 3&1 and outscore the blow up on the last in Stableford
+	formatRounds := []models.Round{
+		holeRound(1, 3, 0, 4, 4, 4, 4, 4, 4, 4, 4, 4),
+		holeRound(2, 3, 0, 3, 3, 3, 4, 4, 4, 4, 4, 9),
+	}
+
+	var formatTests = []struct {
+		name          string
+		leagueFormat  string
+		weekFormat    string
+		expectedOrder []int
+		stableford    bool
+	}{
+		{"stroke play", models.FormatStrokePlay, "", []int{1, 2}, false},
+		{"match play", models.FormatMatchPlay, "", []int{2, 1}, false},
+		{"stableford", models.FormatStableford, "", []int{2, 1}, true},
+		{"week overrides league", models.FormatStableford, models.FormatStrokePlay, []int{1, 2}, false},
+		{"week format", models.FormatStrokePlay, models.FormatStableford, []int{2, 1}, true},
+	}
+
+	for _, e := range formatTests {
+		formatLeague := league
+		formatLeague.ScoringFormat = e.leagueFormat
+		formatWeeks := []models.ScheduleWeek{
+			{PlayDate: date(3), Format: e.weekFormat, Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}}},
+		}
+
+		standings := Calculate(formatLeague, players[:2], formatRounds, formatWeeks, models.StandingsByPoints)
+		if actual := order(standings.Players); !equal(actual, e.expectedOrder) {
+			t.Errorf("failed %s: expected order %v, but got %v", e.name, e.expectedOrder, actual)
+		}
+		if e.stableford && standings.Players[0].StablefordPoints != 19 {
+			t.Errorf("failed %s: expected 19 stableford points for the leader, but got %d", e.name, standings.Players[0].StablefordPoints)
+		}
+		if standings.HasStableford != e.stableford {
+			t.Errorf("failed %s: expected has stableford %t, but got %t", e.name, e.stableford, standings.HasStableford)
+		}
+	}
+}
+
+func TestCalculateMatchPlaySettings(t *testing.T) {
+	matchWeeks := []models.ScheduleWeek{
+		{PlayDate: date(3), Format: models.FormatMatchPlay, Matchups: []models.Matchup{{HomePlayerID: 1, AwayPlayerID: 2}}},
+	}
+
+	var settingsTests = []struct {
+		name           string
+		allowance      float64
+		holePoints     float64
+		homeHandicap   int
+		expectedPoints []float64
+	}{
+		// Baker's three birdies win 3&2 with the four holes between them halved
+		{"match points", 1, 0, 0, []float64{0, 2}},
+		{"hole points", 1, 1, 0, []float64{2, 7}},
+		// four strokes win Adams holes four and nine, two only halve the match
+		{"full allowance", 1, 0, 4, []float64{2, 0}},
+		{"half allowance", 0.5, 0, 4, []float64{1, 1}},
+	}
+
+	for _, e := range settingsTests {
+		settingsLeague := league
+		settingsLeague.MatchAllowance = e.allowance
+		settingsLeague.HolePoints = e.holePoints
+		matchRounds := []models.Round{
+			holeRound(1, 3, e.homeHandicap, 4, 4, 4, 4, 4, 4, 4, 4, 4),
+			holeRound(2, 3, 0, 3, 3, 3, 4, 4, 4, 4, 4, 9),
+		}
+
+		standings := Calculate(settingsLeague, players[:2], matchRounds, matchWeeks, models.StandingsByPoints)
+		points := make(map[int]float64)
+		for _, s := range standings.Players {
+			points[s.Player.ID] = s.Points
+		}
+		if points[1] != e.expectedPoints[0] || points[2] != e.expectedPoints[1] {
+			t.Errorf("failed %s: expected points %v, but got %v and %v", e.name, e.expectedPoints, points[1], points[2])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package standings
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+	"github.com/jdonahue135/golf-league-app/internal/teamplay"
+)
+
+// CalculateTeams returns the teams' standings in the given team format, which
+// falls back to net best ball when it isn't a known format. Each week of the
+// schedule a team scores from the first round each member played that week,
+// and teams are ranked by their average score, lowest first
+func CalculateTeams(format string, teams []models.Team, rounds []models.Round, weeks []models.ScheduleWeek) models.TeamStandings {
+	if !models.IsTeamFormat(format) {
+		format = models.TeamFormatBestBallNet
+	}
+
+	standings := make([]models.TeamStanding, len(teams))
+	for i, t := range teams {
+		standings[i].Team = t
+
+		for _, w := range weeks {
+			var played []models.Round
+			for _, p := range t.Players {
+				if r, ok := weekRound(rounds, p.ID, w); ok {
+					played = append(played, r)
+				}
+			}
+
+			score, ok := teamplay.Score(format, played)
+			if !ok {
+				continue
+			}
+
+			s := &standings[i]
+			s.Weeks++
+			s.Total += score
+			if s.Low == 0 || score < s.Low {
+				s.Low = score
+			}
+		}
+	}
+
+	return models.TeamStandings{
+		Format: format,
+		Teams:  rankTeams(standings),
+	}
+}
+
+// compareTeams orders teams by their average score then their low score, with
+// teams that haven't played last
+func compareTeams(a, b models.TeamStanding) int {
+	switch {
+	case a.Weeks == 0 && b.Weeks == 0:
+		return 0
+	case a.Weeks == 0:
+		return 1
+	case b.Weeks == 0:
+		return -1
+	}
+	return first(
+		compareFloat(a.Average(), b.Average()),
+		compareLow(a.Low, b.Low),
+	)
+}
+
+// rankTeams returns a sorted copy of the team standings with ranks set. Teams
+// tied on every criterion share a rank and are listed by name
+func rankTeams(standings []models.TeamStanding) []models.TeamStanding {
+	ranked := append([]models.TeamStanding(nil), standings...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if result := compareTeams(ranked[i], ranked[j]); result != 0 {
+			return result < 0
+		}
+		return strings.ToLower(ranked[i].Team.Name) < strings.ToLower(ranked[j].Team.Name)
+	})
+
+	for i := range ranked {
+		if i > 0 && compareTeams(ranked[i-1], ranked[i]) == 0 {
+			ranked[i].Rank = ranked[i-1].Rank
+		} else {
+			ranked[i].Rank = i + 1
+		}
+	}
+
+	return ranked
+}
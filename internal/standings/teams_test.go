@@ -0,0 +1,94 @@
+package standings
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+var teams = []models.Team{
+	{ID: 1, Name: "Condors", Players: []models.Player{players[4]}},
+	{ID: 2, Name: "Birdies", Players: []models.Player{players[2], players[3]}},
+	{ID: 3, Name: "Aces", Players: players[:2]},
+}
+
+var teamRounds = []models.Round{
+	// week one: the Aces make a birdie on the last for a best ball of 35
+	holeRound(1, 3, 0, 4, 4, 4, 4, 4, 4, 4, 4, 4),
+	holeRound(2, 3, 0, 5, 5, 5, 5, 5, 5, 5, 5, 3),
+	holeRound(3, 3, 0, 5, 5, 5, 5, 5, 5, 5, 5, 5),
+	holeRound(4, 4, 0, 4, 4, 4, 4, 4, 4, 4, 4, 5),
+	// week two: only one of the Aces plays, so only the Birdies score
+	holeRound(1, 10, 0, 4, 4, 4, 4, 4, 4, 4, 4, 4),
+	holeRound(3, 10, 0, 4, 4, 4, 4, 4, 4, 4, 4, 4),
+	holeRound(4, 11, 0, 4, 4, 4, 4, 4, 4, 4, 4, 4),
+}
+
+var calculateTeamsTests = []struct {
+	name           string
+	format         string
+	expectedFormat string
+	expected       map[int]models.TeamStanding
+}{
+	{
+		"best ball gross",
+		models.TeamFormatBestBallGross,
+		models.TeamFormatBestBallGross,
+		map[int]models.TeamStanding{
+			3: {Rank: 1, Weeks: 1, Total: 35, Low: 35},
+			2: {Rank: 2, Weeks: 2, Total: 73, Low: 36},
+			1: {Rank: 3},
+		},
+	},
+	{
+		"aggregate",
+		models.TeamFormatAggregate,
+		models.TeamFormatAggregate,
+		map[int]models.TeamStanding{
+			2: {Rank: 1, Weeks: 2, Total: 154, Low: 72},
+			3: {Rank: 2, Weeks: 1, Total: 79, Low: 79},
+			1: {Rank: 3},
+		},
+	},
+	{
+		"unknown format",
+		"scramble",
+		models.TeamFormatBestBallNet,
+		map[int]models.TeamStanding{
+			3: {Rank: 1, Weeks: 1, Total: 35, Low: 35},
+			2: {Rank: 2, Weeks: 2, Total: 73, Low: 36},
+			1: {Rank: 3},
+		},
+	},
+}
+
+func TestCalculateTeams(t *testing.T) {
+	for _, e := range calculateTeamsTests {
+		standings := CalculateTeams(e.format, teams, teamRounds, weeks)
+		if standings.Format != e.expectedFormat {
+			t.Errorf("failed %s: expected format %s, but got %s", e.name, e.expectedFormat, standings.Format)
+		}
+
+		for _, s := range standings.Teams {
+			x := e.expected[s.Team.ID]
+			if s.Rank != x.Rank || s.Weeks != x.Weeks || s.Total != x.Total || s.Low != x.Low {
+				t.Errorf("failed %s: expected team %d to be %+v, but got rank %d, %d weeks, total %d and low %d", e.name, s.Team.ID, x, s.Rank, s.Weeks, s.Total, s.Low)
+			}
+		}
+	}
+}
+
+func TestCalculateTeamsTies(t *testing.T) {
+	standings := CalculateTeams(models.TeamFormatBestBallNet, teams, nil, weeks)
+
+	var names []string
+	for _, s := range standings.Teams {
+		names = append(names, s.Team.Name)
+		if s.Rank != 1 {
+			t.Errorf("expected every team tied before anyone plays, but %s is ranked %d", s.Team.Name, s.Rank)
+		}
+	}
+	if names[0] != "Aces" || names[1] != "Birdies" || names[2] != "Condors" {
+		t.Errorf("expected tied teams listed by name, but got %v", names)
+	}
+}
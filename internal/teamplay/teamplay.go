@@ -0,0 +1,75 @@
+// Package teamplay scores a team's week from the rounds its members played, as
+// the best ball on each hole or the aggregate of their net scores
+package teamplay
+
+import (
+	"github.com/jdonahue135/golf-league-app/internal/handicap"
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// MinimumPlayers is how many of a team's players need to post a round for the
+// team to have a score
+const MinimumPlayers = 2
+
+// Score returns the team's score in the given format from its members' rounds,
+// and false when too few of them played to make a team score
+func Score(format string, rounds []models.Round) (int, bool) {
+	if len(rounds) < MinimumPlayers {
+		return 0, false
+	}
+
+	switch format {
+	case models.TeamFormatAggregate:
+		return Aggregate(rounds), true
+	case models.TeamFormatBestBallGross:
+		return BestBall(rounds, false)
+	}
+	return BestBall(rounds, true)
+}
+
+// Aggregate returns the total of the members' net scores
+func Aggregate(rounds []models.Round) int {
+	total := 0
+	for _, r := range rounds {
+		total += r.GrossScore - r.CourseHandicap
+	}
+	return total
+}
+
+// BestBall returns the total of the team's best score on each hole, net of the
+// strokes each member receives on the hole when net is set. Holes nobody
+// scored don't count, and the team has no score when nobody scored a hole
+func BestBall(rounds []models.Round, net bool) (int, bool) {
+	best := BestBallHoles(rounds, net)
+	if len(best) == 0 {
+		return 0, false
+	}
+
+	total := 0
+	for _, score := range best {
+		total += score
+	}
+	return total, true
+}
+
+// BestBallHoles returns the team's best score on each hole, keyed by hole number
+func BestBallHoles(rounds []models.Round, net bool) map[int]int {
+	best := make(map[int]int)
+	for _, r := range rounds {
+		var received map[int]int
+		if net {
+			received = handicap.StrokesByHole(r.CourseHandicap, r.TeeSet.Holes)
+		}
+
+		for _, s := range r.HoleScores {
+			if s.Strokes <= 0 {
+				continue
+			}
+			score := s.Strokes - received[s.HoleNumber]
+			if current, ok := best[s.HoleNumber]; !ok || score < current {
+				best[s.HoleNumber] = score
+			}
+		}
+	}
+	return best
+}
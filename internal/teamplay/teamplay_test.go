@@ -0,0 +1,74 @@
+package teamplay
+
+import (
+	"testing"
+
+	"github.com/jdonahue135/golf-league-app/internal/models"
+)
+
+// holes are three par 4s with stroke indexes matching their numbers
+var holes = []models.Hole{
+	{Number: 1, Par: 4, StrokeIndex: 1},
+	{Number: 2, Par: 4, StrokeIndex: 2},
+	{Number: 3, Par: 4, StrokeIndex: 3},
+}
+
+func round(courseHandicap int, strokes ...int) models.Round {
+	r := models.Round{CourseHandicap: courseHandicap, TeeSet: models.TeeSet{Holes: holes}}
+	for i, s := range strokes {
+		r.GrossScore += s
+		if s > 0 {
+			r.HoleScores = append(r.HoleScores, models.HoleScore{HoleNumber: i + 1, Strokes: s})
+		}
+	}
+	return r
+}
+
+// the first player gets a stroke on the first two holes for nets of 4, 3 and 5
+var team = []models.Round{
+	round(2, 5, 4, 5),
+	round(0, 4, 5, 3),
+}
+
+var scoreTests = []struct {
+	name          string
+	format        string
+	rounds        []models.Round
+	expectedScore int
+	expectedOK    bool
+}{
+	{"best ball net", models.TeamFormatBestBallNet, team, 10, true},
+	{"best ball gross", models.TeamFormatBestBallGross, team, 11, true},
+	{"aggregate", models.TeamFormatAggregate, team, 24, true},
+	{"unknown format is net best ball", "scramble", team, 10, true},
+	{"one player", models.TeamFormatBestBallNet, team[:1], 0, false},
+	{"hole only one player scored", models.TeamFormatBestBallGross, []models.Round{round(0, 5, 4, 5), round(0, 4, 5, 0)}, 13, true},
+	{"no hole scores", models.TeamFormatBestBallNet, []models.Round{{GrossScore: 40}, {GrossScore: 42}}, 0, false},
+	{"aggregate without hole scores", models.TeamFormatAggregate, []models.Round{{GrossScore: 40, CourseHandicap: 4}, {GrossScore: 42}}, 78, true},
+}
+
+func TestScore(t *testing.T) {
+	for _, e := range scoreTests {
+		score, ok := Score(e.format, e.rounds)
+		if ok != e.expectedOK {
+			t.Errorf("failed %s: expected ok %t, but got %t", e.name, e.expectedOK, ok)
+		}
+		if score != e.expectedScore {
+			t.Errorf("failed %s: expected score %d, but got %d", e.name, e.expectedScore, score)
+		}
+	}
+}
+
+func TestBestBallHoles(t *testing.T) {
+	expected := map[int]int{1: 4, 2: 3, 3: 3}
+
+	best := BestBallHoles(team, true)
+	if len(best) != len(expected) {
+		t.Errorf("expected %d holes, but got %d", len(expected), len(best))
+	}
+	for hole, score := range expected {
+		if best[hole] != score {
+			t.Errorf("hole %d: expected a best ball of %d, but got %d", hole, score, best[hole])
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Package tokens makes the single-use tokens emailed to users in links. A
+// token is random and only its hash is stored, so a copy of the database
+// can't be used to follow a link
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// tokenBytes is how many random bytes make up a token
+const tokenBytes = 32
+
+// Generate returns a new URL safe token along with the hash to store for it
+func Generate() (string, string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, Hash(token), nil
+}
+
+// Hash returns the hash a token is stored and looked up by
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
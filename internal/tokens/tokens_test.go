@@ -0,0 +1,34 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	token, hash, err := Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hash != Hash(token) {
+		t.Errorf("expected the hash of the token, but got %s", hash)
+	}
+	if strings.ContainsAny(token, "+/=") {
+		t.Errorf("expected a URL safe token, but got %s", token)
+	}
+
+	other, _, _ := Generate()
+	if other == token {
+		t.Error("expected a different token each time")
+	}
+}
+
+func TestHash(t *testing.T) {
+	if Hash("token") == Hash("Token") {
+		t.Error("expected different tokens to hash differently")
+	}
+	if len(Hash("token")) != 64 {
+		t.Errorf("expected a 64 character hash, but got %d characters", len(Hash("token")))
+	}
+}
@@ -0,0 +1,96 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 secret from RFC 6238's test vectors, "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// codeTests are the RFC 6238 test vectors, keeping the last six digits
+var codeTests = []struct {
+	unix     int64
+	expected string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+}
+
+func TestCode(t *testing.T) {
+	for _, e := range codeTests {
+		code, err := Code(rfcSecret, time.Unix(e.unix, 0))
+		if err != nil {
+			t.Fatalf("failed %d: expected no error, but got %s", e.unix, err.Error())
+		}
+		if code != e.expected {
+			t.Errorf("failed %d: expected code %s, but got %s", e.unix, e.expected, code)
+		}
+	}
+}
+
+func TestCodeBadSecret(t *testing.T) {
+	if _, err := Code("not base32!", time.Now()); err == nil {
+		t.Error("expected error, but didn't get one")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	code, _ := Code(rfcSecret, now)
+	previous, _ := Code(rfcSecret, now.Add(-Period))
+	tooOld, _ := Code(rfcSecret, now.Add(-2*Period))
+
+	var validateTests = []struct {
+		name     string
+		code     string
+		expected bool
+	}{
+		{"current code", code, true},
+		{"current code with spaces", " " + code + " ", true},
+		{"previous code", previous, true},
+		{"code that's too old", tooOld, false},
+		{"wrong code", "000000", false},
+		{"short code", "12345", false},
+	}
+
+	for _, e := range validateTests {
+		step, ok := Validate(rfcSecret, e.code, now)
+		if ok != e.expected {
+			t.Errorf("failed %s: expected %t, but got %t", e.name, e.expected, ok)
+		}
+		if e.name == "current code" && step != Step(now) {
+			t.Errorf("failed %s: expected step %d, but got %d", e.name, Step(now), step)
+		}
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("expected no error, but got %s", err.Error())
+	}
+
+	code, err := Code(secret, time.Now())
+	if err != nil {
+		t.Fatalf("expected the secret to make codes, but got %s", err.Error())
+	}
+	if _, ok := Validate(secret, code, time.Now()); !ok {
+		t.Error("expected a code from the secret to validate")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Golf League", "me@here.ca", "ABC")
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Golf%20League:me@here.ca?") {
+		t.Errorf("expected a labelled otpauth uri, but got %s", uri)
+	}
+	if !strings.Contains(uri, "secret=ABC") || !strings.Contains(uri, "issuer=Golf+League") {
+		t.Errorf("expected the secret and issuer in the uri, but got %s", uri)
+	}
+}
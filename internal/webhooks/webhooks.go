@@ -0,0 +1,192 @@
+// Package webhooks sends signed event payloads to the URLs leagues subscribe.
+// Each request is signed with an HMAC-SHA256 of its body keyed by the
+// webhook's secret, so the receiver can check it came from the app
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// The headers sent with every delivery
+const (
+	EventHeader     = "X-Golf-League-Event"
+	DeliveryHeader  = "X-Golf-League-Delivery"
+	SignatureHeader = "X-Golf-League-Signature"
+)
+
+// signaturePrefix names the algorithm in the signature header
+const signaturePrefix = "sha256="
+
+// Timeout is how long a receiver has to respond before the delivery fails
+const Timeout = 10 * time.Second
+
+// ErrAddressNotAllowed is returned for hosts that are on our own network
+var ErrAddressNotAllowed = errors.New("webhooks can't be sent to private or local addresses")
+
+// privateNetworks are the address ranges reserved for private networks, along
+// with carrier-grade NAT's shared range and the NAT64 prefix, whose addresses
+// can stand in for any IPv4 address
+var privateNetworks = []*net.IPNet{
+	mustParseCIDR("10.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("172.16.0.0/12"),
+	mustParseCIDR("192.168.0.0/16"),
+	mustParseCIDR("fc00::/7"),
+	mustParseCIDR("64:ff9b::/96"),
+}
+
+func mustParseCIDR(s string) *net.IPNet {
+	_, network, err := net.ParseCIDR(s)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
+
+// AllowedIP reports whether deliveries can be sent to an address. Loopback,
+// private, link-local and unspecified addresses aren't allowed, so a webhook
+// can't be used to reach the app's own network
+func AllowedIP(ip net.IP) bool {
+	// an IPv4 address written as IPv6, like ::ffff:127.0.0.1, is checked as
+	// the IPv4 address it is
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckHost returns ErrAddressNotAllowed if host is, or looks up to, an address
+// deliveries can't be sent to. A host that can't be looked up now is let
+// through, since the client checks the address again on every delivery
+func CheckHost(host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !AllowedIP(ip) {
+			return ErrAddressNotAllowed
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if !AllowedIP(addr.IP) {
+			return ErrAddressNotAllowed
+		}
+	}
+	return nil
+}
+
+// NewClient returns an HTTP client for sending deliveries. Redirects aren't
+// followed, so a delivery only ever goes to the URL the commissioner entered,
+// and it refuses to connect to any address AllowedIP rejects. That's checked
+// on the address actually dialed, so a host that looks up to somewhere else
+// after the webhook was saved can't get around it
+func NewClient() *http.Client {
+	return newClient(AllowedIP)
+}
+
+// newClient returns a delivery client that only connects to addresses allowed
+// reports true for
+func newClient(allowed func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: Timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !allowed(ip) {
+				return ErrAddressNotAllowed
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: Timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: Timeout,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Sign returns the signature header value for a payload
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the one Sign makes for a payload, the
+// way a receiver should check it
+func Verify(secret string, payload []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, payload)), []byte(signature))
+}
+
+// Send posts a payload to url and returns the status code it responded with.
+// Anything other than a 2xx response is an error
+func Send(client *http.Client, url, secret, event string, deliveryID int, payload []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "GolfLeague-Webhooks")
+	req.Header.Set(EventHeader, event)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(deliveryID))
+	req.Header.Set(SignatureHeader, Sign(secret, payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// read some of the body so the connection can be reused, but don't let a
+	// receiver keep us reading forever
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	return resp.StatusCode, nil
+}
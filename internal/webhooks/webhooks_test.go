@@ -0,0 +1,189 @@
+package webhooks
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	payload := []byte(`{"event":"webhook.test"}`)
+
+	signature := Sign("secret", payload)
+	if !Verify("secret", payload, signature) {
+		t.Error("failed valid signature: expected it to verify")
+	}
+	if Verify("other secret", payload, signature) {
+		t.Error("failed wrong secret: expected it not to verify")
+	}
+	if Verify("secret", []byte(`{"event":"round.posted"}`), signature) {
+		t.Error("failed changed payload: expected it not to verify")
+	}
+	if Verify("secret", payload, signature[len(signaturePrefix):]) {
+		t.Error("failed missing prefix: expected it not to verify")
+	}
+}
+
+func TestSignKnownValue(t *testing.T) {
+	// HMAC-SHA256 test case 2 from RFC 4231
+	signature := Sign("Jefe", []byte("what do ya want for nothing?"))
+	expected := "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"
+	if signature != expected {
+		t.Errorf("expected %s, but got %s", expected, signature)
+	}
+}
+
+// anyIP lets the tests' clients reach stand-in receivers on loopback
+func anyIP(ip net.IP) bool {
+	return true
+}
+
+var allowedIPTests = []struct {
+	ip       string
+	expected bool
+}{
+	{"93.184.216.34", true},
+	{"2606:2800:220:1:248:1893:25c8:1946", true},
+	{"172.32.0.1", true},
+	{"127.0.0.1", false},
+	{"::1", false},
+	{"10.1.2.3", false},
+	{"172.16.0.1", false},
+	{"192.168.1.1", false},
+	{"fd00::1", false},
+	{"169.254.169.254", false},
+	{"fe80::1", false},
+	{"0.0.0.0", false},
+	{"::", false},
+	{"100.64.0.1", false},
+	{"100.127.255.254", false},
+	{"100.128.0.1", true},
+	{"64:ff9b::7f00:1", false},
+	{"::ffff:127.0.0.1", false},
+	{"::ffff:10.0.0.1", false},
+	{"::ffff:93.184.216.34", true},
+}
+
+func TestAllowedIP(t *testing.T) {
+	for _, e := range allowedIPTests {
+		if allowed := AllowedIP(net.ParseIP(e.ip)); allowed != e.expected {
+			t.Errorf("failed %s: expected %t, but got %t", e.ip, e.expected, allowed)
+		}
+	}
+}
+
+var checkHostTests = []struct {
+	host        string
+	expectError bool
+}{
+	{"93.184.216.34", false},
+	{"127.0.0.1", true},
+	{"10.0.0.1", true},
+	{"169.254.169.254", true},
+	{"::1", true},
+	{"localhost", true},
+}
+
+func TestCheckHost(t *testing.T) {
+	for _, e := range checkHostTests {
+		err := CheckHost(e.host)
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.host)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.host, err.Error())
+		}
+	}
+}
+
+var sendTests = []struct {
+	name               string
+	status             int
+	expectedStatusCode int
+	expectError        bool
+}{
+	{"ok", http.StatusOK, http.StatusOK, false},
+	{"no content", http.StatusNoContent, http.StatusNoContent, false},
+	{"redirect", http.StatusFound, http.StatusFound, true},
+	{"server error", http.StatusInternalServerError, http.StatusInternalServerError, true},
+}
+
+func TestSend(t *testing.T) {
+	payload := []byte(`{"event":"player.added"}`)
+
+	for _, e := range sendTests {
+		var received *http.Request
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			body, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(e.status)
+		}))
+
+		status, err := Send(newClient(anyIP), server.URL, "secret", "player.added", 7, payload)
+		server.Close()
+
+		if err == nil && e.expectError {
+			t.Errorf("failed %s: expected error, but didn't get one", e.name)
+		}
+		if err != nil && !e.expectError {
+			t.Errorf("failed %s: expected no error, but got %s", e.name, err.Error())
+		}
+		if status != e.expectedStatusCode {
+			t.Errorf("failed %s: expected status %d, but got %d", e.name, e.expectedStatusCode, status)
+		}
+
+		if received == nil {
+			t.Errorf("failed %s: expected the stand-in to get a request", e.name)
+			continue
+		}
+		if received.Method != http.MethodPost {
+			t.Errorf("failed %s: expected a POST, but got %s", e.name, received.Method)
+		}
+		if received.Header.Get(EventHeader) != "player.added" {
+			t.Errorf("failed %s: expected event header, but got %q", e.name, received.Header.Get(EventHeader))
+		}
+		if received.Header.Get(DeliveryHeader) != strconv.Itoa(7) {
+			t.Errorf("failed %s: expected delivery header, but got %q", e.name, received.Header.Get(DeliveryHeader))
+		}
+		if !Verify("secret", body, received.Header.Get(SignatureHeader)) {
+			t.Errorf("failed %s: expected a valid signature, but got %q", e.name, received.Header.Get(SignatureHeader))
+		}
+	}
+}
+
+func TestSendUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	status, err := Send(newClient(anyIP), url, "secret", "webhook.test", 1, []byte(`{}`))
+	if err == nil {
+		t.Error("expected error, but didn't get one")
+	}
+	if status != 0 {
+		t.Errorf("expected no status, but got %d", status)
+	}
+}
+
+func TestSendToLocalAddress(t *testing.T) {
+	reached := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer server.Close()
+
+	status, err := Send(NewClient(), server.URL, "secret", "webhook.test", 1, []byte(`{}`))
+	if err == nil {
+		t.Error("expected error, but didn't get one")
+	}
+	if status != 0 {
+		t.Errorf("expected no status, but got %d", status)
+	}
+	if reached {
+		t.Error("expected the delivery not to reach a loopback address")
+	}
+}
@@ -0,0 +1 @@
+drop index seasons_one_active_per_league_idx;
@@ -0,0 +1 @@
+create unique index seasons_one_active_per_league_idx on seasons (league_id) where status = 'active';
@@ -1,4 +1,4 @@
 #!/bin/bash
 
 go build -o app cmd/web/*.go
-./app -dbname=golf_league_app -dbuser=jakedonahue -cache=false -production=false
\ No newline at end of file
+./app -dbname=golf_league_app -dbuser=jakedonahue -cache=false -production=false -sessionstore=file
\ No newline at end of file